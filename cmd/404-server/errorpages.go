@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// errorPages holds the error page templates, keyed by status code ("404")
+// or status class ("4xx").
+type errorPages map[string]*template.Template
+
+// errorPageData is passed to the error page templates.
+type errorPageData struct {
+	// Code is the status code of the response.
+	Code int
+	// Text is the status text of the response, e.g. "Not Found".
+	Text string
+}
+
+// loadErrorPages parses the error page templates of the given directory.
+// Templates are named after the status code or class they serve, e.g.
+// 404.html or 5xx.html. Other files are ignored.
+func loadErrorPages(dir string) (errorPages, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	pages := errorPages{}
+	for _, f := range files {
+		key, ok := strings.CutSuffix(f.Name(), ".html")
+		if f.IsDir() || !ok || !isErrorPageKey(key) {
+			continue
+		}
+		t, err := template.ParseFiles(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pages[key] = t
+	}
+	return pages, nil
+}
+
+// isErrorPageKey returns true if key is a status code between 400 and 599,
+// or one of the 4xx and 5xx status classes.
+func isErrorPageKey(key string) bool {
+	if key == "4xx" || key == "5xx" {
+		return true
+	}
+	code, err := strconv.Atoi(key)
+	return err == nil && isErrorCode(code)
+}
+
+func isErrorCode(code int) bool {
+	return code >= 400 && code <= 599
+}
+
+// lookup returns the template for the given status code, falling back to
+// the template of its status class. It returns nil if there is neither.
+func (p errorPages) lookup(code int) *template.Template {
+	if t, ok := p[strconv.Itoa(code)]; ok {
+		return t
+	}
+	return p[fmt.Sprintf("%dxx", code/100)]
+}
+
+// serve writes the error page for the given status code, or the given
+// fallback text if there is no template for it.
+func (p errorPages) serve(w http.ResponseWriter, code int, fallback string) {
+	t := p.lookup(code)
+	if t == nil {
+		w.WriteHeader(code)
+		fmt.Fprint(w, fallback)
+		return
+	}
+	// Render before writing the header so a failing template does not
+	// result in a truncated page with the error status.
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, errorPageData{Code: code, Text: http.StatusText(code)}); err != nil {
+		fmt.Fprintf(os.Stderr, "could not render error page for %d: %s\n", code, err)
+		w.WriteHeader(code)
+		fmt.Fprint(w, fallback)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(buf.Bytes())
+}
+
+// withErrorPages makes the server render the given error page templates.
+func withErrorPages(pages errorPages) func(*server) {
+	return func(s *server) {
+		s.errorPages = pages
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeErrorPages(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadErrorPages(t *testing.T) {
+	dir := writeErrorPages(t, map[string]string{
+		"404.html":    "not found",
+		"5xx.html":    "server error",
+		"3xx.html":    "ignored class",
+		"200.html":    "ignored code",
+		"404.txt":     "ignored extension",
+		"index.html":  "ignored name",
+		"4xx.html.gz": "ignored suffix",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "4xx.html"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := loadErrorPages(dir)
+	if err != nil {
+		t.Fatalf("loadErrorPages(%q) = %v, want nil", dir, err)
+	}
+	if len(pages) != 2 || pages["404"] == nil || pages["5xx"] == nil {
+		t.Errorf("loadErrorPages(%q) loaded %v, want 404 and 5xx", dir, pages)
+	}
+}
+
+func TestLoadErrorPagesInvalidTemplate(t *testing.T) {
+	dir := writeErrorPages(t, map[string]string{"404.html": "{{.Code"})
+	if _, err := loadErrorPages(dir); err == nil {
+		t.Errorf("loadErrorPages(%q) = nil, want error", dir)
+	}
+}
+
+func TestLoadErrorPagesMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	if _, err := loadErrorPages(dir); err == nil {
+		t.Errorf("loadErrorPages(%q) = nil, want error", dir)
+	}
+}
+
+func TestServeErrorPages(t *testing.T) {
+	pages, err := loadErrorPages(writeErrorPages(t, map[string]string{
+		"404.html": "<h1>{{.Code}} {{.Text}}</h1>",
+		"5xx.html": "<p>{{.Code}}: {{.Text}}</p>",
+		"410.html": "{{.Missing}}",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc            string
+		pages           errorPages
+		path            string
+		wantCode        int
+		wantBody        string
+		wantContentType string
+	}{
+		{
+			desc:     "no templates",
+			path:     "/",
+			wantCode: http.StatusNotFound,
+			wantBody: "default backend - 404",
+		},
+		{
+			desc:            "404 template at /",
+			pages:           pages,
+			path:            "/some/path",
+			wantCode:        http.StatusNotFound,
+			wantBody:        "<h1>404 Not Found</h1>",
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			desc:            "status code template",
+			pages:           pages,
+			path:            "/errors/404",
+			wantCode:        http.StatusNotFound,
+			wantBody:        "<h1>404 Not Found</h1>",
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			desc:            "status class template",
+			pages:           pages,
+			path:            "/errors/503",
+			wantCode:        http.StatusServiceUnavailable,
+			wantBody:        "<p>503: Service Unavailable</p>",
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			desc:     "no template for the status code",
+			pages:    pages,
+			path:     "/errors/403",
+			wantCode: http.StatusForbidden,
+			wantBody: "default backend - 403",
+		},
+		{
+			desc:     "failing template",
+			pages:    pages,
+			path:     "/errors/410",
+			wantCode: http.StatusGone,
+			wantBody: "default backend - 410",
+		},
+		{
+			desc:            "status code out of range",
+			pages:           pages,
+			path:            "/errors/302",
+			wantCode:        http.StatusNotFound,
+			wantBody:        "<h1>404 Not Found</h1>",
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			desc:            "invalid status code",
+			pages:           pages,
+			path:            "/errors/abc",
+			wantCode:        http.StatusNotFound,
+			wantBody:        "<h1>404 Not Found</h1>",
+			wantContentType: "text/html; charset=utf-8",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := notFound(withErrorPages(tc.pages))
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if w.Code != tc.wantCode {
+				t.Errorf("GET %s returned status %d, want %d", tc.path, w.Code, tc.wantCode)
+			}
+			if got := w.Body.String(); got != tc.wantBody {
+				t.Errorf("GET %s returned body %q, want %q", tc.path, got, tc.wantBody)
+			}
+			if tc.wantContentType != "" {
+				if got := w.Header().Get("Content-Type"); got != tc.wantContentType {
+					t.Errorf("GET %s returned Content-Type %q, want %q", tc.path, got, tc.wantContentType)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	s := notFound()
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf("GET /healthz returned %d %q, want 200 \"ok\"", w.Code, w.Body.String())
+	}
+}
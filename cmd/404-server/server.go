@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 )
@@ -32,10 +33,21 @@ func main() {
 	// command line arguments
 	port := flag.Int("port", 8080, "Port number to serve default backend 404 page.")
 	timeout := flag.Duration("timeout", 5*time.Second, "Time in seconds to wait before forcefully terminating the server.")
+	errorPagesDir := flag.String("error-pages-dir", "", "Directory of error page templates, named after the status code or class they serve, e.g. 404.html or 5xx.html.")
 
 	flag.Parse()
 
-	notFound := newHTTPServer(fmt.Sprintf(":%d", *port), notFound())
+	var options []func(*server)
+	if *errorPagesDir != "" {
+		pages, err := loadErrorPages(*errorPagesDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not load error pages: %s\n", err)
+			os.Exit(1)
+		}
+		options = append(options, withErrorPages(pages))
+	}
+
+	notFound := newHTTPServer(fmt.Sprintf(":%d", *port), notFound(options...))
 
 	// start the main http server
 	go func() {
@@ -50,7 +62,8 @@ func main() {
 }
 
 type server struct {
-	mux *http.ServeMux
+	mux        *http.ServeMux
+	errorPages errorPages
 }
 
 func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -76,9 +89,20 @@ func notFound(options ...func(*server)) *server {
 		fmt.Fprint(w, "ok")
 	})
 	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
-		fmt.Fprint(w, "default backend - 404")
+		s.errorPages.serve(w, http.StatusNotFound, "default backend - 404")
 	})
+	// Serves the error page of the status code in the path, with that status
+	s.mux.HandleFunc("/errors/{code}", func(w http.ResponseWriter, r *http.Request) {
+		code, err := strconv.Atoi(r.PathValue("code"))
+		if err != nil || !isErrorCode(code) {
+			s.errorPages.serve(w, http.StatusNotFound, "default backend - 404")
+			return
+		}
+		s.errorPages.serve(w, code, fmt.Sprintf("default backend - %d", code))
+	})
+	for _, option := range options {
+		option(s)
+	}
 	return s
 }
 
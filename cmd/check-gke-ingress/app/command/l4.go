@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/kube"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/l4"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+)
+
+var mixedProtocol bool
+
+var l4Cmd = &cobra.Command{
+	Use:   "l4 [service-name]",
+	Short: "check the correctness of L4 LoadBalancer services and ServiceAttachments.",
+	Long:  "check the correctness of L4 LoadBalancer services and, when no service name is given, of ServiceAttachments.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		validateOutputFlags()
+		client, err := kube.NewClientSet(kubecontext, kubeconfig)
+		saClient, errSA := kube.NewServiceAttachmentClientSet(kubecontext, kubeconfig)
+		if err := errors.Join(err, errSA); err != nil {
+			fmt.Fprintf(os.Stderr, "Error connecting to Kubernetes: %v", err)
+			os.Exit(1)
+		}
+
+		opts := l4.Options{
+			GCEClient:     newGCEClient(),
+			MixedProtocol: mixedProtocol,
+		}
+		var output report.Report
+		if len(args) == 0 {
+			output = l4.CheckAllL4Resources(namespace, client, saClient, opts)
+		} else {
+			output = l4.CheckL4Service(args[0], namespace, client, opts)
+		}
+
+		printReport(&output)
+	},
+}
+
+func init() {
+	l4Cmd.Flags().BoolVar(&mixedProtocol, "mixed-protocol", false, "whether the L4 controllers of the cluster support services with mixed protocols")
+	rootCmd.AddCommand(l4Cmd)
+}
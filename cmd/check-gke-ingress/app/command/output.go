@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+)
+
+// failureExitCode is the exit code when checks fail at or above the --fail-on
+// severity, so that it can be told apart from the errors of the tool.
+const failureExitCode = 2
+
+// validateOutputFlags exits if the --output or --fail-on flags are invalid.
+func validateOutputFlags() {
+	switch outputFormat {
+	case report.JSONOutput, report.SARIFOutput, report.JUnitOutput:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid output format %q, must be one of [%s, %s, %s]", outputFormat, report.JSONOutput, report.SARIFOutput, report.JUnitOutput)
+		os.Exit(1)
+	}
+	if err := report.ValidateSeverity(failOn); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --fail-on: %v", err)
+		os.Exit(1)
+	}
+}
+
+// printReport prints the report in the --output format, and exits with
+// failureExitCode if it has failures at or above the --fail-on severity.
+func printReport(output *report.Report) {
+	var res string
+	var err error
+	switch outputFormat {
+	case report.SARIFOutput:
+		res, err = report.SarifReport(output)
+	case report.JUnitOutput:
+		res, err = report.JUnitReport(output)
+	default:
+		res, err = report.JsonReport(output)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error processing results: %v", err)
+		os.Exit(1)
+	}
+	fmt.Print(res)
+	if report.HasFailures(output, failOn) {
+		os.Exit(failureExitCode)
+	}
+}
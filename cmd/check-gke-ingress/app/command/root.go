@@ -22,47 +22,69 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
 	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/ingress"
 	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/kube"
 	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	beconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	feconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 )
 
 var (
-	kubeconfig  string
-	kubecontext string
-	namespace   string
+	kubeconfig   string
+	kubecontext  string
+	namespace    string
+	checkGCE     bool
+	project      string
+	region       string
+	outputFormat string
+	failOn       string
+	files        []string
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "kubectl check-gke-ingress",
 	Short: "kubectl check-gke-ingress is a kubectl tool to check the correctness of ingress and ingress related resources.",
 	Long:  "kubectl check-gke-ingress is a kubectl tool to check the correctness of ingress and ingress related resources.",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := cmd.ParseFlags(args); err != nil {
 			fmt.Fprintf(os.Stderr, "Error parsing flags: %v", err)
 			os.Exit(1)
 		}
-		client, err := kube.NewClientSet(kubecontext, kubeconfig)
-		beconfigClient, errBackend := kube.NewBackendConfigClientSet(kubecontext, kubeconfig)
-		feConfigClient, errFrontend := kube.NewFrontendConfigClientSet(kubecontext, kubeconfig)
-		if errors.Join(err, errBackend, errFrontend) != nil {
-			fmt.Fprintf(os.Stderr, "Error connecting to Kubernetes: %v", err)
-			os.Exit(1)
+		validateOutputFlags()
+		var client kubernetes.Interface
+		var beconfigClient beconfigclient.Interface
+		var feConfigClient feconfigclient.Interface
+		if len(files) > 0 {
+			client, beconfigClient, feConfigClient = newFakeClientSets()
+		} else {
+			var err, errBackend, errFrontend error
+			client, err = kube.NewClientSet(kubecontext, kubeconfig)
+			beconfigClient, errBackend = kube.NewBackendConfigClientSet(kubecontext, kubeconfig)
+			feConfigClient, errFrontend = kube.NewFrontendConfigClientSet(kubecontext, kubeconfig)
+			if err := errors.Join(err, errBackend, errFrontend); err != nil {
+				fmt.Fprintf(os.Stderr, "Error connecting to Kubernetes: %v", err)
+				os.Exit(1)
+			}
 		}
 
+		gceClient := newGCEClient()
+
 		var output report.Report
 		if len(args) == 0 {
-			output = ingress.CheckAllIngresses(namespace, client, beconfigClient, feConfigClient)
+			output = ingress.CheckAllIngresses(namespace, client, beconfigClient, feConfigClient, gceClient)
 		} else {
-			output = ingress.CheckIngress(args[0], namespace, client, beconfigClient, feConfigClient)
+			ingressNamespace := namespace
+			if len(files) > 0 {
+				ingressNamespace = manifestNamespace()
+			}
+			output = ingress.CheckIngress(args[0], ingressNamespace, client, beconfigClient, feConfigClient, gceClient)
 		}
 
-		res, err := report.JsonReport(&output)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error processing results: %v", err)
-			os.Exit(1)
-		}
-		fmt.Print(res)
+		printReport(&output)
 	},
 }
 
@@ -70,6 +92,54 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&kubeconfig, "kubeconfig", "k", "", "path to the kubeconfig file for Kubernetes config")
 	rootCmd.PersistentFlags().StringVarP(&kubecontext, "context", "c", "", "context to use for Kubernetes config")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "only check resources from this namespace")
+	rootCmd.PersistentFlags().BoolVar(&checkGCE, "gce", false, "also check the GCE resources of the checked resources, using the application default credentials")
+	rootCmd.PersistentFlags().StringVarP(&project, "project", "p", "", "GCP project of the GCE resources, required with --gce")
+	rootCmd.PersistentFlags().StringVarP(&region, "region", "r", "", "region of the regional GCE resources, used by internal ingresses and L4 load balancers")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", report.JSONOutput, "output format of the check results, one of json, sarif or junit")
+	rootCmd.Flags().StringSliceVarP(&files, "files", "f", nil, "check the resources of these manifest files instead of a cluster, \"-\" reads the manifests from stdin")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", report.None, "exit with code 2 if a check of this severity or above fails, one of error, warning, info or none")
+}
+
+// manifestNamespace returns the namespace of the --files manifests without a
+// namespace, --namespace or default.
+func manifestNamespace() string {
+	if namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
+
+// newFakeClientSets returns in-memory clientsets serving the resources of the
+// --files manifests.
+func newFakeClientSets() (kubernetes.Interface, beconfigclient.Interface, feconfigclient.Interface) {
+	manifests, err := kube.ReadManifests(files, manifestNamespace(), os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading manifests: %v", err)
+		os.Exit(1)
+	}
+	return kube.NewFakeClientSets(manifests)
+}
+
+// newGCEClient returns the GCE client used to check the GCE resources, or nil
+// if they are not checked.
+func newGCEClient() *gce.Client {
+	if !checkGCE {
+		return nil
+	}
+	if project == "" {
+		fmt.Fprintf(os.Stderr, "Error: --project must be set to check GCE resources")
+		os.Exit(1)
+	}
+	cloud, err := gce.NewCloud(project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting to GCE: %v", err)
+		os.Exit(1)
+	}
+	return &gce.Client{
+		Cloud:   cloud,
+		Project: project,
+		Region:  region,
+	}
 }
 
 // Execute is the primary entrypoint for this CLI
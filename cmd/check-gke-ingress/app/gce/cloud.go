@@ -0,0 +1,66 @@
+// Copyright 2026 the Kubernetes Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gce
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"golang.org/x/oauth2/google"
+	computealpha "google.golang.org/api/compute/v0.alpha"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// computeScope is the OAuth scope needed to read the GCE resources.
+const computeScope = "https://www.googleapis.com/auth/compute.readonly"
+
+// Client is used to fetch the GCE resources of the checked resources.
+type Client struct {
+	// GCE cloud
+	Cloud cloud.Cloud
+	// Project of the GCE resources
+	Project string
+	// Region of the regional GCE resources
+	Region string
+}
+
+// NewCloud returns a new GCE cloud for the given project, authenticated with
+// the application default credentials.
+func NewCloud(project string) (cloud.Cloud, error) {
+	client, err := google.DefaultClient(context.Background(), computeScope)
+	if err != nil {
+		return nil, err
+	}
+	service, err := compute.New(client)
+	if err != nil {
+		return nil, err
+	}
+	serviceAlpha, err := computealpha.New(client)
+	if err != nil {
+		return nil, err
+	}
+	serviceBeta, err := computebeta.New(client)
+	if err != nil {
+		return nil, err
+	}
+	return cloud.NewGCE(&cloud.Service{
+		GA:            service,
+		Alpha:         serviceAlpha,
+		Beta:          serviceBeta,
+		ProjectRouter: &cloud.SingleProjectRouter{ID: project},
+		RateLimiter:   &cloud.NopRateLimiter{},
+	}), nil
+}
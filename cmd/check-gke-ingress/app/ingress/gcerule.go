@@ -0,0 +1,631 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const (
+	UrlMapExistenceCheck         = "UrlMapExistenceCheck"
+	BackendServiceExistenceCheck = "BackendServiceExistenceCheck"
+	BackendHealthCheck           = "BackendHealthCheck"
+	HealthCheckFirewallCheck     = "HealthCheckFirewallCheck"
+	SSLCertificateCheck          = "SSLCertificateCheck"
+	QuotaCheck                   = "QuotaCheck"
+)
+
+// healthCheckSrcRanges are the source ranges of the GCE health check probes,
+// which the firewall rules of the load balancer backends must allow.
+var healthCheckSrcRanges = []string{"130.211.0.0/22", "35.191.0.0/16"}
+
+type GCEChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
+	// GCE client
+	client *gce.Client
+	// Ingress whose GCE resources are checked
+	ingress *networkingv1.Ingress
+	// Whether the load balancer resources are regional
+	regional bool
+	// URL map of the load balancer
+	urlMap *compute.UrlMap
+	// Backend services referenced by the URL map
+	backendServices []*compute.BackendService
+	// GCE resources of the cluster shared by the checks of all the ingresses
+	cluster *gceCluster
+}
+
+// gceCluster holds the GCE resources of the cluster which the checks of all
+// the ingresses of a report need. They are fetched once, on first use.
+type gceCluster struct {
+	// GCE client
+	client *gce.Client
+	// Kubernetes client, to find the instances of the nodes
+	kubeClient kubernetes.Interface
+
+	loaded bool
+	err    error
+	// Firewall rules of the project
+	firewalls []*compute.Firewall
+	// URL of the network of the nodes
+	network string
+	// Distinct network tags and service accounts of the nodes
+	targets []firewallTarget
+}
+
+// firewallTarget is a set of instances which firewall rules select by their
+// network tags or service accounts.
+type firewallTarget struct {
+	tags            []string
+	serviceAccounts []string
+}
+
+func newGCECluster(client *gce.Client, kubeClient kubernetes.Interface) *gceCluster {
+	return &gceCluster{client: client, kubeClient: kubeClient}
+}
+
+type gceCheckFunc func(c *GCEChecker) (string, string, string)
+
+// CheckUrlMapExistence checks whether the URL map recorded in the ingress
+// annotations exists.
+func CheckUrlMapExistence(c *GCEChecker) (string, string, string) {
+	name, ok := c.ingress.Annotations[annotations.UrlMapKey]
+	if !ok {
+		return UrlMapExistenceCheck, report.Failed, fmt.Sprintf("Ingress %s/%s does not have a url map annotation, its load balancer has not been created", c.ingress.Namespace, c.ingress.Name)
+	}
+	if c.regional && c.client.Region == "" {
+		return UrlMapExistenceCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s is for L7 internal load balancing, region must be set to check its regional resources", c.ingress.Namespace, c.ingress.Name)
+	}
+	var urlMap *compute.UrlMap
+	var err error
+	if c.regional {
+		urlMap, err = c.client.Cloud.RegionUrlMaps().Get(context.TODO(), meta.RegionalKey(name, c.client.Region))
+	} else {
+		urlMap, err = c.client.Cloud.UrlMaps().Get(context.TODO(), meta.GlobalKey(name))
+	}
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return UrlMapExistenceCheck, report.Failed, fmt.Sprintf("URL map %s of ingress %s/%s does not exist", name, c.ingress.Namespace, c.ingress.Name)
+		}
+		return UrlMapExistenceCheck, report.Failed, fmt.Sprintf("Failed to get URL map %s of ingress %s/%s: %v", name, c.ingress.Namespace, c.ingress.Name, err)
+	}
+	c.urlMap = urlMap
+	return UrlMapExistenceCheck, report.Passed, fmt.Sprintf("URL map %s of ingress %s/%s found", name, c.ingress.Namespace, c.ingress.Name)
+}
+
+// CheckBackendServiceExistence checks whether the backend services referenced
+// by the URL map exist.
+func CheckBackendServiceExistence(c *GCEChecker) (string, string, string) {
+	if c.urlMap == nil {
+		return BackendServiceExistenceCheck, report.Skipped, fmt.Sprintf("URL map of ingress %s/%s does not exist", c.ingress.Namespace, c.ingress.Name)
+	}
+	var missing []string
+	for _, url := range urlMapServices(c.urlMap) {
+		id, err := cloud.ParseResourceURL(url)
+		if err != nil || id.Resource != "backendServices" {
+			// Backend buckets are not checked.
+			continue
+		}
+		var bs *compute.BackendService
+		if id.Key.Type() == meta.Regional {
+			bs, err = c.client.Cloud.RegionBackendServices().Get(context.TODO(), id.Key)
+		} else {
+			bs, err = c.client.Cloud.BackendServices().Get(context.TODO(), id.Key)
+		}
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				missing = append(missing, id.Key.Name)
+				continue
+			}
+			return BackendServiceExistenceCheck, report.Failed, fmt.Sprintf("Failed to get backend service %s of ingress %s/%s: %v", id.Key.Name, c.ingress.Namespace, c.ingress.Name, err)
+		}
+		c.backendServices = append(c.backendServices, bs)
+	}
+	if len(missing) > 0 {
+		return BackendServiceExistenceCheck, report.Failed, fmt.Sprintf("Backend services %s of ingress %s/%s do not exist", strings.Join(missing, ", "), c.ingress.Namespace, c.ingress.Name)
+	}
+	return BackendServiceExistenceCheck, report.Passed, fmt.Sprintf("Backend services of ingress %s/%s found", c.ingress.Namespace, c.ingress.Name)
+}
+
+// CheckBackendHealth checks whether all the endpoints of the backends of the
+// backend services are healthy.
+func CheckBackendHealth(c *GCEChecker) (string, string, string) {
+	if len(c.backendServices) == 0 {
+		return BackendHealthCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s does not have backend services", c.ingress.Namespace, c.ingress.Name)
+	}
+	var problems []string
+	for _, bs := range c.backendServices {
+		if len(bs.Backends) == 0 {
+			problems = append(problems, fmt.Sprintf("backend service %s has no backends", bs.Name))
+			continue
+		}
+		for _, be := range bs.Backends {
+			health, err := c.getHealth(bs, be.Group)
+			if err != nil {
+				return BackendHealthCheck, report.Failed, fmt.Sprintf("Failed to get the health of backend %s of backend service %s: %v", be.Group, bs.Name, err)
+			}
+			var healthy, unhealthy int
+			for _, status := range health.HealthStatus {
+				if status.HealthState == "HEALTHY" {
+					healthy++
+				} else {
+					unhealthy++
+				}
+			}
+			if unhealthy > 0 || healthy == 0 {
+				problems = append(problems, fmt.Sprintf("backend %s of backend service %s has %d healthy and %d unhealthy endpoints", resourceName(be.Group), bs.Name, healthy, unhealthy))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return BackendHealthCheck, report.Failed, fmt.Sprintf("Ingress %s/%s has unhealthy backends: %s", c.ingress.Namespace, c.ingress.Name, strings.Join(problems, "; "))
+	}
+	return BackendHealthCheck, report.Passed, fmt.Sprintf("All backends of ingress %s/%s are healthy", c.ingress.Namespace, c.ingress.Name)
+}
+
+// CheckHealthCheckFirewall checks whether the firewall rules of the network
+// of the nodes allow the health check probes to reach the health check ports
+// of the backend services on every node.
+func CheckHealthCheckFirewall(c *GCEChecker) (string, string, string) {
+	if len(c.backendServices) == 0 {
+		return HealthCheckFirewallCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s does not have backend services", c.ingress.Namespace, c.ingress.Name)
+	}
+	if err := c.cluster.load(); err != nil {
+		return HealthCheckFirewallCheck, report.Failed, fmt.Sprintf("Failed to get the firewall rules and the nodes: %v", err)
+	}
+	if len(c.cluster.targets) == 0 {
+		return HealthCheckFirewallCheck, report.Skipped, fmt.Sprintf("No GCE instance of the nodes found to check the firewall rules of ingress %s/%s against", c.ingress.Namespace, c.ingress.Name)
+	}
+	var blocked, unknown []string
+	for _, bs := range c.backendServices {
+		if len(bs.HealthChecks) == 0 {
+			continue
+		}
+		hc, err := c.getHealthCheck(bs.HealthChecks[0])
+		if err != nil {
+			return HealthCheckFirewallCheck, report.Failed, fmt.Sprintf("Failed to get health check of backend service %s: %v", bs.Name, err)
+		}
+		ports := []int64{healthCheckPort(hc)}
+		if ports[0] == 0 {
+			// The health check probes the serving port of each endpoint.
+			if ports, err = c.servingPorts(bs); err != nil {
+				return HealthCheckFirewallCheck, report.Failed, fmt.Sprintf("Failed to get the serving ports of backend service %s: %v", bs.Name, err)
+			}
+			if len(ports) == 0 {
+				unknown = append(unknown, bs.Name)
+				continue
+			}
+		}
+		for _, port := range ports {
+			for _, srcRange := range healthCheckSrcRanges {
+				for _, target := range c.cluster.targets {
+					if !firewallAllows(c.cluster.firewalls, c.cluster.network, target, srcRange, port) {
+						blocked = append(blocked, fmt.Sprintf("health check %s port %d from %s to %s", hc.Name, port, srcRange, target))
+					}
+				}
+			}
+		}
+	}
+	if len(blocked) > 0 {
+		return HealthCheckFirewallCheck, report.Failed, fmt.Sprintf("No firewall rule allows the health check probes of ingress %s/%s: %s", c.ingress.Namespace, c.ingress.Name, strings.Join(blocked, "; "))
+	}
+	if len(unknown) > 0 {
+		return HealthCheckFirewallCheck, report.Passed, fmt.Sprintf("Firewall rules allow the health check probes of ingress %s/%s, except for backend services %s whose serving ports are unknown", c.ingress.Namespace, c.ingress.Name, strings.Join(unknown, ", "))
+	}
+	return HealthCheckFirewallCheck, report.Passed, fmt.Sprintf("Firewall rules allow the health check probes of ingress %s/%s", c.ingress.Namespace, c.ingress.Name)
+}
+
+// CheckSSLCertificates checks whether the SSL certificates recorded in the
+// ingress annotations are provisioned and not expired.
+func CheckSSLCertificates(c *GCEChecker) (string, string, string) {
+	val, ok := c.ingress.Annotations[annotations.SSLCertKey]
+	if !ok || val == "" {
+		return SSLCertificateCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s does not have SSL certificates", c.ingress.Namespace, c.ingress.Name)
+	}
+	if c.regional && c.client.Region == "" {
+		return SSLCertificateCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s is for L7 internal load balancing, region must be set to check its regional resources", c.ingress.Namespace, c.ingress.Name)
+	}
+	var problems []string
+	for _, name := range strings.Split(val, ",") {
+		name = strings.TrimSpace(name)
+		var cert *compute.SslCertificate
+		var err error
+		if c.regional {
+			cert, err = c.client.Cloud.RegionSslCertificates().Get(context.TODO(), meta.RegionalKey(name, c.client.Region))
+		} else {
+			cert, err = c.client.Cloud.SslCertificates().Get(context.TODO(), meta.GlobalKey(name))
+		}
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				problems = append(problems, fmt.Sprintf("certificate %s does not exist", name))
+				continue
+			}
+			return SSLCertificateCheck, report.Failed, fmt.Sprintf("Failed to get SSL certificate %s: %v", name, err)
+		}
+		if cert.Managed != nil && cert.Managed.Status != "ACTIVE" {
+			problems = append(problems, fmt.Sprintf("managed certificate %s is %s%s", name, cert.Managed.Status, domainStatus(cert.Managed.DomainStatus)))
+			continue
+		}
+		if cert.ExpireTime != "" {
+			if expire, err := time.Parse(time.RFC3339, cert.ExpireTime); err == nil && expire.Before(time.Now()) {
+				problems = append(problems, fmt.Sprintf("certificate %s expired at %s", name, cert.ExpireTime))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return SSLCertificateCheck, report.Failed, fmt.Sprintf("Ingress %s/%s has invalid SSL certificates: %s", c.ingress.Namespace, c.ingress.Name, strings.Join(problems, "; "))
+	}
+	return SSLCertificateCheck, report.Passed, fmt.Sprintf("SSL certificates of ingress %s/%s are valid", c.ingress.Namespace, c.ingress.Name)
+}
+
+// CheckQuota checks whether any quota of the project, or of the region of the
+// internal ingresses, is exhausted. It runs once per report, with regional
+// set if any ingress is internal.
+func CheckQuota(c *GCEChecker) (string, string, string) {
+	project, err := c.client.Cloud.Projects().Get(context.TODO(), c.client.Project)
+	if err != nil {
+		return QuotaCheck, report.Failed, fmt.Sprintf("Failed to get project %s: %v", c.client.Project, err)
+	}
+	exhausted := exhaustedQuotas(project.Quotas)
+	if c.regional && c.client.Region != "" {
+		region, err := c.client.Cloud.Regions().Get(context.TODO(), meta.GlobalKey(c.client.Region))
+		if err != nil {
+			return QuotaCheck, report.Failed, fmt.Sprintf("Failed to get region %s: %v", c.client.Region, err)
+		}
+		exhausted = append(exhausted, exhaustedQuotas(region.Quotas)...)
+	}
+	if len(exhausted) > 0 {
+		return QuotaCheck, report.Failed, fmt.Sprintf("Quotas are exhausted: %s", strings.Join(exhausted, ", "))
+	}
+	return QuotaCheck, report.Passed, "No quota is exhausted"
+}
+
+// load fetches the firewall rules of the project, and the network, network
+// tags and service accounts of the instances of the nodes. Nodes without a
+// GCE provider ID are ignored.
+func (g *gceCluster) load() error {
+	if g.loaded {
+		return g.err
+	}
+	g.loaded = true
+	g.firewalls, g.err = g.client.Cloud.Firewalls().List(context.TODO(), filter.None)
+	if g.err != nil {
+		return g.err
+	}
+	nodes, err := g.kubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		g.err = err
+		return err
+	}
+	zoneNodes := map[string]sets.Set[string]{}
+	for _, node := range nodes.Items {
+		zone, name, ok := parseProviderID(node.Spec.ProviderID)
+		if !ok {
+			continue
+		}
+		if zoneNodes[zone] == nil {
+			zoneNodes[zone] = sets.New[string]()
+		}
+		zoneNodes[zone].Insert(name)
+	}
+	seen := sets.New[string]()
+	for _, zone := range sets.List(sets.KeySet(zoneNodes)) {
+		instances, err := g.client.Cloud.Instances().List(context.TODO(), zone, filter.None)
+		if err != nil {
+			g.err = err
+			return err
+		}
+		for _, instance := range instances {
+			if !zoneNodes[zone].Has(instance.Name) {
+				continue
+			}
+			if g.network == "" && len(instance.NetworkInterfaces) > 0 {
+				g.network = instance.NetworkInterfaces[0].Network
+			}
+			target := firewallTarget{}
+			if instance.Tags != nil {
+				target.tags = sets.List(sets.New(instance.Tags.Items...))
+			}
+			for _, sa := range instance.ServiceAccounts {
+				target.serviceAccounts = append(target.serviceAccounts, sa.Email)
+			}
+			sort.Strings(target.serviceAccounts)
+			if key := target.String(); !seen.Has(key) {
+				seen.Insert(key)
+				g.targets = append(g.targets, target)
+			}
+		}
+	}
+	return nil
+}
+
+// parseProviderID returns the zone and the instance name of the provider ID
+// of a GKE node, in the format of gce://<project>/<zone>/<instance>.
+func parseProviderID(providerID string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(providerID, "gce://"), "/")
+	if !strings.HasPrefix(providerID, "gce://") || len(parts) != 3 {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// String describes the instances of the target.
+func (t firewallTarget) String() string {
+	return fmt.Sprintf("nodes with tags [%s] and service accounts [%s]", strings.Join(t.tags, ", "), strings.Join(t.serviceAccounts, ", "))
+}
+
+// selectedBy returns whether a firewall rule applies to the instances of the
+// target. A rule without target tags and service accounts applies to all the
+// instances of its network.
+func (t firewallTarget) selectedBy(fw *compute.Firewall) bool {
+	if len(fw.TargetTags) == 0 && len(fw.TargetServiceAccounts) == 0 {
+		return true
+	}
+	return sets.New(t.tags...).HasAny(fw.TargetTags...) || sets.New(t.serviceAccounts...).HasAny(fw.TargetServiceAccounts...)
+}
+
+// servingPorts returns the ports of the endpoints of the NEG backends of a
+// backend service, or its named port for instance group backends.
+func (c *GCEChecker) servingPorts(bs *compute.BackendService) ([]int64, error) {
+	ports := sets.New[int64]()
+	for _, be := range bs.Backends {
+		id, err := cloud.ParseResourceURL(be.Group)
+		if err != nil {
+			continue
+		}
+		switch {
+		case id.Resource == "networkEndpointGroups" && id.Key.Type() == meta.Zonal:
+			endpoints, err := c.client.Cloud.NetworkEndpointGroups().ListNetworkEndpoints(context.TODO(), id.Key, &compute.NetworkEndpointGroupsListEndpointsRequest{}, filter.None)
+			if err != nil {
+				return nil, err
+			}
+			for _, ep := range endpoints {
+				if ep.NetworkEndpoint != nil && ep.NetworkEndpoint.Port != 0 {
+					ports.Insert(ep.NetworkEndpoint.Port)
+				}
+			}
+		case id.Resource == "instanceGroups" && bs.Port != 0:
+			ports.Insert(bs.Port)
+		}
+	}
+	return sets.List(ports), nil
+}
+
+// getHealth gets the health of a backend of a global or regional backend
+// service.
+func (c *GCEChecker) getHealth(bs *compute.BackendService, group string) (*compute.BackendServiceGroupHealth, error) {
+	ref := &compute.ResourceGroupReference{Group: group}
+	if bs.Region != "" {
+		return c.client.Cloud.RegionBackendServices().GetHealth(context.TODO(), meta.RegionalKey(bs.Name, resourceName(bs.Region)), ref)
+	}
+	return c.client.Cloud.BackendServices().GetHealth(context.TODO(), meta.GlobalKey(bs.Name), ref)
+}
+
+// getHealthCheck gets a global or regional health check from its URL.
+func (c *GCEChecker) getHealthCheck(url string) (*compute.HealthCheck, error) {
+	id, err := cloud.ParseResourceURL(url)
+	if err != nil {
+		return nil, err
+	}
+	if id.Key.Type() == meta.Regional {
+		return c.client.Cloud.RegionHealthChecks().Get(context.TODO(), id.Key)
+	}
+	return c.client.Cloud.HealthChecks().Get(context.TODO(), id.Key)
+}
+
+// urlMapServices returns the URLs of the services referenced by a URL map.
+func urlMapServices(urlMap *compute.UrlMap) []string {
+	seen := make(map[string]bool)
+	var services []string
+	add := func(url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			services = append(services, url)
+		}
+	}
+	add(urlMap.DefaultService)
+	for _, pm := range urlMap.PathMatchers {
+		add(pm.DefaultService)
+		for _, rule := range pm.PathRules {
+			add(rule.Service)
+		}
+		for _, rule := range pm.RouteRules {
+			add(rule.Service)
+			if rule.RouteAction != nil {
+				for _, wbs := range rule.RouteAction.WeightedBackendServices {
+					add(wbs.BackendService)
+				}
+			}
+		}
+	}
+	return services
+}
+
+// healthCheckPort returns the port probed by a health check, or 0 if it
+// probes the serving port of each endpoint.
+func healthCheckPort(hc *compute.HealthCheck) int64 {
+	switch {
+	case hc.HttpHealthCheck != nil:
+		return hc.HttpHealthCheck.Port
+	case hc.HttpsHealthCheck != nil:
+		return hc.HttpsHealthCheck.Port
+	case hc.Http2HealthCheck != nil:
+		return hc.Http2HealthCheck.Port
+	case hc.TcpHealthCheck != nil:
+		return hc.TcpHealthCheck.Port
+	}
+	return 0
+}
+
+// firewallAllows returns whether the firewall rules of the network allow TCP
+// traffic from the source range to the port of the instances of the target.
+// Like GCE, the rule of the highest priority (lowest value) applying to the
+// traffic decides, and deny rules take precedence over allow rules of the
+// same priority. A deny rule applies as soon as its source ranges overlap
+// the source range, since it blocks part of the traffic.
+func firewallAllows(firewalls []*compute.Firewall, network string, target firewallTarget, srcRange string, port int64) bool {
+	src, err := netip.ParsePrefix(srcRange)
+	if err != nil {
+		return false
+	}
+	allowPriority, denyPriority := int64(-1), int64(-1)
+	for _, fw := range firewalls {
+		if fw.Disabled || (fw.Direction != "" && fw.Direction != "INGRESS") {
+			continue
+		}
+		if network != "" && fw.Network != "" && !sameNetwork(fw.Network, network) {
+			continue
+		}
+		if !target.selectedBy(fw) {
+			continue
+		}
+		if rangesCover(fw.SourceRanges, src) {
+			for _, allowed := range fw.Allowed {
+				if protocolAllowsPort(allowed.IPProtocol, allowed.Ports, port) && (allowPriority < 0 || fw.Priority < allowPriority) {
+					allowPriority = fw.Priority
+				}
+			}
+		}
+		if rangesOverlap(fw.SourceRanges, src) {
+			for _, denied := range fw.Denied {
+				if protocolAllowsPort(denied.IPProtocol, denied.Ports, port) && (denyPriority < 0 || fw.Priority < denyPriority) {
+					denyPriority = fw.Priority
+				}
+			}
+		}
+	}
+	return allowPriority >= 0 && (denyPriority < 0 || allowPriority < denyPriority)
+}
+
+// protocolAllowsPort returns whether the protocol and ports of a firewall
+// rule match TCP traffic to the port.
+func protocolAllowsPort(protocol string, ports []string, port int64) bool {
+	if protocol != "tcp" && protocol != "all" {
+		return false
+	}
+	return len(ports) == 0 || portsCover(ports, port)
+}
+
+// sameNetwork returns whether two network URLs reference the same network.
+func sameNetwork(a, b string) bool {
+	idA, errA := cloud.ParseResourceURL(a)
+	idB, errB := cloud.ParseResourceURL(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return idA.ProjectID == idB.ProjectID && idA.Key.Name == idB.Key.Name
+}
+
+// rangesOverlap returns whether one of the ranges overlaps the prefix.
+func rangesOverlap(ranges []string, prefix netip.Prefix) bool {
+	for _, r := range ranges {
+		p, err := netip.ParsePrefix(r)
+		if err != nil {
+			continue
+		}
+		if p.Overlaps(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangesCover returns whether one of the ranges contains the prefix.
+func rangesCover(ranges []string, prefix netip.Prefix) bool {
+	for _, r := range ranges {
+		p, err := netip.ParsePrefix(r)
+		if err != nil {
+			continue
+		}
+		if p.Bits() <= prefix.Bits() && p.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// portsCover returns whether the port is in one of the ports or port ranges
+// of a firewall rule.
+func portsCover(ports []string, port int64) bool {
+	for _, p := range ports {
+		low, high, found := strings.Cut(p, "-")
+		if !found {
+			high = low
+		}
+		l, errLow := strconv.ParseInt(low, 10, 64)
+		h, errHigh := strconv.ParseInt(high, 10, 64)
+		if errLow == nil && errHigh == nil && l <= port && port <= h {
+			return true
+		}
+	}
+	return false
+}
+
+// exhaustedQuotas returns the metrics of the quotas whose usage reached their
+// limit.
+func exhaustedQuotas(quotas []*compute.Quota) []string {
+	var exhausted []string
+	for _, q := range quotas {
+		if q.Limit > 0 && q.Usage >= q.Limit {
+			exhausted = append(exhausted, fmt.Sprintf("%s (%v/%v)", q.Metric, q.Usage, q.Limit))
+		}
+	}
+	return exhausted
+}
+
+// domainStatus formats the provisioning status of the domains of a managed
+// certificate.
+func domainStatus(status map[string]string) string {
+	if len(status) == 0 {
+		return ""
+	}
+	domains := make([]string, 0, len(status))
+	for domain := range status {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	var parts []string
+	for _, domain := range domains {
+		parts = append(parts, fmt.Sprintf("%s: %s", domain, status[domain]))
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
+}
+
+// resourceName returns the last segment of a resource URL.
+func resourceName(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
@@ -0,0 +1,719 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	"k8s.io/ingress-gce/pkg/annotations"
+	fakebeconfig "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	fakefeconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned/fake"
+)
+
+const (
+	testProject = "test-project"
+	testRegion  = "us-central1"
+)
+
+func newTestGCEClient() (*gce.Client, *cloud.MockGCE) {
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: testProject})
+	return &gce.Client{Cloud: mockGCE, Project: testProject, Region: testRegion}, mockGCE
+}
+
+func testSelfLink(resource string, key *meta.Key) string {
+	return cloud.SelfLink(meta.VersionGA, testProject, resource, key)
+}
+
+func testGCEIngress(annotationMap map[string]string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "ingress1",
+			Annotations: annotationMap,
+		},
+	}
+}
+
+func TestCheckUrlMapExistence(t *testing.T) {
+	client, mockGCE := newTestGCEClient()
+	mockGCE.UrlMaps().Insert(context.TODO(), meta.GlobalKey("um"), &compute.UrlMap{Name: "um"})
+	mockGCE.RegionUrlMaps().Insert(context.TODO(), meta.RegionalKey("ilb-um", testRegion), &compute.UrlMap{Name: "ilb-um"})
+
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		regional    bool
+		region      string
+		expect      string
+	}{
+		{
+			desc:   "no url map annotation",
+			expect: report.Failed,
+		},
+		{
+			desc:        "url map does not exist",
+			annotations: map[string]string{annotations.UrlMapKey: "missing"},
+			expect:      report.Failed,
+		},
+		{
+			desc:        "url map exists",
+			annotations: map[string]string{annotations.UrlMapKey: "um"},
+			expect:      report.Passed,
+		},
+		{
+			desc:        "regional url map without region",
+			annotations: map[string]string{annotations.UrlMapKey: "ilb-um"},
+			regional:    true,
+			expect:      report.Skipped,
+		},
+		{
+			desc:        "regional url map exists",
+			annotations: map[string]string{annotations.UrlMapKey: "ilb-um"},
+			regional:    true,
+			region:      testRegion,
+			expect:      report.Passed,
+		},
+	} {
+		checker := &GCEChecker{
+			client:   &gce.Client{Cloud: client.Cloud, Project: testProject, Region: tc.region},
+			ingress:  testGCEIngress(tc.annotations),
+			regional: tc.regional,
+		}
+		_, res, msg := CheckUrlMapExistence(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+		if res == report.Passed && checker.urlMap == nil {
+			t.Errorf("For test case %q, url map was not recorded", tc.desc)
+		}
+	}
+}
+
+func TestCheckBackendServiceExistence(t *testing.T) {
+	client, mockGCE := newTestGCEClient()
+	for _, name := range []string{"bs1", "bs2"} {
+		mockGCE.BackendServices().Insert(context.TODO(), meta.GlobalKey(name), &compute.BackendService{Name: name})
+	}
+	mockGCE.RegionBackendServices().Insert(context.TODO(), meta.RegionalKey("ilb-bs", testRegion), &compute.BackendService{Name: "ilb-bs"})
+
+	for _, tc := range []struct {
+		desc       string
+		urlMap     *compute.UrlMap
+		expect     string
+		expectNum  int
+		expectName string
+	}{
+		{
+			desc:   "no url map",
+			expect: report.Skipped,
+		},
+		{
+			desc: "all backend services exist",
+			urlMap: &compute.UrlMap{
+				DefaultService: testSelfLink("backendServices", meta.GlobalKey("bs1")),
+				PathMatchers: []*compute.PathMatcher{
+					{
+						DefaultService: testSelfLink("backendServices", meta.GlobalKey("bs1")),
+						PathRules: []*compute.PathRule{
+							{Paths: []string{"/a"}, Service: testSelfLink("backendServices", meta.GlobalKey("bs2"))},
+							{Paths: []string{"/static"}, Service: testSelfLink("backendBuckets", meta.GlobalKey("bucket"))},
+						},
+					},
+				},
+			},
+			expect:    report.Passed,
+			expectNum: 2,
+		},
+		{
+			desc: "regional backend service exists",
+			urlMap: &compute.UrlMap{
+				DefaultService: testSelfLink("backendServices", meta.RegionalKey("ilb-bs", testRegion)),
+			},
+			expect:    report.Passed,
+			expectNum: 1,
+		},
+		{
+			desc: "backend service does not exist",
+			urlMap: &compute.UrlMap{
+				DefaultService: testSelfLink("backendServices", meta.GlobalKey("bs1")),
+				PathMatchers: []*compute.PathMatcher{
+					{
+						DefaultService: testSelfLink("backendServices", meta.GlobalKey("missing")),
+					},
+				},
+			},
+			expect:    report.Failed,
+			expectNum: 1,
+		},
+	} {
+		checker := &GCEChecker{
+			client:  client,
+			ingress: testGCEIngress(nil),
+			urlMap:  tc.urlMap,
+		}
+		_, res, msg := CheckBackendServiceExistence(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+		if len(checker.backendServices) != tc.expectNum {
+			t.Errorf("For test case %q, expect %d backend services, but got %d", tc.desc, tc.expectNum, len(checker.backendServices))
+		}
+	}
+}
+
+func TestCheckBackendHealth(t *testing.T) {
+	client, mockGCE := newTestGCEClient()
+	healthyNEG := testSelfLink("networkEndpointGroups", meta.ZonalKey("healthy", "us-central1-a"))
+	unhealthyNEG := testSelfLink("networkEndpointGroups", meta.ZonalKey("unhealthy", "us-central1-b"))
+	emptyNEG := testSelfLink("networkEndpointGroups", meta.ZonalKey("empty", "us-central1-c"))
+	health := map[string][]*compute.HealthStatus{
+		healthyNEG:   {{HealthState: "HEALTHY"}, {HealthState: "HEALTHY"}},
+		unhealthyNEG: {{HealthState: "HEALTHY"}, {HealthState: "UNHEALTHY"}},
+	}
+	getHealth := func(group string) (*compute.BackendServiceGroupHealth, error) {
+		return &compute.BackendServiceGroupHealth{HealthStatus: health[group]}, nil
+	}
+	mockGCE.MockBackendServices.GetHealthHook = func(_ context.Context, _ *meta.Key, ref *compute.ResourceGroupReference, _ *cloud.MockBackendServices, _ ...cloud.Option) (*compute.BackendServiceGroupHealth, error) {
+		return getHealth(ref.Group)
+	}
+	mockGCE.MockRegionBackendServices.GetHealthHook = func(_ context.Context, key *meta.Key, ref *compute.ResourceGroupReference, _ *cloud.MockRegionBackendServices, _ ...cloud.Option) (*compute.BackendServiceGroupHealth, error) {
+		if key.Region != testRegion {
+			return nil, fmt.Errorf("unexpected region %q", key.Region)
+		}
+		return getHealth(ref.Group)
+	}
+
+	for _, tc := range []struct {
+		desc            string
+		backendServices []*compute.BackendService
+		expect          string
+	}{
+		{
+			desc:   "no backend services",
+			expect: report.Skipped,
+		},
+		{
+			desc: "healthy backends",
+			backendServices: []*compute.BackendService{
+				{Name: "bs1", Backends: []*compute.Backend{{Group: healthyNEG}}},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "healthy regional backends",
+			backendServices: []*compute.BackendService{
+				{Name: "bs1", Region: testSelfLink("regions", meta.GlobalKey(testRegion)), Backends: []*compute.Backend{{Group: healthyNEG}}},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "unhealthy endpoint",
+			backendServices: []*compute.BackendService{
+				{Name: "bs1", Backends: []*compute.Backend{{Group: healthyNEG}, {Group: unhealthyNEG}}},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "backend without endpoints",
+			backendServices: []*compute.BackendService{
+				{Name: "bs1", Backends: []*compute.Backend{{Group: emptyNEG}}},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "backend service without backends",
+			backendServices: []*compute.BackendService{
+				{Name: "bs1"},
+			},
+			expect: report.Failed,
+		},
+	} {
+		checker := &GCEChecker{
+			client:          client,
+			ingress:         testGCEIngress(nil),
+			backendServices: tc.backendServices,
+		}
+		_, res, msg := CheckBackendHealth(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckHealthCheckFirewall(t *testing.T) {
+	network := testSelfLink("networks", meta.GlobalKey("default"))
+	for _, tc := range []struct {
+		desc      string
+		port      int64
+		noNodes   bool
+		firewalls []*compute.Firewall
+		expect    string
+	}{
+		{
+			desc:   "no firewall rules",
+			port:   8080,
+			expect: report.Failed,
+		},
+		{
+			desc: "firewall rule allows health check port",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"30000-32767", "8080"}}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "firewall rules with wider source ranges",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"0.0.0.0/0"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "all"}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "firewall rule misses a source range",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"130.211.0.0/22"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "firewall rule allows another port",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"30000-32767"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "disabled firewall rule",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					Disabled:     true,
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "firewall rule of another network",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					Network:      testSelfLink("networks", meta.GlobalKey("other")),
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "firewall rule targets the tag of the nodes",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					Network:      network,
+					TargetTags:   []string{"gke-node"},
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "firewall rule targets the service account of the nodes",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:                  "fw1",
+					TargetServiceAccounts: []string{"node@test-project.iam.gserviceaccount.com"},
+					SourceRanges:          []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:               []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "firewall rule targets other instances",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					TargetTags:   []string{"other"},
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "deny rule of higher priority",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "allow",
+					Priority:     1000,
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+				{
+					Name:         "deny",
+					Priority:     900,
+					SourceRanges: []string{"35.191.0.0/24"},
+					Denied:       []*compute.FirewallDenied{{IPProtocol: "all"}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "deny rule of the same priority",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "allow",
+					Priority:     1000,
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+				{
+					Name:         "deny",
+					Priority:     1000,
+					SourceRanges: []string{"0.0.0.0/0"},
+					Denied:       []*compute.FirewallDenied{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "deny rule of lower priority",
+			port: 8080,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "allow",
+					Priority:     1000,
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+				{
+					Name:         "deny",
+					Priority:     2000,
+					SourceRanges: []string{"0.0.0.0/0"},
+					Denied:       []*compute.FirewallDenied{{IPProtocol: "all"}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "serving port health check",
+			port: 0,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"9376"}}},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "serving port health check blocked",
+			port: 0,
+			firewalls: []*compute.Firewall{
+				{
+					Name:         "fw1",
+					SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080"}}},
+				},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc:    "no nodes",
+			port:    8080,
+			noNodes: true,
+			expect:  report.Skipped,
+		},
+	} {
+		client, mockGCE := newTestGCEClient()
+		mockGCE.HealthChecks().Insert(context.TODO(), meta.GlobalKey("hc1"), &compute.HealthCheck{
+			Name:            "hc1",
+			Type:            "HTTP",
+			HttpHealthCheck: &compute.HTTPHealthCheck{Port: tc.port},
+		})
+		for _, fw := range tc.firewalls {
+			mockGCE.Firewalls().Insert(context.TODO(), meta.GlobalKey(fw.Name), fw)
+		}
+		mockGCE.MockNetworkEndpointGroups.ListNetworkEndpointsHook = func(_ context.Context, _ *meta.Key, _ *compute.NetworkEndpointGroupsListEndpointsRequest, _ *filter.F, _ *cloud.MockNetworkEndpointGroups, _ ...cloud.Option) ([]*compute.NetworkEndpointWithHealthStatus, error) {
+			return []*compute.NetworkEndpointWithHealthStatus{{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.1", Port: 9376}}}, nil
+		}
+		kubeClient := fake.NewSimpleClientset()
+		if !tc.noNodes {
+			kubeClient = fake.NewSimpleClientset(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Spec:       corev1.NodeSpec{ProviderID: fmt.Sprintf("gce://%s/%s-a/node-1", testProject, testRegion)},
+			})
+			mockGCE.Instances().Insert(context.TODO(), meta.ZonalKey("node-1", testRegion+"-a"), &compute.Instance{
+				Name:              "node-1",
+				Tags:              &compute.Tags{Items: []string{"gke-node"}},
+				ServiceAccounts:   []*compute.ServiceAccount{{Email: "node@test-project.iam.gserviceaccount.com"}},
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+			})
+		}
+		checker := &GCEChecker{
+			client:  client,
+			ingress: testGCEIngress(nil),
+			backendServices: []*compute.BackendService{
+				{
+					Name:         "bs1",
+					HealthChecks: []string{testSelfLink("healthChecks", meta.GlobalKey("hc1"))},
+					Backends:     []*compute.Backend{{Group: testSelfLink("networkEndpointGroups", meta.ZonalKey("neg1", testRegion+"-a"))}},
+				},
+			},
+			cluster: newGCECluster(client, kubeClient),
+		}
+		_, res, msg := CheckHealthCheckFirewall(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckSSLCertificates(t *testing.T) {
+	client, mockGCE := newTestGCEClient()
+	for _, cert := range []*compute.SslCertificate{
+		{Name: "active", Type: "MANAGED", Managed: &compute.SslCertificateManagedSslCertificate{Status: "ACTIVE"}},
+		{Name: "provisioning", Type: "MANAGED", Managed: &compute.SslCertificateManagedSslCertificate{
+			Status:       "PROVISIONING",
+			DomainStatus: map[string]string{"abc.xyz": "FAILED_NOT_VISIBLE"},
+		}},
+		{Name: "valid", ExpireTime: time.Now().Add(24 * time.Hour).Format(time.RFC3339)},
+		{Name: "expired", ExpireTime: time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+	} {
+		mockGCE.SslCertificates().Insert(context.TODO(), meta.GlobalKey(cert.Name), cert)
+	}
+	mockGCE.RegionSslCertificates().Insert(context.TODO(), meta.RegionalKey("ilb-cert", testRegion), &compute.SslCertificate{Name: "ilb-cert"})
+
+	for _, tc := range []struct {
+		desc     string
+		certs    string
+		regional bool
+		expect   string
+	}{
+		{
+			desc:   "no certificates",
+			expect: report.Skipped,
+		},
+		{
+			desc:   "active and valid certificates",
+			certs:  "active,valid",
+			expect: report.Passed,
+		},
+		{
+			desc:   "managed certificate provisioning",
+			certs:  "active,provisioning",
+			expect: report.Failed,
+		},
+		{
+			desc:   "expired certificate",
+			certs:  "expired",
+			expect: report.Failed,
+		},
+		{
+			desc:   "certificate does not exist",
+			certs:  "missing",
+			expect: report.Failed,
+		},
+		{
+			desc:     "regional certificate",
+			certs:    "ilb-cert",
+			regional: true,
+			expect:   report.Passed,
+		},
+	} {
+		ingAnnotations := map[string]string{}
+		if tc.certs != "" {
+			ingAnnotations[annotations.SSLCertKey] = tc.certs
+		}
+		checker := &GCEChecker{
+			client:   client,
+			ingress:  testGCEIngress(ingAnnotations),
+			regional: tc.regional,
+		}
+		_, res, msg := CheckSSLCertificates(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		projectQuotas []*compute.Quota
+		regionQuotas  []*compute.Quota
+		regional      bool
+		expect        string
+	}{
+		{
+			desc: "quotas available",
+			projectQuotas: []*compute.Quota{
+				{Metric: "BACKEND_SERVICES", Limit: 50, Usage: 10},
+				{Metric: "URL_MAPS", Limit: 30, Usage: 3},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "project quota exhausted",
+			projectQuotas: []*compute.Quota{
+				{Metric: "BACKEND_SERVICES", Limit: 50, Usage: 50},
+			},
+			expect: report.Failed,
+		},
+		{
+			desc: "region quota exhausted for internal ingress",
+			regionQuotas: []*compute.Quota{
+				{Metric: "INTERNAL_ADDRESSES", Limit: 10, Usage: 10},
+			},
+			regional: true,
+			expect:   report.Failed,
+		},
+		{
+			desc: "region quota ignored for external ingress",
+			regionQuotas: []*compute.Quota{
+				{Metric: "INTERNAL_ADDRESSES", Limit: 10, Usage: 10},
+			},
+			expect: report.Passed,
+		},
+	} {
+		client, mockGCE := newTestGCEClient()
+		mockGCE.MockProjects.Objects[*meta.GlobalKey(testProject)] = &cloud.MockProjectsObj{Obj: &compute.Project{Name: testProject, Quotas: tc.projectQuotas}}
+		mockGCE.MockRegions.Objects[*meta.GlobalKey(testRegion)] = &cloud.MockRegionsObj{Obj: &compute.Region{Name: testRegion, Quotas: tc.regionQuotas}}
+		checker := &GCEChecker{
+			client:   client,
+			ingress:  testGCEIngress(nil),
+			regional: tc.regional,
+		}
+		_, res, msg := CheckQuota(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+// countingProjects counts the gets of the project.
+type countingProjects struct {
+	cloud.Projects
+	gets int
+}
+
+func (p *countingProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
+	p.gets++
+	return p.Projects.Get(ctx, projectID)
+}
+
+// projectsCloud is a cloud whose projects are counted.
+type projectsCloud struct {
+	cloud.Cloud
+	projects *countingProjects
+}
+
+func (c *projectsCloud) Projects() cloud.Projects {
+	return c.projects
+}
+
+func TestRunChecksWithGCE(t *testing.T) {
+	client, mockGCE := newTestGCEClient()
+	mockGCE.UrlMaps().Insert(context.TODO(), meta.GlobalKey("um"), &compute.UrlMap{
+		Name:           "um",
+		DefaultService: testSelfLink("backendServices", meta.GlobalKey("bs1")),
+	})
+	mockGCE.MockProjects.Objects[*meta.GlobalKey(testProject)] = &cloud.MockProjectsObj{Obj: &compute.Project{Name: testProject}}
+	projects := &countingProjects{Projects: mockGCE.Projects()}
+	client.Cloud = &projectsCloud{Cloud: mockGCE, projects: projects}
+
+	ingress := *testGCEIngress(map[string]string{annotations.UrlMapKey: "um"})
+	other := *ingress.DeepCopy()
+	other.Name = "ingress2"
+	result := RunChecks([]networkingv1.Ingress{ingress, other}, fake.NewSimpleClientset(), fakebeconfig.NewSimpleClientset(), fakefeconfig.NewSimpleClientset(), client)
+
+	expect := map[string]string{
+		UrlMapExistenceCheck:         report.Passed,
+		BackendServiceExistenceCheck: report.Failed,
+		BackendHealthCheck:           report.Skipped,
+		HealthCheckFirewallCheck:     report.Skipped,
+		SSLCertificateCheck:          report.Skipped,
+	}
+	for _, res := range result.Resources[:2] {
+		got := map[string]string{}
+		for _, check := range res.Checks {
+			if _, ok := expect[check.Name]; ok {
+				got[check.Name] = check.Result
+			}
+		}
+		if diff := cmp.Diff(expect, got); diff != "" {
+			t.Errorf("RunChecks() GCE check results of ingress %s mismatch (-want +got):\n%s", res.Name, diff)
+		}
+	}
+
+	// The quotas are checked once for all the ingresses.
+	if len(result.Resources) != 3 {
+		t.Fatalf("RunChecks() returned %d resources, want 3", len(result.Resources))
+	}
+	project := result.Resources[2]
+	if project.Kind != "Project" || project.Name != testProject || len(project.Checks) != 1 || project.Checks[0].Name != QuotaCheck || project.Checks[0].Result != report.Passed {
+		t.Errorf("RunChecks() returned project resource %+v, want a passed %s on project %s", project, QuotaCheck, testProject)
+	}
+	if projects.gets != 1 {
+		t.Errorf("Got project %d times, want 1", projects.gets)
+	}
+}
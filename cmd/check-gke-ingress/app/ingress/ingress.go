@@ -24,30 +24,31 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
 	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
 	beconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	feconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 )
 
-func CheckAllIngresses(namespace string, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface) report.Report {
+func CheckAllIngresses(namespace string, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface, gceClient *gce.Client) report.Report {
 	ingressList, err := client.NetworkingV1().Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing ingresses: %v", err)
 		os.Exit(1)
 	}
-	return RunChecks(ingressList.Items, client, beconfigClient, feConfigClient)
+	return RunChecks(ingressList.Items, client, beconfigClient, feConfigClient, gceClient)
 }
 
-func CheckIngress(ingressName, namespace string, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface) report.Report {
+func CheckIngress(ingressName, namespace string, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface, gceClient *gce.Client) report.Report {
 	ingress, err := client.NetworkingV1().Ingresses(namespace).Get(context.TODO(), ingressName, metav1.GetOptions{})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting ingress %s/%s: %v", namespace, ingressName, err)
 		os.Exit(1)
 	}
-	return RunChecks([]networkingv1.Ingress{*ingress}, client, beconfigClient, feConfigClient)
+	return RunChecks([]networkingv1.Ingress{*ingress}, client, beconfigClient, feConfigClient, gceClient)
 }
 
-func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface) report.Report {
+func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, beconfigClient beconfigclient.Interface, feConfigClient feconfigclient.Interface, gceClient *gce.Client) report.Report {
 	output := report.Report{
 		Resources: []*report.Resource{},
 	}
@@ -56,6 +57,7 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 		CheckIngressRule,
 		CheckL7ILBFrontendConfig,
 		CheckRuleHostOverwrite,
+		CheckRouteMatchesAnnotation,
 	}
 
 	serviceChecks := []serviceCheckFunc{
@@ -72,6 +74,18 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 		CheckHealthCheckTimeout,
 	}
 
+	gceChecks := []gceCheckFunc{
+		CheckUrlMapExistence,
+		CheckBackendServiceExistence,
+		CheckBackendHealth,
+		CheckHealthCheckFirewall,
+		CheckSSLCertificates,
+	}
+	var cluster *gceCluster
+	if gceClient != nil {
+		cluster = newGCECluster(gceClient, client)
+	}
+
 	for _, ingress := range ingresses {
 
 		// Ingress related checks
@@ -87,7 +101,7 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 
 		for _, check := range ingressChecks {
 			checkName, res, msg := check(ingressChecker)
-			addCheckResult(ingressRes, checkName, msg, res)
+			addCheckResult(ingressRes, checkName, msg, res, ingressChecker.TakeFix())
 		}
 
 		feConfigName, ok := getFrontendConfigAnnotation(&ingress)
@@ -101,7 +115,7 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 
 			for _, check := range feconfigChecks {
 				checkName, res, msg := check(feconfigChecker)
-				addCheckResult(ingressRes, checkName, msg, res)
+				addCheckResult(ingressRes, checkName, msg, res, feconfigChecker.TakeFix())
 			}
 		}
 
@@ -133,7 +147,7 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 
 			for _, check := range serviceChecks {
 				checkName, res, msg := check(serviceChecker)
-				addCheckResult(ingressRes, checkName, msg, res)
+				addCheckResult(ingressRes, checkName, msg, res, serviceChecker.TakeFix())
 			}
 
 			// Get all the BackendConfigs referenced by the service.
@@ -157,20 +171,57 @@ func RunChecks(ingresses []networkingv1.Ingress, client kubernetes.Interface, be
 
 				for _, check := range beconfigChecks {
 					checkName, res, msg := check(beconfigChecker)
-					addCheckResult(ingressRes, checkName, msg, res)
+					addCheckResult(ingressRes, checkName, msg, res, beconfigChecker.TakeFix())
 				}
 			}
 		}
+
+		// GCE resource related checks, only run when a GCE client is given.
+		if gceClient != nil {
+			gceChecker := &GCEChecker{
+				client:   gceClient,
+				ingress:  &ingress,
+				regional: isL7ILB(&ingress),
+				cluster:  cluster,
+			}
+
+			for _, check := range gceChecks {
+				checkName, res, msg := check(gceChecker)
+				addCheckResult(ingressRes, checkName, msg, res, gceChecker.TakeFix())
+			}
+		}
 		output.Resources = append(output.Resources, ingressRes)
 	}
 
+	// The quotas are shared by all the ingresses, so they are checked once,
+	// on the project.
+	if gceClient != nil && len(ingresses) > 0 {
+		quotaChecker := &GCEChecker{client: gceClient}
+		for i := range ingresses {
+			quotaChecker.regional = quotaChecker.regional || isL7ILB(&ingresses[i])
+		}
+		projectRes := &report.Resource{
+			Kind:   "Project",
+			Name:   gceClient.Project,
+			Checks: []*report.Check{},
+		}
+		checkName, res, msg := CheckQuota(quotaChecker)
+		addCheckResult(projectRes, checkName, msg, res, quotaChecker.TakeFix())
+		output.Resources = append(output.Resources, projectRes)
+	}
+
 	return output
 }
 
-func addCheckResult(ingressRes *report.Resource, checkName, msg, res string) {
-	ingressRes.Checks = append(ingressRes.Checks, &report.Check{
-		Name:    checkName,
-		Message: msg,
-		Result:  res,
-	})
+func addCheckResult(ingressRes *report.Resource, checkName, msg, res string, fix *report.Fix) {
+	check := &report.Check{
+		Name:     checkName,
+		Message:  msg,
+		Result:   res,
+		Severity: checkSeverities[checkName],
+	}
+	if res == report.Failed {
+		check.Fix = fix
+	}
+	ingressRes.Checks = append(ingressRes.Checks, check)
 }
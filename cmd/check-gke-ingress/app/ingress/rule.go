@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -45,14 +46,42 @@ const (
 	AppProtocolAnnotationCheck   = "AppProtocolAnnotationCheck"
 	L7ILBFrontendConfigCheck     = "L7ILBFrontendConfigCheck"
 	L7ILBNegAnnotationCheck      = "L7ILBNegAnnotationCheck"
+	RouteMatchesAnnotationCheck  = "RouteMatchesAnnotationCheck"
 )
 
+// checkSeverities are the severities of the checks. Failed checks of
+// severity warning do not break the load balancer, but part of the
+// configuration is ignored.
+var checkSeverities = map[string]string{
+	ServiceExistenceCheck:        report.Error,
+	BackendConfigAnnotationCheck: report.Error,
+	BackendConfigExistenceCheck:  report.Error,
+	HealthCheckTimeoutCheck:      report.Error,
+	IngressRuleCheck:             report.Error,
+	FrontendConfigExistenceCheck: report.Error,
+	RuleHostOverwriteCheck:       report.Warning,
+	AppProtocolAnnotationCheck:   report.Error,
+	L7ILBFrontendConfigCheck:     report.Warning,
+	L7ILBNegAnnotationCheck:      report.Error,
+	RouteMatchesAnnotationCheck:  report.Error,
+	UrlMapExistenceCheck:         report.Error,
+	BackendServiceExistenceCheck: report.Error,
+	BackendHealthCheck:           report.Warning,
+	HealthCheckFirewallCheck:     report.Error,
+	SSLCertificateCheck:          report.Error,
+	QuotaCheck:                   report.Error,
+}
+
 type IngressChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
 	// Ingress object to be checked
 	ingress *networkingv1.Ingress
 }
 
 type ServiceChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
 	// Kubernetes client
 	client clientset.Interface
 	// Service object to be checked
@@ -68,6 +97,8 @@ type ServiceChecker struct {
 }
 
 type BackendConfigChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
 	// BackendConfig client
 	client beconfigclient.Interface
 	// Namespace of the backendConfig
@@ -81,6 +112,8 @@ type BackendConfigChecker struct {
 }
 
 type FrontendConfigChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
 	// FrontendConfig client
 	client feconfigclient.Interface
 	// Namespace of the frontendConfig
@@ -114,23 +147,44 @@ func CheckL7ILBFrontendConfig(c *IngressChecker) (string, string, string) {
 		return L7ILBFrontendConfigCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s is not for L7 internal load balancing", c.ingress.Namespace, c.ingress.Name)
 	}
 	if _, ok := getFrontendConfigAnnotation(c.ingress); ok {
+		c.Suggest(&report.Fix{
+			Kind:      "Ingress",
+			Namespace: c.ingress.Namespace,
+			Name:      c.ingress.Name,
+			Patch:     []*report.PatchOperation{report.RemoveAnnotation(annotations.FrontendConfigKey)},
+		})
 		return L7ILBFrontendConfigCheck, report.Failed, fmt.Sprintf("Ingress %s/%s for L7 internal load balancing has a frontendConfig annotation, frontendConfig can only be used with external ingresses", c.ingress.Namespace, c.ingress.Name)
 	}
 	return L7ILBFrontendConfigCheck, report.Passed, fmt.Sprintf("Ingress %s/%s for L7 internal load balancing does not have a frontendConfig annotation", c.ingress.Namespace, c.ingress.Name)
 }
 
 // CheckRuleHostOverwrite checks whether hosts of ingress rules are unique.
+// The suggested fix merges the paths of the duplicate rule into the first
+// rule of the host.
 func CheckRuleHostOverwrite(c *IngressChecker) (string, string, string) {
-	hostSet := make(map[string]struct{})
-	for _, rule := range c.ingress.Spec.Rules {
-		if _, ok := hostSet[rule.Host]; ok {
+	hostRules := make(map[string]int)
+	for i, rule := range c.ingress.Spec.Rules {
+		if first, ok := hostRules[rule.Host]; ok {
+			c.suggestMergeRules(first, i)
 			return RuleHostOverwriteCheck, report.Failed, fmt.Sprintf("Ingress rules have identical host: %s", rule.Host)
 		}
-		hostSet[rule.Host] = struct{}{}
+		hostRules[rule.Host] = i
 	}
 	return RuleHostOverwriteCheck, report.Passed, "Ingress rule hosts are unique"
 }
 
+// CheckRouteMatchesAnnotation checks whether the route matches annotation of
+// an ingress is valid.
+func CheckRouteMatchesAnnotation(c *IngressChecker) (string, string, string) {
+	if _, ok := c.ingress.Annotations[annotations.RouteMatchesKey]; !ok {
+		return RouteMatchesAnnotationCheck, report.Skipped, fmt.Sprintf("Ingress %s/%s does not have a route matches annotation", c.ingress.Namespace, c.ingress.Name)
+	}
+	if _, err := annotations.FromIngress(c.ingress).RouteMatches(); err != nil {
+		return RouteMatchesAnnotationCheck, report.Failed, fmt.Sprintf("Invalid route matches annotation in ingress %s/%s: %v", c.ingress.Namespace, c.ingress.Name, err)
+	}
+	return RouteMatchesAnnotationCheck, report.Passed, fmt.Sprintf("Route matches annotation is valid in ingress %s/%s", c.ingress.Namespace, c.ingress.Name)
+}
+
 // CheckServiceExistence checks whether a service exists.
 func CheckServiceExistence(c *ServiceChecker) (string, string, string) {
 	service, err := c.client.CoreV1().Services(c.namespace).Get(context.TODO(), c.name, metav1.GetOptions{})
@@ -174,7 +228,7 @@ func CheckAppProtocolAnnotation(c *ServiceChecker) (string, string, string) {
 	if c.service == nil {
 		return AppProtocolAnnotationCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not exist", c.namespace, c.name)
 	}
-	val, ok := getAppProtocolsAnnotation(c.service)
+	key, val, ok := getAppProtocolsAnnotation(c.service)
 	if !ok {
 		return AppProtocolAnnotationCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not have AppProtocolAnnotation", c.namespace, c.name)
 	}
@@ -183,7 +237,8 @@ func CheckAppProtocolAnnotation(c *ServiceChecker) (string, string, string) {
 		return AppProtocolAnnotationCheck, report.Failed, fmt.Sprintf("AppProtocol annotation is in invalid format in service %s/%s", c.namespace, c.name)
 	}
 	for _, protocol := range portToProtocols {
-		if protocol != annotations.ProtocolHTTP && protocol != annotations.ProtocolHTTPS && protocol != annotations.ProtocolHTTP2 {
+		if !validAppProtocol(protocol) {
+			c.suggestAppProtocols(key, portToProtocols)
 			return AppProtocolAnnotationCheck, report.Failed, fmt.Sprintf("Invalid port application protocol in service %s/%s: %v, must be one of [`HTTP`,`HTTPS`,`HTTP2`]", c.namespace, c.name, protocol)
 		}
 	}
@@ -201,13 +256,20 @@ func CheckL7ILBNegAnnotation(c *ServiceChecker) (string, string, string) {
 	}
 	val, ok := getNegAnnotation(c.service)
 	if !ok {
+		c.suggestNegAnnotation(report.AddAnnotation(c.service.Annotations, negannotation.NEGAnnotationKey, `{"ingress":true}`))
 		return L7ILBNegAnnotationCheck, report.Failed, fmt.Sprintf("No Neg annotation found in service %s/%s for internal HTTP(S) load balancing, internal ingress requires Neg as backends", c.namespace, c.name)
 	}
 	var res negannotation.NegAnnotation
 	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		c.suggestNegAnnotation(&report.PatchOperation{Op: "replace", Path: report.AnnotationPath(negannotation.NEGAnnotationKey), Value: `{"ingress":true}`})
 		return L7ILBNegAnnotationCheck, report.Failed, fmt.Sprintf("Invalid Neg annotation found in service %s/%s for internal HTTP(S) load balancing", c.namespace, c.name)
 	}
 	if !res.Ingress {
+		// Keep the exposed ports of standalone NEGs.
+		res.Ingress = true
+		if fixed, err := json.Marshal(res); err == nil {
+			c.suggestNegAnnotation(&report.PatchOperation{Op: "replace", Path: report.AnnotationPath(negannotation.NEGAnnotationKey), Value: string(fixed)})
+		}
 		return L7ILBNegAnnotationCheck, report.Failed, fmt.Sprintf("Neg annotation ingress field is not true in service %s/%s for internal HTTP(S) load balancing", c.namespace, c.name)
 	}
 	return L7ILBNegAnnotationCheck, report.Passed, fmt.Sprintf("Neg annotation is set correctly in service %s/%s for internal HTTP(S) load balancing", c.namespace, c.name)
@@ -239,6 +301,14 @@ func CheckHealthCheckTimeout(c *BackendConfigChecker) (string, string, string) {
 		return HealthCheckTimeoutCheck, report.Skipped, fmt.Sprintf("BackendConfig %s/%s does not have timeoutSec or checkIntervalSec specified", c.namespace, c.name)
 	}
 	if *c.beConfig.Spec.HealthCheck.TimeoutSec > *c.beConfig.Spec.HealthCheck.CheckIntervalSec {
+		c.Suggest(&report.Fix{
+			Kind:      "BackendConfig",
+			Namespace: c.namespace,
+			Name:      c.name,
+			Patch: []*report.PatchOperation{
+				{Op: "replace", Path: "/spec/healthCheck/timeoutSec", Value: *c.beConfig.Spec.HealthCheck.CheckIntervalSec},
+			},
+		})
 		return HealthCheckTimeoutCheck, report.Failed, fmt.Sprintf("BackendConfig %s/%s has healthcheck timeoutSec greater than checkIntervalSec", c.namespace, c.name)
 	}
 	return HealthCheckTimeoutCheck, report.Passed, fmt.Sprintf("BackendConfig %s/%s healthcheck configuration is valid", c.namespace, c.name)
@@ -256,6 +326,64 @@ func CheckFrontendConfigExistence(c *FrontendConfigChecker) (string, string, str
 	return FrontendConfigExistenceCheck, report.Passed, fmt.Sprintf("FrontendConfig %s/%s found", c.namespace, c.name)
 }
 
+// suggestMergeRules suggests moving the paths of the rule at index dup to the
+// rule of the same host at index first, and removing the rule at index dup.
+func (c *IngressChecker) suggestMergeRules(first, dup int) {
+	rules := c.ingress.Spec.Rules
+	var patch []*report.PatchOperation
+	switch {
+	case rules[dup].HTTP == nil:
+		// The duplicate rule has no paths to merge.
+	case rules[first].HTTP == nil:
+		patch = append(patch, &report.PatchOperation{Op: "add", Path: fmt.Sprintf("/spec/rules/%d/http", first), Value: rules[dup].HTTP})
+	default:
+		for _, path := range rules[dup].HTTP.Paths {
+			patch = append(patch, &report.PatchOperation{Op: "add", Path: fmt.Sprintf("/spec/rules/%d/http/paths/-", first), Value: path})
+		}
+	}
+	patch = append(patch, &report.PatchOperation{Op: "remove", Path: fmt.Sprintf("/spec/rules/%d", dup)})
+	c.Suggest(&report.Fix{
+		Kind:      "Ingress",
+		Namespace: c.ingress.Namespace,
+		Name:      c.ingress.Name,
+		Patch:     patch,
+	})
+}
+
+// suggestAppProtocols suggests replacing the AppProtocols annotation with its
+// protocols in upper case, if that makes all of them valid. Other invalid
+// protocols have no fix.
+func (c *ServiceChecker) suggestAppProtocols(key string, portToProtocols map[string]annotations.AppProtocol) {
+	fixed := make(map[string]annotations.AppProtocol)
+	for port, protocol := range portToProtocols {
+		protocol = annotations.AppProtocol(strings.ToUpper(string(protocol)))
+		if !validAppProtocol(protocol) {
+			return
+		}
+		fixed[port] = protocol
+	}
+	val, err := json.Marshal(fixed)
+	if err != nil {
+		return
+	}
+	c.Suggest(&report.Fix{
+		Kind:      "Service",
+		Namespace: c.service.Namespace,
+		Name:      c.service.Name,
+		Patch:     []*report.PatchOperation{{Op: "replace", Path: report.AnnotationPath(key), Value: string(val)}},
+	})
+}
+
+// suggestNegAnnotation suggests a patch of the NEG annotation of the service.
+func (c *ServiceChecker) suggestNegAnnotation(op *report.PatchOperation) {
+	c.Suggest(&report.Fix{
+		Kind:      "Service",
+		Namespace: c.service.Namespace,
+		Name:      c.service.Name,
+		Patch:     []*report.PatchOperation{op},
+	})
+}
+
 // getBackendConfigAnnotation gets the BackendConfig annotation from a service.
 func getBackendConfigAnnotation(svc *corev1.Service) (string, bool) {
 	for _, bcKey := range []string{annotations.BackendConfigKey, annotations.BetaBackendConfigKey} {
@@ -267,15 +395,22 @@ func getBackendConfigAnnotation(svc *corev1.Service) (string, bool) {
 	return "", false
 }
 
-// getAppProtocolsAnnotation gets the AppProtocols annotation from a service.
-func getAppProtocolsAnnotation(svc *corev1.Service) (string, bool) {
+// getAppProtocolsAnnotation gets the key and the value of the AppProtocols
+// annotation from a service.
+func getAppProtocolsAnnotation(svc *corev1.Service) (string, string, bool) {
 	for _, key := range []string{annotations.ServiceApplicationProtocolKey, annotations.GoogleServiceApplicationProtocolKey} {
 		val, ok := svc.Annotations[key]
 		if ok {
-			return val, true
+			return key, val, true
 		}
 	}
-	return "", false
+	return "", "", false
+}
+
+// validAppProtocol returns whether the application protocol is supported by
+// the load balancer.
+func validAppProtocol(protocol annotations.AppProtocol) bool {
+	return protocol == annotations.ProtocolHTTP || protocol == annotations.ProtocolHTTPS || protocol == annotations.ProtocolHTTP2
 }
 
 // isL7ILB whether an ingress is for internal load balancing.
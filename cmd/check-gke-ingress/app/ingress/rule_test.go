@@ -283,6 +283,9 @@ func TestCheckHealthCheckConfig(t *testing.T) {
 		if diff := cmp.Diff(tc.expect, res); diff != "" {
 			t.Errorf("For test case %s,  (-want +got):\n%s", tc.desc, diff)
 		}
+		if fix := checker.TakeFix(); (fix != nil) != (res == report.Failed) {
+			t.Errorf("For test case %s, got suggested fix %+v for result %s", tc.desc, fix, res)
+		}
 	}
 }
 
@@ -384,9 +387,10 @@ func TestCheckIngressRule(t *testing.T) {
 
 func TestCheckRuleHostOverwrite(t *testing.T) {
 	for _, tc := range []struct {
-		desc   string
-		rules  []networkingv1.IngressRule
-		expect string
+		desc      string
+		rules     []networkingv1.IngressRule
+		expect    string
+		expectFix []*report.PatchOperation
 	}{
 		{
 			desc:   "Empty rules",
@@ -403,7 +407,30 @@ func TestCheckRuleHostOverwrite(t *testing.T) {
 					Host: "foo.bar.com",
 				},
 			},
+			expect:    report.Failed,
+			expectFix: []*report.PatchOperation{{Op: "remove", Path: "/spec/rules/1"}},
+		},
+		{
+			desc: "Rules with identical host and paths",
+			rules: []networkingv1.IngressRule{
+				{
+					Host:             "foo.bar.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{Path: "/a"}}}},
+				},
+				{
+					Host: "abc.xyz.com",
+				},
+				{
+					Host:             "foo.bar.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{Path: "/b"}, {Path: "/c"}}}},
+				},
+			},
 			expect: report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "add", Path: "/spec/rules/0/http/paths/-", Value: networkingv1.HTTPIngressPath{Path: "/b"}},
+				{Op: "add", Path: "/spec/rules/0/http/paths/-", Value: networkingv1.HTTPIngressPath{Path: "/c"}},
+				{Op: "remove", Path: "/spec/rules/2"},
+			},
 		},
 		{
 			desc: "Rules with unique hosts",
@@ -429,14 +456,22 @@ func TestCheckRuleHostOverwrite(t *testing.T) {
 		if res != tc.expect {
 			t.Errorf("For test case %q, expect check result = %s, but got %s", tc.desc, tc.expect, res)
 		}
+		var gotFix []*report.PatchOperation
+		if fix := checker.TakeFix(); fix != nil {
+			gotFix = fix.Patch
+		}
+		if diff := cmp.Diff(tc.expectFix, gotFix); diff != "" {
+			t.Errorf("For test case %q, unexpected fix (-want +got):\n%s", tc.desc, diff)
+		}
 	}
 }
 
 func TestCheckAppProtocolAnnotation(t *testing.T) {
 	for _, tc := range []struct {
-		desc   string
-		svc    corev1.Service
-		expect string
+		desc      string
+		svc       corev1.Service
+		expect    string
+		expectFix []*report.PatchOperation
 	}{
 		{
 			desc:   "empty input",
@@ -504,6 +539,22 @@ func TestCheckAppProtocolAnnotation(t *testing.T) {
 			},
 			expect: report.Failed,
 		},
+		{
+			desc: "service with lower case AppProtocol annotation value",
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svc-1",
+					Namespace: "test",
+					Annotations: map[string]string{
+						annotations.ServiceApplicationProtocolKey: `{"port1": "HTTP", "port2": "https"}`,
+					},
+				},
+			},
+			expect: report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "replace", Path: report.AnnotationPath(annotations.ServiceApplicationProtocolKey), Value: `{"port1":"HTTP","port2":"HTTPS"}`},
+			},
+		},
 	} {
 		checker := &ServiceChecker{
 			service: &tc.svc,
@@ -512,6 +563,13 @@ func TestCheckAppProtocolAnnotation(t *testing.T) {
 		if res != tc.expect {
 			t.Errorf("For test case %q, expect check result = %s, but got %s", tc.desc, tc.expect, res)
 		}
+		var gotFix []*report.PatchOperation
+		if fix := checker.TakeFix(); fix != nil {
+			gotFix = fix.Patch
+		}
+		if diff := cmp.Diff(tc.expectFix, gotFix); diff != "" {
+			t.Errorf("For test case %q, unexpected fix (-want +got):\n%s", tc.desc, diff)
+		}
 	}
 }
 
@@ -572,11 +630,65 @@ func TestCheckL7ILBFrontendConfig(t *testing.T) {
 	}
 }
 
+func TestCheckRouteMatchesAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		ingress networkingv1.Ingress
+		expect  string
+	}{
+		{
+			desc: "Ingress without route matches annotation",
+			ingress: networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "ingress-1",
+				},
+			},
+			expect: report.Skipped,
+		},
+		{
+			desc: "Ingress with valid route matches annotation",
+			ingress: networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "ingress-1",
+					Annotations: map[string]string{
+						annotations.RouteMatchesKey: `{"svc-1":[{"headers":[{"name":"x-canary","value":"true"}],"service":{"name":"svc-2","port":{"number":80}}}]}`,
+					},
+				},
+			},
+			expect: report.Passed,
+		},
+		{
+			desc: "Ingress with invalid route matches annotation",
+			ingress: networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "ingress-1",
+					Annotations: map[string]string{
+						annotations.RouteMatchesKey: `{"svc-1":[{"headers":[{"name":"x canary"}],"service":{"name":"svc-2","port":{"number":80}}}]}`,
+					},
+				},
+			},
+			expect: report.Failed,
+		},
+	} {
+		checker := &IngressChecker{
+			ingress: &tc.ingress,
+		}
+		_, res, _ := CheckRouteMatchesAnnotation(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s", tc.desc, tc.expect, res)
+		}
+	}
+}
+
 func TestCheckL7ILBNegAnnotation(t *testing.T) {
 	for _, tc := range []struct {
-		desc   string
-		svc    corev1.Service
-		expect string
+		desc      string
+		svc       corev1.Service
+		expect    string
+		expectFix []*report.PatchOperation
 	}{
 		{
 			desc: "Service without NEG annotation",
@@ -587,6 +699,9 @@ func TestCheckL7ILBNegAnnotation(t *testing.T) {
 				},
 			},
 			expect: report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "add", Path: "/metadata/annotations", Value: map[string]string{negannotation.NEGAnnotationKey: `{"ingress":true}`}},
+			},
 		},
 		{
 			desc: "Service with invalid NEG annotation json",
@@ -600,6 +715,9 @@ func TestCheckL7ILBNegAnnotation(t *testing.T) {
 				},
 			},
 			expect: report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "replace", Path: "/metadata/annotations/cloud.google.com~1neg", Value: `{"ingress":true}`},
+			},
 		},
 		{
 			desc: "Service with NEG annotation which does not have ingress key",
@@ -613,6 +731,9 @@ func TestCheckL7ILBNegAnnotation(t *testing.T) {
 				},
 			},
 			expect: report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "replace", Path: "/metadata/annotations/cloud.google.com~1neg", Value: `{"ingress":true,"exposed_ports":{"80":{"name":"neg1"}}}`},
+			},
 		},
 		{
 			desc: "Service with correct NEG annotation",
@@ -636,6 +757,13 @@ func TestCheckL7ILBNegAnnotation(t *testing.T) {
 		if res != tc.expect {
 			t.Errorf("For test case %q, expect check result = %s, but got %s", tc.desc, tc.expect, res)
 		}
+		var gotFix []*report.PatchOperation
+		if fix := checker.TakeFix(); fix != nil {
+			gotFix = fix.Patch
+		}
+		if diff := cmp.Diff(tc.expectFix, gotFix); diff != "" {
+			t.Errorf("For test case %q, suggested fix mismatch (-want +got):\n%s", tc.desc, diff)
+		}
 	}
 }
 
@@ -771,6 +899,7 @@ func TestCheckFunctions(t *testing.T) {
 							{Name: "IngressRuleCheck", Result: "PASSED"},
 							{Name: "L7ILBFrontendConfigCheck", Result: "FAILED"},
 							{Name: "RuleHostOverwriteCheck", Result: "PASSED"},
+							{Name: "RouteMatchesAnnotationCheck", Result: "SKIPPED"},
 							{Name: "FrontenådConfigExistenceCheck", Result: "FAILED"},
 							{Name: "ServiceExistenceCheck", Result: "PASSED"},
 							{Name: "BackendConfigAnnotationCheck", Result: "PASSED"},
@@ -788,6 +917,7 @@ func TestCheckFunctions(t *testing.T) {
 							{Name: "IngressRuleCheck", Result: "FAILED"},
 							{Name: "L7ILBFrontendConfigCheck", Result: "SKIPPED"},
 							{Name: "RuleHostOverwriteCheck", Result: "FAILED"},
+							{Name: "RouteMatchesAnnotationCheck", Result: "SKIPPED"},
 							{Name: "FrontendConfigExistenceCheck", Result: "PASSED"},
 							{Name: "ServiceExistenceCheck", Result: "PASSED"},
 							{Name: "BackendConfigAnnotationCheck", Result: "PASSED"},
@@ -814,6 +944,7 @@ func TestCheckFunctions(t *testing.T) {
 							{Name: "IngressRuleCheck", Result: "FAILED"},
 							{Name: "L7ILBFrontendConfigCheck", Result: "SKIPPED"},
 							{Name: "RuleHostOverwriteCheck", Result: "FAILED"},
+							{Name: "RouteMatchesAnnotationCheck", Result: "SKIPPED"},
 							{Name: "FrontendConfigExistenceCheck", Result: "PASSED"},
 							{Name: "ServiceExistenceCheck", Result: "PASSED"},
 							{Name: "BackendConfigAnnotationCheck", Result: "PASSED"},
@@ -829,9 +960,9 @@ func TestCheckFunctions(t *testing.T) {
 	} {
 		var result report.Report
 		if tc.ingressName == "" {
-			result = CheckAllIngresses(tc.namespace, client, beClient, feClient)
+			result = CheckAllIngresses(tc.namespace, client, beClient, feClient, nil)
 		} else {
-			result = CheckIngress(tc.ingressName, tc.namespace, client, beClient, feClient)
+			result = CheckIngress(tc.ingressName, tc.namespace, client, beClient, feClient, nil)
 		}
 
 		for _, resource := range result.Resources {
@@ -864,6 +995,7 @@ func TestCheckFunctions(t *testing.T) {
 		AppProtocolAnnotationCheck,
 		L7ILBFrontendConfigCheck,
 		L7ILBNegAnnotationCheck,
+		RouteMatchesAnnotationCheck,
 	} {
 		if _, ok := checkSet[check]; !ok {
 			t.Errorf("Missing check %s in check functions", check)
@@ -20,6 +20,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	beconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	feconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
+	saclient "k8s.io/ingress-gce/pkg/serviceattachment/client/clientset/versioned"
 )
 
 // NewClientSet returns a new Kubernetes clientset
@@ -59,3 +60,12 @@ func NewFrontendConfigClientSet(kubeContext, kubeConfigPath string) (*feconfigcl
 	}
 	return feconfigclient.NewForConfig(config)
 }
+
+// NewServiceAttachmentClientSet returns a new ServiceAttachment clientset
+func NewServiceAttachmentClientSet(kubeContext, kubeConfigPath string) (*saclient.Clientset, error) {
+	config, err := getKubeConfig(kubeContext, kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return saclient.NewForConfig(config)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 the Kubernetes Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/cmd/render-gke-ingress/app/render"
+	fakebeconfig "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	fakefeconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned/fake"
+)
+
+// StdinFile is the file name of the standard input in the manifest files.
+const StdinFile = "-"
+
+// ReadManifests reads the resources of the YAML or JSON manifest files, where
+// StdinFile reads stdin, e.g. the output of `helm template`. Resources without
+// a namespace are put in the given namespace.
+func ReadManifests(files []string, namespace string, stdin io.Reader) (*render.Manifests, error) {
+	m := &render.Manifests{}
+	for _, file := range files {
+		if file == StdinFile {
+			if err := m.Read(stdin, namespace); err != nil {
+				return nil, fmt.Errorf("error reading stdin: %w", err)
+			}
+			continue
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = m.Read(f, namespace)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file, err)
+		}
+	}
+	return m, nil
+}
+
+// NewFakeClientSets returns in-memory Kubernetes, BackendConfig and
+// FrontendConfig clientsets serving the resources of the manifests.
+func NewFakeClientSets(m *render.Manifests) (*fake.Clientset, *fakebeconfig.Clientset, *fakefeconfig.Clientset) {
+	var objects, beConfigs, feConfigs []runtime.Object
+	for _, ing := range m.Ingresses {
+		objects = append(objects, ing)
+	}
+	for _, svc := range m.Services {
+		objects = append(objects, svc)
+	}
+	for _, secret := range m.Secrets {
+		objects = append(objects, secret)
+	}
+	for _, beConfig := range m.BackendConfigs {
+		beConfigs = append(beConfigs, beConfig)
+	}
+	for _, feConfig := range m.FrontendConfigs {
+		feConfigs = append(feConfigs, feConfig)
+	}
+	return fake.NewSimpleClientset(objects...), fakebeconfig.NewSimpleClientset(beConfigs...), fakefeconfig.NewSimpleClientset(feConfigs...)
+}
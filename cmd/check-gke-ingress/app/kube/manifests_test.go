@@ -0,0 +1,146 @@
+// Copyright 2026 the Kubernetes Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/ingress"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+)
+
+const testIngressManifest = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ingress-1
+  annotations:
+    kubernetes.io/ingress.class: gce-internal
+spec:
+  defaultBackend:
+    service:
+      name: svc-1
+      port:
+        number: 80
+`
+
+const testBackendManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc-1
+  annotations:
+    cloud.google.com/backend-config: '{"default": "beconfig-1"}'
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: cloud.google.com/v1
+kind: BackendConfig
+metadata:
+  name: beconfig-1
+spec:
+  healthCheck:
+    checkIntervalSec: 5
+    timeoutSec: 10
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func TestReadManifests(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ingress.yaml")
+	if err := os.WriteFile(file, []byte(testIngressManifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest file: %v", err)
+	}
+
+	m, err := ReadManifests([]string{file, StdinFile}, "test", strings.NewReader(testBackendManifests))
+	if err != nil {
+		t.Fatalf("ReadManifests() returned error: %v", err)
+	}
+	if len(m.Ingresses) != 1 || len(m.Services) != 1 || len(m.BackendConfigs) != 1 || len(m.FrontendConfigs) != 0 {
+		t.Fatalf("Expect 1 ingress, 1 service and 1 backendConfig, got %d ingresses, %d services, %d backendConfigs and %d frontendConfigs",
+			len(m.Ingresses), len(m.Services), len(m.BackendConfigs), len(m.FrontendConfigs))
+	}
+
+	client, beconfigClient, feConfigClient := NewFakeClientSets(m)
+	if _, err := client.CoreV1().Services("test").Get(context.TODO(), "svc-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Failed to get service test/svc-1 from the fake clientset: %v", err)
+	}
+	if _, err := beconfigClient.CloudV1().BackendConfigs("test").Get(context.TODO(), "beconfig-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Failed to get backendConfig test/beconfig-1 from the fake clientset: %v", err)
+	}
+
+	output := ingress.CheckAllIngresses("", client, beconfigClient, feConfigClient, nil)
+	if len(output.Resources) != 1 {
+		t.Fatalf("Expect 1 checked ingress, got %d", len(output.Resources))
+	}
+	results := make(map[string]string)
+	for _, check := range output.Resources[0].Checks {
+		results[check.Name] = check.Result
+	}
+	for checkName, expect := range map[string]string{
+		ingress.ServiceExistenceCheck:       report.Passed,
+		ingress.BackendConfigExistenceCheck: report.Passed,
+		ingress.HealthCheckTimeoutCheck:     report.Failed,
+		ingress.L7ILBNegAnnotationCheck:     report.Failed,
+	} {
+		if results[checkName] != expect {
+			t.Errorf("Expect check %s result = %s, but got %s", checkName, expect, results[checkName])
+		}
+	}
+}
+
+func TestReadManifestsV1beta1BackendConfig(t *testing.T) {
+	manifest := `
+apiVersion: cloud.google.com/v1beta1
+kind: BackendConfig
+metadata:
+  name: beconfig-1
+spec:
+  timeoutSec: 40
+`
+	m, err := ReadManifests([]string{StdinFile}, "test", strings.NewReader(manifest))
+	if err != nil {
+		t.Fatalf("ReadManifests() returned error: %v", err)
+	}
+	if len(m.BackendConfigs) != 1 {
+		t.Fatalf("Expect 1 backendConfig, got %d", len(m.BackendConfigs))
+	}
+	_, beconfigClient, _ := NewFakeClientSets(m)
+	beConfig, err := beconfigClient.CloudV1().BackendConfigs("test").Get(context.TODO(), "beconfig-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get backendConfig test/beconfig-1 from the fake clientset: %v", err)
+	}
+	if beConfig.Spec.TimeoutSec == nil || *beConfig.Spec.TimeoutSec != 40 {
+		t.Errorf("Expect backendConfig timeoutSec = 40, got %v", beConfig.Spec.TimeoutSec)
+	}
+}
+
+func TestReadManifestsInvalidFile(t *testing.T) {
+	if _, err := ReadManifests([]string{filepath.Join(t.TempDir(), "missing.yaml")}, "test", nil); err == nil {
+		t.Errorf("Expect ReadManifests() to return an error for a missing file")
+	}
+	if _, err := ReadManifests([]string{StdinFile}, "test", strings.NewReader("kind: Ingress\n  bad: [")); err == nil {
+		t.Errorf("Expect ReadManifests() to return an error for an invalid manifest")
+	}
+}
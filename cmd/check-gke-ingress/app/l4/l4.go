@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	sav1 "k8s.io/ingress-gce/pkg/apis/serviceattachment/v1"
+	saclient "k8s.io/ingress-gce/pkg/serviceattachment/client/clientset/versioned"
+)
+
+// Options are the options of the L4 checks.
+type Options struct {
+	// GCE client, nil if the GCE resources are not checked
+	GCEClient *gce.Client
+	// Whether the L4 controllers support services with mixed protocols
+	MixedProtocol bool
+}
+
+func CheckAllL4Resources(namespace string, client kubernetes.Interface, saClient saclient.Interface, opts Options) report.Report {
+	svcList, err := client.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing services: %v", err)
+		os.Exit(1)
+	}
+	saList, err := saClient.NetworkingV1().ServiceAttachments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing serviceAttachments: %v", err)
+		os.Exit(1)
+	}
+	var services []corev1.Service
+	for _, svc := range svcList.Items {
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			services = append(services, svc)
+		}
+	}
+	return RunChecks(services, saList.Items, client, opts)
+}
+
+func CheckL4Service(serviceName, namespace string, client kubernetes.Interface, opts Options) report.Report {
+	svc, err := client.CoreV1().Services(namespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting service %s/%s: %v", namespace, serviceName, err)
+		os.Exit(1)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		fmt.Fprintf(os.Stderr, "Service %s/%s is of type %s, only LoadBalancer services are checked", namespace, serviceName, svc.Spec.Type)
+		os.Exit(1)
+	}
+	return RunChecks([]corev1.Service{*svc}, nil, client, opts)
+}
+
+func RunChecks(services []corev1.Service, serviceAttachments []sav1.ServiceAttachment, client kubernetes.Interface, opts Options) report.Report {
+	output := report.Report{
+		Resources: []*report.Resource{},
+	}
+
+	serviceChecks := []serviceCheckFunc{
+		CheckLoadBalancerClass,
+		CheckStaticAddressAnnotation,
+		CheckNetworkTier,
+		CheckMixedProtocol,
+		CheckWeightedLoadBalancing,
+	}
+
+	serviceAttachmentChecks := []serviceAttachmentCheckFunc{
+		CheckServiceAttachmentResource,
+		CheckServiceAttachmentNATSubnets,
+	}
+
+	for _, svc := range services {
+		svcRes := &report.Resource{
+			Kind:      "Service",
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+			Checks:    []*report.Check{},
+		}
+		serviceChecker := &ServiceChecker{
+			service:       &svc,
+			gceClient:     opts.GCEClient,
+			mixedProtocol: opts.MixedProtocol,
+		}
+
+		for _, check := range serviceChecks {
+			checkName, res, msg := check(serviceChecker)
+			addCheckResult(svcRes, checkName, msg, res, serviceChecker.TakeFix())
+		}
+		output.Resources = append(output.Resources, svcRes)
+	}
+
+	for _, sa := range serviceAttachments {
+		saRes := &report.Resource{
+			Kind:      "ServiceAttachment",
+			Namespace: sa.Namespace,
+			Name:      sa.Name,
+			Checks:    []*report.Check{},
+		}
+		saChecker := &ServiceAttachmentChecker{
+			client:            client,
+			gceClient:         opts.GCEClient,
+			serviceAttachment: &sa,
+		}
+
+		for _, check := range serviceAttachmentChecks {
+			checkName, res, msg := check(saChecker)
+			addCheckResult(saRes, checkName, msg, res, saChecker.TakeFix())
+		}
+		output.Resources = append(output.Resources, saRes)
+	}
+
+	return output
+}
+
+func addCheckResult(res *report.Resource, checkName, msg, result string, fix *report.Fix) {
+	check := &report.Check{
+		Name:     checkName,
+		Message:  msg,
+		Result:   result,
+		Severity: checkSeverities[checkName],
+	}
+	if result == report.Failed {
+		check.Fix = fix
+	}
+	res.Checks = append(res.Checks, check)
+}
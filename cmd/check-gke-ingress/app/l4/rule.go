@@ -0,0 +1,350 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	sav1 "k8s.io/ingress-gce/pkg/apis/serviceattachment/v1"
+	"k8s.io/ingress-gce/pkg/l4annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const (
+	LoadBalancerClassCheck           = "LoadBalancerClassCheck"
+	StaticAddressAnnotationCheck     = "StaticAddressAnnotationCheck"
+	NetworkTierCheck                 = "NetworkTierCheck"
+	MixedProtocolCheck               = "MixedProtocolCheck"
+	WeightedLoadBalancingCheck       = "WeightedLoadBalancingCheck"
+	ServiceAttachmentResourceCheck   = "ServiceAttachmentResourceCheck"
+	ServiceAttachmentNATSubnetsCheck = "ServiceAttachmentNATSubnetsCheck"
+)
+
+// checkSeverities are the severities of the checks. Failed checks of
+// severity warning do not break the load balancer, but part of the
+// configuration is ignored.
+var checkSeverities = map[string]string{
+	LoadBalancerClassCheck:           report.Warning,
+	StaticAddressAnnotationCheck:     report.Error,
+	NetworkTierCheck:                 report.Error,
+	MixedProtocolCheck:               report.Error,
+	WeightedLoadBalancingCheck:       report.Warning,
+	ServiceAttachmentResourceCheck:   report.Error,
+	ServiceAttachmentNATSubnetsCheck: report.Error,
+}
+
+// pscSubnetPurpose is the purpose of the subnets used as NAT subnets of
+// service attachments.
+const pscSubnetPurpose = "PRIVATE_SERVICE_CONNECT"
+
+// maxStaticAddresses is the maximum number of addresses in the static
+// addresses annotation, one IPv4 and one IPv6 address.
+const maxStaticAddresses = 2
+
+// addressNameRegex matches the valid names of GCE addresses.
+var addressNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+type ServiceChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
+	// Service object to be checked
+	service *corev1.Service
+	// GCE client, nil if the GCE resources are not checked
+	gceClient *gce.Client
+	// Whether the L4 controllers support services with mixed protocols
+	mixedProtocol bool
+	// Static addresses of the service, set when the GCE resources are checked
+	addresses []*compute.Address
+}
+
+type ServiceAttachmentChecker struct {
+	// Fix suggested by the failed check
+	report.Suggestion
+	// Kubernetes client
+	client clientset.Interface
+	// GCE client, nil if the GCE resources are not checked
+	gceClient *gce.Client
+	// ServiceAttachment object to be checked
+	serviceAttachment *sav1.ServiceAttachment
+}
+
+type serviceCheckFunc func(c *ServiceChecker) (string, string, string)
+
+type serviceAttachmentCheckFunc func(c *ServiceAttachmentChecker) (string, string, string)
+
+// CheckLoadBalancerClass checks whether the loadBalancerClass of a service
+// conflicts with its load balancer type annotation.
+func CheckLoadBalancerClass(c *ServiceChecker) (string, string, string) {
+	svc := c.service
+	typeKey, lbType, hasType := loadBalancerTypeAnnotation(svc)
+	if hasType && lbType != l4annotations.LBTypeInternal && lbType != l4annotations.LBTypeExternal && lbType != "internal" {
+		c.suggest(report.RemoveAnnotation(typeKey))
+		return LoadBalancerClassCheck, report.Failed, fmt.Sprintf("Invalid load balancer type annotation %q in service %s/%s, must be one of [`Internal`,`External`]", lbType, svc.Namespace, svc.Name)
+	}
+	if svc.Spec.LoadBalancerClass == nil {
+		return LoadBalancerClassCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not have a loadBalancerClass", svc.Namespace, svc.Name)
+	}
+	class := *svc.Spec.LoadBalancerClass
+	var classType l4annotations.LoadBalancerType
+	switch class {
+	case l4annotations.RegionalInternalLoadBalancerClass, l4annotations.LegacyRegionalInternalLoadBalancerClass:
+		classType = l4annotations.LBTypeInternal
+	case l4annotations.RegionalExternalLoadBalancerClass, l4annotations.LegacyRegionalExternalLoadBalancerClass:
+		classType = l4annotations.LBTypeExternal
+	default:
+		return LoadBalancerClassCheck, report.Skipped, fmt.Sprintf("Service %s/%s has loadBalancerClass %s which is not managed by GKE", svc.Namespace, svc.Name, class)
+	}
+	if hasType && isInternalType(lbType) != (classType == l4annotations.LBTypeInternal) {
+		c.suggest(report.RemoveAnnotation(typeKey))
+		return LoadBalancerClassCheck, report.Failed, fmt.Sprintf("Service %s/%s has loadBalancerClass %s and load balancer type annotation %q, the annotation is ignored", svc.Namespace, svc.Name, class, lbType)
+	}
+	return LoadBalancerClassCheck, report.Passed, fmt.Sprintf("LoadBalancerClass %s of service %s/%s does not conflict with its annotations", class, svc.Namespace, svc.Name)
+}
+
+// CheckStaticAddressAnnotation checks whether the static addresses annotation
+// of a service is valid, and with a GCE client, whether the addresses exist
+// and have the type of the load balancer.
+func CheckStaticAddressAnnotation(c *ServiceChecker) (string, string, string) {
+	svc := c.service
+	val, ok := svc.Annotations[l4annotations.StaticL4AddressesAnnotationKey]
+	if !ok {
+		return StaticAddressAnnotationCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not have a static addresses annotation", svc.Namespace, svc.Name)
+	}
+	names := strings.Split(val, ",")
+	if len(names) > maxStaticAddresses {
+		return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Static addresses annotation of service %s/%s has %d addresses, at most one IPv4 and one IPv6 address can be used", svc.Namespace, svc.Name, len(names))
+	}
+	seen := make(map[string]bool)
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if !addressNameRegex.MatchString(name) {
+			return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Static addresses annotation of service %s/%s has invalid address name %q", svc.Namespace, svc.Name, name)
+		}
+		if seen[name] {
+			return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Static addresses annotation of service %s/%s has duplicate address %s", svc.Namespace, svc.Name, name)
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	if c.gceClient == nil || c.gceClient.Region == "" {
+		return StaticAddressAnnotationCheck, report.Passed, fmt.Sprintf("Static addresses annotation is valid in service %s/%s", svc.Namespace, svc.Name)
+	}
+
+	wantType := "EXTERNAL"
+	if isInternal(svc) {
+		wantType = "INTERNAL"
+	}
+	versions := make(map[string]bool)
+	var addresses []*compute.Address
+	for _, name := range names {
+		addr, err := c.gceClient.Cloud.Addresses().Get(context.TODO(), meta.RegionalKey(name, c.gceClient.Region))
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Address %s of service %s/%s does not exist in region %s", name, svc.Namespace, svc.Name, c.gceClient.Region)
+			}
+			return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Failed to get address %s of service %s/%s: %v", name, svc.Namespace, svc.Name, err)
+		}
+		addrType := addr.AddressType
+		if addrType == "" {
+			addrType = "EXTERNAL"
+		}
+		if addrType != wantType {
+			return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Address %s of service %s/%s is %s, the load balancer needs an %s address", name, svc.Namespace, svc.Name, addrType, wantType)
+		}
+		version := addr.IpVersion
+		if version == "" {
+			version = l4annotations.IPv4Version
+		}
+		if versions[version] {
+			return StaticAddressAnnotationCheck, report.Failed, fmt.Sprintf("Static addresses annotation of service %s/%s has more than one %s address", svc.Namespace, svc.Name, version)
+		}
+		versions[version] = true
+		addresses = append(addresses, addr)
+	}
+	c.addresses = addresses
+	return StaticAddressAnnotationCheck, report.Passed, fmt.Sprintf("Static addresses of service %s/%s are valid", svc.Namespace, svc.Name)
+}
+
+// CheckNetworkTier checks whether the network tier annotation of a service is
+// valid, is only used by external load balancers, and matches the network
+// tier of its static addresses.
+func CheckNetworkTier(c *ServiceChecker) (string, string, string) {
+	svc := c.service
+	val, hasTier := svc.Annotations[l4annotations.NetworkTierAnnotationKey]
+	tier, valid := l4annotations.NetworkTier(svc)
+	if hasTier && !valid {
+		c.suggest(report.RemoveAnnotation(l4annotations.NetworkTierAnnotationKey))
+		return NetworkTierCheck, report.Failed, fmt.Sprintf("Invalid network tier annotation %q in service %s/%s, must be one of [`Standard`,`Premium`]", val, svc.Namespace, svc.Name)
+	}
+	if hasTier && isInternal(svc) {
+		c.suggest(report.RemoveAnnotation(l4annotations.NetworkTierAnnotationKey))
+		return NetworkTierCheck, report.Failed, fmt.Sprintf("Service %s/%s for L4 internal load balancing has a network tier annotation, network tiers only apply to external load balancers", svc.Namespace, svc.Name)
+	}
+	if isInternal(svc) {
+		return NetworkTierCheck, report.Skipped, fmt.Sprintf("Service %s/%s is for L4 internal load balancing", svc.Namespace, svc.Name)
+	}
+	for _, addr := range c.addresses {
+		if addr.NetworkTier != "" && addr.NetworkTier != tier.ToGCEValue() {
+			addrTier := cloud.NetworkTierPremium
+			if addr.NetworkTier == cloud.NetworkTierStandard.ToGCEValue() {
+				addrTier = cloud.NetworkTierStandard
+			}
+			c.suggest(report.AddAnnotation(svc.Annotations, l4annotations.NetworkTierAnnotationKey, string(addrTier)))
+			return NetworkTierCheck, report.Failed, fmt.Sprintf("Address %s of service %s/%s is in network tier %s, but the service uses network tier %s", addr.Name, svc.Namespace, svc.Name, addr.NetworkTier, tier.ToGCEValue())
+		}
+	}
+	return NetworkTierCheck, report.Passed, fmt.Sprintf("Network tier %s of service %s/%s is valid", tier, svc.Namespace, svc.Name)
+}
+
+// CheckMixedProtocol checks whether a service with ports of different
+// protocols is supported by the L4 controllers.
+func CheckMixedProtocol(c *ServiceChecker) (string, string, string) {
+	svc := c.service
+	protocols := make(map[corev1.Protocol]bool)
+	for _, port := range svc.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		protocols[protocol] = true
+	}
+	if len(protocols) <= 1 {
+		return MixedProtocolCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not use mixed protocols", svc.Namespace, svc.Name)
+	}
+	if !c.mixedProtocol {
+		return MixedProtocolCheck, report.Failed, fmt.Sprintf("Service %s/%s has ports with different protocols, but mixed protocol load balancers are not enabled", svc.Namespace, svc.Name)
+	}
+	return MixedProtocolCheck, report.Passed, fmt.Sprintf("Service %s/%s uses mixed protocols which are enabled", svc.Namespace, svc.Name)
+}
+
+// CheckWeightedLoadBalancing checks whether the weighted load balancing
+// annotation of a service is valid and takes effect.
+func CheckWeightedLoadBalancing(c *ServiceChecker) (string, string, string) {
+	svc := c.service
+	val, ok := svc.Annotations[l4annotations.WeightedL4AnnotationKey]
+	if !ok {
+		return WeightedLoadBalancingCheck, report.Skipped, fmt.Sprintf("Service %s/%s does not have a weighted load balancing annotation", svc.Namespace, svc.Name)
+	}
+	if val != l4annotations.WeightedL4AnnotationPodsPerNode {
+		c.suggest(&report.PatchOperation{Op: "replace", Path: report.AnnotationPath(l4annotations.WeightedL4AnnotationKey), Value: l4annotations.WeightedL4AnnotationPodsPerNode})
+		return WeightedLoadBalancingCheck, report.Failed, fmt.Sprintf("Invalid weighted load balancing annotation %q in service %s/%s, must be %q", val, svc.Namespace, svc.Name, l4annotations.WeightedL4AnnotationPodsPerNode)
+	}
+	if svc.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal {
+		c.suggest(&report.PatchOperation{Op: "add", Path: "/spec/externalTrafficPolicy", Value: string(corev1.ServiceExternalTrafficPolicyLocal)})
+		return WeightedLoadBalancingCheck, report.Failed, fmt.Sprintf("Service %s/%s has weighted load balancing with externalTrafficPolicy: Cluster, weighted load balancing only takes effect with externalTrafficPolicy: Local", svc.Namespace, svc.Name)
+	}
+	return WeightedLoadBalancingCheck, report.Passed, fmt.Sprintf("Weighted load balancing annotation is valid in service %s/%s", svc.Namespace, svc.Name)
+}
+
+// CheckServiceAttachmentResource checks whether a ServiceAttachment references
+// an existing service for L4 internal load balancing.
+func CheckServiceAttachmentResource(c *ServiceAttachmentChecker) (string, string, string) {
+	sa := c.serviceAttachment
+	ref := sa.Spec.ResourceRef
+	if (ref.APIGroup != nil && *ref.APIGroup != "") || strings.ToLower(ref.Kind) != "service" {
+		return ServiceAttachmentResourceCheck, report.Failed, fmt.Sprintf("ServiceAttachment %s/%s references a %s, only services can be referenced", sa.Namespace, sa.Name, ref.Kind)
+	}
+	svc, err := c.client.CoreV1().Services(sa.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ServiceAttachmentResourceCheck, report.Failed, fmt.Sprintf("Service %s/%s referenced by ServiceAttachment %s/%s does not exist", sa.Namespace, ref.Name, sa.Namespace, sa.Name)
+		}
+		return ServiceAttachmentResourceCheck, report.Failed, fmt.Sprintf("Failed to get service %s/%s referenced by ServiceAttachment %s/%s: %v", sa.Namespace, ref.Name, sa.Namespace, sa.Name, err)
+	}
+	if wantsILB, _ := l4annotations.WantsL4ILB(svc); !wantsILB {
+		return ServiceAttachmentResourceCheck, report.Failed, fmt.Sprintf("Service %s/%s referenced by ServiceAttachment %s/%s is not for L4 internal load balancing", sa.Namespace, ref.Name, sa.Namespace, sa.Name)
+	}
+	return ServiceAttachmentResourceCheck, report.Passed, fmt.Sprintf("ServiceAttachment %s/%s references service %s/%s for L4 internal load balancing", sa.Namespace, sa.Name, sa.Namespace, ref.Name)
+}
+
+// CheckServiceAttachmentNATSubnets checks whether a ServiceAttachment has NAT
+// subnets, and with a GCE client, whether they exist and are subnets for
+// Private Service Connect.
+func CheckServiceAttachmentNATSubnets(c *ServiceAttachmentChecker) (string, string, string) {
+	sa := c.serviceAttachment
+	if len(sa.Spec.NATSubnets) == 0 {
+		return ServiceAttachmentNATSubnetsCheck, report.Failed, fmt.Sprintf("ServiceAttachment %s/%s does not have NAT subnets", sa.Namespace, sa.Name)
+	}
+	if c.gceClient == nil || c.gceClient.Region == "" {
+		return ServiceAttachmentNATSubnetsCheck, report.Passed, fmt.Sprintf("ServiceAttachment %s/%s has NAT subnets", sa.Namespace, sa.Name)
+	}
+	for _, name := range sa.Spec.NATSubnets {
+		// Subnets of shared VPCs are given by their URL.
+		key := meta.RegionalKey(name, c.gceClient.Region)
+		if id, err := cloud.ParseResourceURL(name); err == nil {
+			key = id.Key
+		}
+		subnet, err := c.gceClient.Cloud.Subnetworks().Get(context.TODO(), key)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return ServiceAttachmentNATSubnetsCheck, report.Failed, fmt.Sprintf("NAT subnet %s of ServiceAttachment %s/%s does not exist", key.Name, sa.Namespace, sa.Name)
+			}
+			return ServiceAttachmentNATSubnetsCheck, report.Failed, fmt.Sprintf("Failed to get NAT subnet %s of ServiceAttachment %s/%s: %v", key.Name, sa.Namespace, sa.Name, err)
+		}
+		if subnet.Purpose != pscSubnetPurpose {
+			return ServiceAttachmentNATSubnetsCheck, report.Failed, fmt.Sprintf("NAT subnet %s of ServiceAttachment %s/%s has purpose %s, NAT subnets must have purpose %s", key.Name, sa.Namespace, sa.Name, subnet.Purpose, pscSubnetPurpose)
+		}
+	}
+	return ServiceAttachmentNATSubnetsCheck, report.Passed, fmt.Sprintf("NAT subnets of ServiceAttachment %s/%s are valid", sa.Namespace, sa.Name)
+}
+
+// suggest suggests a patch of the service.
+func (c *ServiceChecker) suggest(op *report.PatchOperation) {
+	c.Suggest(&report.Fix{
+		Kind:      "Service",
+		Namespace: c.service.Namespace,
+		Name:      c.service.Name,
+		Patch:     []*report.PatchOperation{op},
+	})
+}
+
+// loadBalancerTypeAnnotation gets the key and value of the load balancer type
+// annotation, or of its deprecated form, from a service.
+func loadBalancerTypeAnnotation(svc *corev1.Service) (string, l4annotations.LoadBalancerType, bool) {
+	for _, key := range []string{l4annotations.ServiceAnnotationLoadBalancerType, "cloud.google.com/load-balancer-type"} {
+		if val, ok := svc.Annotations[key]; ok {
+			return key, l4annotations.LoadBalancerType(val), true
+		}
+	}
+	return "", "", false
+}
+
+// isInternalType returns whether a load balancer type annotation value is for
+// internal load balancing.
+func isInternalType(lbType l4annotations.LoadBalancerType) bool {
+	return lbType == l4annotations.LBTypeInternal || lbType == "internal"
+}
+
+// isInternal returns whether a service is for L4 internal load balancing.
+func isInternal(svc *corev1.Service) bool {
+	if l4annotations.HasLoadBalancerClass(svc, l4annotations.LegacyRegionalInternalLoadBalancerClass) {
+		return true
+	}
+	return l4annotations.GetLoadBalancerAnnotationType(svc) == l4annotations.LBTypeInternal
+}
@@ -0,0 +1,533 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/gce"
+	"k8s.io/ingress-gce/cmd/check-gke-ingress/app/report"
+	sav1 "k8s.io/ingress-gce/pkg/apis/serviceattachment/v1"
+	"k8s.io/ingress-gce/pkg/l4annotations"
+	fakesaclient "k8s.io/ingress-gce/pkg/serviceattachment/client/clientset/versioned/fake"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testProject = "test-project"
+	testRegion  = "us-central1"
+)
+
+func newTestGCEClient() (*gce.Client, *cloud.MockGCE) {
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: testProject})
+	return &gce.Client{Cloud: mockGCE, Project: testProject, Region: testRegion}, mockGCE
+}
+
+func testService(annotations map[string]string, class *string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "svc-1",
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:              corev1.ServiceTypeLoadBalancer,
+			LoadBalancerClass: class,
+			Ports:             []corev1.ServicePort{{Name: "tcp", Port: 80, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+}
+
+func TestCheckLoadBalancerClass(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		class       *string
+		expect      string
+	}{
+		{
+			desc:   "no loadBalancerClass",
+			expect: report.Skipped,
+		},
+		{
+			desc:        "invalid load balancer type annotation",
+			annotations: map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Private"},
+			expect:      report.Failed,
+		},
+		{
+			desc:   "loadBalancerClass not managed by GKE",
+			class:  ptr.To("example.com/lb"),
+			expect: report.Skipped,
+		},
+		{
+			desc:   "loadBalancerClass without annotation",
+			class:  ptr.To(l4annotations.RegionalInternalLoadBalancerClass),
+			expect: report.Passed,
+		},
+		{
+			desc:        "matching loadBalancerClass and annotation",
+			annotations: map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Internal"},
+			class:       ptr.To(l4annotations.RegionalInternalLoadBalancerClass),
+			expect:      report.Passed,
+		},
+		{
+			desc:        "external loadBalancerClass with internal annotation",
+			annotations: map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Internal"},
+			class:       ptr.To(l4annotations.RegionalExternalLoadBalancerClass),
+			expect:      report.Failed,
+		},
+		{
+			desc:        "internal loadBalancerClass with deprecated external annotation",
+			annotations: map[string]string{"cloud.google.com/load-balancer-type": "External"},
+			class:       ptr.To(l4annotations.LegacyRegionalInternalLoadBalancerClass),
+			expect:      report.Failed,
+		},
+	} {
+		checker := &ServiceChecker{service: testService(tc.annotations, tc.class)}
+		_, res, msg := CheckLoadBalancerClass(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckStaticAddressAnnotation(t *testing.T) {
+	gceClient, mockGCE := newTestGCEClient()
+	for _, addr := range []*compute.Address{
+		{Name: "external-v4", AddressType: "EXTERNAL", NetworkTier: "PREMIUM"},
+		{Name: "external-v6", AddressType: "EXTERNAL", IpVersion: "IPV6"},
+		{Name: "external-v4-2", AddressType: "EXTERNAL"},
+		{Name: "internal-v4", AddressType: "INTERNAL"},
+	} {
+		mockGCE.Addresses().Insert(context.TODO(), meta.RegionalKey(addr.Name, testRegion), addr)
+	}
+	internal := map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Internal"}
+
+	for _, tc := range []struct {
+		desc          string
+		annotations   map[string]string
+		addresses     string
+		gceClient     *gce.Client
+		expect        string
+		wantAddresses int
+	}{
+		{
+			desc:   "no annotation",
+			expect: report.Skipped,
+		},
+		{
+			desc:      "valid annotation",
+			addresses: "external-v4, external-v6",
+			expect:    report.Passed,
+		},
+		{
+			desc:      "too many addresses",
+			addresses: "a,b,c",
+			expect:    report.Failed,
+		},
+		{
+			desc:      "invalid address name",
+			addresses: "External_V4",
+			expect:    report.Failed,
+		},
+		{
+			desc:      "empty address name",
+			addresses: "external-v4,",
+			expect:    report.Failed,
+		},
+		{
+			desc:      "duplicate address",
+			addresses: "external-v4,external-v4",
+			expect:    report.Failed,
+		},
+		{
+			desc:          "existing addresses",
+			addresses:     "external-v4,external-v6",
+			gceClient:     gceClient,
+			expect:        report.Passed,
+			wantAddresses: 2,
+		},
+		{
+			desc:      "address does not exist",
+			addresses: "missing",
+			gceClient: gceClient,
+			expect:    report.Failed,
+		},
+		{
+			desc:      "two IPv4 addresses",
+			addresses: "external-v4,external-v4-2",
+			gceClient: gceClient,
+			expect:    report.Failed,
+		},
+		{
+			desc:        "external address for internal load balancer",
+			annotations: internal,
+			addresses:   "external-v4",
+			gceClient:   gceClient,
+			expect:      report.Failed,
+		},
+		{
+			desc:          "internal address for internal load balancer",
+			annotations:   internal,
+			addresses:     "internal-v4",
+			gceClient:     gceClient,
+			expect:        report.Passed,
+			wantAddresses: 1,
+		},
+	} {
+		annotations := map[string]string{}
+		for k, v := range tc.annotations {
+			annotations[k] = v
+		}
+		if tc.addresses != "" {
+			annotations[l4annotations.StaticL4AddressesAnnotationKey] = tc.addresses
+		}
+		checker := &ServiceChecker{service: testService(annotations, nil), gceClient: tc.gceClient}
+		_, res, msg := CheckStaticAddressAnnotation(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+		if len(checker.addresses) != tc.wantAddresses {
+			t.Errorf("For test case %q, expect %d addresses, but got %d", tc.desc, tc.wantAddresses, len(checker.addresses))
+		}
+	}
+}
+
+func TestCheckNetworkTier(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		addresses   []*compute.Address
+		expect      string
+	}{
+		{
+			desc:   "default network tier",
+			expect: report.Passed,
+		},
+		{
+			desc:        "invalid network tier",
+			annotations: map[string]string{l4annotations.NetworkTierAnnotationKey: "Gold"},
+			expect:      report.Failed,
+		},
+		{
+			desc: "network tier on internal load balancer",
+			annotations: map[string]string{
+				l4annotations.ServiceAnnotationLoadBalancerType: "Internal",
+				l4annotations.NetworkTierAnnotationKey:          "Standard",
+			},
+			expect: report.Failed,
+		},
+		{
+			desc:        "internal load balancer",
+			annotations: map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Internal"},
+			expect:      report.Skipped,
+		},
+		{
+			desc:        "matching address network tier",
+			annotations: map[string]string{l4annotations.NetworkTierAnnotationKey: "Standard"},
+			addresses:   []*compute.Address{{Name: "addr", NetworkTier: "STANDARD"}},
+			expect:      report.Passed,
+		},
+		{
+			desc:        "address network tier mismatch",
+			annotations: map[string]string{l4annotations.NetworkTierAnnotationKey: "Standard"},
+			addresses:   []*compute.Address{{Name: "addr", NetworkTier: "PREMIUM"}},
+			expect:      report.Failed,
+		},
+		{
+			desc:      "address network tier mismatch with default tier",
+			addresses: []*compute.Address{{Name: "addr", NetworkTier: "STANDARD"}},
+			expect:    report.Failed,
+		},
+	} {
+		checker := &ServiceChecker{service: testService(tc.annotations, nil), addresses: tc.addresses}
+		_, res, msg := CheckNetworkTier(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckMixedProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		ports         []corev1.ServicePort
+		mixedProtocol bool
+		expect        string
+	}{
+		{
+			desc:   "single protocol",
+			ports:  []corev1.ServicePort{{Port: 80}, {Port: 443, Protocol: corev1.ProtocolTCP}},
+			expect: report.Skipped,
+		},
+		{
+			desc:   "mixed protocols without support",
+			ports:  []corev1.ServicePort{{Port: 53, Protocol: corev1.ProtocolTCP}, {Port: 53, Protocol: corev1.ProtocolUDP}},
+			expect: report.Failed,
+		},
+		{
+			desc:          "mixed protocols with support",
+			ports:         []corev1.ServicePort{{Port: 53, Protocol: corev1.ProtocolTCP}, {Port: 53, Protocol: corev1.ProtocolUDP}},
+			mixedProtocol: true,
+			expect:        report.Passed,
+		},
+	} {
+		svc := testService(nil, nil)
+		svc.Spec.Ports = tc.ports
+		checker := &ServiceChecker{service: svc, mixedProtocol: tc.mixedProtocol}
+		_, res, msg := CheckMixedProtocol(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckWeightedLoadBalancing(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		annotations   map[string]string
+		trafficPolicy corev1.ServiceExternalTrafficPolicy
+		expect        string
+		expectFix     []*report.PatchOperation
+	}{
+		{
+			desc:   "no annotation",
+			expect: report.Skipped,
+		},
+		{
+			desc:          "invalid annotation",
+			annotations:   map[string]string{l4annotations.WeightedL4AnnotationKey: "pods"},
+			trafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			expect:        report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "replace", Path: "/metadata/annotations/networking.gke.io~1weighted-load-balancing", Value: l4annotations.WeightedL4AnnotationPodsPerNode},
+			},
+		},
+		{
+			desc:          "weighted load balancing with cluster traffic policy",
+			annotations:   map[string]string{l4annotations.WeightedL4AnnotationKey: l4annotations.WeightedL4AnnotationPodsPerNode},
+			trafficPolicy: corev1.ServiceExternalTrafficPolicyCluster,
+			expect:        report.Failed,
+			expectFix: []*report.PatchOperation{
+				{Op: "add", Path: "/spec/externalTrafficPolicy", Value: "Local"},
+			},
+		},
+		{
+			desc:          "weighted load balancing with local traffic policy",
+			annotations:   map[string]string{l4annotations.WeightedL4AnnotationKey: l4annotations.WeightedL4AnnotationPodsPerNode},
+			trafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			expect:        report.Passed,
+		},
+	} {
+		svc := testService(tc.annotations, nil)
+		svc.Spec.ExternalTrafficPolicy = tc.trafficPolicy
+		checker := &ServiceChecker{service: svc}
+		_, res, msg := CheckWeightedLoadBalancing(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+		var gotFix []*report.PatchOperation
+		if fix := checker.TakeFix(); fix != nil {
+			gotFix = fix.Patch
+		}
+		if diff := cmp.Diff(tc.expectFix, gotFix); diff != "" {
+			t.Errorf("For test case %q, suggested fix mismatch (-want +got):\n%s", tc.desc, diff)
+		}
+	}
+}
+
+func TestCheckServiceAttachmentResource(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	ilb := testService(map[string]string{l4annotations.ServiceAnnotationLoadBalancerType: "Internal"}, nil)
+	ilb.Name = "ilb"
+	netlb := testService(nil, nil)
+	netlb.Name = "netlb"
+	for _, svc := range []*corev1.Service{ilb, netlb} {
+		client.CoreV1().Services("test").Create(context.TODO(), svc, metav1.CreateOptions{})
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		ref    corev1.TypedLocalObjectReference
+		expect string
+	}{
+		{
+			desc:   "internal load balancer service",
+			ref:    corev1.TypedLocalObjectReference{Kind: "Service", Name: "ilb"},
+			expect: report.Passed,
+		},
+		{
+			desc:   "external load balancer service",
+			ref:    corev1.TypedLocalObjectReference{Kind: "Service", Name: "netlb"},
+			expect: report.Failed,
+		},
+		{
+			desc:   "service does not exist",
+			ref:    corev1.TypedLocalObjectReference{Kind: "Service", Name: "missing"},
+			expect: report.Failed,
+		},
+		{
+			desc:   "not a service",
+			ref:    corev1.TypedLocalObjectReference{APIGroup: ptr.To("apps"), Kind: "Deployment", Name: "ilb"},
+			expect: report.Failed,
+		},
+	} {
+		checker := &ServiceAttachmentChecker{
+			client: client,
+			serviceAttachment: &sav1.ServiceAttachment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "sa-1"},
+				Spec:       sav1.ServiceAttachmentSpec{ResourceRef: tc.ref},
+			},
+		}
+		_, res, msg := CheckServiceAttachmentResource(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckServiceAttachmentNATSubnets(t *testing.T) {
+	gceClient, mockGCE := newTestGCEClient()
+	mockGCE.Subnetworks().Insert(context.TODO(), meta.RegionalKey("psc-subnet", testRegion), &compute.Subnetwork{Name: "psc-subnet", Purpose: pscSubnetPurpose})
+	mockGCE.Subnetworks().Insert(context.TODO(), meta.RegionalKey("node-subnet", testRegion), &compute.Subnetwork{Name: "node-subnet", Purpose: "PRIVATE"})
+	mockGCE.Subnetworks().Insert(context.TODO(), meta.RegionalKey("shared-subnet", testRegion), &compute.Subnetwork{Name: "shared-subnet", Purpose: pscSubnetPurpose})
+
+	for _, tc := range []struct {
+		desc      string
+		subnets   []string
+		gceClient *gce.Client
+		expect    string
+	}{
+		{
+			desc:   "no NAT subnets",
+			expect: report.Failed,
+		},
+		{
+			desc:    "NAT subnets without GCE client",
+			subnets: []string{"missing"},
+			expect:  report.Passed,
+		},
+		{
+			desc:      "PSC subnets",
+			subnets:   []string{"psc-subnet", cloud.SelfLink(meta.VersionGA, testProject, "subnetworks", meta.RegionalKey("shared-subnet", testRegion))},
+			gceClient: gceClient,
+			expect:    report.Passed,
+		},
+		{
+			desc:      "subnet does not exist",
+			subnets:   []string{"missing"},
+			gceClient: gceClient,
+			expect:    report.Failed,
+		},
+		{
+			desc:      "subnet is not for PSC",
+			subnets:   []string{"psc-subnet", "node-subnet"},
+			gceClient: gceClient,
+			expect:    report.Failed,
+		},
+	} {
+		checker := &ServiceAttachmentChecker{
+			gceClient: tc.gceClient,
+			serviceAttachment: &sav1.ServiceAttachment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "sa-1"},
+				Spec:       sav1.ServiceAttachmentSpec{NATSubnets: tc.subnets},
+			},
+		}
+		_, res, msg := CheckServiceAttachmentNATSubnets(checker)
+		if res != tc.expect {
+			t.Errorf("For test case %q, expect check result = %s, but got %s: %s", tc.desc, tc.expect, res, msg)
+		}
+	}
+}
+
+func TestCheckAllL4Resources(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	saClient := fakesaclient.NewSimpleClientset()
+
+	ilb := testService(map[string]string{
+		l4annotations.ServiceAnnotationLoadBalancerType: "Internal",
+		l4annotations.NetworkTierAnnotationKey:          "Standard",
+	}, nil)
+	ilb.Name = "ilb"
+	clusterIP := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "cluster-ip"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	}
+	for _, svc := range []*corev1.Service{ilb, clusterIP} {
+		client.CoreV1().Services("test").Create(context.TODO(), svc, metav1.CreateOptions{})
+	}
+	saClient.NetworkingV1().ServiceAttachments("test").Create(context.TODO(), &sav1.ServiceAttachment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "sa-1"},
+		Spec: sav1.ServiceAttachmentSpec{
+			ResourceRef: corev1.TypedLocalObjectReference{Kind: "Service", Name: "ilb"},
+		},
+	}, metav1.CreateOptions{})
+
+	expect := []*report.Resource{
+		{
+			Kind:      "Service",
+			Namespace: "test",
+			Name:      "ilb",
+			Checks: []*report.Check{
+				{Name: LoadBalancerClassCheck, Result: report.Skipped, Severity: report.Warning},
+				{Name: StaticAddressAnnotationCheck, Result: report.Skipped, Severity: report.Error},
+				{
+					Name:     NetworkTierCheck,
+					Result:   report.Failed,
+					Severity: report.Error,
+					Fix: &report.Fix{
+						Kind:      "Service",
+						Namespace: "test",
+						Name:      "ilb",
+						Patch: []*report.PatchOperation{
+							{Op: "remove", Path: "/metadata/annotations/cloud.google.com~1network-tier"},
+						},
+					},
+				},
+				{Name: MixedProtocolCheck, Result: report.Skipped, Severity: report.Error},
+				{Name: WeightedLoadBalancingCheck, Result: report.Skipped, Severity: report.Warning},
+			},
+		},
+		{
+			Kind:      "ServiceAttachment",
+			Namespace: "test",
+			Name:      "sa-1",
+			Checks: []*report.Check{
+				{Name: ServiceAttachmentResourceCheck, Result: report.Passed, Severity: report.Error},
+				{Name: ServiceAttachmentNATSubnetsCheck, Result: report.Failed, Severity: report.Error},
+			},
+		},
+	}
+
+	result := CheckAllL4Resources("test", client, saClient, Options{})
+	for _, res := range result.Resources {
+		for _, check := range res.Checks {
+			check.Message = ""
+		}
+	}
+	if diff := cmp.Diff(expect, result.Resources); diff != "" {
+		t.Errorf("CheckAllL4Resources() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Name       string            `xml:"name,attr"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	// Contents is the suggested fix of the failure, if any.
+	Contents string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitReport returns the report in the JUnit XML format, with a test suite
+// per checked resource and a test case per check. The type of a failure is
+// the severity of the check, and its contents the suggested fix.
+func JUnitReport(report *Report) (string, error) {
+	suites := &junitTestSuites{Name: toolName}
+	for _, res := range report.Resources {
+		suite := &junitTestSuite{Name: resourceName(res)}
+		for _, check := range res.Checks {
+			testCase := &junitTestCase{
+				Name:      check.Name,
+				ClassName: suite.Name,
+			}
+			switch check.Result {
+			case Failed:
+				testCase.Failure = &junitFailure{Message: check.Message, Type: check.Severity}
+				if check.Fix != nil {
+					fix, err := json.MarshalIndent(check.Fix, "", "  ")
+					if err != nil {
+						return "", err
+					}
+					testCase.Failure.Contents = string(fix)
+				}
+				suite.Failures++
+			case Skipped:
+				testCase.Skipped = &junitSkipped{Message: check.Message}
+				suite.Skipped++
+			default:
+				testCase.SystemOut = check.Message
+			}
+			suite.Tests++
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+
+	xmlRaw, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(xmlRaw), nil
+}
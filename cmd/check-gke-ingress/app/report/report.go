@@ -18,6 +18,8 @@ package report
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 )
 
 const (
@@ -29,11 +31,35 @@ const (
 	Skipped string = "SKIPPED"
 )
 
+const (
+	// Error is the severity of a check whose failure breaks the load balancer
+	Error string = "error"
+	// Warning is the severity of a check whose failure makes the load
+	// balancer behave differently than configured
+	Warning string = "warning"
+	// Info is the severity of a check whose failure is informational
+	Info string = "info"
+	// None is the --fail-on threshold that never fails
+	None string = "none"
+)
+
 const (
 	//JSONOutput is the constant value for output type JSON
 	JSONOutput string = "json"
+	// SARIFOutput is the constant value for output type SARIF
+	SARIFOutput string = "sarif"
+	// JUnitOutput is the constant value for output type JUnit XML
+	JUnitOutput string = "junit"
 )
 
+// severityLevels orders the severities, from the least to the most severe.
+var severityLevels = map[string]int{
+	None:    0,
+	Info:    1,
+	Warning: 2,
+	Error:   3,
+}
+
 // Report represents the final output of the analyzer
 type Report struct {
 	Resources []*Resource `json:"resources"`
@@ -49,9 +75,104 @@ type Resource struct {
 
 // Check represents the result of a check
 type Check struct {
-	Name    string `json:"name"`
-	Message string `json:"message"`
-	Result  string `json:"result"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+	Result   string `json:"result"`
+	Severity string `json:"severity,omitempty"`
+	Fix      *Fix   `json:"fix,omitempty"`
+}
+
+// Fix is a suggested fix of a failed check, a JSON patch of a resource which
+// can be applied with `kubectl patch <kind> <name> --type=json -p <patch>`.
+type Fix struct {
+	Kind      string            `json:"kind"`
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Patch     []*PatchOperation `json:"patch"`
+}
+
+// PatchOperation is an operation of a JSON patch.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON omits the value of remove operations, which have none. The
+// value of the other operations is kept even if it is false, 0 or empty.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{Op: op.Op, Path: op.Path})
+	}
+	type patchOperation PatchOperation
+	return json.Marshal(patchOperation(op))
+}
+
+// Suggestion holds the fix suggested by the last failed check of a checker.
+// Checkers embed it, so that check functions can suggest a fix.
+type Suggestion struct {
+	fix *Fix
+}
+
+// Suggest records the fix of the failing check.
+func (s *Suggestion) Suggest(fix *Fix) {
+	s.fix = fix
+}
+
+// TakeFix returns the suggested fix and clears it for the next check.
+func (s *Suggestion) TakeFix() *Fix {
+	fix := s.fix
+	s.fix = nil
+	return fix
+}
+
+// AnnotationPath returns the JSON pointer of an annotation.
+func AnnotationPath(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	key = strings.ReplaceAll(key, "/", "~1")
+	return "/metadata/annotations/" + key
+}
+
+// AddAnnotation returns the patch operation adding an annotation to a resource
+// with the given annotations.
+func AddAnnotation(annotations map[string]string, key, value string) *PatchOperation {
+	if len(annotations) == 0 {
+		return &PatchOperation{Op: "add", Path: "/metadata/annotations", Value: map[string]string{key: value}}
+	}
+	return &PatchOperation{Op: "add", Path: AnnotationPath(key), Value: value}
+}
+
+// RemoveAnnotation returns the patch operation removing an annotation.
+func RemoveAnnotation(key string) *PatchOperation {
+	return &PatchOperation{Op: "remove", Path: AnnotationPath(key)}
+}
+
+// ValidateSeverity returns an error if the severity is not a valid --fail-on
+// threshold.
+func ValidateSeverity(severity string) error {
+	if _, ok := severityLevels[severity]; !ok {
+		return fmt.Errorf("invalid severity %q, must be one of [%s, %s, %s, %s]", severity, Error, Warning, Info, None)
+	}
+	return nil
+}
+
+// HasFailures returns whether the report has failed checks with a severity at
+// or above the threshold.
+func HasFailures(report *Report, threshold string) bool {
+	if severityLevels[threshold] == severityLevels[None] {
+		return false
+	}
+	for _, res := range report.Resources {
+		for _, check := range res.Checks {
+			if check.Result == Failed && severityLevels[check.Severity] >= severityLevels[threshold] {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func JsonReport(report *Report) (string, error) {
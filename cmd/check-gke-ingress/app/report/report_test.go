@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testReport() *Report {
+	return &Report{
+		Resources: []*Resource{
+			{
+				Kind:      "Service",
+				Namespace: "test",
+				Name:      "svc-1",
+				Checks: []*Check{
+					{
+						Name:     "NetworkTierCheck",
+						Message:  "Invalid network tier",
+						Result:   Failed,
+						Severity: Error,
+						Fix: &Fix{
+							Kind:      "Service",
+							Namespace: "test",
+							Name:      "svc-1",
+							Patch:     []*PatchOperation{RemoveAnnotation("cloud.google.com/network-tier")},
+						},
+					},
+					{
+						Name:     "LoadBalancerClassCheck",
+						Message:  "No loadBalancerClass",
+						Result:   Skipped,
+						Severity: Warning,
+					},
+				},
+			},
+			{
+				Kind:      "Service",
+				Namespace: "test",
+				Name:      "svc-2",
+				Checks: []*Check{
+					{
+						Name:     "NetworkTierCheck",
+						Message:  "Valid network tier",
+						Result:   Passed,
+						Severity: Error,
+					},
+					{
+						Name:     "LoadBalancerClassCheck",
+						Message:  "Conflicting loadBalancerClass",
+						Result:   Failed,
+						Severity: Warning,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSarifReport(t *testing.T) {
+	res, err := SarifReport(testReport())
+	if err != nil {
+		t.Fatalf("SarifReport() returned error: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal([]byte(res), &log); err != nil {
+		t.Fatalf("Failed to unmarshal SARIF report: %v", err)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Expect 1 run, got %d", len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	expectRules := []*sarifRule{
+		{ID: "NetworkTierCheck", DefaultConfiguration: sarifConfiguration{Level: "error"}},
+		{ID: "LoadBalancerClassCheck", DefaultConfiguration: sarifConfiguration{Level: "warning"}},
+	}
+	if diff := cmp.Diff(expectRules, run.Tool.Driver.Rules); diff != "" {
+		t.Errorf("Unexpected rules (-want +got):\n%s", diff)
+	}
+
+	expectResults := []*sarifResult{
+		{
+			RuleID:  "NetworkTierCheck",
+			Level:   "error",
+			Message: sarifMessage{Text: "Invalid network tier"},
+			Locations: []*sarifLocation{{
+				LogicalLocations: []*sarifLogicalLocation{{FullyQualifiedName: "Service/test/svc-1", Kind: "resource"}},
+			}},
+			Properties: &sarifProperties{Fix: &Fix{
+				Kind:      "Service",
+				Namespace: "test",
+				Name:      "svc-1",
+				Patch:     []*PatchOperation{{Op: "remove", Path: "/metadata/annotations/cloud.google.com~1network-tier"}},
+			}},
+		},
+		{
+			RuleID:  "LoadBalancerClassCheck",
+			Level:   "warning",
+			Message: sarifMessage{Text: "Conflicting loadBalancerClass"},
+			Locations: []*sarifLocation{{
+				LogicalLocations: []*sarifLogicalLocation{{FullyQualifiedName: "Service/test/svc-2", Kind: "resource"}},
+			}},
+		},
+	}
+	if diff := cmp.Diff(expectResults, run.Results); diff != "" {
+		t.Errorf("Unexpected results (-want +got):\n%s", diff)
+	}
+}
+
+func TestJUnitReport(t *testing.T) {
+	res, err := JUnitReport(testReport())
+	if err != nil {
+		t.Fatalf("JUnitReport() returned error: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal([]byte(res), &suites); err != nil {
+		t.Fatalf("Failed to unmarshal JUnit report: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 2 || suites.Skipped != 1 {
+		t.Errorf("Expect 4 tests, 2 failures and 1 skipped, got %d tests, %d failures and %d skipped", suites.Tests, suites.Failures, suites.Skipped)
+	}
+	if len(suites.TestSuites) != 2 {
+		t.Fatalf("Expect 2 test suites, got %d", len(suites.TestSuites))
+	}
+	suite := suites.TestSuites[0]
+	if suite.Name != "Service/test/svc-1" {
+		t.Errorf("Expect test suite name Service/test/svc-1, got %s", suite.Name)
+	}
+	failure := suite.TestCases[0].Failure
+	if failure == nil || failure.Type != Error || failure.Message != "Invalid network tier" {
+		t.Fatalf("Unexpected failure %+v", failure)
+	}
+	var fix Fix
+	if err := json.Unmarshal([]byte(failure.Contents), &fix); err != nil {
+		t.Fatalf("Failed to unmarshal the fix of the failure: %v", err)
+	}
+	if fix.Name != "svc-1" || len(fix.Patch) != 1 {
+		t.Errorf("Unexpected fix %+v", fix)
+	}
+	if suite.TestCases[1].Skipped == nil {
+		t.Errorf("Expect test case %s to be skipped", suite.TestCases[1].Name)
+	}
+}
+
+func TestHasFailures(t *testing.T) {
+	for _, tc := range []struct {
+		threshold string
+		expect    bool
+	}{
+		{threshold: None, expect: false},
+		{threshold: Info, expect: true},
+		{threshold: Warning, expect: true},
+		{threshold: Error, expect: true},
+	} {
+		if got := HasFailures(testReport(), tc.threshold); got != tc.expect {
+			t.Errorf("For threshold %q, expect HasFailures() = %t, but got %t", tc.threshold, tc.expect, got)
+		}
+	}
+
+	onlyWarnings := testReport()
+	onlyWarnings.Resources = onlyWarnings.Resources[1:]
+	if HasFailures(onlyWarnings, Error) {
+		t.Errorf("Expect no failures of severity %s in a report with only warnings", Error)
+	}
+	if !HasFailures(onlyWarnings, Warning) {
+		t.Errorf("Expect failures of severity %s in a report with warnings", Warning)
+	}
+}
+
+func TestValidateSeverity(t *testing.T) {
+	for _, severity := range []string{Error, Warning, Info, None} {
+		if err := ValidateSeverity(severity); err != nil {
+			t.Errorf("ValidateSeverity(%q) returned error: %v", severity, err)
+		}
+	}
+	if err := ValidateSeverity("critical"); err == nil {
+		t.Errorf("Expect ValidateSeverity(%q) to return an error", "critical")
+	}
+}
+
+func TestAnnotationPatch(t *testing.T) {
+	key := "networking.gke.io/load-balancer-type"
+	if got, want := AnnotationPath(key), "/metadata/annotations/networking.gke.io~1load-balancer-type"; got != want {
+		t.Errorf("AnnotationPath(%q) = %q, want %q", key, got, want)
+	}
+	if diff := cmp.Diff(&PatchOperation{Op: "add", Path: "/metadata/annotations", Value: map[string]string{key: "Internal"}}, AddAnnotation(nil, key, "Internal")); diff != "" {
+		t.Errorf("Unexpected patch when adding to empty annotations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&PatchOperation{Op: "add", Path: AnnotationPath(key), Value: "Internal"}, AddAnnotation(map[string]string{"foo": "bar"}, key, "Internal")); diff != "" {
+		t.Errorf("Unexpected patch when adding to annotations (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatchOperationJSON(t *testing.T) {
+	for _, tc := range []struct {
+		op   *PatchOperation
+		want string
+	}{
+		{op: &PatchOperation{Op: "replace", Path: "/spec/enabled", Value: false}, want: `{"op":"replace","path":"/spec/enabled","value":false}`},
+		{op: &PatchOperation{Op: "replace", Path: "/spec/port", Value: 0}, want: `{"op":"replace","path":"/spec/port","value":0}`},
+		{op: &PatchOperation{Op: "add", Path: "/spec/name", Value: ""}, want: `{"op":"add","path":"/spec/name","value":""}`},
+		{op: RemoveAnnotation("foo"), want: `{"op":"remove","path":"/metadata/annotations/foo"}`},
+	} {
+		got, err := json.Marshal(tc.op)
+		if err != nil {
+			t.Fatalf("json.Marshal(%+v) returned error: %v", tc.op, err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("json.Marshal(%+v) = %s, want %s", tc.op, got, tc.want)
+		}
+	}
+}
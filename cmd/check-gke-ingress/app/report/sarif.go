@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "check-gke-ingress"
+	toolURI      = "https://github.com/kubernetes/ingress-gce/tree/master/cmd/check-gke-ingress"
+)
+
+// sarifLevels maps the severities of the checks to SARIF levels.
+var sarifLevels = map[string]string{
+	Error:   "error",
+	Warning: "warning",
+	Info:    "note",
+}
+
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID     string           `json:"ruleId"`
+	Level      string           `json:"level"`
+	Message    sarifMessage     `json:"message"`
+	Locations  []*sarifLocation `json:"locations"`
+	Properties *sarifProperties `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifProperties struct {
+	Fix *Fix `json:"fix"`
+}
+
+// SarifReport returns the failed checks of the report in the SARIF format.
+// Each checked resource is a logical location of the results, and the
+// suggested fixes are in the properties of the results.
+func SarifReport(report *Report) (string, error) {
+	run := &sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          []*sarifRule{},
+			},
+		},
+		Results: []*sarifResult{},
+	}
+	seenRules := make(map[string]bool)
+	for _, res := range report.Resources {
+		for _, check := range res.Checks {
+			level := sarifLevel(check.Severity)
+			if !seenRules[check.Name] {
+				seenRules[check.Name] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &sarifRule{
+					ID:                   check.Name,
+					DefaultConfiguration: sarifConfiguration{Level: level},
+				})
+			}
+			if check.Result != Failed {
+				continue
+			}
+			result := &sarifResult{
+				RuleID:  check.Name,
+				Level:   level,
+				Message: sarifMessage{Text: check.Message},
+				Locations: []*sarifLocation{{
+					LogicalLocations: []*sarifLogicalLocation{{
+						FullyQualifiedName: resourceName(res),
+						Kind:               "resource",
+					}},
+				}},
+			}
+			if check.Fix != nil {
+				result.Properties = &sarifProperties{Fix: check.Fix}
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	jsonRaw, err := json.MarshalIndent(&sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []*sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonRaw), nil
+}
+
+// sarifLevel returns the SARIF level of a severity.
+func sarifLevel(severity string) string {
+	if level, ok := sarifLevels[severity]; ok {
+		return level
+	}
+	return "error"
+}
+
+// resourceName returns the kind, namespace and name of a resource.
+func resourceName(res *Resource) string {
+	return fmt.Sprintf("%s/%s/%s", res.Kind, res.Namespace, res.Name)
+}
@@ -34,6 +34,7 @@ import (
 	flag "github.com/spf13/pflag"
 	crdclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	informers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -43,6 +44,9 @@ import (
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/frontendconfig"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/gateway"
+	"k8s.io/ingress-gce/pkg/ingsyncstatus"
+	ingsyncstatusclient "k8s.io/ingress-gce/pkg/ingsyncstatus/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/instancegroups"
 	"k8s.io/ingress-gce/pkg/l4lb"
 	multiprojectgce "k8s.io/ingress-gce/pkg/multiproject/gce"
@@ -211,6 +215,19 @@ func main() {
 		}
 	}
 
+	var ingSyncStatusClient ingsyncstatusclient.Interface
+	if flags.F.EnableIngressSyncStatus {
+		ingSyncStatusCRDMeta := ingsyncstatus.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(ingSyncStatusCRDMeta, true); err != nil {
+			klog.Fatalf("Failed to ensure IngressSyncStatus CRD: %v", err)
+		}
+
+		ingSyncStatusClient, err = ingsyncstatusclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create IngressSyncStatus client: %v", err)
+		}
+	}
+
 	namer, err := app.NewNamer(kubeClient, flags.F.ClusterName, firewalls.DefaultFirewallName, rootLogger)
 	if err != nil {
 		klog.Fatalf("app.NewNamer(ctx.KubeClient, %q, %q) = %v", flags.F.ClusterName, firewalls.DefaultFirewallName, err)
@@ -365,11 +382,18 @@ func main() {
 		EnableL4ILBZonalAffinity:                  flags.F.EnableL4ILBZonalAffinity,
 		EnableL4NetLBForwardingRulesOptimizations: flags.F.EnableL4NetLBForwardingRulesOptimizations,
 		ReadOnlyMode:                              flags.F.ReadOnlyMode,
+		IngressPlanMode:                           flags.F.IngressPlanMode,
 	}
-	ctx, err := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, firewallCRClient, svcNegClient, svcAttachmentClient, networkClient, nodeTopologyClient, eventRecorderKubeClient, cloud, namer, kubeSystemUID, ctxConfig, rootLogger)
+	ctx, err := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, firewallCRClient, svcNegClient, svcAttachmentClient, networkClient, nodeTopologyClient, ingSyncStatusClient, eventRecorderKubeClient, cloud, namer, kubeSystemUID, ctxConfig, rootLogger)
 	if err != nil {
 		klog.Fatalf("unable to set up controller context: %v", err)
 	}
+	if flags.F.EnableGatewayController {
+		ctx.GatewayClient, err = dynamic.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create Gateway API client: %v", err)
+		}
+	}
 
 	leOption := leaderElectionOption{
 		client:   leaderElectKubeClient,
@@ -607,6 +631,18 @@ func makeRunnerWithLeaderElection(
 func runIngressControllers(ctx *ingctx.ControllerContext, systemHealth *systemhealth.SystemHealth, option runOption, leOption leaderElectionOption, logger klog.Logger) {
 	if flags.F.RunIngressController {
 		lbc := controller.NewLoadBalancerController(ctx, option.stopCh, logger)
+		// The Gateway controller syncs and garbage collects its backends
+		// with the Ingress controller, which keeps the backends of the
+		// Gateways.
+		var gwc *gateway.Controller
+		if ctx.GatewayClient != nil {
+			var err error
+			gwc, err = gateway.NewController(ctx, lbc, option.stopCh, logger)
+			if err != nil {
+				klog.Fatalf("Failed to create Gateway controller: %v", err)
+			}
+			lbc.SetExtraIngresses(gwc.Informers().Ingresses)
+		}
 		systemHealth.AddHealthCheck("ingress", lbc.SystemHealth)
 		runWithWg(lbc.Run, option.wg)
 		logger.V(0).Info("ingress controller started")
@@ -618,8 +654,19 @@ func runIngressControllers(ctx *ingctx.ControllerContext, systemHealth *systemhe
 		if err != nil {
 			klog.Fatalf("failed to create firewall controller: %v", err)
 		}
+		if gwc != nil {
+			gwInformers := gwc.Informers()
+			fwc.SetExtraIngresses(gwInformers.Ingresses, gwInformers.GatewayClass, gwInformers.Gateway, gwInformers.HTTPRoute)
+		}
 		runWithWg(fwc.Run, option.wg)
 		logger.V(0).Info("firewall controller started")
+
+		// The Gateway controller shares the backends, instance groups and
+		// firewall rule of the Ingress controller.
+		if gwc != nil {
+			runWithWg(gwc.Run, option.wg)
+			logger.V(0).Info("gateway controller started")
+		}
 	}
 
 	ctx.Start(option.stopCh)
@@ -770,6 +817,19 @@ func createNEGController(ctx *ingctx.ControllerContext, systemHealth *systemheal
 		return nil, fmt.Errorf("failed to create NEG controller: %w", err)
 	}
 
+	if ctx.BackendConfigInformer != nil {
+		// Services that receive the requests mirrored by BackendConfigs get
+		// NEGs like the backends they mirror.
+		negController.SetBackendConfigInformer(ctx.BackendConfigInformer)
+	}
+
+	if ctx.GatewayClient != nil {
+		// Services used by Gateways get NEGs like those used by Ingresses.
+		gwInformers := gateway.NewInformers(ctx.GatewayClient, ctx.ResyncPeriod)
+		negController.SetExtraIngresses(gwInformers.Ingresses, gwInformers.GatewayClass, gwInformers.Gateway, gwInformers.HTTPRoute)
+		gwInformers.Run(stopCh)
+	}
+
 	systemHealth.AddHealthCheck("neg-controller", negController.IsHealthy)
 	return negController, nil
 }
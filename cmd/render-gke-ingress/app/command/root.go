@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/ingress-gce/cmd/render-gke-ingress/app/render"
+	"k8s.io/ingress-gce/pkg/utils"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	yamlOutput = "yaml"
+	jsonOutput = "json"
+)
+
+var (
+	files                     []string
+	namespace                 string
+	output                    string
+	project                   string
+	region                    string
+	clusterUID                string
+	kubeSystemUID             string
+	defaultBackendService     string
+	defaultBackendServicePort string
+	healthCheckPath           string
+)
+
+// Output is the list of rendered resources printed by the command.
+type Output struct {
+	Resources []*render.Resources `json:"resources"`
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "render-gke-ingress",
+	Short: "render-gke-ingress prints the GCE resources that the GKE Ingress controller would create for ingress manifests.",
+	Long:  "render-gke-ingress translates Ingress, Service, Secret, BackendConfig and FrontendConfig manifests into the URL maps, target proxies, forwarding rules, backend services and health checks of their GCE load balancers, without a cluster or GCE API calls.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(files) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: at least one manifest file must be given with --files\n")
+			os.Exit(1)
+		}
+		if output != yamlOutput && output != jsonOutput {
+			fmt.Fprintf(os.Stderr, "Error: unsupported output format %q, must be %q or %q\n", output, yamlOutput, jsonOutput)
+			os.Exit(1)
+		}
+		defaultBackend, err := utils.ToNamespacedName(defaultBackendService)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --default-backend-service: %v\n", err)
+			os.Exit(1)
+		}
+
+		manifests, err := render.LoadManifests(files, namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading manifests: %v\n", err)
+			os.Exit(1)
+		}
+		renderer, err := render.NewRenderer(manifests, render.Config{
+			Project:       project,
+			Region:        region,
+			ClusterUID:    clusterUID,
+			KubeSystemUID: kubeSystemUID,
+			DefaultBackend: utils.ServicePortID{
+				Service: defaultBackend,
+				Port:    networkingv1.ServiceBackendPort{Name: defaultBackendServicePort},
+			},
+			HealthCheckPath: healthCheckPath,
+		}, logr.Discard())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing translation: %v\n", err)
+			os.Exit(1)
+		}
+
+		out := Output{Resources: renderer.Render()}
+		var res []byte
+		if output == jsonOutput {
+			res, err = json.MarshalIndent(out, "", "  ")
+		} else {
+			res, err = yaml.Marshal(out)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error processing results: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(res))
+
+		for _, r := range out.Resources {
+			if len(r.Errors) > 0 {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.Flags().StringSliceVarP(&files, "files", "f", nil, "manifest files to read the Ingresses and their Services, Secrets, BackendConfigs and FrontendConfigs from")
+	rootCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the resources that do not specify one")
+	rootCmd.Flags().StringVarP(&output, "output", "o", yamlOutput, "output format, yaml or json")
+	rootCmd.Flags().StringVar(&project, "project", "my-project", "GCP project of the load balancers")
+	rootCmd.Flags().StringVar(&region, "region", "us-central1", "region of the cluster, used by regional load balancers")
+	rootCmd.Flags().StringVar(&clusterUID, "cluster-uid", "", "cluster UID used in the names of the GCE resources")
+	rootCmd.Flags().StringVar(&kubeSystemUID, "kube-system-uid", "", "UID of the kube-system namespace, used in the names of the GCE frontend resources")
+	rootCmd.Flags().StringVar(&defaultBackendService, "default-backend-service", "kube-system/default-http-backend", "namespace/name of the system default backend Service")
+	rootCmd.Flags().StringVar(&defaultBackendServicePort, "default-backend-service-port", "http", "port name of the system default backend Service")
+	rootCmd.Flags().StringVar(&healthCheckPath, "health-check-path", "/", "default path of the health checks")
+}
+
+// Execute is the primary entrypoint for this CLI
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
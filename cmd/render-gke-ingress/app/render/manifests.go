@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+// Manifests are the Kubernetes resources read from manifest files.
+type Manifests struct {
+	Ingresses       []*networkingv1.Ingress
+	Services        []*apiv1.Service
+	Secrets         []*apiv1.Secret
+	BackendConfigs  []*backendconfigv1.BackendConfig
+	FrontendConfigs []*frontendconfigv1beta1.FrontendConfig
+}
+
+// LoadManifests reads the resources of the given YAML or JSON manifest
+// files. Resources without a namespace are put in the given namespace.
+func LoadManifests(paths []string, namespace string) (*Manifests, error) {
+	m := &Manifests{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = m.Read(f, namespace)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+	}
+	return m, nil
+}
+
+// Read adds the resources of the YAML or JSON documents of r to the
+// Manifests. Lists are expanded and resources of other kinds are ignored.
+func (m *Manifests) Read(r io.Reader, namespace string) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(obj.Object) == 0 {
+			// Empty document.
+			continue
+		}
+		if obj.IsList() {
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				return m.add(item.(*unstructured.Unstructured), namespace)
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.add(obj, namespace); err != nil {
+			return err
+		}
+	}
+}
+
+// add converts the resource to its type and adds it to the Manifests.
+func (m *Manifests) add(obj *unstructured.Unstructured, namespace string) error {
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	gvk := obj.GroupVersionKind()
+	var typed interface{}
+	switch gvk {
+	case networkingv1.SchemeGroupVersion.WithKind("Ingress"):
+		ing := &networkingv1.Ingress{}
+		m.Ingresses = append(m.Ingresses, ing)
+		typed = ing
+	case apiv1.SchemeGroupVersion.WithKind("Service"):
+		svc := &apiv1.Service{}
+		m.Services = append(m.Services, svc)
+		typed = svc
+	case apiv1.SchemeGroupVersion.WithKind("Secret"):
+		secret := &apiv1.Secret{}
+		m.Secrets = append(m.Secrets, secret)
+		typed = secret
+	case backendconfigv1.SchemeGroupVersion.WithKind("BackendConfig"), backendconfigv1beta1.SchemeGroupVersion.WithKind("BackendConfig"):
+		// The API server serves v1beta1 BackendConfigs as v1, whose fields
+		// are a superset of the v1beta1 fields.
+		obj.SetAPIVersion(backendconfigv1.SchemeGroupVersion.String())
+		beConfig := &backendconfigv1.BackendConfig{}
+		m.BackendConfigs = append(m.BackendConfigs, beConfig)
+		typed = beConfig
+	case frontendconfigv1beta1.SchemeGroupVersion.WithKind("FrontendConfig"):
+		feConfig := &frontendconfigv1beta1.FrontendConfig{}
+		m.FrontendConfigs = append(m.FrontendConfigs, feConfig)
+		typed = feConfig
+	default:
+		return nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+		return fmt.Errorf("invalid %s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	if secret, ok := typed.(*apiv1.Secret); ok {
+		// The API server merges stringData into data on write.
+		for k, v := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[k] = []byte(v)
+		}
+	}
+	return nil
+}
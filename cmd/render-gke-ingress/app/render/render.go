@@ -0,0 +1,380 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render translates Ingress manifests into the GCE resources that
+// the Ingress controller would create for them, without a cluster or a
+// cloud.
+package render
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	informerv1 "k8s.io/client-go/informers/core/v1"
+	discoveryinformer "k8s.io/client-go/informers/discovery/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/composite"
+	legacytranslator "k8s.io/ingress-gce/pkg/controller/translator"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
+	"k8s.io/ingress-gce/pkg/healthchecks"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	lbfeatures "k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/translator"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/endpointslices"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+// redactedPrivateKey replaces the private keys of the rendered SSL
+// certificates.
+const redactedPrivateKey = "REDACTED"
+
+// Config are the cluster and project settings used for the translation.
+type Config struct {
+	// Project is the GCP project of the load balancers.
+	Project string
+	// Region is the region of the cluster and the regional load balancers.
+	Region string
+	// ClusterUID is the UID used by the namer of the GCE resources.
+	ClusterUID string
+	// KubeSystemUID is the UID of the kube-system namespace, used by the v2
+	// frontend namer.
+	KubeSystemUID string
+	// DefaultBackend is the system default backend Service, used by Ingresses
+	// without a default backend. A NodePort Service is assumed if the
+	// manifests do not contain it.
+	DefaultBackend utils.ServicePortID
+	// HealthCheckPath is the default health check path of the backends.
+	HealthCheckPath string
+}
+
+// Resources are the GCE resources of an Ingress.
+type Resources struct {
+	Kind             string                      `json:"kind"`
+	Namespace        string                      `json:"namespace"`
+	Name             string                      `json:"name"`
+	UrlMap           *composite.UrlMap           `json:"urlMap,omitempty"`
+	RedirectUrlMap   *composite.UrlMap           `json:"redirectUrlMap,omitempty"`
+	TargetHttpProxy  *composite.TargetHttpProxy  `json:"targetHttpProxy,omitempty"`
+	TargetHttpsProxy *composite.TargetHttpsProxy `json:"targetHttpsProxy,omitempty"`
+	SslCertificates  []*composite.SslCertificate `json:"sslCertificates,omitempty"`
+	Addresses        []*composite.Address        `json:"addresses,omitempty"`
+	ForwardingRules  []*composite.ForwardingRule `json:"forwardingRules,omitempty"`
+	BackendServices  []*composite.BackendService `json:"backendServices,omitempty"`
+	HealthChecks     []*compute.HealthCheck      `json:"healthChecks,omitempty"`
+	Errors           []string                    `json:"errors,omitempty"`
+}
+
+// Renderer translates Ingresses into GCE resources.
+type Renderer struct {
+	config         Config
+	cloud          *gce.Cloud
+	namer          *namer_util.Namer
+	translator     *legacytranslator.Translator
+	backendSyncer  *backends.Syncer
+	l7Pool         loadbalancers.LoadBalancerPool
+	plan           *plan.Recorder
+	manifests      *Manifests
+	feConfigs      []*frontendconfigv1beta1.FrontendConfig
+	defaultBackend utils.ServicePortID
+
+	logger klog.Logger
+}
+
+// NewRenderer returns a Renderer of the Ingresses of the manifests.
+//
+// The resources are computed by the backend syncer and the load balancer
+// pool of the controller in plan mode, against an empty fake cloud, so that
+// no GCE API is called.
+func NewRenderer(m *Manifests, config Config, logger klog.Logger) (*Renderer, error) {
+	vals := gce.DefaultTestClusterValues()
+	vals.ProjectID = config.Project
+	vals.Region = config.Region
+	fakeGCE := gce.NewFakeGCECloud(vals)
+
+	var secrets []runtime.Object
+	for _, secret := range m.Secrets {
+		secrets = append(secrets, secret)
+	}
+	kubeClient := fake.NewSimpleClientset(secrets...)
+	serviceInformer := informerv1.NewServiceInformer(kubeClient, apiv1.NamespaceAll, 0, utils.NewNamespaceIndexer())
+	backendConfigInformer := informerbackendconfig.NewBackendConfigInformer(backendconfigclient.NewSimpleClientset(), apiv1.NamespaceAll, 0, utils.NewNamespaceIndexer())
+	podInformer := informerv1.NewPodInformer(kubeClient, apiv1.NamespaceAll, 0, utils.NewNamespaceIndexer())
+	nodeInformer := informerv1.NewNodeInformer(kubeClient, 0, utils.NewNamespaceIndexer())
+	endpointSliceInformer := discoveryinformer.NewEndpointSliceInformer(kubeClient, apiv1.NamespaceAll, 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc, endpointslices.EndpointSlicesByServiceIndex: endpointslices.EndpointSlicesByServiceFunc})
+
+	for _, svc := range m.Services {
+		if err := serviceInformer.GetIndexer().Add(svc); err != nil {
+			return nil, err
+		}
+	}
+	if _, exists, _ := serviceInformer.GetIndexer().GetByKey(config.DefaultBackend.Service.String()); !exists {
+		if err := serviceInformer.GetIndexer().Add(defaultBackendService(config.DefaultBackend)); err != nil {
+			return nil, err
+		}
+	}
+	for _, beConfig := range m.BackendConfigs {
+		if err := backendConfigInformer.GetIndexer().Add(beConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	recorders := healthchecks.NewFakeRecorderGetter(0)
+	tr := legacytranslator.NewTranslator(serviceInformer, backendConfigInformer, nodeInformer, podInformer, endpointSliceInformer, kubeClient, recorders, false, true, config.Region, logger)
+	namer := namer_util.NewNamer(config.ClusterUID, "", logger)
+	planRecorder := plan.NewRecorder()
+	healthChecker := healthchecks.NewHealthCheckerWithPlan(fakeGCE, config.HealthCheckPath, config.DefaultBackend.Service, recorders, tr, healthchecks.HealthcheckFlags{}, planRecorder, nil)
+	backendPool := backends.NewPoolWithPlan(fakeGCE, namer, planRecorder, nil)
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, types.UID(config.KubeSystemUID), logger)
+
+	return &Renderer{
+		config:         config,
+		cloud:          fakeGCE,
+		namer:          namer,
+		translator:     tr,
+		backendSyncer:  backends.NewBackendSyncer(backendPool, healthChecker, fakeGCE, tr, events.RecorderProducerMock{}),
+		l7Pool:         loadbalancers.NewLoadBalancerPool(fakeGCE, namer, events.RecorderProducerMock{}, namerFactory, planRecorder, nil, logger),
+		plan:           planRecorder,
+		manifests:      m,
+		feConfigs:      m.FrontendConfigs,
+		defaultBackend: config.DefaultBackend,
+		logger:         logger,
+	}, nil
+}
+
+// defaultBackendService returns the NodePort Service assumed for the system
+// default backend.
+func defaultBackendService(id utils.ServicePortID) *apiv1.Service {
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      id.Service.Name,
+			Namespace: id.Service.Namespace,
+		},
+		Spec: apiv1.ServiceSpec{
+			Type: apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{
+				{
+					Name:       id.Port.Name,
+					Port:       80,
+					NodePort:   30000,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+}
+
+// Render returns the GCE resources of the GCE Ingresses of the manifests,
+// sorted by namespace and name.
+func (r *Renderer) Render() []*Resources {
+	var ings []*networkingv1.Ingress
+	for _, ing := range r.manifests.Ingresses {
+		if utils.IsGCEIngress(ing) {
+			ings = append(ings, ing)
+		}
+	}
+	sort.Slice(ings, func(i, j int) bool {
+		return common.NamespacedName(ings[i]) < common.NamespacedName(ings[j])
+	})
+
+	var ret []*Resources
+	for _, ing := range ings {
+		ret = append(ret, r.RenderIngress(ing))
+	}
+	return ret
+}
+
+// RenderIngress returns the GCE resources of the Ingress. Translation errors
+// are reported in the returned Resources.
+func (r *Renderer) RenderIngress(ing *networkingv1.Ingress) *Resources {
+	res := &Resources{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name}
+	ingLogger := r.logger.WithValues("ingress", klog.KObj(ing))
+
+	// New Ingresses get the finalizer of the v2 naming scheme.
+	ing = ing.DeepCopy()
+	if !common.HasFinalizer(ing.ObjectMeta) {
+		ing.Finalizers = append(ing.Finalizers, common.FinalizerKeyV2)
+	}
+
+	urlMap, errs, _ := r.translator.TranslateIngress(ing, r.defaultBackend, r.namer)
+	for _, err := range errs {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	if len(errs) > 0 {
+		return res
+	}
+	if err := r.renderBackends(res, urlMap, ingLogger); err != nil {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	if err := r.renderFrontends(res, ing, urlMap); err != nil {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	return res
+}
+
+// renderBackends sets the backend services and health checks of the service
+// ports of the URL map.
+func (r *Renderer) renderBackends(res *Resources, urlMap *utils.GCEURLMap, ingLogger klog.Logger) error {
+	r.plan.Flush()
+	svcPorts := urlMap.AllServicePorts()
+	if err := r.backendSyncer.Sync(svcPorts, ingLogger); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, sp := range svcPorts {
+		key, err := composite.CreateKey(r.cloud, sp.BackendName(), features.ScopeFromServicePort(&sp))
+		if err != nil {
+			return err
+		}
+		obj, ok := r.plan.Planned("backendServices", key)
+		if !ok || obj == nil || seen[key.String()] {
+			continue
+		}
+		seen[key.String()] = true
+		be := obj.(*composite.BackendService)
+		res.BackendServices = append(res.BackendServices, be)
+
+		for _, link := range be.HealthChecks {
+			id, err := cloud.ParseResourceURL(link)
+			if err != nil {
+				return err
+			}
+			if hc, ok := r.plan.Planned("healthChecks", id.Key); ok && hc != nil && !seen[link] {
+				seen[link] = true
+				res.HealthChecks = append(res.HealthChecks, hc.(*compute.HealthCheck))
+			}
+		}
+	}
+	return nil
+}
+
+// renderFrontends sets the URL maps, target proxies, SSL certificates,
+// addresses and forwarding rules of the Ingress. They are the resources that
+// the load balancer pool of the controller plans to create for it, in plan
+// mode against the empty fake cloud.
+func (r *Renderer) renderFrontends(res *Resources, ing *networkingv1.Ingress, urlMap *utils.GCEURLMap) error {
+	feConfig, err := frontendconfig.FrontendConfigForIngress(r.feConfigs, ing)
+	if err != nil {
+		return err
+	}
+	if err := frontendconfig.Validate(feConfig, loadbalancers.LBSchemeForIngress(ing)); err != nil {
+		return err
+	}
+	ingAnnotations := annotations.FromIngress(ing)
+	staticIPName, err := ingAnnotations.StaticIPName()
+	if err != nil {
+		return err
+	}
+	if staticIPName != "" {
+		if err := r.reserveStaticIP(staticIPName, lbfeatures.ScopeFromIngress(ing)); err != nil {
+			return err
+		}
+	}
+	env, err := translator.NewEnv(ing, r.translator.KubeClient, "", "", "")
+	if err != nil {
+		return err
+	}
+	tlsCerts, errs := translator.ToTLSCerts(env)
+	if len(errs) > 0 {
+		return fmt.Errorf("error reading TLS certificates: %v", utils.JoinErrs(errs))
+	}
+	certificateMap := ingAnnotations.CertificateMap()
+	if certificateMap == "" && feConfig != nil && feConfig.Spec.CertificateMap != nil {
+		certificateMap = *feConfig.Spec.CertificateMap
+	}
+
+	r.plan.Flush()
+	l7, err := r.l7Pool.Ensure(&loadbalancers.L7RuntimeInfo{
+		TLS:            tlsCerts,
+		TLSName:        ingAnnotations.UseNamedTLS(),
+		CertificateMap: certificateMap,
+		Ingress:        ing,
+		AllowHTTP:      ingAnnotations.AllowHTTP(),
+		StaticIPName:   staticIPName,
+		UrlMap:         urlMap,
+		FrontendConfig: feConfig,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range r.plan.Resources() {
+		switch obj := obj.(type) {
+		case *composite.UrlMap:
+			if obj.Name == l7.UrlMap().Name {
+				res.UrlMap = obj
+			} else {
+				res.RedirectUrlMap = obj
+			}
+		case *composite.TargetHttpProxy:
+			res.TargetHttpProxy = obj
+		case *composite.TargetHttpsProxy:
+			res.TargetHttpsProxy = obj
+		case *composite.SslCertificate:
+			if obj.PrivateKey != "" {
+				obj.PrivateKey = redactedPrivateKey
+			}
+			res.SslCertificates = append(res.SslCertificates, obj)
+		case *composite.Address:
+			res.Addresses = append(res.Addresses, obj)
+		case *composite.ForwardingRule:
+			res.ForwardingRules = append(res.ForwardingRules, obj)
+		}
+	}
+	return nil
+}
+
+// reserveStaticIP reserves the static IP of the given name in the fake cloud,
+// as the controller expects it to exist. Its address is its self link, which
+// GCE accepts as the IP address of a forwarding rule.
+func (r *Renderer) reserveStaticIP(name string, scope meta.KeyType) error {
+	key, err := composite.CreateKey(r.cloud, name, scope)
+	if err != nil {
+		return err
+	}
+	if ip, _ := composite.GetAddress(r.cloud, key, meta.VersionGA, r.logger); ip != nil {
+		return nil
+	}
+	address := &composite.Address{
+		Name:    name,
+		Address: cloud.SelfLink(meta.VersionGA, r.config.Project, "addresses", key),
+		Version: meta.VersionGA,
+	}
+	return composite.CreateAddress(r.cloud, key, address, r.logger)
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+const testManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    cloud.google.com/neg: '{"ingress": true}'
+    cloud.google.com/backend-config: '{"default": "web"}'
+spec:
+  ports:
+  - port: 80
+    targetPort: 8080
+---
+apiVersion: cloud.google.com/v1
+kind: BackendConfig
+metadata:
+  name: web
+spec:
+  timeoutSec: 42
+  healthCheck:
+    requestPath: /healthz
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: web
+    annotations:
+      ingress.gcp.kubernetes.io/pre-shared-cert: my-cert
+  spec:
+    rules:
+    - host: foo.example.com
+      http:
+        paths:
+        - path: /
+          pathType: Prefix
+          backend:
+            service:
+              name: web
+              port:
+                number: 80
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: missing-service
+  spec:
+    defaultBackend:
+      service:
+        name: missing
+        port:
+          number: 80
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: other-class
+    annotations:
+      kubernetes.io/ingress.class: nginx
+  spec:
+    defaultBackend:
+      service:
+        name: web
+        port:
+          number: 80
+`
+
+const testTLSManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  ports:
+  - port: 80
+    nodePort: 30080
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-tls
+type: kubernetes.io/tls
+data:
+  tls.crt: Y2VydA==
+  tls.key: a2V5
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  tls:
+  - secretName: web-tls
+  defaultBackend:
+    service:
+      name: web
+      port:
+        number: 80
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: static-ip
+  annotations:
+    kubernetes.io/ingress.global-static-ip-name: my-ip
+spec:
+  defaultBackend:
+    service:
+      name: web
+      port:
+        number: 80
+`
+
+func newTestRenderer(t *testing.T) *Renderer {
+	t.Helper()
+	return newTestRendererFor(t, testManifests)
+}
+
+func newTestRendererFor(t *testing.T, manifests string) *Renderer {
+	t.Helper()
+	m := &Manifests{}
+	if err := m.Read(strings.NewReader(manifests), "test"); err != nil {
+		t.Fatalf("m.Read() = %v, want nil", err)
+	}
+	r, err := NewRenderer(m, Config{
+		Project:    "test-project",
+		Region:     "us-central1",
+		ClusterUID: "uid1",
+		DefaultBackend: utils.ServicePortID{
+			Service: types.NamespacedName{Namespace: "kube-system", Name: "default-http-backend"},
+			Port:    networkingv1.ServiceBackendPort{Name: "http"},
+		},
+		HealthCheckPath: "/",
+	}, klog.TODO())
+	if err != nil {
+		t.Fatalf("NewRenderer() = %v, want nil", err)
+	}
+	return r
+}
+
+func TestRead(t *testing.T) {
+	r := newTestRenderer(t)
+	m := r.manifests
+	if len(m.Ingresses) != 3 || len(m.Services) != 1 || len(m.BackendConfigs) != 1 {
+		t.Fatalf("Got %d Ingresses, %d Services and %d BackendConfigs, want 3, 1 and 1", len(m.Ingresses), len(m.Services), len(m.BackendConfigs))
+	}
+	for _, ing := range m.Ingresses {
+		if ing.Namespace != "test" {
+			t.Errorf("Ingress %s has namespace %q, want the default namespace %q", ing.Name, ing.Namespace, "test")
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	r := newTestRenderer(t)
+	resources := r.Render()
+	if len(resources) != 2 {
+		t.Fatalf("Render() returned %d Ingresses, want 2 GCE Ingresses", len(resources))
+	}
+
+	missing := resources[0]
+	if missing.Name != "missing-service" || len(missing.Errors) == 0 || !strings.Contains(missing.Errors[0], "missing") {
+		t.Errorf("Got %s with errors %v, want an error for the missing Service", missing.Name, missing.Errors)
+	}
+
+	web := resources[1]
+	if len(web.Errors) != 0 {
+		t.Fatalf("Got errors %v for Ingress web, want none", web.Errors)
+	}
+	if web.UrlMap == nil || len(web.UrlMap.HostRules) != 1 || web.UrlMap.HostRules[0].Hosts[0] != "foo.example.com" {
+		t.Errorf("Got URL map %+v, want a host rule for foo.example.com", web.UrlMap)
+	}
+	if web.TargetHttpProxy == nil || !strings.HasSuffix(web.TargetHttpProxy.UrlMap, "/urlMaps/"+web.UrlMap.Name) {
+		t.Errorf("Got target HTTP proxy %+v, want one for URL map %s", web.TargetHttpProxy, web.UrlMap.Name)
+	}
+	if web.TargetHttpsProxy == nil || len(web.TargetHttpsProxy.SslCertificates) != 1 || !strings.HasSuffix(web.TargetHttpsProxy.SslCertificates[0], "/sslCertificates/my-cert") {
+		t.Errorf("Got target HTTPS proxy %+v, want one with the pre-shared certificate my-cert", web.TargetHttpsProxy)
+	}
+	if len(web.ForwardingRules) != 2 {
+		t.Errorf("Got %d forwarding rules, want 2", len(web.ForwardingRules))
+	}
+
+	if len(web.BackendServices) != 2 || len(web.HealthChecks) != 2 {
+		t.Fatalf("Got %d backend services and %d health checks, want 2 and 2", len(web.BackendServices), len(web.HealthChecks))
+	}
+	var found bool
+	for _, be := range web.BackendServices {
+		if be.TimeoutSec == 42 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Got backend services %+v, want one with the timeout of the BackendConfig", web.BackendServices)
+	}
+	found = false
+	for _, hc := range web.HealthChecks {
+		if hc.HttpHealthCheck != nil && hc.HttpHealthCheck.RequestPath == "/healthz" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Got health checks %+v, want one with the request path of the BackendConfig", web.HealthChecks)
+	}
+}
+
+func TestRenderTLS(t *testing.T) {
+	r := newTestRendererFor(t, testTLSManifests)
+	resources := r.Render()
+	if len(resources) != 2 {
+		t.Fatalf("Render() returned %d Ingresses, want 2", len(resources))
+	}
+
+	staticIP := resources[0]
+	if len(staticIP.Errors) != 0 {
+		t.Fatalf("Got errors %v for Ingress static-ip, want none", staticIP.Errors)
+	}
+	if len(staticIP.ForwardingRules) != 1 || !strings.HasSuffix(staticIP.ForwardingRules[0].IPAddress, "/global/addresses/my-ip") {
+		t.Errorf("Got forwarding rules %+v, want one with the static IP my-ip", staticIP.ForwardingRules)
+	}
+	if len(staticIP.Addresses) != 0 {
+		t.Errorf("Got addresses %+v, want none for a static IP of the user", staticIP.Addresses)
+	}
+
+	web := resources[1]
+	if len(web.Errors) != 0 {
+		t.Fatalf("Got errors %v for Ingress web, want none", web.Errors)
+	}
+	if len(web.SslCertificates) != 1 || web.SslCertificates[0].Certificate != "cert" || web.SslCertificates[0].PrivateKey != redactedPrivateKey {
+		t.Errorf("Got SSL certificates %+v, want the certificate of the Secret with a redacted private key", web.SslCertificates)
+	}
+	if web.TargetHttpsProxy == nil || len(web.TargetHttpsProxy.SslCertificates) != 1 || !strings.HasSuffix(web.TargetHttpsProxy.SslCertificates[0], "/sslCertificates/"+web.SslCertificates[0].Name) {
+		t.Errorf("Got target HTTPS proxy %+v, want one with the certificate of the Secret", web.TargetHttpsProxy)
+	}
+	// The ephemeral IP of the HTTP forwarding rule is promoted to a static IP
+	// shared with the HTTPS forwarding rule.
+	if len(web.Addresses) != 1 || len(web.ForwardingRules) != 2 {
+		t.Errorf("Got %d addresses and %d forwarding rules, want 1 and 2", len(web.Addresses), len(web.ForwardingRules))
+	}
+}
@@ -109,6 +109,20 @@ echo "Generating openapi for ServiceNetworkEndpointGroup v1beta1"
   --output-package k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1 \
   --go-header-file "${SCRIPT_ROOT}"/boilerplate.go.txt
 
+echo "Performing code generation for IngressSyncStatus CRD"
+"${CODEGEN_PKG}"/generate-groups.sh \
+  "deepcopy,client,informer,lister" \
+  k8s.io/ingress-gce/pkg/ingsyncstatus/client k8s.io/ingress-gce/pkg/apis \
+  "ingsyncstatus:v1beta1" \
+  --go-header-file "${SCRIPT_ROOT}"/boilerplate.go.txt
+
+echo "Generating openapi for IngressSyncStatus v1beta1"
+"${OPENAPI_PKG}"/openapi-gen \
+  --output-file-base zz_generated.openapi \
+  --input-dirs k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1 \
+  --output-package k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1 \
+  --go-header-file "${SCRIPT_ROOT}"/boilerplate.go.txt
+
 echo "Performing code generation for ProviderConfig CRD"
 "${CODEGEN_PKG}"/generate-groups.sh \
   "deepcopy,client,informer,lister" \
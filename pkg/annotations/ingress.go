@@ -17,8 +17,12 @@ limitations under the License.
 package annotations
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/networking/v1"
 )
@@ -56,6 +60,17 @@ const (
 	// to the target proxies of the Ingress.
 	PreSharedCertKey = "ingress.gcp.kubernetes.io/pre-shared-cert"
 
+	// CertificateMapKey represents the Certificate Manager certificate map
+	// for the Ingress controller to use. The value is the name of a global
+	// certificate map in the project of the cluster, or its full resource URL.
+	// The controller *does not* manage this certificate map. When set, the
+	// certificate map is attached to the target https proxy of the Ingress
+	// instead of SSL certificates. Only supported by global external Ingresses.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/certificate-map: 'my-certificate-map'
+	CertificateMapKey = "networking.gke.io/certificate-map"
+
 	// IngressClassKey picks a specific "class" for the Ingress. The controller
 	// only processes Ingresses with this annotation either unset, or set
 	// to either gceIngressClass or the empty string.
@@ -87,6 +102,66 @@ const (
 	//     networking.gke.io/v1beta1.FrontendConfig: 'my-frontendconfig'
 	FrontendConfigKey = "networking.gke.io/v1beta1.FrontendConfig"
 
+	// WeightedBackendsKey is the annotation key used to split the traffic of
+	// the Ingress paths that point to a Service between several Services.
+	// The value is a stringified JSON map from the name of a Service used as
+	// a path backend to the list of weighted backends that serve the traffic
+	// of those paths. Weights must be in the range [0, 1000]. The referenced
+	// Service only receives traffic if it is listed itself.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/weighted-backends: '{"web":[{"service":{"name":"web","port":{"number":80}},"weight":90},{"service":{"name":"web-canary","port":{"number":80}},"weight":10}]}'
+	WeightedBackendsKey = "networking.gke.io/weighted-backends"
+
+	// RouteMatchesKey is the annotation key used to route the requests of the
+	// Ingress paths that point to a Service to another Service based on the
+	// request headers and query parameters.
+	// The value is a stringified JSON map from the name of a Service used as
+	// a path backend to the list of route matches evaluated, in order, before
+	// the requests are sent to the path backend. All conditions of a route
+	// match must be met. A condition without a value only requires the header
+	// or query parameter to be present.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/route-matches: '{"web":[{"headers":[{"name":"x-canary","value":"true"}],"service":{"name":"web-canary","port":{"number":80}}},{"queryParams":[{"name":"beta","value":"1"}],"service":{"name":"web-canary","port":{"number":80}}}]}'
+	RouteMatchesKey = "networking.gke.io/route-matches"
+
+	// DriftPolicyKey is the annotation key used to choose what the
+	// controller does when the GCE resources of the Ingress were changed
+	// outside of the controller. The value is "Reconcile" (the default),
+	// which reverts the changes, or "Alert", which only reports them in
+	// events and metrics. The backend services and health checks of a
+	// Service are only left changed if all the Ingresses that reference the
+	// Service use "Alert".
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/drift-policy: 'Alert'
+	DriftPolicyKey = "networking.gke.io/drift-policy"
+
+	// IngressGroupKey is the annotation key used to merge several Ingresses
+	// into one load balancer. Ingresses of the same class and scope with the
+	// same group share the URL map, target proxies and forwarding rules of the
+	// load balancer. The oldest Ingress of the group owns it: Ingresses of
+	// other namespaces can only join if the owner allows their namespace with
+	// IngressGroupNamespacesKey, and the frontend settings of the load
+	// balancer are those of the owner. A host is owned by the namespace of the
+	// oldest Ingress that uses it, and a host and path by the oldest Ingress
+	// that uses them. Conflicting rules of the other Ingresses are ignored.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/ingress-group: 'shop'
+	IngressGroupKey = "networking.gke.io/ingress-group"
+
+	// IngressGroupNamespacesKey is the annotation key used on the oldest
+	// Ingress of a group, which owns the group, to allow Ingresses of other
+	// namespaces to join the group. The value is a comma separated list of
+	// namespaces. Only the Ingresses of the namespace of the owner can join
+	// the group otherwise.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/ingress-group-namespaces: 'api,payments'
+	IngressGroupNamespacesKey = "networking.gke.io/ingress-group-namespaces"
+
 	// UrlMapKey is the annotation key used by controller to record GCP URL map.
 	UrlMapKey = StatusPrefix + "/url-map"
 	// UrlMapKey is the annotation key used by controller to record GCP URL map used for Https Redirects only.
@@ -107,8 +182,73 @@ const (
 	SSLCertKey = StatusPrefix + "/ssl-cert"
 	// StaticIPKey is the annotation key used by controller to record GCP static ip.
 	StaticIPKey = StatusPrefix + "/static-ip"
+	// ServerTlsPolicyKey is the annotation key used by controller to record
+	// the server TLS policy of the GCP target https proxy.
+	ServerTlsPolicyKey = StatusPrefix + "/server-tls-policy"
+	// PlanKey is the annotation key used by controller to record the GCP
+	// operations that the sync would make, when it runs in plan mode.
+	PlanKey = StatusPrefix + "/plan"
+	// IngressGroupStatusKey is the annotation key used by controller to record
+	// the group of the load balancer that serves the Ingress.
+	IngressGroupStatusKey = StatusPrefix + "/ingress-group"
+	// BackendHealthKey is the annotation key used by controller to record the
+	// number of healthy and unhealthy endpoints of each NEG backend, the
+	// pods of the unhealthy endpoints and when the health was last polled.
+	BackendHealthKey = StatusPrefix + "/backend-health"
 )
 
+// MaxBackendWeight is the largest weight GCE accepts for a weighted backend.
+const MaxBackendWeight = 1000
+
+// DriftPolicy is what the controller does with the changes made to the GCE
+// resources of an Ingress outside of the controller.
+type DriftPolicy string
+
+const (
+	// DriftPolicyReconcile reverts the changes.
+	DriftPolicyReconcile DriftPolicy = "Reconcile"
+	// DriftPolicyAlert reports the changes without reverting them.
+	DriftPolicyAlert DriftPolicy = "Alert"
+)
+
+var (
+	ErrWeightedBackendsInvalidJSON = errors.New("weighted backends annotation is invalid json")
+	ErrRouteMatchesInvalidJSON     = errors.New("route matches annotation is invalid json")
+
+	// httpTokenRegexp matches the header names allowed by RFC 7230.
+	httpTokenRegexp = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+)
+
+// WeightedBackend is a Service backend that receives a share of the traffic
+// of the Ingress paths it is configured for.
+type WeightedBackend struct {
+	// Service is the Service backend, in the namespace of the Ingress.
+	Service v1.IngressServiceBackend `json:"service"`
+	// Weight is the share of the traffic sent to the Service, computed as
+	// weight / (sum of the weights of the list).
+	Weight int64 `json:"weight"`
+}
+
+// RouteMatch sends the requests that meet all of its conditions to a
+// Service backend.
+type RouteMatch struct {
+	// Headers are the conditions on the request headers.
+	Headers []MatchCondition `json:"headers,omitempty"`
+	// QueryParams are the conditions on the request query parameters.
+	QueryParams []MatchCondition `json:"queryParams,omitempty"`
+	// Service is the Service backend, in the namespace of the Ingress.
+	Service v1.IngressServiceBackend `json:"service"`
+}
+
+// MatchCondition is a condition on a request header or query parameter.
+type MatchCondition struct {
+	// Name is the name of the header or query parameter.
+	Name string `json:"name"`
+	// Value is the exact value to match. If empty, the header or query
+	// parameter only needs to be present.
+	Value string `json:"value,omitempty"`
+}
+
 // Ingress represents ingress annotations.
 type Ingress struct {
 	v map[string]string
@@ -146,6 +286,17 @@ func (ing *Ingress) UseNamedTLS() string {
 	return val
 }
 
+// CertificateMap returns the name or URL of the Certificate Manager
+// certificate map. Empty by default.
+func (ing *Ingress) CertificateMap() string {
+	val, ok := ing.v[CertificateMapKey]
+	if !ok {
+		return ""
+	}
+
+	return val
+}
+
 func (ing *Ingress) StaticIPName() (string, error) {
 	globalIp := ing.GlobalStaticIPName()
 	regionalIp := ing.RegionalStaticIPName()
@@ -199,6 +350,33 @@ func (ing *Ingress) SuppressFirewallXPNError() bool {
 	return v
 }
 
+// DriftPolicy returns the drift policy of the Ingress. DriftPolicyReconcile
+// by default and for unknown values.
+func (ing *Ingress) DriftPolicy() DriftPolicy {
+	if DriftPolicy(ing.v[DriftPolicyKey]) == DriftPolicyAlert {
+		return DriftPolicyAlert
+	}
+	return DriftPolicyReconcile
+}
+
+// IngressGroup returns the group of the load balancer shared by the Ingress,
+// or the empty string if the Ingress has its own load balancer.
+func (ing *Ingress) IngressGroup() string {
+	return ing.v[IngressGroupKey]
+}
+
+// IngressGroupNamespaces returns the namespaces of other Ingresses allowed to
+// join the group owned by the Ingress.
+func (ing *Ingress) IngressGroupNamespaces() []string {
+	var namespaces []string
+	for _, ns := range strings.Split(ing.v[IngressGroupNamespacesKey], ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 func (ing *Ingress) FrontendConfig() string {
 	val, ok := ing.v[FrontendConfigKey]
 	if !ok {
@@ -206,3 +384,96 @@ func (ing *Ingress) FrontendConfig() string {
 	}
 	return val
 }
+
+// WeightedBackends returns the weighted backends keyed by the name of the
+// Service whose paths they serve. An empty map is returned if the annotation
+// is not set.
+func (ing *Ingress) WeightedBackends() (map[string][]WeightedBackend, error) {
+	val, ok := ing.v[WeightedBackendsKey]
+	if !ok {
+		return map[string][]WeightedBackend{}, nil
+	}
+
+	var ret map[string][]WeightedBackend
+	if err := json.Unmarshal([]byte(val), &ret); err != nil {
+		return nil, ErrWeightedBackendsInvalidJSON
+	}
+	for svcName, backends := range ret {
+		if err := validateWeightedBackends(backends); err != nil {
+			return nil, fmt.Errorf("invalid weighted backends for service %q: %w", svcName, err)
+		}
+	}
+	return ret, nil
+}
+
+func validateWeightedBackends(backends []WeightedBackend) error {
+	if len(backends) == 0 {
+		return errors.New("no backends specified")
+	}
+	var total int64
+	for _, b := range backends {
+		if b.Service.Name == "" {
+			return errors.New("backend service name is empty")
+		}
+		if (b.Service.Port.Name == "") == (b.Service.Port.Number == 0) {
+			return fmt.Errorf("exactly one of port name or number must be specified for backend service %q", b.Service.Name)
+		}
+		if b.Weight < 0 || b.Weight > MaxBackendWeight {
+			return fmt.Errorf("weight %d of backend service %q is not in the range [0, %d]", b.Weight, b.Service.Name, MaxBackendWeight)
+		}
+		total += b.Weight
+	}
+	if total == 0 {
+		return errors.New("at least one backend must have a non-zero weight")
+	}
+	return nil
+}
+
+// RouteMatches returns the route matches keyed by the name of the Service
+// whose paths they apply to. An empty map is returned if the annotation is
+// not set.
+func (ing *Ingress) RouteMatches() (map[string][]RouteMatch, error) {
+	val, ok := ing.v[RouteMatchesKey]
+	if !ok {
+		return map[string][]RouteMatch{}, nil
+	}
+
+	var ret map[string][]RouteMatch
+	if err := json.Unmarshal([]byte(val), &ret); err != nil {
+		return nil, ErrRouteMatchesInvalidJSON
+	}
+	for svcName, matches := range ret {
+		if err := validateRouteMatches(matches); err != nil {
+			return nil, fmt.Errorf("invalid route matches for service %q: %w", svcName, err)
+		}
+	}
+	return ret, nil
+}
+
+func validateRouteMatches(matches []RouteMatch) error {
+	if len(matches) == 0 {
+		return errors.New("no route matches specified")
+	}
+	for _, m := range matches {
+		if m.Service.Name == "" {
+			return errors.New("backend service name is empty")
+		}
+		if (m.Service.Port.Name == "") == (m.Service.Port.Number == 0) {
+			return fmt.Errorf("exactly one of port name or number must be specified for backend service %q", m.Service.Name)
+		}
+		if len(m.Headers) == 0 && len(m.QueryParams) == 0 {
+			return fmt.Errorf("no header or query parameter conditions specified for backend service %q", m.Service.Name)
+		}
+		for _, h := range m.Headers {
+			if !httpTokenRegexp.MatchString(h.Name) {
+				return fmt.Errorf("invalid header name %q", h.Name)
+			}
+		}
+		for _, q := range m.QueryParams {
+			if q.Name == "" {
+				return errors.New("query parameter name is empty")
+			}
+		}
+	}
+	return nil
+}
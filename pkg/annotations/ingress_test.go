@@ -17,26 +17,31 @@ limitations under the License.
 package annotations
 
 import (
+	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestIngress(t *testing.T) {
 	for _, tc := range []struct {
-		desc         string
-		ing          *v1.Ingress
-		allowHTTP    bool
-		useNamedTLS  string
-		staticIPName string
-		ingressClass string
-		wantErr      bool
+		desc           string
+		ing            *v1.Ingress
+		allowHTTP      bool
+		useNamedTLS    string
+		certificateMap string
+		staticIPName   string
+		ingressClass   string
+		driftPolicy    DriftPolicy
+		wantErr        bool
 	}{
 		{
-			desc:      "Empty ingress",
-			ing:       &v1.Ingress{},
-			allowHTTP: true, // defaults to true.
+			desc:        "Empty ingress",
+			ing:         &v1.Ingress{},
+			allowHTTP:   true, // defaults to true.
+			driftPolicy: DriftPolicyReconcile,
 		},
 		{
 			desc: "Global and Regional StaticIP Specified",
@@ -52,6 +57,7 @@ func TestIngress(t *testing.T) {
 			ingressClass: GceL7ILBIngressClass,
 			staticIPName: "",
 			allowHTTP:    true,
+			driftPolicy:  DriftPolicyReconcile,
 			wantErr:      true,
 		},
 		{
@@ -62,14 +68,30 @@ func TestIngress(t *testing.T) {
 						AllowHTTPKey:          "false",
 						IngressClassKey:       "gce",
 						PreSharedCertKey:      "shared-cert-key",
+						CertificateMapKey:     "cert-map",
 						GlobalStaticIPNameKey: "1.2.3.4",
+						DriftPolicyKey:        "Alert",
 					},
 				},
 			},
-			allowHTTP:    false,
-			useNamedTLS:  "shared-cert-key",
-			staticIPName: "1.2.3.4",
-			ingressClass: "gce",
+			allowHTTP:      false,
+			useNamedTLS:    "shared-cert-key",
+			certificateMap: "cert-map",
+			staticIPName:   "1.2.3.4",
+			ingressClass:   "gce",
+			driftPolicy:    DriftPolicyAlert,
+		},
+		{
+			desc: "Unknown drift policy",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						DriftPolicyKey: "Ignore",
+					},
+				},
+			},
+			allowHTTP:   true,
+			driftPolicy: DriftPolicyReconcile,
 		},
 	} {
 		ing := FromIngress(tc.ing)
@@ -80,6 +102,9 @@ func TestIngress(t *testing.T) {
 		if x := ing.UseNamedTLS(); x != tc.useNamedTLS {
 			t.Errorf("ingress %+v; UseNamedTLS() = %v, want %v", tc.ing, x, tc.useNamedTLS)
 		}
+		if x := ing.CertificateMap(); x != tc.certificateMap {
+			t.Errorf("ingress %+v; CertificateMap() = %v, want %v", tc.ing, x, tc.certificateMap)
+		}
 		staticIp, err := ing.StaticIPName()
 		if (err != nil) != tc.wantErr {
 			t.Errorf("ingress: %+v, err = %v, wantErr = %v", tc.ing, err, tc.wantErr)
@@ -90,5 +115,223 @@ func TestIngress(t *testing.T) {
 		if x := ing.IngressClass(); x != tc.ingressClass {
 			t.Errorf("ingress %+v; IngressClass() = %v, want %v", tc.ing, x, tc.ingressClass)
 		}
+		if x := ing.DriftPolicy(); x != tc.driftPolicy {
+			t.Errorf("ingress %+v; DriftPolicy() = %v, want %v", tc.ing, x, tc.driftPolicy)
+		}
+	}
+}
+
+func TestWeightedBackends(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		ing     *v1.Ingress
+		want    map[string][]WeightedBackend
+		wantErr bool
+	}{
+		{
+			desc: "No annotation",
+			ing:  &v1.Ingress{},
+			want: map[string][]WeightedBackend{},
+		},
+		{
+			desc: "Valid annotation",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						WeightedBackendsKey: `{"web":[{"service":{"name":"web","port":{"number":80}},"weight":90},{"service":{"name":"web-canary","port":{"name":"http"}},"weight":10}]}`,
+					},
+				},
+			},
+			want: map[string][]WeightedBackend{
+				"web": {
+					{Service: v1.IngressServiceBackend{Name: "web", Port: v1.ServiceBackendPort{Number: 80}}, Weight: 90},
+					{Service: v1.IngressServiceBackend{Name: "web-canary", Port: v1.ServiceBackendPort{Name: "http"}}, Weight: 10},
+				},
+			},
+		},
+		{
+			desc: "Invalid JSON",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{WeightedBackendsKey: `{"web":`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Empty backend list",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{WeightedBackendsKey: `{"web":[]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Missing port",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{WeightedBackendsKey: `{"web":[{"service":{"name":"web"},"weight":10}]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Weight out of range",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{WeightedBackendsKey: `{"web":[{"service":{"name":"web","port":{"number":80}},"weight":1001}]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "All weights zero",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{WeightedBackendsKey: `{"web":[{"service":{"name":"web","port":{"number":80}},"weight":0}]}`},
+				},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FromIngress(tc.ing).WeightedBackends()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("WeightedBackends() = _, %v, wantErr = %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("WeightedBackends() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRouteMatches(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		ing     *v1.Ingress
+		want    map[string][]RouteMatch
+		wantErr bool
+	}{
+		{
+			desc: "No annotation",
+			ing:  &v1.Ingress{},
+			want: map[string][]RouteMatch{},
+		},
+		{
+			desc: "Valid annotation",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						RouteMatchesKey: `{"web":[{"headers":[{"name":"x-canary","value":"true"}],"service":{"name":"web-canary","port":{"number":80}}},{"queryParams":[{"name":"beta"}],"service":{"name":"web-beta","port":{"name":"http"}}}]}`,
+					},
+				},
+			},
+			want: map[string][]RouteMatch{
+				"web": {
+					{
+						Headers: []MatchCondition{{Name: "x-canary", Value: "true"}},
+						Service: v1.IngressServiceBackend{Name: "web-canary", Port: v1.ServiceBackendPort{Number: 80}},
+					},
+					{
+						QueryParams: []MatchCondition{{Name: "beta"}},
+						Service:     v1.IngressServiceBackend{Name: "web-beta", Port: v1.ServiceBackendPort{Name: "http"}},
+					},
+				},
+			},
+		},
+		{
+			desc: "Invalid JSON",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Empty route match list",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":[]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "No conditions",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":[{"service":{"name":"web-canary","port":{"number":80}}}]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Invalid header name",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":[{"headers":[{"name":"x canary"}],"service":{"name":"web-canary","port":{"number":80}}}]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Empty query parameter name",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":[{"queryParams":[{"value":"1"}],"service":{"name":"web-canary","port":{"number":80}}}]}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Missing port",
+			ing: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RouteMatchesKey: `{"web":[{"headers":[{"name":"x-canary"}],"service":{"name":"web-canary"}}]}`},
+				},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FromIngress(tc.ing).RouteMatches()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RouteMatches() = _, %v, wantErr = %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("RouteMatches() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIngressGroupNamespaces(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		val  *string
+		want []string
+	}{
+		{desc: "No annotation"},
+		{desc: "Empty annotation", val: ptr.To("")},
+		{desc: "Namespaces", val: ptr.To("api, payments,,"), want: []string{"api", "payments"}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ing := &v1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tc.val != nil {
+				ing.Annotations[IngressGroupNamespacesKey] = *tc.val
+			}
+			if got := FromIngress(ing).IngressGroupNamespaces(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("IngressGroupNamespaces() = %v, want %v", got, tc.want)
+			}
+		})
 	}
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -47,12 +48,97 @@ type BackendConfigSpec struct {
 	HealthCheck           *HealthCheckConfig           `json:"healthCheck,omitempty"`
 	// Logging specifies the configuration for access logs.
 	Logging *LogConfig `json:"logging,omitempty"`
+	// RetryPolicy specifies when and how often failed requests to this
+	// backend are retried by the load balancer.
+	RetryPolicy *RetryPolicyConfig `json:"retryPolicy,omitempty"`
+	// FaultInjection specifies delays and aborts that the load balancer
+	// injects into a percentage of the requests sent to this backend.
+	FaultInjection *FaultInjectionConfig `json:"faultInjection,omitempty"`
+	// RequestMirror specifies a shadow Service that receives a copy of the
+	// requests sent to this backend.
+	RequestMirror *RequestMirrorConfig `json:"requestMirror,omitempty"`
+	// OutlierDetection specifies how unhealthy endpoints are ejected from
+	// the load balancing pool of this backend.
+	OutlierDetection *OutlierDetectionConfig `json:"outlierDetection,omitempty"`
+	// CircuitBreakers specifies limits on the connections and requests the
+	// load balancer sends to this backend.
+	CircuitBreakers *CircuitBreakersConfig `json:"circuitBreakers,omitempty"`
+	// LocalityLbPolicy specifies the load balancing algorithm used within
+	// the scope of a locality. Valid values are ROUND_ROBIN, LEAST_REQUEST,
+	// RING_HASH and MAGLEV.
+	LocalityLbPolicy *string `json:"localityLbPolicy,omitempty"`
+	// ConsistentHash specifies how requests are hashed when LocalityLbPolicy
+	// is RING_HASH or MAGLEV. It cannot be combined with SessionAffinity.
+	ConsistentHash *ConsistentHashConfig `json:"consistentHash,omitempty"`
+	// Capacity specifies the balancing mode and the capacity of the NEG
+	// backends of this backend. It takes precedence over the
+	// networking.gke.io/max-rate-per-endpoint and
+	// networking.gke.io/capacity-scaler annotations of the Service.
+	Capacity *CapacityConfig `json:"capacity,omitempty"`
+	// TargetRef attaches the BackendConfig to a Service of its namespace,
+	// for the ports of the Service that do not reference a BackendConfig
+	// with the cloud.google.com/backend-config annotation, if the
+	// controller runs with --enable-backendconfig-target-ref; or to a
+	// backend bucket referenced by the Ingresses of its namespace.
+	TargetRef *PolicyTargetReference `json:"targetRef,omitempty"`
 }
 
 // BackendConfigStatus is the status for a BackendConfig resource
+// +k8s:openapi-gen=true
 type BackendConfigStatus struct {
+	// Conditions report whether the BackendConfig was accepted and applied
+	// to the backend services by the last sync of an Ingress using it.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Ingresses are the names of the Ingresses with a backend using the
+	// BackendConfig.
+	// +optional
+	Ingresses []string `json:"ingresses,omitempty"`
+}
+
+// Condition contains details for the current condition of a BackendConfig.
+// +k8s:openapi-gen=true
+type Condition struct {
+	// Type is the type of the condition.
+	// +required
+	Type string `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	// +required
+	Status corev1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation of the BackendConfig the
+	// condition was set for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +required
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// The reason for the condition's last transition.
+	// +required
+	Reason string `json:"reason"`
+	// A human readable message indicating details about the transition.
+	// This field may be empty.
+	// +required
+	Message string `json:"message"`
 }
 
+// These are valid conditions of a BackendConfig.
+const (
+	// AcceptedCondition means the BackendConfig is valid for the service
+	// ports using it.
+	AcceptedCondition = "Accepted"
+	// ProgrammedCondition means the BackendConfig was applied to the
+	// backend services of the service ports using it.
+	ProgrammedCondition = "Programmed"
+)
+
+// These are the reasons of the BackendConfig conditions.
+const (
+	AcceptedReason   = "Accepted"
+	InvalidReason    = "Invalid"
+	ProgrammedReason = "Programmed"
+	SyncFailedReason = "SyncFailed"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // BackendConfigList is a list of BackendConfig resources
@@ -245,3 +331,201 @@ type LogConfig struct {
 	// requests are reported. The default value is 1.0.
 	SampleRate *float64 `json:"sampleRate,omitempty"`
 }
+
+// RetryPolicyConfig contains configuration for retrying failed requests.
+// Retry policies are only supported by the internal and regional external
+// HTTP(S) load balancers.
+// +k8s:openapi-gen=true
+type RetryPolicyConfig struct {
+	// RetryConditions specifies one or more conditions when this retry policy
+	// applies. Valid values are 5xx, gateway-error, connect-failure,
+	// retriable-4xx, refused-stream, cancelled, deadline-exceeded, internal,
+	// resource-exhausted and unavailable.
+	RetryConditions []string `json:"retryConditions,omitempty"`
+	// NumRetries specifies the allowed number of retries. This number must
+	// be > 0. If not specified, defaults to 1.
+	NumRetries *int64 `json:"numRetries,omitempty"`
+	// PerTryTimeoutSec specifies a non-zero timeout per retry attempt. If
+	// not specified, the timeout of the backend service is used.
+	PerTryTimeoutSec *int64 `json:"perTryTimeoutSec,omitempty"`
+}
+
+// FaultInjectionConfig contains configuration for fault injection.
+// Fault injection is only supported by the internal and regional external
+// HTTP(S) load balancers.
+// +k8s:openapi-gen=true
+type FaultInjectionConfig struct {
+	// Delay specifies how requests are delayed before being sent to the
+	// backend.
+	Delay *FaultDelayConfig `json:"delay,omitempty"`
+	// Abort specifies how requests are aborted before being sent to the
+	// backend.
+	Abort *FaultAbortConfig `json:"abort,omitempty"`
+}
+
+// FaultDelayConfig contains configuration for injected delays.
+// +k8s:openapi-gen=true
+type FaultDelayConfig struct {
+	// FixedDelaySec specifies the value of the fixed delay interval.
+	FixedDelaySec int64 `json:"fixedDelaySec"`
+	// Percentage of requests on which the delay will be introduced. The
+	// value must be in [0, 100].
+	Percentage float64 `json:"percentage"`
+}
+
+// FaultAbortConfig contains configuration for injected aborts.
+// +k8s:openapi-gen=true
+type FaultAbortConfig struct {
+	// HttpStatus specifies the HTTP status code used to abort the request.
+	// The value must be in [200, 599].
+	HttpStatus int64 `json:"httpStatus"`
+	// Percentage of requests which will be aborted. The value must be in
+	// [0, 100].
+	Percentage float64 `json:"percentage"`
+}
+
+// RequestMirrorConfig contains configuration for request mirroring. The
+// load balancer does not wait for responses from the mirror Service.
+// The mirror Service gets NEGs like the Services of the Ingress.
+// +k8s:openapi-gen=true
+type RequestMirrorConfig struct {
+	// ServiceName is the name of the Service, in the namespace of the
+	// BackendConfig, that receives the mirrored requests.
+	ServiceName string `json:"serviceName"`
+	// ServicePort is the port number of the Service that receives the
+	// mirrored requests. Exactly one of ServicePort and ServicePortName
+	// must be set.
+	ServicePort int32 `json:"servicePort,omitempty"`
+	// ServicePortName is the port name of the Service that receives the
+	// mirrored requests.
+	ServicePortName string `json:"servicePortName,omitempty"`
+}
+
+// OutlierDetectionConfig contains configuration for outlier detection.
+// Outlier detection is only supported by the internal and regional external
+// HTTP(S) load balancers. Fields that are not specified keep their GCE
+// defaults.
+// +k8s:openapi-gen=true
+type OutlierDetectionConfig struct {
+	// ConsecutiveErrors is the number of consecutive 5xx errors after which
+	// an endpoint is ejected. Defaults to 5.
+	ConsecutiveErrors *int64 `json:"consecutiveErrors,omitempty"`
+	// ConsecutiveGatewayFailure is the number of consecutive 502, 503 and
+	// 504 errors after which an endpoint is ejected. Defaults to 3.
+	ConsecutiveGatewayFailure *int64 `json:"consecutiveGatewayFailure,omitempty"`
+	// EnforcingConsecutiveErrors is the percentage chance that an endpoint
+	// is actually ejected when ConsecutiveErrors is reached. The value must
+	// be in [0, 100]. Defaults to 0.
+	EnforcingConsecutiveErrors *int64 `json:"enforcingConsecutiveErrors,omitempty"`
+	// EnforcingConsecutiveGatewayFailure is the percentage chance that an
+	// endpoint is actually ejected when ConsecutiveGatewayFailure is
+	// reached. The value must be in [0, 100]. Defaults to 100.
+	EnforcingConsecutiveGatewayFailure *int64 `json:"enforcingConsecutiveGatewayFailure,omitempty"`
+	// IntervalSec is the time interval between ejection analysis sweeps.
+	// Defaults to 10 seconds.
+	IntervalSec *int64 `json:"intervalSec,omitempty"`
+	// BaseEjectionTimeSec is the base time that an endpoint is ejected for.
+	// The real ejection time is equal to the base ejection time multiplied
+	// by the number of times the endpoint has been ejected. Defaults to 30
+	// seconds.
+	BaseEjectionTimeSec *int64 `json:"baseEjectionTimeSec,omitempty"`
+	// MaxEjectionPercent is the maximum percentage of endpoints that can be
+	// ejected. The value must be in [0, 100]. Defaults to 50.
+	MaxEjectionPercent *int64 `json:"maxEjectionPercent,omitempty"`
+}
+
+// CircuitBreakersConfig contains configuration for circuit breakers.
+// Circuit breakers are only supported by the internal and regional external
+// HTTP(S) load balancers.
+// +k8s:openapi-gen=true
+type CircuitBreakersConfig struct {
+	// MaxConnections is the maximum number of connections to the backend.
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// MaxPendingRequests is the maximum number of pending requests allowed
+	// to the backend.
+	MaxPendingRequests *int64 `json:"maxPendingRequests,omitempty"`
+	// MaxRequests is the maximum number of parallel requests allowed to the
+	// backend.
+	MaxRequests *int64 `json:"maxRequests,omitempty"`
+	// MaxRequestsPerConnection is the maximum number of requests for a
+	// single connection to the backend.
+	MaxRequestsPerConnection *int64 `json:"maxRequestsPerConnection,omitempty"`
+	// MaxRetries is the maximum number of parallel retries allowed to the
+	// backend.
+	MaxRetries *int64 `json:"maxRetries,omitempty"`
+}
+
+// ConsistentHashConfig contains configuration for consistent hash based
+// load balancing. Exactly one of HttpHeaderName, HttpCookie and UseSourceIp
+// must be set.
+// +k8s:openapi-gen=true
+type ConsistentHashConfig struct {
+	// HttpHeaderName is the name of the request header whose value is
+	// hashed.
+	HttpHeaderName string `json:"httpHeaderName,omitempty"`
+	// HttpCookie specifies the cookie whose value is hashed. The cookie is
+	// generated by the load balancer if it is not present in the request.
+	HttpCookie *ConsistentHashCookieConfig `json:"httpCookie,omitempty"`
+	// UseSourceIp specifies that the client IP address is hashed.
+	UseSourceIp bool `json:"useSourceIp,omitempty"`
+	// MinimumRingSize is the minimum number of virtual nodes to use for the
+	// hash ring. Only applies when LocalityLbPolicy is RING_HASH. Defaults
+	// to 1024.
+	MinimumRingSize *int64 `json:"minimumRingSize,omitempty"`
+}
+
+// PolicyTargetReference identifies the object a BackendConfig is attached
+// to. Services and backend buckets are supported.
+// +k8s:openapi-gen=true
+type PolicyTargetReference struct {
+	// Group is the API group of the target, which is empty for Services and
+	// networking.gke.io for backend buckets.
+	Group string `json:"group"`
+	// Kind is the kind of the target, Service or BackendBucket.
+	Kind string `json:"kind"`
+	// Name is the name of the target.
+	Name string `json:"name"`
+}
+
+// ConsistentHashCookieConfig contains configuration for the cookie used for
+// consistent hashing.
+// +k8s:openapi-gen=true
+type ConsistentHashCookieConfig struct {
+	// Name of the cookie.
+	Name string `json:"name"`
+	// Path to set for the cookie.
+	Path string `json:"path,omitempty"`
+	// TtlSec is the lifetime of the cookie in seconds.
+	TtlSec *int64 `json:"ttlSec,omitempty"`
+}
+
+// CapacityConfig contains configuration for the balancing mode and the
+// capacity of the NEG backends of a backend service.
+// +k8s:openapi-gen=true
+type CapacityConfig struct {
+	// BalancingMode is how the load balancer determines when the backends
+	// are at capacity. The NEG backends of HTTP(S) load balancers only
+	// support RATE, which is the default.
+	BalancingMode *string `json:"balancingMode,omitempty"`
+	// MaxRatePerEndpoint is the target number of requests per second of an
+	// endpoint.
+	MaxRatePerEndpoint *float64 `json:"maxRatePerEndpoint,omitempty"`
+	// CapacityScaler scales the capacity of the backends, between 0.0 and
+	// 1.0. Defaults to 1.0.
+	CapacityScaler *float64 `json:"capacityScaler,omitempty"`
+	// Zones overrides the capacity scaler of the backends in some zones. A
+	// capacity scaler of 0.0 drains the zone.
+	// +listType=map
+	// +listMapKey=zone
+	Zones []ZoneCapacityConfig `json:"zones,omitempty"`
+}
+
+// ZoneCapacityConfig contains the capacity of the NEG backend of a zone.
+// +k8s:openapi-gen=true
+type ZoneCapacityConfig struct {
+	// Zone of the NEG.
+	Zone string `json:"zone"`
+	// CapacityScaler scales the capacity of the backend of the zone,
+	// between 0.0 and 1.0.
+	CapacityScaler float64 `json:"capacityScaler"`
+}
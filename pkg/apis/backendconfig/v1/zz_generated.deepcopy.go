@@ -31,7 +31,7 @@ func (in *BackendConfig) DeepCopyInto(out *BackendConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -139,6 +139,51 @@ func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 		*out = new(LogConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FaultInjection != nil {
+		in, out := &in.FaultInjection, &out.FaultInjection
+		*out = new(FaultInjectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestMirror != nil {
+		in, out := &in.RequestMirror, &out.RequestMirror
+		*out = new(RequestMirrorConfig)
+		**out = **in
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = new(CircuitBreakersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalityLbPolicy != nil {
+		in, out := &in.LocalityLbPolicy, &out.LocalityLbPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConsistentHash != nil {
+		in, out := &in.ConsistentHash, &out.ConsistentHash
+		*out = new(ConsistentHashConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(PolicyTargetReference)
+		**out = **in
+	}
 	return
 }
 
@@ -155,6 +200,18 @@ func (in *BackendConfigSpec) DeepCopy() *BackendConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigStatus) DeepCopyInto(out *BackendConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingresses != nil {
+		in, out := &in.Ingresses, &out.Ingresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -304,6 +361,100 @@ func (in *CacheKeyPolicy) DeepCopy() *CacheKeyPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityConfig) DeepCopyInto(out *CapacityConfig) {
+	*out = *in
+	if in.BalancingMode != nil {
+		in, out := &in.BalancingMode, &out.BalancingMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxRatePerEndpoint != nil {
+		in, out := &in.MaxRatePerEndpoint, &out.MaxRatePerEndpoint
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CapacityScaler != nil {
+		in, out := &in.CapacityScaler, &out.CapacityScaler
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneCapacityConfig, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityConfig.
+func (in *CapacityConfig) DeepCopy() *CapacityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakersConfig) DeepCopyInto(out *CircuitBreakersConfig) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequestsPerConnection != nil {
+		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakersConfig.
+func (in *CircuitBreakersConfig) DeepCopy() *CircuitBreakersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDrainingConfig) DeepCopyInto(out *ConnectionDrainingConfig) {
 	*out = *in
@@ -320,6 +471,53 @@ func (in *ConnectionDrainingConfig) DeepCopy() *ConnectionDrainingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashConfig) DeepCopyInto(out *ConsistentHashConfig) {
+	*out = *in
+	if in.HttpCookie != nil {
+		in, out := &in.HttpCookie, &out.HttpCookie
+		*out = new(ConsistentHashCookieConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinimumRingSize != nil {
+		in, out := &in.MinimumRingSize, &out.MinimumRingSize
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashConfig.
+func (in *ConsistentHashConfig) DeepCopy() *ConsistentHashConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashCookieConfig) DeepCopyInto(out *ConsistentHashCookieConfig) {
+	*out = *in
+	if in.TtlSec != nil {
+		in, out := &in.TtlSec, &out.TtlSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashCookieConfig.
+func (in *ConsistentHashCookieConfig) DeepCopy() *ConsistentHashCookieConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashCookieConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomRequestHeadersConfig) DeepCopyInto(out *CustomRequestHeadersConfig) {
 	*out = *in
@@ -362,6 +560,64 @@ func (in *CustomResponseHeadersConfig) DeepCopy() *CustomResponseHeadersConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultAbortConfig) DeepCopyInto(out *FaultAbortConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultAbortConfig.
+func (in *FaultAbortConfig) DeepCopy() *FaultAbortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultAbortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultDelayConfig) DeepCopyInto(out *FaultDelayConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultDelayConfig.
+func (in *FaultDelayConfig) DeepCopy() *FaultDelayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultDelayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjectionConfig) DeepCopyInto(out *FaultInjectionConfig) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(FaultDelayConfig)
+		**out = **in
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(FaultAbortConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjectionConfig.
+func (in *FaultInjectionConfig) DeepCopy() *FaultInjectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
 	*out = *in
@@ -487,6 +743,120 @@ func (in *OAuthClientCredentials) DeepCopy() *OAuthClientCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionConfig) DeepCopyInto(out *OutlierDetectionConfig) {
+	*out = *in
+	if in.ConsecutiveErrors != nil {
+		in, out := &in.ConsecutiveErrors, &out.ConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsecutiveGatewayFailure != nil {
+		in, out := &in.ConsecutiveGatewayFailure, &out.ConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveErrors != nil {
+		in, out := &in.EnforcingConsecutiveErrors, &out.EnforcingConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveGatewayFailure != nil {
+		in, out := &in.EnforcingConsecutiveGatewayFailure, &out.EnforcingConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IntervalSec != nil {
+		in, out := &in.IntervalSec, &out.IntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BaseEjectionTimeSec != nil {
+		in, out := &in.BaseEjectionTimeSec, &out.BaseEjectionTimeSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionConfig.
+func (in *OutlierDetectionConfig) DeepCopy() *OutlierDetectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTargetReference) DeepCopyInto(out *PolicyTargetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTargetReference.
+func (in *PolicyTargetReference) DeepCopy() *PolicyTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestMirrorConfig) DeepCopyInto(out *RequestMirrorConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestMirrorConfig.
+func (in *RequestMirrorConfig) DeepCopy() *RequestMirrorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestMirrorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicyConfig) DeepCopyInto(out *RetryPolicyConfig) {
+	*out = *in
+	if in.RetryConditions != nil {
+		in, out := &in.RetryConditions, &out.RetryConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumRetries != nil {
+		in, out := &in.NumRetries, &out.NumRetries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerTryTimeoutSec != nil {
+		in, out := &in.PerTryTimeoutSec, &out.PerTryTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicyConfig.
+func (in *RetryPolicyConfig) DeepCopy() *RetryPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityPolicyConfig) DeepCopyInto(out *SecurityPolicyConfig) {
 	*out = *in
@@ -539,3 +909,19 @@ func (in *SignedUrlKey) DeepCopy() *SignedUrlKey {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneCapacityConfig) DeepCopyInto(out *ZoneCapacityConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneCapacityConfig.
+func (in *ZoneCapacityConfig) DeepCopy() *ZoneCapacityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneCapacityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
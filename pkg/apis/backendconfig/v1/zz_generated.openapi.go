@@ -32,20 +32,34 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 	return map[string]common.OpenAPIDefinition{
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":               schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":           schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigStatus":         schema_pkg_apis_backendconfig_v1_BackendConfigStatus(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BypassCacheOnRequestHeader":  schema_pkg_apis_backendconfig_v1_BypassCacheOnRequestHeader(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                   schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":              schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CapacityConfig":              schema_pkg_apis_backendconfig_v1_CapacityConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig":       schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Condition":                   schema_pkg_apis_backendconfig_v1_Condition(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig":    schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig":        schema_pkg_apis_backendconfig_v1_ConsistentHashConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashCookieConfig":  schema_pkg_apis_backendconfig_v1_ConsistentHashCookieConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig":  schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig": schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultAbortConfig":            schema_pkg_apis_backendconfig_v1_FaultAbortConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultDelayConfig":            schema_pkg_apis_backendconfig_v1_FaultDelayConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultInjectionConfig":        schema_pkg_apis_backendconfig_v1_FaultInjectionConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig":           schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig":                   schema_pkg_apis_backendconfig_v1_IAPConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig":                   schema_pkg_apis_backendconfig_v1_LogConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy":       schema_pkg_apis_backendconfig_v1_NegativeCachingPolicy(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OAuthClientCredentials":      schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig":      schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.PolicyTargetReference":       schema_pkg_apis_backendconfig_v1_PolicyTargetReference(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RequestMirrorConfig":         schema_pkg_apis_backendconfig_v1_RequestMirrorConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RetryPolicyConfig":           schema_pkg_apis_backendconfig_v1_RetryPolicyConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig":        schema_pkg_apis_backendconfig_v1_SecurityPolicyConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig":       schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey":                schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ZoneCapacityConfig":          schema_pkg_apis_backendconfig_v1_ZoneCapacityConfig(ref),
 	}
 }
 
@@ -154,11 +168,110 @@ func schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref common.ReferenceCall
 							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig"),
 						},
 					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPolicy specifies when and how often failed requests to this backend are retried by the load balancer.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RetryPolicyConfig"),
+						},
+					},
+					"faultInjection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FaultInjection specifies delays and aborts that the load balancer injects into a percentage of the requests sent to this backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultInjectionConfig"),
+						},
+					},
+					"requestMirror": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestMirror specifies a shadow Service that receives a copy of the requests sent to this backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RequestMirrorConfig"),
+						},
+					},
+					"outlierDetection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutlierDetection specifies how unhealthy endpoints are ejected from the load balancing pool of this backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig"),
+						},
+					},
+					"circuitBreakers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CircuitBreakers specifies limits on the connections and requests the load balancer sends to this backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig"),
+						},
+					},
+					"localityLbPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalityLbPolicy specifies the load balancing algorithm used within the scope of a locality. Valid values are ROUND_ROBIN, LEAST_REQUEST, RING_HASH and MAGLEV.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"consistentHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsistentHash specifies how requests are hashed when LocalityLbPolicy is RING_HASH or MAGLEV. It cannot be combined with SessionAffinity.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig"),
+						},
+					},
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity specifies the balancing mode and the capacity of the NEG backends of this backend. It takes precedence over the networking.gke.io/max-rate-per-endpoint and networking.gke.io/capacity-scaler annotations of the Service.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CapacityConfig"),
+						},
+					},
+					"targetRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetRef attaches the BackendConfig to a Service of its namespace, for the ports of the Service that do not reference a BackendConfig with the cloud.google.com/backend-config annotation, if the controller runs with --enable-backendconfig-target-ref; or to a backend bucket referenced by the Ingresses of its namespace.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.PolicyTargetReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CapacityConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultInjectionConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.PolicyTargetReference", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RequestMirrorConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.RetryPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_BackendConfigStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackendConfigStatus is the status for a BackendConfig resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions report whether the BackendConfig was accepted and applied to the backend services by the last sync of an Ingress using it.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"ingresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingresses are the names of the Ingresses with a backend using the BackendConfig.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Condition"},
 	}
 }
 
@@ -358,6 +471,174 @@ func schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_CapacityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CapacityConfig contains configuration for the balancing mode and the capacity of the NEG backends of a backend service.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"balancingMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BalancingMode is how the load balancer determines when the backends are at capacity. The NEG backends of HTTP(S) load balancers only support RATE, which is the default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxRatePerEndpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRatePerEndpoint is the target number of requests per second of an endpoint.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"capacityScaler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityScaler scales the capacity of the backends, between 0.0 and 1.0. Defaults to 1.0.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"zones": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"zone",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Zones overrides the capacity scaler of the backends in some zones. A capacity scaler of 0.0 drains the zone.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ZoneCapacityConfig"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ZoneCapacityConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CircuitBreakersConfig contains configuration for circuit breakers. Circuit breakers are only supported by the internal and regional external HTTP(S) load balancers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxConnections": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxConnections is the maximum number of connections to the backend.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxPendingRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxPendingRequests is the maximum number of pending requests allowed to the backend.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequests is the maximum number of parallel requests allowed to the backend.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequestsPerConnection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequestsPerConnection is the maximum number of requests for a single connection to the backend.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries is the maximum number of parallel retries allowed to the backend.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_Condition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Condition contains details for the current condition of a BackendConfig.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the condition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the BackendConfig the condition was set for.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last time the condition transitioned from one status to another.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The reason for the condition's last transition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating details about the transition. This field may be empty.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status", "lastTransitionTime", "reason", "message"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -378,6 +659,84 @@ func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_ConsistentHashConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsistentHashConfig contains configuration for consistent hash based load balancing. Exactly one of HttpHeaderName, HttpCookie and UseSourceIp must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"httpHeaderName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HttpHeaderName is the name of the request header whose value is hashed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"httpCookie": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HttpCookie specifies the cookie whose value is hashed. The cookie is generated by the load balancer if it is not present in the request.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashCookieConfig"),
+						},
+					},
+					"useSourceIp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UseSourceIp specifies that the client IP address is hashed.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"minimumRingSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinimumRingSize is the minimum number of virtual nodes to use for the hash ring. Only applies when LocalityLbPolicy is RING_HASH. Defaults to 1024.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConsistentHashCookieConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_ConsistentHashCookieConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsistentHashCookieConfig contains configuration for the cookie used for consistent hashing.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the cookie.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path to set for the cookie.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ttlSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TtlSec is the lifetime of the cookie in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -432,6 +791,93 @@ func schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_FaultAbortConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FaultAbortConfig contains configuration for injected aborts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"httpStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HttpStatus specifies the HTTP status code used to abort the request. The value must be in [200, 599].",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"percentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of requests which will be aborted. The value must be in [0, 100].",
+							Default:     0,
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+				Required: []string{"httpStatus", "percentage"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_FaultDelayConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FaultDelayConfig contains configuration for injected delays.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fixedDelaySec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FixedDelaySec specifies the value of the fixed delay interval.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"percentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of requests on which the delay will be introduced. The value must be in [0, 100].",
+							Default:     0,
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+				Required: []string{"fixedDelaySec", "percentage"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_FaultInjectionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FaultInjectionConfig contains configuration for fault injection. Fault injection is only supported by the internal and regional external HTTP(S) load balancers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"delay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Delay specifies how requests are delayed before being sent to the backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultDelayConfig"),
+						},
+					},
+					"abort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Abort specifies how requests are aborted before being sent to the backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultAbortConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultAbortConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.FaultDelayConfig"},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -612,6 +1058,184 @@ func schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OutlierDetectionConfig contains configuration for outlier detection. Outlier detection is only supported by the internal and regional external HTTP(S) load balancers. Fields that are not specified keep their GCE defaults.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"consecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveErrors is the number of consecutive 5xx errors after which an endpoint is ejected. Defaults to 5.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"consecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveGatewayFailure is the number of consecutive 502, 503 and 504 errors after which an endpoint is ejected. Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveErrors is the percentage chance that an endpoint is actually ejected when ConsecutiveErrors is reached. The value must be in [0, 100]. Defaults to 0.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveGatewayFailure is the percentage chance that an endpoint is actually ejected when ConsecutiveGatewayFailure is reached. The value must be in [0, 100]. Defaults to 100.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"intervalSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IntervalSec is the time interval between ejection analysis sweeps. Defaults to 10 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"baseEjectionTimeSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BaseEjectionTimeSec is the base time that an endpoint is ejected for. The real ejection time is equal to the base ejection time multiplied by the number of times the endpoint has been ejected. Defaults to 30 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxEjectionPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxEjectionPercent is the maximum percentage of endpoints that can be ejected. The value must be in [0, 100]. Defaults to 50.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_PolicyTargetReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicyTargetReference identifies the object a BackendConfig is attached to. Services and backend buckets are supported.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the API group of the target, which is empty for Services and networking.gke.io for backend buckets.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the target, Service or BackendBucket.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the target.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"group", "kind", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_RequestMirrorConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RequestMirrorConfig contains configuration for request mirroring. The load balancer does not wait for responses from the mirror Service. The mirror Service gets NEGs like the Services of the Ingress.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceName is the name of the Service, in the namespace of the BackendConfig, that receives the mirrored requests.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servicePort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePort is the port number of the Service that receives the mirrored requests. Exactly one of ServicePort and ServicePortName must be set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"servicePortName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePortName is the port name of the Service that receives the mirrored requests.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"serviceName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_RetryPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetryPolicyConfig contains configuration for retrying failed requests. Retry policies are only supported by the internal and regional external HTTP(S) load balancers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"retryConditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryConditions specifies one or more conditions when this retry policy applies. Valid values are 5xx, gateway-error, connect-failure, retriable-4xx, refused-stream, cancelled, deadline-exceeded, internal, resource-exhausted and unavailable.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"numRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumRetries specifies the allowed number of retries. This number must be > 0. If not specified, defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"perTryTimeoutSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerTryTimeoutSec specifies a non-zero timeout per retry attempt. If not specified, the timeout of the backend service is used.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_SecurityPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -692,3 +1316,33 @@ func schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref common.ReferenceCallback)
 		},
 	}
 }
+
+func schema_pkg_apis_backendconfig_v1_ZoneCapacityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ZoneCapacityConfig contains the capacity of the NEG backend of a zone.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone of the NEG.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"capacityScaler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityScaler scales the capacity of the backend of the zone, between 0.0 and 1.0.",
+							Default:     0,
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+				Required: []string{"zone", "capacityScaler"},
+			},
+		},
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,6 +38,94 @@ type FrontendConfig struct {
 type FrontendConfigSpec struct {
 	SslPolicy       *string              `json:"sslPolicy,omitempty"`
 	RedirectToHttps *HttpsRedirectConfig `json:"redirectToHttps,omitempty"`
+	// CertificateMap is the name or full resource URL of the Certificate
+	// Manager certificate map attached to the target https proxy instead of
+	// SSL certificates. The networking.gke.io/certificate-map annotation
+	// takes precedence over it.
+	CertificateMap *string `json:"certificateMap,omitempty"`
+	// ServerTlsPolicy is the name of the network security ServerTlsPolicy
+	// attached to the target https proxy. Its mTLS policy requires client
+	// certificates, validated against the referenced trust config. Regional
+	// load balancers use the policy of their region. An empty string
+	// detaches the policy.
+	ServerTlsPolicy *string `json:"serverTlsPolicy,omitempty"`
+	// RouteActions are the URL rewrites and header actions applied to the
+	// requests of the Ingress paths they select.
+	RouteActions []RouteActionConfig `json:"routeActions,omitempty"`
+	// CustomErrorResponses serves custom error pages for the responses of
+	// the load balancer matching the configured status codes.
+	CustomErrorResponses *CustomErrorResponsesConfig `json:"customErrorResponses,omitempty"`
+}
+
+// CustomErrorResponsesConfig representing the error pages served in place of
+// the error responses of the load balancer. GCE only supports backend buckets
+// as error services, and does not support custom error responses on classic
+// Application Load Balancers, used by Ingresses of the gce class.
+// +k8s:openapi-gen=true
+type CustomErrorResponsesConfig struct {
+	// ErrorBackendBucket is the name of the backend bucket serving the error
+	// pages.
+	ErrorBackendBucket string `json:"errorBackendBucket"`
+	// Rules map response status codes to the error page served for them.
+	Rules []CustomErrorResponseRule `json:"rules"`
+}
+
+// CustomErrorResponseRule representing the error page served for a set of
+// response status codes
+// +k8s:openapi-gen=true
+type CustomErrorResponseRule struct {
+	// ResponseCodes are the matched status codes. Options are 4xx, 5xx, or
+	// a single code between 400 and 599.
+	ResponseCodes []string `json:"responseCodes"`
+	// Path of the error page in the error backend bucket. It must start with /.
+	Path string `json:"path"`
+	// OverrideResponseCode replaces the status code of the response. If
+	// unset, the original status code is kept.
+	OverrideResponseCode int64 `json:"overrideResponseCode,omitempty"`
+}
+
+// RouteActionConfig configures the URL rewrite and header actions applied
+// to the requests of the Ingress paths matching Host and Path. When several
+// configs select a path, the most specific one applies.
+// +k8s:openapi-gen=true
+type RouteActionConfig struct {
+	// Host of the Ingress rule. If empty, the actions apply to all hosts.
+	Host string `json:"host,omitempty"`
+	// Path of the Ingress rule path. If empty, the actions apply to all paths
+	// of the selected hosts.
+	Path string `json:"path,omitempty"`
+	// UrlRewrite rewrites the request before it is sent to the backend.
+	UrlRewrite *UrlRewriteConfig `json:"urlRewrite,omitempty"`
+	// HeaderAction adds or removes request and response headers.
+	HeaderAction *HeaderActionConfig `json:"headerAction,omitempty"`
+}
+
+// UrlRewriteConfig representing the rewrite of the request URL
+// +k8s:openapi-gen=true
+type UrlRewriteConfig struct {
+	// PathPrefixRewrite replaces the matched portion of the request path.
+	PathPrefixRewrite string `json:"pathPrefixRewrite,omitempty"`
+	// HostRewrite replaces the request host header.
+	HostRewrite string `json:"hostRewrite,omitempty"`
+}
+
+// HeaderActionConfig representing the headers added to or removed from
+// requests and responses
+// +k8s:openapi-gen=true
+type HeaderActionConfig struct {
+	RequestHeadersToAdd     []HeaderConfig `json:"requestHeadersToAdd,omitempty"`
+	RequestHeadersToRemove  []string       `json:"requestHeadersToRemove,omitempty"`
+	ResponseHeadersToAdd    []HeaderConfig `json:"responseHeadersToAdd,omitempty"`
+	ResponseHeadersToRemove []string       `json:"responseHeadersToRemove,omitempty"`
+}
+
+// HeaderConfig representing a header to add
+// +k8s:openapi-gen=true
+type HeaderConfig struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Replace the existing values of the header instead of appending.
+	Replace bool `json:"replace,omitempty"`
 }
 
 // HttpsRedirectConfig representing the configuration of Https redirects
@@ -49,7 +138,59 @@ type HttpsRedirectConfig struct {
 }
 
 // FrontendConfigStatus is the status for a FrontendConfig resource
-type FrontendConfigStatus struct{}
+// +k8s:openapi-gen=true
+type FrontendConfigStatus struct {
+	// Conditions report whether the FrontendConfig was accepted and applied
+	// to the load balancer by the last sync of an Ingress using it.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Ingresses are the names of the Ingresses using the FrontendConfig.
+	// +optional
+	Ingresses []string `json:"ingresses,omitempty"`
+}
+
+// Condition contains details for the current condition of a FrontendConfig.
+// +k8s:openapi-gen=true
+type Condition struct {
+	// Type is the type of the condition.
+	// +required
+	Type string `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	// +required
+	Status corev1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation of the FrontendConfig the
+	// condition was set for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +required
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// The reason for the condition's last transition.
+	// +required
+	Reason string `json:"reason"`
+	// A human readable message indicating details about the transition.
+	// This field may be empty.
+	// +required
+	Message string `json:"message"`
+}
+
+// These are valid conditions of a FrontendConfig.
+const (
+	// AcceptedCondition means the FrontendConfig is valid for the load
+	// balancer of the Ingress.
+	AcceptedCondition = "Accepted"
+	// ProgrammedCondition means the FrontendConfig was applied to the load
+	// balancer of the Ingress.
+	ProgrammedCondition = "Programmed"
+)
+
+// These are the reasons of the FrontendConfig conditions.
+const (
+	AcceptedReason   = "Accepted"
+	InvalidReason    = "Invalid"
+	ProgrammedReason = "Programmed"
+	SyncFailedReason = "SyncFailed"
+)
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -25,13 +25,74 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomErrorResponseRule) DeepCopyInto(out *CustomErrorResponseRule) {
+	*out = *in
+	if in.ResponseCodes != nil {
+		in, out := &in.ResponseCodes, &out.ResponseCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomErrorResponseRule.
+func (in *CustomErrorResponseRule) DeepCopy() *CustomErrorResponseRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomErrorResponseRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomErrorResponsesConfig) DeepCopyInto(out *CustomErrorResponsesConfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]CustomErrorResponseRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomErrorResponsesConfig.
+func (in *CustomErrorResponsesConfig) DeepCopy() *CustomErrorResponsesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomErrorResponsesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendConfig) DeepCopyInto(out *FrontendConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -99,6 +160,28 @@ func (in *FrontendConfigSpec) DeepCopyInto(out *FrontendConfigSpec) {
 		*out = new(HttpsRedirectConfig)
 		**out = **in
 	}
+	if in.CertificateMap != nil {
+		in, out := &in.CertificateMap, &out.CertificateMap
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServerTlsPolicy != nil {
+		in, out := &in.ServerTlsPolicy, &out.ServerTlsPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.RouteActions != nil {
+		in, out := &in.RouteActions, &out.RouteActions
+		*out = make([]RouteActionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CustomErrorResponses != nil {
+		in, out := &in.CustomErrorResponses, &out.CustomErrorResponses
+		*out = new(CustomErrorResponsesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -115,6 +198,18 @@ func (in *FrontendConfigSpec) DeepCopy() *FrontendConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendConfigStatus) DeepCopyInto(out *FrontendConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingresses != nil {
+		in, out := &in.Ingresses, &out.Ingresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -128,6 +223,58 @@ func (in *FrontendConfigStatus) DeepCopy() *FrontendConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderActionConfig) DeepCopyInto(out *HeaderActionConfig) {
+	*out = *in
+	if in.RequestHeadersToAdd != nil {
+		in, out := &in.RequestHeadersToAdd, &out.RequestHeadersToAdd
+		*out = make([]HeaderConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestHeadersToRemove != nil {
+		in, out := &in.RequestHeadersToRemove, &out.RequestHeadersToRemove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeadersToAdd != nil {
+		in, out := &in.ResponseHeadersToAdd, &out.ResponseHeadersToAdd
+		*out = make([]HeaderConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeadersToRemove != nil {
+		in, out := &in.ResponseHeadersToRemove, &out.ResponseHeadersToRemove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderActionConfig.
+func (in *HeaderActionConfig) DeepCopy() *HeaderActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderConfig) DeepCopyInto(out *HeaderConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderConfig.
+func (in *HeaderConfig) DeepCopy() *HeaderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpsRedirectConfig) DeepCopyInto(out *HttpsRedirectConfig) {
 	*out = *in
@@ -143,3 +290,45 @@ func (in *HttpsRedirectConfig) DeepCopy() *HttpsRedirectConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteActionConfig) DeepCopyInto(out *RouteActionConfig) {
+	*out = *in
+	if in.UrlRewrite != nil {
+		in, out := &in.UrlRewrite, &out.UrlRewrite
+		*out = new(UrlRewriteConfig)
+		**out = **in
+	}
+	if in.HeaderAction != nil {
+		in, out := &in.HeaderAction, &out.HeaderAction
+		*out = new(HeaderActionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteActionConfig.
+func (in *RouteActionConfig) DeepCopy() *RouteActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UrlRewriteConfig) DeepCopyInto(out *UrlRewriteConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UrlRewriteConfig.
+func (in *UrlRewriteConfig) DeepCopy() *UrlRewriteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UrlRewriteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
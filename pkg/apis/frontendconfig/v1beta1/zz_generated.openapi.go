@@ -30,9 +30,161 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":      schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":  schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig": schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.Condition":                  schema_pkg_apis_frontendconfig_v1beta1_Condition(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule":    schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponseRule(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsesConfig": schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponsesConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":             schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":         schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigStatus":       schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigStatus(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderActionConfig":         schema_pkg_apis_frontendconfig_v1beta1_HeaderActionConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderConfig":               schema_pkg_apis_frontendconfig_v1beta1_HeaderConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig":        schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteActionConfig":          schema_pkg_apis_frontendconfig_v1beta1_RouteActionConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewriteConfig":           schema_pkg_apis_frontendconfig_v1beta1_UrlRewriteConfig(ref),
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_Condition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Condition contains details for the current condition of a FrontendConfig.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the condition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the FrontendConfig the condition was set for.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last time the condition transitioned from one status to another.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The reason for the condition's last transition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating details about the transition. This field may be empty.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status", "lastTransitionTime", "reason", "message"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponseRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomErrorResponseRule representing the error page served for a set of response status codes",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"responseCodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResponseCodes are the matched status codes. Options are 4xx, 5xx, or a single code between 400 and 599.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path of the error page in the error backend bucket. It must start with /.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"overrideResponseCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OverrideResponseCode replaces the status code of the response. If unset, the original status code is kept.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"responseCodes", "path"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_CustomErrorResponsesConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomErrorResponsesConfig representing the error pages served in place of the error responses of the load balancer. GCE only supports backend buckets as error services, and does not support custom error responses on classic Application Load Balancers, used by Ingresses of the gce class.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"errorBackendBucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ErrorBackendBucket is the name of the backend bucket serving the error pages.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules map response status codes to the error page served for them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"errorBackendBucket", "rules"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponseRule"},
 	}
 }
 
@@ -100,11 +252,193 @@ func schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref common.Refere
 							Ref: ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"),
 						},
 					},
+					"certificateMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertificateMap is the name or full resource URL of the Certificate Manager certificate map attached to the target https proxy instead of SSL certificates. The networking.gke.io/certificate-map annotation takes precedence over it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serverTlsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServerTlsPolicy is the name of the network security ServerTlsPolicy attached to the target https proxy. Its mTLS policy requires client certificates, validated against the referenced trust config. Regional load balancers use the policy of their region. An empty string detaches the policy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"routeActions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RouteActions are the URL rewrites and header actions applied to the requests of the Ingress paths they select.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteActionConfig"),
+									},
+								},
+							},
+						},
+					},
+					"customErrorResponses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CustomErrorResponses serves custom error pages for the responses of the load balancer matching the configured status codes.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsesConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"},
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.CustomErrorResponsesConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RouteActionConfig"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FrontendConfigStatus is the status for a FrontendConfig resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions report whether the FrontendConfig was accepted and applied to the load balancer by the last sync of an Ingress using it.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"ingresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingresses are the names of the Ingresses using the FrontendConfig.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.Condition"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_HeaderActionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HeaderActionConfig representing the headers added to or removed from requests and responses",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"requestHeadersToAdd": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderConfig"),
+									},
+								},
+							},
+						},
+					},
+					"requestHeadersToRemove": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"responseHeadersToAdd": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderConfig"),
+									},
+								},
+							},
+						},
+					},
+					"responseHeadersToRemove": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderConfig"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_HeaderConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HeaderConfig representing a header to add",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"replace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replace the existing values of the header instead of appending.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "value"},
+			},
+		},
 	}
 }
 
@@ -135,3 +469,71 @@ func schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref common.Refer
 		},
 	}
 }
+
+func schema_pkg_apis_frontendconfig_v1beta1_RouteActionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouteActionConfig configures the URL rewrite and header actions applied to the requests of the Ingress paths matching Host and Path. When several configs select a path, the most specific one applies.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host of the Ingress rule. If empty, the actions apply to all hosts.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path of the Ingress rule path. If empty, the actions apply to all paths of the selected hosts.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"urlRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UrlRewrite rewrites the request before it is sent to the backend.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewriteConfig"),
+						},
+					},
+					"headerAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HeaderAction adds or removes request and response headers.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderActionConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HeaderActionConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.UrlRewriteConfig"},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_UrlRewriteConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UrlRewriteConfig representing the rewrite of the request URL",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pathPrefixRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathPrefixRewrite replaces the matched portion of the request path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostRewrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostRewrite replaces the request host header.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//
+// +k8s:openapi-gen=true
+
+// IngressSyncStatus represents the result of the last sync of an Ingress to
+// its GCE load balancer. It has the same name and namespace as the Ingress,
+// which owns it.
+type IngressSyncStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IngressSyncStatusSpec   `json:"spec,omitempty"`
+	Status IngressSyncStatusStatus `json:"status,omitempty"`
+}
+
+// IngressSyncStatusSpec is the spec for a IngressSyncStatus resource
+type IngressSyncStatusSpec struct{}
+
+// IngressSyncStatusStatus is the status for a IngressSyncStatus resource
+// +k8s:openapi-gen=true
+type IngressSyncStatusStatus struct {
+	// Generation of the Ingress that was last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Last time the controller synced the Ingress. When nothing else in the
+	// status changed, it is only refreshed every 10 minutes.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Result of the last sync.
+	// +optional
+	LastSyncResult SyncResult `json:"lastSyncResult,omitempty"`
+
+	// Last error that failed a sync of the Ingress. It is kept after the
+	// following successful syncs.
+	// +optional
+	LastError *SyncError `json:"lastError,omitempty"`
+
+	// GCE resources of the load balancer, as of the last successful sync.
+	// +optional
+	// +listType=atomic
+	Resources []GCEResource `json:"resources,omitempty"`
+
+	// Health of the backend services of the load balancer, as of the last
+	// successful sync.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Backends []BackendHealth `json:"backends,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type SyncResult string
+
+const (
+	// SyncResultSuccess means that the GCE resources of the Ingress are
+	// up to date.
+	SyncResultSuccess = SyncResult("Success")
+	// SyncResultError means that the last sync failed with LastError.
+	SyncResultError = SyncResult("Error")
+)
+
+// SyncError describes an error that failed a sync of an Ingress.
+// +k8s:openapi-gen=true
+type SyncError struct {
+	// Class of the error, e.g. ErrSvcNotFound for a missing Service.
+	// +required
+	Class string `json:"class"`
+
+	// A human readable message of the error.
+	// +required
+	Message string `json:"message"`
+
+	// Time of the sync that failed. When the same error fails the following
+	// syncs, it is only refreshed every 10 minutes.
+	// +required
+	Time metav1.Time `json:"time"`
+}
+
+// GCEResource is a reference to a GCE resource of the load balancer.
+// +k8s:openapi-gen=true
+type GCEResource struct {
+	// Type of the resource, e.g. UrlMap or TargetHttpsProxy.
+	// +required
+	Type string `json:"type"`
+
+	// Name of the resource in GCE.
+	// +required
+	Name string `json:"name"`
+}
+
+// BackendHealth is the health of a backend service of the load balancer.
+// +k8s:openapi-gen=true
+type BackendHealth struct {
+	// Name of the backend service in GCE.
+	// +required
+	Name string `json:"name"`
+
+	// Health state of the backend service, e.g. HEALTHY, UNHEALTHY or
+	// Unknown.
+	// +required
+	Health string `json:"health"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressSyncStatusList is a list of IngressSyncStatus resources
+type IngressSyncStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []IngressSyncStatus `json:"items"`
+}
@@ -54,6 +54,17 @@ type ServiceNetworkEndpointGroupStatus struct {
 	// Last time the NEG syncer syncs associated NEGs.
 	// +optional
 	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Health of the endpoints of each NEG, per zone, as last seen by the
+	// readiness reflector when it polled the NEG. The readiness reflector
+	// polls a NEG while some of its pods wait for their readiness gate, and
+	// every few minutes otherwise. See LastPollTime for when it was last
+	// seen.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=zone
+	EndpointHealth []NegEndpointHealth `json:"endpointHealth,omitempty"`
 }
 
 // NegObjectReference is the object reference to the NEG resource in GCE
@@ -78,6 +89,41 @@ type NegObjectReference struct {
 	State NegState `json:"state,omitempty"`
 }
 
+// NegEndpointHealth is the health of the endpoints of a NEG in a zone.
+// +k8s:openapi-gen=true
+type NegEndpointHealth struct {
+	// Name of the NEG.
+	// +required
+	Name string `json:"name"`
+
+	// Zone of the NEG.
+	// +required
+	Zone string `json:"zone"`
+
+	// Number of endpoints which at least one backend service considers
+	// healthy.
+	HealthyEndpoints int32 `json:"healthyEndpoints"`
+
+	// Number of health checked endpoints which no backend service considers
+	// healthy.
+	UnhealthyEndpoints int32 `json:"unhealthyEndpoints"`
+
+	// Pods of the unhealthy endpoints, in the format of "namespace/name".
+	// Only the pods the readiness reflector is waiting for are listed.
+	// +optional
+	// +listType=set
+	UnhealthyPods []string `json:"unhealthyPods,omitempty"`
+
+	// Last time the health of the endpoints changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Last time the readiness reflector polled the health of the endpoints.
+	// It is refreshed at most every few minutes.
+	// +optional
+	LastPollTime metav1.Time `json:"lastPollTime,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type NegState string
 
@@ -42,6 +42,29 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegEndpointHealth) DeepCopyInto(out *NegEndpointHealth) {
+	*out = *in
+	if in.UnhealthyPods != nil {
+		in, out := &in.UnhealthyPods, &out.UnhealthyPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastPollTime.DeepCopyInto(&out.LastPollTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegEndpointHealth.
+func (in *NegEndpointHealth) DeepCopy() *NegEndpointHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(NegEndpointHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NegObjectReference) DeepCopyInto(out *NegObjectReference) {
 	*out = *in
@@ -151,6 +174,13 @@ func (in *ServiceNetworkEndpointGroupStatus) DeepCopyInto(out *ServiceNetworkEnd
 		}
 	}
 	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.EndpointHealth != nil {
+		in, out := &in.EndpointHealth, &out.EndpointHealth
+		*out = make([]NegEndpointHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
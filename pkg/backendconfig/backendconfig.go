@@ -18,6 +18,9 @@ package backendconfig
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +31,8 @@ import (
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/crd"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/utils"
 )
 
 var (
@@ -44,8 +49,8 @@ func CRDMeta() *crd.CRDMeta {
 		"backendconfig",
 		"backendconfigs",
 		[]*crd.Version{
-			crd.NewVersion("v1", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig", backendconfigv1.GetOpenAPIDefinitions, false),
-			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1.BackendConfig", backendconfigv1beta1.GetOpenAPIDefinitions, false),
+			crd.NewVersion("v1", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig", backendconfigv1.GetOpenAPIDefinitions, false).WithStatusSubresource(),
+			crd.NewVersion("v1beta1", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1.BackendConfig", backendconfigv1beta1.GetOpenAPIDefinitions, false).WithStatusSubresource(),
 		},
 		"bc",
 	)
@@ -60,7 +65,10 @@ func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.
 		// If the user did not provide the annotation at all, then we
 		// do not want to return an error.
 		if err == annotations.ErrBackendConfigAnnotationMissing {
-			return nil, nil
+			if !flags.F.EnableBackendConfigTargetRef {
+				return nil, nil
+			}
+			return attachedBackendConfig(backendConfigLister, svc.Namespace, "", "Service", svc.Name), nil
 		}
 		return nil, err
 	}
@@ -86,3 +94,57 @@ func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.
 
 	return obj.(*backendconfigv1.BackendConfig), nil
 }
+
+// GetBackendConfigForBackendBucket returns the BackendConfig of the given
+// namespace attached to the backend bucket with its targetRef, or nil if
+// there is none. Unlike Services, backend buckets are shared by all the
+// Ingresses of the project, so an error is returned if several
+// BackendConfigs with different CDN settings target the backend bucket.
+func GetBackendConfigForBackendBucket(backendConfigLister cache.Store, namespace, bucket string) (*backendconfigv1.BackendConfig, error) {
+	attached := attachedBackendConfigs(backendConfigLister, namespace, utils.BackendBucketAPIGroup, utils.BackendBucketKind, bucket)
+	if len(attached) == 0 {
+		return nil, nil
+	}
+	for _, beConfig := range attached[1:] {
+		if !reflect.DeepEqual(beConfig.Spec.Cdn, attached[0].Spec.Cdn) {
+			return nil, fmt.Errorf("BackendConfigs %s and %s set different CDN settings for backend bucket %q", attached[0].Name, beConfig.Name, bucket)
+		}
+	}
+	return attached[0], nil
+}
+
+// attachedBackendConfig returns the BackendConfig of the given namespace
+// attached to the target of the given group, kind and name with its
+// targetRef, or nil if there is none. If several BackendConfigs target it,
+// the oldest one is used.
+func attachedBackendConfig(backendConfigLister cache.Store, namespace, group, kind, name string) *backendconfigv1.BackendConfig {
+	attached := attachedBackendConfigs(backendConfigLister, namespace, group, kind, name)
+	if len(attached) == 0 {
+		return nil
+	}
+	return attached[0]
+}
+
+// attachedBackendConfigs returns the BackendConfigs of the given namespace
+// attached to the target of the given group, kind and name with their
+// targetRef, oldest first.
+func attachedBackendConfigs(backendConfigLister cache.Store, namespace, group, kind, name string) []*backendconfigv1.BackendConfig {
+	var attached []*backendconfigv1.BackendConfig
+	for _, obj := range backendConfigLister.List() {
+		beConfig, ok := obj.(*backendconfigv1.BackendConfig)
+		if !ok || beConfig.Namespace != namespace || beConfig.Spec.TargetRef == nil {
+			continue
+		}
+		ref := beConfig.Spec.TargetRef
+		if ref.Group == group && ref.Kind == kind && ref.Name == name {
+			attached = append(attached, beConfig)
+		}
+	}
+	sort.Slice(attached, func(i, j int) bool {
+		if !attached[i].CreationTimestamp.Equal(&attached[j].CreationTimestamp) {
+			return attached[i].CreationTimestamp.Before(&attached[j].CreationTimestamp)
+		}
+		return attached[i].Name < attached[j].Name
+	})
+	return attached
+}
@@ -20,19 +20,43 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/flags"
 )
 
 func TestGetBackendConfigForServicePort(t *testing.T) {
+	now := time.Now()
+	attachedConfig := func(namespace, name, target string, created time.Time) *backendconfigv1.BackendConfig {
+		return &backendconfigv1.BackendConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec: backendconfigv1.BackendConfigSpec{
+				TargetRef: &backendconfigv1.PolicyTargetReference{Kind: "Service", Name: target},
+			},
+		}
+	}
+	oldestAttached := attachedConfig("test", "newer-name-older", "svc-no-config", now.Add(-time.Hour))
+	attachedConfigs := func() []interface{} {
+		return []interface{}{
+			attachedConfig("test", "a-newer", "svc-no-config", now),
+			attachedConfig("other", "other-namespace", "svc-no-config", now.Add(-2*time.Hour)),
+			attachedConfig("test", "other-service", "svc", now.Add(-2*time.Hour)),
+			oldestAttached,
+		}
+	}
+
 	testCases := []struct {
 		desc           string
 		svc            *apiv1.Service
 		svcPort        *apiv1.ServicePort
 		getFunc        func(obj interface{}) (item interface{}, exists bool, err error)
+		listFunc       func() []interface{}
+		enableRef      bool
 		expectedConfig *backendconfigv1.BackendConfig
 		expectedErr    error
 	}{
@@ -77,6 +101,21 @@ func TestGetBackendConfigForServicePort(t *testing.T) {
 			svc:            SvcWithoutConfig,
 			expectedConfig: nil,
 		},
+		{
+			desc:           "service with no backend config and an attached backend config",
+			svc:            SvcWithoutConfig,
+			svcPort:        &apiv1.ServicePort{Name: "port1"},
+			listFunc:       attachedConfigs,
+			enableRef:      true,
+			expectedConfig: oldestAttached,
+		},
+		{
+			desc:           "service with no backend config and an attached backend config, targetRef disabled",
+			svc:            SvcWithoutConfig,
+			svcPort:        &apiv1.ServicePort{Name: "port1"},
+			listFunc:       attachedConfigs,
+			expectedConfig: nil,
+		},
 		{
 			desc:        "service with backend config but port doesn't match",
 			svc:         SvcWithTestConfigMismatchPort,
@@ -103,9 +142,12 @@ func TestGetBackendConfigForServicePort(t *testing.T) {
 		},
 	}
 
+	defer func(enabled bool) { flags.F.EnableBackendConfigTargetRef = enabled }(flags.F.EnableBackendConfigTargetRef)
 	for _, tc := range testCases {
+		flags.F.EnableBackendConfigTargetRef = tc.enableRef
 		fakeStore := &cache.FakeCustomStore{
-			GetFunc: tc.getFunc,
+			GetFunc:  tc.getFunc,
+			ListFunc: tc.listFunc,
 		}
 		config, err := GetBackendConfigForServicePort(fakeStore, tc.svc, tc.svcPort)
 		if !reflect.DeepEqual(config, tc.expectedConfig) || tc.expectedErr != err {
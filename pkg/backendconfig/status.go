@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+)
+
+// SetCondition sets the condition of the same type in the status. The last
+// transition time of the existing condition is kept if its status is
+// unchanged.
+func SetCondition(status *backendconfigv1.BackendConfigStatus, condition backendconfigv1.Condition) {
+	for i, c := range status.Conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		status.Conditions[i] = condition
+		return
+	}
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// PatchStatus patches the status of the BackendConfig through its status
+// subresource. It is a no-op if the status is unchanged.
+func PatchStatus(client backendconfigclient.Interface, beConfig *backendconfigv1.BackendConfig, newStatus backendconfigv1.BackendConfigStatus) error {
+	if reflect.DeepEqual(beConfig.Status, newStatus) {
+		return nil
+	}
+	patchBytes, err := patch.MergePatchBytes(backendconfigv1.BackendConfig{Status: beConfig.Status}, backendconfigv1.BackendConfig{Status: newStatus})
+	if err != nil {
+		return fmt.Errorf("failed to prepare patch bytes: %w", err)
+	}
+	_, err = client.CloudV1().BackendConfigs(beConfig.Namespace).Patch(context.TODO(), beConfig.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, "status")
+	return err
+}
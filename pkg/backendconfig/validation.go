@@ -38,6 +38,35 @@ var supportedAffinities = map[string]bool{
 	"GENERATED_COOKIE": true,
 }
 
+// supportedLocalityLbPolicies are the locality load balancing policies of
+// the backend services of HTTP(S) load balancers. WEIGHTED_MAGLEV is only
+// supported by external passthrough Network Load Balancers.
+var supportedLocalityLbPolicies = map[string]bool{
+	"ROUND_ROBIN":   true,
+	"LEAST_REQUEST": true,
+	"RING_HASH":     true,
+	"MAGLEV":        true,
+}
+
+// supportedBalancingModes are the balancing modes GCE accepts for the
+// GCE_VM_IP_PORT NEG backends of HTTP(S) load balancers.
+var supportedBalancingModes = map[string]bool{
+	"RATE": true,
+}
+
+var supportedRetryConditions = map[string]bool{
+	"5xx":                true,
+	"gateway-error":      true,
+	"connect-failure":    true,
+	"retriable-4xx":      true,
+	"refused-stream":     true,
+	"cancelled":          true,
+	"deadline-exceeded":  true,
+	"internal":           true,
+	"resource-exhausted": true,
+	"unavailable":        true,
+}
+
 func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
 	if beConfig == nil {
 		return nil
@@ -59,6 +88,26 @@ func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.Backend
 		return err
 	}
 
+	if err := validateRouteActions(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateOutlierDetection(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateCircuitBreakers(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateLocalityLbPolicy(beConfig, servicePort); err != nil {
+		return err
+	}
+
+	if err := validateCapacity(beConfig, servicePort); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -156,3 +205,219 @@ func validateCDN(kubeClient kubernetes.Interface, beConfig *backendconfigv1.Back
 
 	return nil
 }
+
+// validateRouteActions validates the BackendConfig settings that are
+// translated into URL map route actions: retry policy, fault injection and
+// request mirroring.
+func validateRouteActions(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	spec := beConfig.Spec
+	if spec.RetryPolicy == nil && spec.FaultInjection == nil && spec.RequestMirror == nil {
+		return nil
+	}
+	if servicePort != nil && !servicePort.L7ILBEnabled && !servicePort.L7XLBRegionalEnabled {
+		return fmt.Errorf("retryPolicy, faultInjection and requestMirror are only supported for internal and regional external Ingresses")
+	}
+
+	if spec.RetryPolicy != nil {
+		for _, cond := range spec.RetryPolicy.RetryConditions {
+			if !supportedRetryConditions[cond] {
+				return fmt.Errorf("unsupported RetryCondition: %q", cond)
+			}
+		}
+		if spec.RetryPolicy.NumRetries != nil && *spec.RetryPolicy.NumRetries <= 0 {
+			return fmt.Errorf("unsupported NumRetries: %d, should be greater than 0", *spec.RetryPolicy.NumRetries)
+		}
+		if spec.RetryPolicy.PerTryTimeoutSec != nil && *spec.RetryPolicy.PerTryTimeoutSec <= 0 {
+			return fmt.Errorf("unsupported PerTryTimeoutSec: %d, should be greater than 0", *spec.RetryPolicy.PerTryTimeoutSec)
+		}
+	}
+
+	if spec.FaultInjection != nil {
+		if delay := spec.FaultInjection.Delay; delay != nil {
+			if delay.FixedDelaySec < 0 {
+				return fmt.Errorf("unsupported FixedDelaySec: %d, should not be negative", delay.FixedDelaySec)
+			}
+			if delay.Percentage < 0.0 || delay.Percentage > 100.0 {
+				return fmt.Errorf("unsupported delay Percentage: %f, should be between 0.0 and 100.0", delay.Percentage)
+			}
+		}
+		if abort := spec.FaultInjection.Abort; abort != nil {
+			if abort.HttpStatus < 200 || abort.HttpStatus > 599 {
+				return fmt.Errorf("unsupported abort HttpStatus: %d, should be between 200 and 599", abort.HttpStatus)
+			}
+			if abort.Percentage < 0.0 || abort.Percentage > 100.0 {
+				return fmt.Errorf("unsupported abort Percentage: %f, should be between 0.0 and 100.0", abort.Percentage)
+			}
+		}
+	}
+
+	if spec.RequestMirror != nil {
+		if spec.RequestMirror.ServiceName == "" {
+			return fmt.Errorf("requestMirror.serviceName must be set")
+		}
+		if (spec.RequestMirror.ServicePort == 0) == (spec.RequestMirror.ServicePortName == "") {
+			return fmt.Errorf("exactly one of requestMirror.servicePort and requestMirror.servicePortName must be set")
+		}
+	}
+
+	return nil
+}
+
+func validateOutlierDetection(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	od := beConfig.Spec.OutlierDetection
+	if od == nil {
+		return nil
+	}
+	if servicePort != nil && !servicePort.L7ILBEnabled && !servicePort.L7XLBRegionalEnabled {
+		return fmt.Errorf("outlierDetection is only supported for internal and regional external Ingresses")
+	}
+
+	for _, f := range []struct {
+		name string
+		val  *int64
+	}{
+		{"ConsecutiveErrors", od.ConsecutiveErrors},
+		{"ConsecutiveGatewayFailure", od.ConsecutiveGatewayFailure},
+		{"IntervalSec", od.IntervalSec},
+		{"BaseEjectionTimeSec", od.BaseEjectionTimeSec},
+	} {
+		if f.val != nil && *f.val <= 0 {
+			return fmt.Errorf("unsupported %s: %d, should be greater than 0", f.name, *f.val)
+		}
+	}
+	for _, f := range []struct {
+		name string
+		val  *int64
+	}{
+		{"EnforcingConsecutiveErrors", od.EnforcingConsecutiveErrors},
+		{"EnforcingConsecutiveGatewayFailure", od.EnforcingConsecutiveGatewayFailure},
+		{"MaxEjectionPercent", od.MaxEjectionPercent},
+	} {
+		if f.val != nil && (*f.val < 0 || *f.val > 100) {
+			return fmt.Errorf("unsupported %s: %d, should be between 0 and 100", f.name, *f.val)
+		}
+	}
+
+	return nil
+}
+
+func validateCircuitBreakers(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	cb := beConfig.Spec.CircuitBreakers
+	if cb == nil {
+		return nil
+	}
+	if servicePort != nil && !servicePort.L7ILBEnabled && !servicePort.L7XLBRegionalEnabled {
+		return fmt.Errorf("circuitBreakers is only supported for internal and regional external Ingresses")
+	}
+
+	for _, f := range []struct {
+		name string
+		val  *int64
+	}{
+		{"MaxConnections", cb.MaxConnections},
+		{"MaxPendingRequests", cb.MaxPendingRequests},
+		{"MaxRequests", cb.MaxRequests},
+		{"MaxRequestsPerConnection", cb.MaxRequestsPerConnection},
+		{"MaxRetries", cb.MaxRetries},
+	} {
+		if f.val != nil && *f.val <= 0 {
+			return fmt.Errorf("unsupported %s: %d, should be greater than 0", f.name, *f.val)
+		}
+	}
+
+	return nil
+}
+
+func validateLocalityLbPolicy(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	spec := beConfig.Spec
+	if spec.LocalityLbPolicy == nil && spec.ConsistentHash == nil {
+		return nil
+	}
+	if servicePort != nil && !servicePort.L7ILBEnabled && !servicePort.L7XLBRegionalEnabled {
+		return fmt.Errorf("localityLbPolicy and consistentHash are only supported for internal and regional external Ingresses")
+	}
+
+	var policy string
+	if spec.LocalityLbPolicy != nil {
+		policy = *spec.LocalityLbPolicy
+		if !supportedLocalityLbPolicies[policy] {
+			return fmt.Errorf("unsupported LocalityLbPolicy: %s, should be one of ROUND_ROBIN, LEAST_REQUEST, RING_HASH or MAGLEV", policy)
+		}
+	}
+
+	hash := spec.ConsistentHash
+	if hash == nil {
+		return nil
+	}
+	if policy != "RING_HASH" && policy != "MAGLEV" {
+		return fmt.Errorf("consistentHash requires LocalityLbPolicy RING_HASH or MAGLEV, got %q", policy)
+	}
+	if spec.SessionAffinity != nil {
+		return fmt.Errorf("consistentHash and sessionAffinity cannot be specified at the same time")
+	}
+	keys := 0
+	if hash.HttpHeaderName != "" {
+		keys++
+	}
+	if hash.HttpCookie != nil {
+		keys++
+		if hash.HttpCookie.Name == "" {
+			return fmt.Errorf("consistentHash.httpCookie.name must be set")
+		}
+		if hash.HttpCookie.TtlSec != nil && *hash.HttpCookie.TtlSec < 0 {
+			return fmt.Errorf("unsupported consistentHash.httpCookie.ttlSec: %d, should not be negative", *hash.HttpCookie.TtlSec)
+		}
+	}
+	if hash.UseSourceIp {
+		keys++
+	}
+	if keys != 1 {
+		return fmt.Errorf("exactly one of consistentHash.httpHeaderName, consistentHash.httpCookie and consistentHash.useSourceIp must be set")
+	}
+	if hash.MinimumRingSize != nil {
+		if policy != "RING_HASH" {
+			return fmt.Errorf("consistentHash.minimumRingSize requires LocalityLbPolicy RING_HASH")
+		}
+		if *hash.MinimumRingSize <= 0 {
+			return fmt.Errorf("unsupported consistentHash.minimumRingSize: %d, should be greater than 0", *hash.MinimumRingSize)
+		}
+	}
+
+	return nil
+}
+
+func validateCapacity(beConfig *backendconfigv1.BackendConfig, servicePort *utils.ServicePort) error {
+	c := beConfig.Spec.Capacity
+	if c == nil {
+		return nil
+	}
+	if servicePort != nil && (!servicePort.NEGEnabled || servicePort.ExternalNEG != nil) {
+		return fmt.Errorf("capacity is only supported for backends with container-native load balancing")
+	}
+
+	if c.BalancingMode != nil && !supportedBalancingModes[*c.BalancingMode] {
+		return fmt.Errorf("unsupported capacity.balancingMode: %s, the NEG backends of HTTP(S) load balancers only support RATE", *c.BalancingMode)
+	}
+	if c.MaxRatePerEndpoint != nil && *c.MaxRatePerEndpoint <= 0 {
+		return fmt.Errorf("unsupported capacity.maxRatePerEndpoint: %v, should be greater than 0", *c.MaxRatePerEndpoint)
+	}
+	if c.CapacityScaler != nil && (*c.CapacityScaler < 0 || *c.CapacityScaler > 1) {
+		return fmt.Errorf("unsupported capacity.capacityScaler: %v, should be between 0.0 and 1.0", *c.CapacityScaler)
+	}
+
+	zones := map[string]bool{}
+	for _, z := range c.Zones {
+		if z.Zone == "" {
+			return fmt.Errorf("capacity.zones[].zone must be set")
+		}
+		if zones[z.Zone] {
+			return fmt.Errorf("duplicate zone %q in capacity.zones", z.Zone)
+		}
+		zones[z.Zone] = true
+		if z.CapacityScaler < 0 || z.CapacityScaler > 1 {
+			return fmt.Errorf("unsupported capacityScaler %v of zone %q, should be between 0.0 and 1.0", z.CapacityScaler, z.Zone)
+		}
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	testutils "k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/utils/ptr"
 )
 
 var (
@@ -479,3 +480,467 @@ func TestValidateCDN(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRouteActions(t *testing.T) {
+	ilbPort := &utils.ServicePort{L7ILBEnabled: true}
+	for _, tc := range []struct {
+		desc        string
+		spec        backendconfigv1.BackendConfigSpec
+		servicePort *utils.ServicePort
+		expectError bool
+	}{
+		{
+			desc:        "no route action settings",
+			spec:        backendconfigv1.BackendConfigSpec{},
+			servicePort: &utils.ServicePort{},
+		},
+		{
+			desc: "valid retry policy",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{
+					RetryConditions:  []string{"5xx", "connect-failure"},
+					NumRetries:       testutils.Int64ToPtr(3),
+					PerTryTimeoutSec: testutils.Int64ToPtr(5),
+				},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "retry policy on regional external Ingress",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{RetryConditions: []string{"5xx"}},
+			},
+			servicePort: &utils.ServicePort{L7XLBRegionalEnabled: true},
+		},
+		{
+			desc: "retry policy on global external Ingress",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{RetryConditions: []string{"5xx"}},
+			},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc: "unsupported retry condition",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{RetryConditions: []string{"always"}},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "zero retries",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{NumRetries: testutils.Int64ToPtr(0)},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "negative per try timeout",
+			spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{PerTryTimeoutSec: testutils.Int64ToPtr(-1)},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "valid fault injection",
+			spec: backendconfigv1.BackendConfigSpec{
+				FaultInjection: &backendconfigv1.FaultInjectionConfig{
+					Delay: &backendconfigv1.FaultDelayConfig{FixedDelaySec: 2, Percentage: 50},
+					Abort: &backendconfigv1.FaultAbortConfig{HttpStatus: 503, Percentage: 10.5},
+				},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "delay percentage out of range",
+			spec: backendconfigv1.BackendConfigSpec{
+				FaultInjection: &backendconfigv1.FaultInjectionConfig{
+					Delay: &backendconfigv1.FaultDelayConfig{FixedDelaySec: 2, Percentage: 100.1},
+				},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "abort status out of range",
+			spec: backendconfigv1.BackendConfigSpec{
+				FaultInjection: &backendconfigv1.FaultInjectionConfig{
+					Abort: &backendconfigv1.FaultAbortConfig{HttpStatus: 600, Percentage: 10},
+				},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "valid request mirror",
+			spec: backendconfigv1.BackendConfigSpec{
+				RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow", ServicePort: 80},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "request mirror without service name",
+			spec: backendconfigv1.BackendConfigSpec{
+				RequestMirror: &backendconfigv1.RequestMirrorConfig{ServicePort: 80},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "request mirror with port number and name",
+			spec: backendconfigv1.BackendConfigSpec{
+				RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow", ServicePort: 80, ServicePortName: "http"},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "request mirror without port",
+			spec: backendconfigv1.BackendConfigSpec{
+				RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow"},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: tc.spec,
+			}
+			kubeClient := fake.NewSimpleClientset()
+			err := Validate(kubeClient, beConfig, tc.servicePort)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateOutlierDetectionAndCircuitBreakers(t *testing.T) {
+	ilbPort := &utils.ServicePort{L7ILBEnabled: true}
+	for _, tc := range []struct {
+		desc        string
+		spec        backendconfigv1.BackendConfigSpec
+		servicePort *utils.ServicePort
+		expectError bool
+	}{
+		{
+			desc: "valid outlier detection",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+					ConsecutiveErrors:  testutils.Int64ToPtr(5),
+					IntervalSec:        testutils.Int64ToPtr(10),
+					MaxEjectionPercent: testutils.Int64ToPtr(0),
+				},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "outlier detection on global external Ingress",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{ConsecutiveErrors: testutils.Int64ToPtr(5)},
+			},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc: "zero consecutive errors",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{ConsecutiveErrors: testutils.Int64ToPtr(0)},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "max ejection percent out of range",
+			spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{MaxEjectionPercent: testutils.Int64ToPtr(101)},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "valid circuit breakers",
+			spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+					MaxConnections:     testutils.Int64ToPtr(100),
+					MaxPendingRequests: testutils.Int64ToPtr(10),
+				},
+			},
+			servicePort: &utils.ServicePort{L7XLBRegionalEnabled: true},
+		},
+		{
+			desc: "circuit breakers on global external Ingress",
+			spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{MaxConnections: testutils.Int64ToPtr(100)},
+			},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc: "zero max requests",
+			spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{MaxRequests: testutils.Int64ToPtr(0)},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: tc.spec,
+			}
+			kubeClient := fake.NewSimpleClientset()
+			err := Validate(kubeClient, beConfig, tc.servicePort)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateLocalityLbPolicy(t *testing.T) {
+	ilbPort := &utils.ServicePort{L7ILBEnabled: true}
+	ringHash := "RING_HASH"
+	maglev := "MAGLEV"
+	leastRequest := "LEAST_REQUEST"
+	invalid := "RANDOM_ROBIN"
+	weightedMaglev := "WEIGHTED_MAGLEV"
+	for _, tc := range []struct {
+		desc        string
+		spec        backendconfigv1.BackendConfigSpec
+		servicePort *utils.ServicePort
+		expectError bool
+	}{
+		{
+			desc:        "valid policy",
+			spec:        backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			servicePort: ilbPort,
+		},
+		{
+			desc:        "policy on global external Ingress",
+			spec:        backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc:        "unsupported policy",
+			spec:        backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &invalid},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc:        "policy of Network Load Balancers",
+			spec:        backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &weightedMaglev},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "valid header hash",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpHeaderName:  "x-user",
+					MinimumRingSize: testutils.Int64ToPtr(2048),
+				},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "valid cookie hash",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &maglev,
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpCookie: &backendconfigv1.ConsistentHashCookieConfig{Name: "session"},
+				},
+			},
+			servicePort: ilbPort,
+		},
+		{
+			desc: "consistent hash without hash based policy",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &leastRequest,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{UseSourceIp: true},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "consistent hash with session affinity",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{UseSourceIp: true},
+				SessionAffinity:  &backendconfigv1.SessionAffinityConfig{AffinityType: "CLIENT_IP"},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "multiple hash keys",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{HttpHeaderName: "x-user", UseSourceIp: true},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "no hash key",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "cookie without name",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpCookie: &backendconfigv1.ConsistentHashCookieConfig{},
+				},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+		{
+			desc: "minimum ring size with maglev",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &maglev,
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					UseSourceIp:     true,
+					MinimumRingSize: testutils.Int64ToPtr(2048),
+				},
+			},
+			servicePort: ilbPort,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: tc.spec,
+			}
+			kubeClient := fake.NewSimpleClientset()
+			err := Validate(kubeClient, beConfig, tc.servicePort)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCapacity(t *testing.T) {
+	negPort := &utils.ServicePort{NEGEnabled: true}
+	rate := "RATE"
+	connection := "CONNECTION"
+	utilization := "UTILIZATION"
+	invalid := "REQUESTS"
+	for _, tc := range []struct {
+		desc        string
+		capacity    *backendconfigv1.CapacityConfig
+		servicePort *utils.ServicePort
+		expectError bool
+	}{
+		{
+			desc: "valid rate",
+			capacity: &backendconfigv1.CapacityConfig{
+				BalancingMode:      &rate,
+				MaxRatePerEndpoint: ptr.To(50.0),
+				CapacityScaler:     ptr.To(0.5),
+				Zones:              []backendconfigv1.ZoneCapacityConfig{{Zone: "zone-a", CapacityScaler: 0}},
+			},
+			servicePort: negPort,
+		},
+		{
+			desc:        "instance group backend",
+			capacity:    &backendconfigv1.CapacityConfig{CapacityScaler: ptr.To(0.5)},
+			servicePort: &utils.ServicePort{},
+			expectError: true,
+		},
+		{
+			desc:        "unsupported balancing mode",
+			capacity:    &backendconfigv1.CapacityConfig{BalancingMode: &invalid},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc:        "connection balancing mode is not supported by GCE_VM_IP_PORT NEGs",
+			capacity:    &backendconfigv1.CapacityConfig{BalancingMode: &connection},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc:        "utilization balancing mode is not supported by GCE_VM_IP_PORT NEGs",
+			capacity:    &backendconfigv1.CapacityConfig{BalancingMode: &utilization},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc:        "max rate out of range",
+			capacity:    &backendconfigv1.CapacityConfig{MaxRatePerEndpoint: ptr.To(0.0)},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc:        "capacity scaler out of range",
+			capacity:    &backendconfigv1.CapacityConfig{CapacityScaler: ptr.To(1.5)},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc: "duplicate zone",
+			capacity: &backendconfigv1.CapacityConfig{
+				Zones: []backendconfigv1.ZoneCapacityConfig{{Zone: "zone-a"}, {Zone: "zone-a", CapacityScaler: 1}},
+			},
+			servicePort: negPort,
+			expectError: true,
+		},
+		{
+			desc: "zone capacity scaler out of range",
+			capacity: &backendconfigv1.CapacityConfig{
+				Zones: []backendconfigv1.ZoneCapacityConfig{{Zone: "zone-a", CapacityScaler: -1}},
+			},
+			servicePort: negPort,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: backendconfigv1.BackendConfigSpec{Capacity: tc.capacity},
+			}
+			kubeClient := fake.NewSimpleClientset()
+			err := Validate(kubeClient, beConfig, tc.servicePort)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect error but got: %v", err)
+			}
+		})
+	}
+}
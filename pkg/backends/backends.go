@@ -16,6 +16,7 @@ package backends
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
@@ -26,14 +27,20 @@ import (
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/network"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
 )
 
 const (
+	// backendServicesResource is the collection of backend services, as used
+	// in resource paths.
+	backendServicesResource = "backendServices"
+
 	DefaultConnectionDrainingTimeoutSeconds = 30
 	defaultTrackingMode                     = "PER_CONNECTION"
 	PerSessionTrackingMode                  = "PER_SESSION" // the only one supported with strong session affinity
@@ -63,6 +70,11 @@ type Pool struct {
 	cloud                       *gce.Cloud
 	namer                       namer.BackendNamer
 	useConnectionTrackingPolicy bool
+	// plan records the GCE mutations instead of making them in plan mode.
+	plan *plan.Recorder
+	// drift detects the changes made to the backend services outside of the
+	// controller.
+	drift *drift.Detector
 }
 
 // NewPool returns a new backend pool.
@@ -88,6 +100,19 @@ func NewPoolWithConnectionTrackingPolicy(cloud *gce.Cloud, namer namer.BackendNa
 	}
 }
 
+// NewPoolWithPlan returns a new backend pool.
+// It is similar to NewPool() but records the GCE mutations instead of making
+// them if planRecorder is non-nil, and detects the changes made outside of
+// the controller if driftDetector is non-nil.
+func NewPoolWithPlan(cloud *gce.Cloud, namer namer.BackendNamer, planRecorder *plan.Recorder, driftDetector *drift.Detector) *Pool {
+	return &Pool{
+		cloud: cloud,
+		namer: namer,
+		plan:  planRecorder,
+		drift: driftDetector,
+	}
+}
+
 // L4BackendServiceParams encapsulates parameters for ensuring an L4 BackendService.
 type L4BackendServiceParams struct {
 	Name                     string
@@ -136,6 +161,12 @@ func selectApiVersionForUpdate(current, expected meta.Version) meta.Version {
 func ensureDescription(be *composite.BackendService, sp *utils.ServicePort) (needsUpdate bool) {
 	desc := sp.GetDescription()
 	features.SetDescription(&desc, sp)
+	if features.HasFeature(be.Description, features.FeatureCapacity) && !features.HasFeature(desc.String(), features.FeatureCapacity) {
+		// The capacity marker is cleared by the NEG linker, once it reverted
+		// the capacity of the backends.
+		desc.XFeatures = append(desc.XFeatures, features.FeatureCapacity)
+		sort.Strings(desc.XFeatures)
+	}
 	descString := desc.String()
 	if be.Description == descString {
 		return false
@@ -168,6 +199,11 @@ func (p *Pool) Create(sp utils.ServicePort, hcLink string, beLogger klog.Logger)
 		},
 	}
 
+	if hcLink == "" {
+		// The backend services of external NEGs have no health check.
+		be.HealthChecks = nil
+	}
+
 	if sp.L7ILBEnabled {
 		// This enables l7-ILB and advanced traffic management features
 		be.LoadBalancingScheme = "INTERNAL_MANAGED"
@@ -182,6 +218,12 @@ func (p *Pool) Create(sp utils.ServicePort, hcLink string, beLogger klog.Logger)
 		return nil, err
 	}
 
+	if p.plan.Enabled() {
+		beLogger.V(2).Info("Skipping backend service creation in plan mode")
+		be.SelfLink = plan.SelfLink(p.cloud.ProjectID(), backendServicesResource, key)
+		p.plan.Record(plan.Create, backendServicesResource, key, nil, be)
+		return be, nil
+	}
 	if err := composite.CreateBackendService(p.cloud, key, be, beLogger); err != nil {
 		return nil, err
 	}
@@ -204,10 +246,28 @@ func (p *Pool) Update(be *composite.BackendService, beLogger klog.Logger) error
 	if err != nil {
 		return err
 	}
-	if err := composite.UpdateBackendService(p.cloud, key, be, beLogger); err != nil {
-		return err
+	return p.update(key, be, beLogger)
+}
+
+// update updates the BackendService of the key, or records the update in
+// plan mode.
+func (p *Pool) update(key *meta.Key, be *composite.BackendService, beLogger klog.Logger) error {
+	if p.plan.Enabled() {
+		beLogger.V(2).Info("Skipping backend service update in plan mode")
+		current, _ := composite.GetBackendService(p.cloud, key, be.Version, beLogger)
+		p.plan.Record(plan.Update, backendServicesResource, key, current, be)
+		return nil
 	}
-	return nil
+	return composite.UpdateBackendService(p.cloud, key, be, beLogger)
+}
+
+// get returns the BackendService of the key, or the planned BackendService
+// in plan mode.
+func (p *Pool) get(key *meta.Key, version meta.Version, beLogger klog.Logger) (*composite.BackendService, error) {
+	if obj, ok := p.plan.Planned(backendServicesResource, key); ok && obj != nil {
+		return obj.(*composite.BackendService), nil
+	}
+	return composite.GetBackendService(p.cloud, key, version, beLogger)
 }
 
 // Get a composite BackendService given a required version.
@@ -216,7 +276,7 @@ func (p *Pool) Get(name string, version meta.Version, scope meta.KeyType, beLogg
 	if err != nil {
 		return nil, err
 	}
-	be, err := composite.GetBackendService(p.cloud, key, version, beLogger)
+	be, err := p.get(key, version, beLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +303,13 @@ func (p *Pool) Delete(name string, version meta.Version, scope meta.KeyType, beL
 		return err
 	}
 	beLogger = beLogger.WithValues("backendKey", key)
+	if p.plan.Enabled() {
+		if _, err := composite.GetBackendService(p.cloud, key, version, beLogger); err == nil {
+			beLogger.V(2).Info("Skipping backend service deletion in plan mode")
+			p.plan.Record(plan.Delete, backendServicesResource, key, nil, nil)
+		}
+		return nil
+	}
 	err = composite.DeleteBackendService(p.cloud, key, version, beLogger)
 	if err != nil {
 		if utils.IsHTTPErrorCode(err, http.StatusNotFound) || utils.IsInUsedByError(err) {
@@ -253,6 +320,7 @@ func (p *Pool) Delete(name string, version meta.Version, scope meta.KeyType, beL
 		beLogger.Error(err, "DeleteBackendService()")
 		return err
 	}
+	p.drift.Forget(backendServicesResource, key)
 	beLogger.Info("DeleteBackendService() ok")
 	return nil
 }
@@ -342,6 +410,11 @@ func (p *Pool) AddSignedURLKey(be *composite.BackendService, signedurlkey *compo
 	if err != nil {
 		return err
 	}
+	if p.plan.Enabled() {
+		urlKeyLogger.V(2).Info("Skipping SignedUrlKey addition in plan mode")
+		p.plan.Record(plan.Update, backendServicesResource, key, be, withSignedURLKeyNames(be, append(signedURLKeyNames(be), signedurlkey.KeyName)))
+		return nil
+	}
 	if err := composite.AddSignedUrlKey(p.cloud, key, be, signedurlkey, urlKeyLogger); err != nil {
 		return err
 	}
@@ -361,12 +434,45 @@ func (p *Pool) DeleteSignedURLKey(be *composite.BackendService, keyName string,
 	if err != nil {
 		return err
 	}
+	if p.plan.Enabled() {
+		urlKeyLogger.V(2).Info("Skipping SignedUrlKey deletion in plan mode")
+		var keyNames []string
+		for _, name := range signedURLKeyNames(be) {
+			if name != keyName {
+				keyNames = append(keyNames, name)
+			}
+		}
+		p.plan.Record(plan.Update, backendServicesResource, key, be, withSignedURLKeyNames(be, keyNames))
+		return nil
+	}
 	if err := composite.DeleteSignedUrlKey(p.cloud, key, be, keyName, urlKeyLogger); err != nil {
 		return err
 	}
 	return nil
 }
 
+// signedURLKeyNames returns a copy of the names of the SignedUrlKeys of the
+// BackendService.
+func signedURLKeyNames(be *composite.BackendService) []string {
+	if be.CdnPolicy == nil {
+		return nil
+	}
+	return append([]string{}, be.CdnPolicy.SignedUrlKeyNames...)
+}
+
+// withSignedURLKeyNames returns a copy of the BackendService with the given
+// SignedUrlKey names, to plan the addition or deletion of a SignedUrlKey.
+func withSignedURLKeyNames(be *composite.BackendService, keyNames []string) *composite.BackendService {
+	desired := *be
+	cdnPolicy := composite.BackendServiceCdnPolicy{}
+	if be.CdnPolicy != nil {
+		cdnPolicy = *be.CdnPolicy
+	}
+	cdnPolicy.SignedUrlKeyNames = keyNames
+	desired.CdnPolicy = &cdnPolicy
+	return &desired
+}
+
 // apiVersionRequiredbyServiceFeatures to create a backend service with the given params
 func apiVersionRequiredbyServiceFeatures(params L4BackendServiceParams) meta.Version {
 	if params.EnableZonalAffinity {
@@ -9,8 +9,11 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/network"
@@ -1351,3 +1354,30 @@ func TestVersionSelectionInUpdate(t *testing.T) {
 		})
 	}
 }
+
+// TestEnsureDescriptionCapacity checks that the description keeps marking
+// the backend capacity as managed by the controller after the capacity
+// section is removed, until the NEG linker reverted the capacity.
+func TestEnsureDescriptionCapacity(t *testing.T) {
+	sp := &utils.ServicePort{
+		ID:         utils.ServicePortID{Service: types.NamespacedName{Namespace: "ns", Name: "svc"}, Port: networkingv1.ServiceBackendPort{Number: 80}},
+		NEGEnabled: true,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{Capacity: &backendconfigv1.CapacityConfig{}},
+		},
+	}
+	be := &composite.BackendService{}
+	if !ensureDescription(be, sp) || !features.HasFeature(be.Description, features.FeatureCapacity) {
+		t.Fatalf("Got description %q, want the %s feature", be.Description, features.FeatureCapacity)
+	}
+
+	sp.BackendConfig = nil
+	if ensureDescription(be, sp) || !features.HasFeature(be.Description, features.FeatureCapacity) {
+		t.Errorf("Got description %q, want the %s feature kept after removing the capacity section", be.Description, features.FeatureCapacity)
+	}
+
+	be.Description = features.SetFeature(be.Description, features.FeatureCapacity, false)
+	if ensureDescription(be, sp) || features.HasFeature(be.Description, features.FeatureCapacity) {
+		t.Errorf("Got description %q, want no %s feature", be.Description, features.FeatureCapacity)
+	}
+}
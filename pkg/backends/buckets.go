@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+const backendBucketsResource = "backendBuckets"
+
+// BucketSyncer applies the settings of the BackendConfigs attached to the
+// backend buckets that serve the paths of Ingresses. The backend buckets
+// themselves, and the Cloud Storage buckets they serve, are managed outside
+// of the controller.
+type BucketSyncer struct {
+	buckets BackendBuckets
+	plan    *plan.Recorder
+}
+
+// NewBucketSyncer returns a BucketSyncer that records the updates of the
+// backend buckets in plan instead of making them, if plan mode is enabled.
+func NewBucketSyncer(buckets BackendBuckets, plan *plan.Recorder) *BucketSyncer {
+	return &BucketSyncer{buckets: buckets, plan: plan}
+}
+
+// Sync applies the CDN settings of the BackendConfigs of the given backend
+// buckets. Backend buckets without a BackendConfig are left untouched.
+func (s *BucketSyncer) Sync(buckets []utils.BackendBucket, ingLogger klog.Logger) error {
+	for _, bucket := range buckets {
+		if bucket.BackendConfig == nil || bucket.BackendConfig.Spec.Cdn == nil {
+			continue
+		}
+		bucketLogger := ingLogger.WithValues("backendBucket", bucket.Name)
+		bucketLogger.Info("Sync backend bucket")
+		bb, err := s.buckets.GetBackendBucket(bucket.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get backend bucket %q: %w", bucket.Name, err)
+		}
+		current := *bb
+		needUpdate, err := features.EnsureBackendBucketCDN(bucket, bb, bucketLogger)
+		if err != nil {
+			return err
+		}
+		if !needUpdate {
+			continue
+		}
+		if s.plan.Enabled() {
+			bucketLogger.V(2).Info("Skipping backend bucket update in plan mode")
+			s.plan.Record(plan.Update, backendBucketsResource, meta.GlobalKey(bucket.Name), &current, bb)
+			continue
+		}
+		if err := s.buckets.PatchBackendBucket(bb); err != nil {
+			return fmt.Errorf("failed to update backend bucket %q: %w", bucket.Name, err)
+		}
+	}
+	return nil
+}
+
+// gceBackendBuckets implements BackendBuckets with the GA compute API.
+type gceBackendBuckets struct {
+	cloud  *gce.Cloud
+	logger klog.Logger
+}
+
+// NewBackendBuckets returns the BackendBuckets of the project of the given
+// cloud.
+func NewBackendBuckets(cloud *gce.Cloud, logger klog.Logger) BackendBuckets {
+	return &gceBackendBuckets{cloud: cloud, logger: logger}
+}
+
+// GetBackendBucket implements BackendBuckets.
+func (b *gceBackendBuckets) GetBackendBucket(name string) (*compute.BackendBucket, error) {
+	return composite.GetBackendBucket(b.cloud, meta.GlobalKey(name), b.logger)
+}
+
+// PatchBackendBucket implements BackendBuckets.
+func (b *gceBackendBuckets) PatchBackendBucket(bucket *compute.BackendBucket) error {
+	return composite.PatchBackendBucket(b.cloud, meta.GlobalKey(bucket.Name), bucket, b.logger)
+}
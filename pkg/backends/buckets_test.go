@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestBucketSyncerSync(t *testing.T) {
+	cdnBucket := utils.BackendBucket{
+		Name: "assets",
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{Cdn: &backendconfigv1.CDNConfig{Enabled: true}},
+		},
+	}
+	for _, tc := range []struct {
+		desc        string
+		buckets     []utils.BackendBucket
+		plan        bool
+		wantPatches int
+	}{
+		{
+			desc:        "CDN enabled by the BackendConfig",
+			buckets:     []utils.BackendBucket{cdnBucket},
+			wantPatches: 1,
+		},
+		{
+			desc:    "backend bucket without BackendConfig",
+			buckets: []utils.BackendBucket{{Name: "assets"}},
+		},
+		{
+			desc:    "plan mode",
+			buckets: []utils.BackendBucket{cdnBucket},
+			plan:    true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fake := NewFakeBackendBuckets(&compute.BackendBucket{Name: "assets"})
+			var recorder *plan.Recorder
+			if tc.plan {
+				recorder = plan.NewRecorder()
+			}
+			syncer := NewBucketSyncer(fake, recorder)
+			if err := syncer.Sync(tc.buckets, klog.TODO()); err != nil {
+				t.Fatalf("Sync() = %v", err)
+			}
+			if fake.Patches != tc.wantPatches {
+				t.Errorf("Got %d patches, want %d", fake.Patches, tc.wantPatches)
+			}
+			if tc.plan {
+				if got := len(recorder.Flush()); got != 1 {
+					t.Errorf("Got %d planned operations, want 1", got)
+				}
+			}
+
+			// A second sync finds the backend bucket up to date.
+			if err := syncer.Sync(tc.buckets, klog.TODO()); err != nil {
+				t.Fatalf("Sync() = %v", err)
+			}
+			if fake.Patches != tc.wantPatches {
+				t.Errorf("Got %d patches after a second sync, want %d", fake.Patches, tc.wantPatches)
+			}
+		})
+	}
+}
+
+func TestBucketSyncerDisableCDN(t *testing.T) {
+	fake := NewFakeBackendBuckets(&compute.BackendBucket{Name: "assets"})
+	syncer := NewBucketSyncer(fake, nil)
+	bucket := func(cdn *backendconfigv1.CDNConfig) []utils.BackendBucket {
+		return []utils.BackendBucket{{
+			Name:          "assets",
+			BackendConfig: &backendconfigv1.BackendConfig{Spec: backendconfigv1.BackendConfigSpec{Cdn: cdn}},
+		}}
+	}
+
+	cdn := &backendconfigv1.CDNConfig{Enabled: true, CachePolicy: &backendconfigv1.CacheKeyPolicy{IncludeQueryString: true, QueryStringWhitelist: []string{"v"}}}
+	if err := syncer.Sync(bucket(cdn), klog.TODO()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	bb, err := fake.GetBackendBucket("assets")
+	if err != nil {
+		t.Fatalf("GetBackendBucket() = %v", err)
+	}
+	if !bb.EnableCdn || bb.CdnPolicy == nil || bb.CdnPolicy.CacheKeyPolicy == nil {
+		t.Fatalf("Got backend bucket %+v, want CDN enabled with a cache key policy", bb)
+	}
+
+	// Dropping the query string whitelist clears the cache key policy.
+	if err := syncer.Sync(bucket(&backendconfigv1.CDNConfig{Enabled: true}), klog.TODO()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if bb, _ = fake.GetBackendBucket("assets"); bb.CdnPolicy.CacheKeyPolicy != nil {
+		t.Errorf("Got cache key policy %+v, want nil", bb.CdnPolicy.CacheKeyPolicy)
+	}
+
+	if err := syncer.Sync(bucket(&backendconfigv1.CDNConfig{Enabled: false}), klog.TODO()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if bb, _ = fake.GetBackendBucket("assets"); bb.EnableCdn {
+		t.Errorf("Got backend bucket %+v, want CDN disabled", bb)
+	}
+	patches := fake.Patches
+	if err := syncer.Sync(bucket(&backendconfigv1.CDNConfig{Enabled: false}), klog.TODO()); err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+	if fake.Patches != patches {
+		t.Errorf("Got %d patches after a second sync, want %d", fake.Patches, patches)
+	}
+}
+
+func TestGCEBackendBuckets(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	path := fmt.Sprintf("/compute/v1/projects/%s/global/backendBuckets/assets", fakeGCE.ProjectID())
+	bucket := &compute.BackendBucket{Name: "assets", BucketName: "assets-bucket"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == path:
+			json.NewEncoder(w).Encode(bucket)
+		case r.Method == http.MethodPatch && r.URL.Path == path:
+			patch := &compute.BackendBucket{}
+			if err := json.NewDecoder(r.Body).Decode(patch); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			bucket = patch
+			json.NewEncoder(w).Encode(&compute.Operation{Name: "patch-operation", Status: "RUNNING"})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/global/operations/patch-operation/wait"):
+			json.NewEncoder(w).Encode(&compute.Operation{Name: "patch-operation", Status: "DONE"})
+		default:
+			http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	fakeGCE.ComputeServices().GA.BasePath = server.URL + "/compute/v1/"
+
+	buckets := NewBackendBuckets(fakeGCE, klog.TODO())
+	got, err := buckets.GetBackendBucket("assets")
+	if err != nil {
+		t.Fatalf("GetBackendBucket() = %v", err)
+	}
+	if got.BucketName != "assets-bucket" {
+		t.Errorf("GetBackendBucket() = %+v, want the backend bucket of assets-bucket", got)
+	}
+	got.EnableCdn = true
+	if err := buckets.PatchBackendBucket(got); err != nil {
+		t.Fatalf("PatchBackendBucket() = %v", err)
+	}
+	if !bucket.EnableCdn {
+		t.Errorf("Backend bucket was not patched, got %+v", bucket)
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package backends
 
 import (
+	"encoding/json"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
 
 	"k8s.io/ingress-gce/pkg/utils"
@@ -39,3 +43,58 @@ func (pp *FakeProbeProvider) GetProbe(port utils.ServicePort) (*api_v1.Probe, er
 	}
 	return nil, nil
 }
+
+// FakeBackendBuckets implements the BackendBuckets interface for tests.
+type FakeBackendBuckets struct {
+	Buckets map[string]*compute.BackendBucket
+	// Patches is the number of patched backend buckets.
+	Patches int
+}
+
+// NewFakeBackendBuckets returns a FakeBackendBuckets with the given backend
+// buckets.
+func NewFakeBackendBuckets(buckets ...*compute.BackendBucket) *FakeBackendBuckets {
+	f := &FakeBackendBuckets{Buckets: map[string]*compute.BackendBucket{}}
+	for _, bb := range buckets {
+		f.Buckets[bb.Name] = bb
+	}
+	return f
+}
+
+// GetBackendBucket returns a copy of the backend bucket of the given name.
+func (f *FakeBackendBuckets) GetBackendBucket(name string) (*compute.BackendBucket, error) {
+	bb, ok := f.Buckets[name]
+	if !ok {
+		return nil, fmt.Errorf("backend bucket %q not found", name)
+	}
+	ret := *bb
+	return &ret, nil
+}
+
+// PatchBackendBucket patches the backend bucket of the same name like GCE
+// does: the fields that are omitted from the JSON of the given backend
+// bucket are left unchanged.
+func (f *FakeBackendBuckets) PatchBackendBucket(bucket *compute.BackendBucket) error {
+	bb, ok := f.Buckets[bucket.Name]
+	if !ok {
+		return fmt.Errorf("backend bucket %q not found", bucket.Name)
+	}
+	current, err := json.Marshal(bb)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(bucket)
+	if err != nil {
+		return err
+	}
+	patched := &compute.BackendBucket{}
+	if err := json.Unmarshal(current, patched); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patch, patched); err != nil {
+		return err
+	}
+	f.Buckets[bucket.Name] = patched
+	f.Patches++
+	return nil
+}
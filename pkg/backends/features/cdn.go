@@ -17,12 +17,14 @@ limitations under the License.
 package features
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/kr/pretty"
+	compute "google.golang.org/api/compute/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog/v2"
@@ -245,3 +247,101 @@ func applyConfig(new *composite.BackendService, current *composite.BackendServic
 	current.EnableCDN = new.EnableCDN
 	current.CdnPolicy = new.CdnPolicy
 }
+
+// EnsureBackendBucketCDN reads the CDN configuration specified in the
+// BackendBucket.BackendConfig and applies it to the GCE backend bucket. The
+// cache key policy of a backend bucket only supports the query string
+// whitelist, so the other cache key settings are ignored. It returns true if
+// the settings of the backend bucket were changed.
+func EnsureBackendBucketCDN(bucket utils.BackendBucket, bb *compute.BackendBucket, logger klog.Logger) (bool, error) {
+	if bucket.BackendConfig == nil || bucket.BackendConfig.Spec.Cdn == nil {
+		return false, nil
+	}
+	newConfig := renderConfig(utils.ServicePort{BackendConfig: bucket.BackendConfig})
+	if !newConfig.EnableCDN {
+		if !bb.EnableCdn {
+			return false, nil
+		}
+		bb.EnableCdn = false
+		// The backend bucket is patched, which omits the zero values that
+		// are not forced.
+		bb.ForceSendFields = append(bb.ForceSendFields, "EnableCdn")
+		logger.V(2).Info("Disabled CDN for backend bucket", "backendBucket", bucket.Name)
+		return true, nil
+	}
+
+	newPolicy, err := toBackendBucketCdnPolicy(newConfig.CdnPolicy)
+	if err != nil {
+		return false, err
+	}
+	if bb.CdnPolicy != nil {
+		// SignedUrlKeyNames are not handled by this function and must be
+		// preserved as they are.
+		newPolicy.SignedUrlKeyNames = bb.CdnPolicy.SignedUrlKeyNames
+	}
+	if bb.EnableCdn && backendBucketCdnPolicyEqual(newPolicy, bb.CdnPolicy) {
+		return false, nil
+	}
+	if bb.CdnPolicy != nil {
+		clearBackendBucketCdnPolicyFields(newPolicy, bb.CdnPolicy)
+	}
+	logger.V(2).Info("Updated CDN settings for backend bucket", "backendBucket", bucket.Name)
+	bb.EnableCdn = true
+	bb.CdnPolicy = newPolicy
+	return true, nil
+}
+
+// clearBackendBucketCdnPolicyFields forces the patch of a backend bucket to
+// clear the fields of its current CDN policy that are not set in the new
+// policy, as the patch leaves the omitted fields unchanged.
+func clearBackendBucketCdnPolicyFields(newPolicy, current *compute.BackendBucketCdnPolicy) {
+	if newPolicy.CacheKeyPolicy == nil && current.CacheKeyPolicy != nil {
+		newPolicy.NullFields = append(newPolicy.NullFields, "CacheKeyPolicy")
+	}
+	if len(newPolicy.NegativeCachingPolicy) == 0 && len(current.NegativeCachingPolicy) > 0 {
+		newPolicy.ForceSendFields = append(newPolicy.ForceSendFields, "NegativeCachingPolicy")
+	}
+	if len(newPolicy.BypassCacheOnRequestHeaders) == 0 && len(current.BypassCacheOnRequestHeaders) > 0 {
+		newPolicy.ForceSendFields = append(newPolicy.ForceSendFields, "BypassCacheOnRequestHeaders")
+	}
+}
+
+// toBackendBucketCdnPolicy converts the CDN policy of a backend service to
+// that of a backend bucket, whose fields have the same JSON names.
+func toBackendBucketCdnPolicy(policy *composite.BackendServiceCdnPolicy) (*compute.BackendBucketCdnPolicy, error) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	ret := &compute.BackendBucketCdnPolicy{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, err
+	}
+	ret.ForceSendFields = policy.ForceSendFields
+	if ret.CacheKeyPolicy != nil && len(ret.CacheKeyPolicy.QueryStringWhitelist) == 0 && len(ret.CacheKeyPolicy.IncludeHttpHeaders) == 0 {
+		ret.CacheKeyPolicy = nil
+	}
+	return ret, nil
+}
+
+// backendBucketCdnPolicyEqual returns true if the given CDN policies of
+// backend buckets are equal, regardless of the order of their negative
+// caching policies and of the fields they force to send.
+func backendBucketCdnPolicyEqual(x, y *compute.BackendBucketCdnPolicy) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	normalize := func(p *compute.BackendBucketCdnPolicy) compute.BackendBucketCdnPolicy {
+		ret := *p
+		ret.ForceSendFields, ret.NullFields = nil, nil
+		if ret.CacheKeyPolicy != nil && len(ret.CacheKeyPolicy.QueryStringWhitelist) == 0 && len(ret.CacheKeyPolicy.IncludeHttpHeaders) == 0 {
+			ret.CacheKeyPolicy = nil
+		}
+		ret.NegativeCachingPolicy = append([]*compute.BackendBucketCdnPolicyNegativeCachingPolicy(nil), p.NegativeCachingPolicy...)
+		sort.Slice(ret.NegativeCachingPolicy, func(i, j int) bool {
+			return ret.NegativeCachingPolicy[i].Code < ret.NegativeCachingPolicy[j].Code
+		})
+		return ret
+	}
+	return reflect.DeepEqual(normalize(x), normalize(y))
+}
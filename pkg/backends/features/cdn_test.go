@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"testing"
 
+	compute "google.golang.org/api/compute/v1"
 	bcnf "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	v1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -1189,3 +1190,41 @@ func TestEnsureCDNBypassCacheOnRequestHeaders(t *testing.T) {
 
 	evaluateTestCases(t, testCases)
 }
+
+func TestEnsureBackendBucketCDN(t *testing.T) {
+	bucketWithCDN := func(cdn *bcnf.CDNConfig) utils.BackendBucket {
+		return utils.BackendBucket{
+			Name:          "assets",
+			BackendConfig: &bcnf.BackendConfig{Spec: bcnf.BackendConfigSpec{Cdn: cdn}},
+		}
+	}
+	enabled := bucketWithCDN(&bcnf.CDNConfig{Enabled: true, CacheMode: &cacheAllStatic})
+
+	// A backend bucket without CDN gets the CDN settings of its BackendConfig.
+	bb := &compute.BackendBucket{Name: "assets", CdnPolicy: &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: []string{"key"}}}
+	updated, err := EnsureBackendBucketCDN(enabled, bb, klog.TODO())
+	if err != nil || !updated {
+		t.Fatalf("EnsureBackendBucketCDN() = %t, %v, want true, nil", updated, err)
+	}
+	if !bb.EnableCdn || bb.CdnPolicy.CacheMode != cacheAllStatic {
+		t.Errorf("Got backend bucket %+v with policy %+v, want CDN enabled with cache mode %s", bb, bb.CdnPolicy, cacheAllStatic)
+	}
+	if len(bb.CdnPolicy.SignedUrlKeyNames) != 1 {
+		t.Errorf("Got signed URL keys %v, want the existing key preserved", bb.CdnPolicy.SignedUrlKeyNames)
+	}
+
+	// Applying the same settings again is a no-op.
+	if updated, err := EnsureBackendBucketCDN(enabled, bb, klog.TODO()); err != nil || updated {
+		t.Errorf("EnsureBackendBucketCDN() = %t, %v for an up to date backend bucket, want false, nil", updated, err)
+	}
+
+	// Disabling CDN only clears the flag.
+	if updated, err := EnsureBackendBucketCDN(bucketWithCDN(&bcnf.CDNConfig{}), bb, klog.TODO()); err != nil || !updated || bb.EnableCdn {
+		t.Errorf("EnsureBackendBucketCDN() = %t, %v with CDN enabled %t, want true, nil and CDN disabled", updated, err, bb.EnableCdn)
+	}
+
+	// Backend buckets without a CDN config are left untouched.
+	if updated, err := EnsureBackendBucketCDN(utils.BackendBucket{Name: "assets"}, bb, klog.TODO()); err != nil || updated {
+		t.Errorf("EnsureBackendBucketCDN() = %t, %v without a BackendConfig, want false, nil", updated, err)
+	}
+}
@@ -40,6 +40,10 @@ const (
 	FeatureL7XLBRegional = "L7XLBRegional"
 	//FeatureVMIPNEG defines the feature name of GCE_VM_IP NEGs which are used for L4 ILB.
 	FeatureVMIPNEG = "VMIPNEG"
+	// FeatureCapacity defines the feature name of the capacity section of
+	// BackendConfig. It marks the backend services whose backend capacity
+	// is managed by the controller.
+	FeatureCapacity = "Capacity"
 )
 
 var (
@@ -81,11 +85,35 @@ func featuresFromServicePort(sp *utils.ServicePort) []string {
 	if sp.L7XLBRegionalEnabled {
 		features = append(features, FeatureL7XLBRegional)
 	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.Capacity != nil {
+		features = append(features, FeatureCapacity)
+	}
 	// Keep feature names sorted to be consistent.
 	sort.Strings(features)
 	return features
 }
 
+// HasFeature returns true if the given backend service description lists the
+// given feature.
+func HasFeature(desc, feature string) bool {
+	return sets.NewString(utils.DescriptionFromString(desc).XFeatures...).Has(feature)
+}
+
+// SetFeature returns the given backend service description with the given
+// feature added or removed.
+func SetFeature(desc, feature string, enabled bool) string {
+	d := utils.DescriptionFromString(desc)
+	features := sets.NewString(d.XFeatures...)
+	if enabled {
+		features.Insert(feature)
+	} else {
+		features.Delete(feature)
+	}
+	// List returns the features sorted.
+	d.XFeatures = features.List()
+	return d.String()
+}
+
 // VersionFromServicePort returns the meta.Version for the backend that this ServicePort is
 // associated with.
 func VersionFromServicePort(sp *utils.ServicePort) meta.Version {
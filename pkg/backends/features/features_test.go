@@ -74,6 +74,16 @@ var (
 		ID:         fakeSvcPortID,
 		NEGEnabled: true,
 	}
+
+	svcPortWithCapacity = utils.ServicePort{
+		ID:         fakeSvcPortID,
+		NEGEnabled: true,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Capacity: &backendconfigv1.CapacityConfig{},
+			},
+		},
+	}
 )
 
 func TestFeaturesFromServicePort(t *testing.T) {
@@ -107,6 +117,11 @@ func TestFeaturesFromServicePort(t *testing.T) {
 			svcPort:          svcPortWithHTTP2SecurityPolicy,
 			expectedFeatures: []string{"HTTP2", "SecurityPolicy"},
 		},
+		{
+			desc:             "NEG + Capacity",
+			svcPort:          svcPortWithCapacity,
+			expectedFeatures: []string{"Capacity", "NEG"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -288,3 +303,15 @@ func TestIsLowerVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestSetFeature(t *testing.T) {
+	desc := utils.Description{ServiceName: "testNS/testName", ServicePort: "80", XFeatures: []string{"NEG"}}.String()
+
+	withCapacity := SetFeature(desc, FeatureCapacity, true)
+	if !HasFeature(withCapacity, FeatureCapacity) || !HasFeature(withCapacity, FeatureNEG) {
+		t.Errorf("SetFeature(%q, %q, true) = %q, want both features", desc, FeatureCapacity, withCapacity)
+	}
+	if got := SetFeature(withCapacity, FeatureCapacity, false); got != desc {
+		t.Errorf("SetFeature(%q, %q, false) = %q, want %q", withCapacity, FeatureCapacity, got, desc)
+	}
+}
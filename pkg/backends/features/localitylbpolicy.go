@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Session affinities that select the key hashed by consistent hashing.
+	sessionAffinityHeaderField = "HEADER_FIELD"
+	sessionAffinityHttpCookie  = "HTTP_COOKIE"
+	sessionAffinityClientIP    = "CLIENT_IP"
+	sessionAffinityNone        = "NONE"
+)
+
+// EnsureLocalityLbPolicy reads the LocalityLbPolicy and ConsistentHash
+// configuration specified in the ServicePort.BackendConfig and applies it to
+// the BackendService. When the ConsistentHash section is removed, the
+// consistent hash settings and their session affinity are reset. It returns
+// true if there were existing settings on the BackendService that were
+// overwritten.
+func EnsureLocalityLbPolicy(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.LocalityLbPolicy == nil && sp.BackendConfig.Spec.ConsistentHash == nil && !usesConsistentHash(be) {
+		return false
+	}
+	beTemp := &composite.BackendService{
+		LocalityLbPolicy: be.LocalityLbPolicy,
+		SessionAffinity:  be.SessionAffinity,
+	}
+	applyLocalityLbPolicySettings(sp, beTemp)
+	// GCE defaults the minimum ring size of RING_HASH, so it is only
+	// compared if specified.
+	config := sp.BackendConfig.Spec.ConsistentHash
+	if (config == nil || config.MinimumRingSize == nil) && be.ConsistentHash != nil && be.ConsistentHash.MinimumRingSize != 0 {
+		if beTemp.ConsistentHash == nil {
+			beTemp.ConsistentHash = &composite.ConsistentHashLoadBalancerSettings{}
+		}
+		beTemp.ConsistentHash.MinimumRingSize = be.ConsistentHash.MinimumRingSize
+	}
+	if beTemp.LocalityLbPolicy != be.LocalityLbPolicy || beTemp.SessionAffinity != be.SessionAffinity || !consistentHashEqual(beTemp.ConsistentHash, be.ConsistentHash) {
+		be.LocalityLbPolicy = beTemp.LocalityLbPolicy
+		be.SessionAffinity = beTemp.SessionAffinity
+		be.ConsistentHash = beTemp.ConsistentHash
+		logger.V(2).Info("Updated LocalityLbPolicy settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// usesConsistentHash returns true if the BackendService hashes requests on a
+// header, a cookie or the client IP.
+func usesConsistentHash(be *composite.BackendService) bool {
+	if be.ConsistentHash != nil && (be.ConsistentHash.HttpHeaderName != "" || be.ConsistentHash.HttpCookie != nil) {
+		return true
+	}
+	return consistentHashSessionAffinity(be)
+}
+
+// consistentHashSessionAffinity returns true if the session affinity of the
+// BackendService selects the key hashed by its consistent hashing.
+func consistentHashSessionAffinity(be *composite.BackendService) bool {
+	switch be.SessionAffinity {
+	case sessionAffinityHeaderField, sessionAffinityHttpCookie:
+		return true
+	case sessionAffinityClientIP:
+		return be.LocalityLbPolicy == "RING_HASH" || be.LocalityLbPolicy == "MAGLEV"
+	}
+	return false
+}
+
+// consistentHashEqual returns true if the given consistent hash settings are
+// equal, treating nil as empty settings.
+func consistentHashEqual(a, b *composite.ConsistentHashLoadBalancerSettings) bool {
+	if a == nil {
+		a = &composite.ConsistentHashLoadBalancerSettings{}
+	}
+	if b == nil {
+		b = &composite.ConsistentHashLoadBalancerSettings{}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// applyLocalityLbPolicySettings applies the LocalityLbPolicy and ConsistentHash
+// settings specified in the BackendConfig to the passed in
+// composite.BackendService. The session affinity is set to the one matching
+// the hashed key, as GCE requires. A GCE API call still needs to be made to
+// actually persist the changes.
+func applyLocalityLbPolicySettings(sp utils.ServicePort, be *composite.BackendService) {
+	hashAffinity := consistentHashSessionAffinity(be)
+	if sp.BackendConfig.Spec.LocalityLbPolicy != nil {
+		be.LocalityLbPolicy = *sp.BackendConfig.Spec.LocalityLbPolicy
+	}
+	config := sp.BackendConfig.Spec.ConsistentHash
+	if config == nil {
+		be.ConsistentHash = nil
+		// The session affinity set by the SessionAffinity section is kept.
+		if sp.BackendConfig.Spec.SessionAffinity == nil && hashAffinity {
+			be.SessionAffinity = sessionAffinityNone
+		}
+		return
+	}
+	be.ConsistentHash = &composite.ConsistentHashLoadBalancerSettings{}
+	switch {
+	case config.HttpHeaderName != "":
+		be.ConsistentHash.HttpHeaderName = config.HttpHeaderName
+		be.SessionAffinity = sessionAffinityHeaderField
+	case config.HttpCookie != nil:
+		be.ConsistentHash.HttpCookie = &composite.ConsistentHashLoadBalancerSettingsHttpCookie{
+			Name: config.HttpCookie.Name,
+			Path: config.HttpCookie.Path,
+		}
+		if config.HttpCookie.TtlSec != nil {
+			be.ConsistentHash.HttpCookie.Ttl = &composite.Duration{Seconds: *config.HttpCookie.TtlSec}
+		}
+		be.SessionAffinity = sessionAffinityHttpCookie
+	case config.UseSourceIp:
+		be.SessionAffinity = sessionAffinityClientIP
+	}
+	if config.MinimumRingSize != nil {
+		be.ConsistentHash.MinimumRingSize = *config.MinimumRingSize
+	}
+}
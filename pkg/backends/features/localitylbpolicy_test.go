@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureLocalityLbPolicy(t *testing.T) {
+	leastRequest := "LEAST_REQUEST"
+	ringHash := "RING_HASH"
+	ttl := int64(60)
+
+	testCases := []struct {
+		desc           string
+		spec           backendconfigv1.BackendConfigSpec
+		be             *composite.BackendService
+		updateExpected bool
+		wantBe         *composite.BackendService
+	}{
+		{
+			desc:           "settings missing from both ends, no update needed",
+			be:             &composite.BackendService{},
+			updateExpected: false,
+			wantBe:         &composite.BackendService{},
+		},
+		{
+			desc:           "policy is different, update needed",
+			spec:           backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			be:             &composite.BackendService{LocalityLbPolicy: "ROUND_ROBIN"},
+			updateExpected: true,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST"},
+		},
+		{
+			desc:           "policy is identical, no update needed",
+			spec:           backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			be:             &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST"},
+			updateExpected: false,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST"},
+		},
+		{
+			desc: "header hash, update needed",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{HttpHeaderName: "x-user"},
+			},
+			be:             &composite.BackendService{SessionAffinity: "NONE"},
+			updateExpected: true,
+			wantBe: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+				SessionAffinity:  "HEADER_FIELD",
+				ConsistentHash:   &composite.ConsistentHashLoadBalancerSettings{HttpHeaderName: "x-user"},
+			},
+		},
+		{
+			desc: "cookie hash with defaulted ring size, no update needed",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash: &backendconfigv1.ConsistentHashConfig{
+					HttpCookie: &backendconfigv1.ConsistentHashCookieConfig{Name: "session", TtlSec: &ttl},
+				},
+			},
+			be: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+				SessionAffinity:  "HTTP_COOKIE",
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{
+					HttpCookie:      &composite.ConsistentHashLoadBalancerSettingsHttpCookie{Name: "session", Ttl: &composite.Duration{Seconds: 60}},
+					MinimumRingSize: 1024,
+				},
+			},
+			updateExpected: false,
+			wantBe: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+				SessionAffinity:  "HTTP_COOKIE",
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{
+					HttpCookie:      &composite.ConsistentHashLoadBalancerSettingsHttpCookie{Name: "session", Ttl: &composite.Duration{Seconds: 60}},
+					MinimumRingSize: 1024,
+				},
+			},
+		},
+		{
+			desc: "source IP hash, no consistent hash returned by GCE, no update needed",
+			spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: &ringHash,
+				ConsistentHash:   &backendconfigv1.ConsistentHashConfig{UseSourceIp: true},
+			},
+			be:             &composite.BackendService{LocalityLbPolicy: "RING_HASH", SessionAffinity: "CLIENT_IP"},
+			updateExpected: false,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "RING_HASH", SessionAffinity: "CLIENT_IP"},
+		},
+		{
+			desc:           "consistent hash removed, update needed",
+			spec:           backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			be:             &composite.BackendService{LocalityLbPolicy: "RING_HASH", ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{HttpHeaderName: "x-user"}},
+			updateExpected: true,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST"},
+		},
+		{
+			desc: "consistent hash section removed, session affinity reset",
+			be: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+				SessionAffinity:  "HTTP_COOKIE",
+				ConsistentHash: &composite.ConsistentHashLoadBalancerSettings{
+					HttpCookie:      &composite.ConsistentHashLoadBalancerSettingsHttpCookie{Name: "session"},
+					MinimumRingSize: 1024,
+				},
+			},
+			updateExpected: true,
+			wantBe: &composite.BackendService{
+				LocalityLbPolicy: "RING_HASH",
+				SessionAffinity:  "NONE",
+				ConsistentHash:   &composite.ConsistentHashLoadBalancerSettings{MinimumRingSize: 1024},
+			},
+		},
+		{
+			desc:           "source IP hash removed along with its policy, session affinity reset",
+			spec:           backendconfigv1.BackendConfigSpec{LocalityLbPolicy: &leastRequest},
+			be:             &composite.BackendService{LocalityLbPolicy: "MAGLEV", SessionAffinity: "CLIENT_IP"},
+			updateExpected: true,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST", SessionAffinity: "NONE"},
+		},
+		{
+			desc: "consistent hash removed, session affinity section kept",
+			spec: backendconfigv1.BackendConfigSpec{
+				SessionAffinity: &backendconfigv1.SessionAffinityConfig{AffinityType: "CLIENT_IP"},
+			},
+			be:             &composite.BackendService{LocalityLbPolicy: "RING_HASH", SessionAffinity: "CLIENT_IP"},
+			updateExpected: false,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "RING_HASH", SessionAffinity: "CLIENT_IP"},
+		},
+		{
+			desc:           "client IP affinity without consistent hash policy, no update needed",
+			be:             &composite.BackendService{LocalityLbPolicy: "ROUND_ROBIN", SessionAffinity: "CLIENT_IP"},
+			updateExpected: false,
+			wantBe:         &composite.BackendService{LocalityLbPolicy: "ROUND_ROBIN", SessionAffinity: "CLIENT_IP"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sp := utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{Spec: tc.spec}}
+			result := EnsureLocalityLbPolicy(sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if !reflect.DeepEqual(tc.be, tc.wantBe) {
+				t.Errorf("%v: got backend service %+v, want %+v", tc.desc, tc.be, tc.wantBe)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// EnsureOutlierDetection reads the OutlierDetection configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+//
+// GCE fills in defaults for the settings that are not specified, so only the
+// specified settings are compared to avoid updating the BackendService on
+// every sync. Missing settings are compared as empty ones, as GCE returns no
+// settings after an empty outlierDetection is applied.
+func EnsureOutlierDetection(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.OutlierDetection == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	if be.OutlierDetection != nil {
+		od := *be.OutlierDetection
+		beTemp.OutlierDetection = &od
+	}
+	applyOutlierDetectionSettings(sp, beTemp)
+	want, got := *beTemp.OutlierDetection, composite.OutlierDetection{}
+	if be.OutlierDetection != nil {
+		got = *be.OutlierDetection
+	}
+	want.ForceSendFields, got.ForceSendFields = nil, nil
+	if !reflect.DeepEqual(want, got) {
+		be.OutlierDetection = beTemp.OutlierDetection
+		logger.V(2).Info("Updated OutlierDetection settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// applyOutlierDetectionSettings applies the OutlierDetection settings specified
+// in the BackendConfig to the passed in composite.BackendService, keeping the
+// existing values of the settings that are not specified. Specified settings
+// are always sent, as zero is a meaningful value for some of them. A GCE API
+// call still needs to be made to actually persist the changes.
+func applyOutlierDetectionSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.OutlierDetection
+	if be.OutlierDetection == nil {
+		be.OutlierDetection = &composite.OutlierDetection{}
+	}
+	od := be.OutlierDetection
+	od.ForceSendFields = nil
+	if config.ConsecutiveErrors != nil {
+		od.ConsecutiveErrors = *config.ConsecutiveErrors
+		od.ForceSendFields = append(od.ForceSendFields, "ConsecutiveErrors")
+	}
+	if config.ConsecutiveGatewayFailure != nil {
+		od.ConsecutiveGatewayFailure = *config.ConsecutiveGatewayFailure
+		od.ForceSendFields = append(od.ForceSendFields, "ConsecutiveGatewayFailure")
+	}
+	if config.EnforcingConsecutiveErrors != nil {
+		od.EnforcingConsecutiveErrors = *config.EnforcingConsecutiveErrors
+		od.ForceSendFields = append(od.ForceSendFields, "EnforcingConsecutiveErrors")
+	}
+	if config.EnforcingConsecutiveGatewayFailure != nil {
+		od.EnforcingConsecutiveGatewayFailure = *config.EnforcingConsecutiveGatewayFailure
+		od.ForceSendFields = append(od.ForceSendFields, "EnforcingConsecutiveGatewayFailure")
+	}
+	if config.IntervalSec != nil {
+		od.Interval = &composite.Duration{Seconds: *config.IntervalSec}
+	}
+	if config.BaseEjectionTimeSec != nil {
+		od.BaseEjectionTime = &composite.Duration{Seconds: *config.BaseEjectionTimeSec}
+	}
+	if config.MaxEjectionPercent != nil {
+		od.MaxEjectionPercent = *config.MaxEjectionPercent
+		od.ForceSendFields = append(od.ForceSendFields, "MaxEjectionPercent")
+	}
+}
+
+// EnsureCircuitBreakers reads the CircuitBreakers configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten. As for outlier detection, only the specified
+// settings are compared, and missing settings are compared as empty ones.
+func EnsureCircuitBreakers(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) bool {
+	if sp.BackendConfig.Spec.CircuitBreakers == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	if be.CircuitBreakers != nil {
+		cb := *be.CircuitBreakers
+		beTemp.CircuitBreakers = &cb
+	}
+	applyCircuitBreakersSettings(sp, beTemp)
+	want, got := *beTemp.CircuitBreakers, composite.CircuitBreakers{}
+	if be.CircuitBreakers != nil {
+		got = *be.CircuitBreakers
+	}
+	if !reflect.DeepEqual(want, got) {
+		be.CircuitBreakers = beTemp.CircuitBreakers
+		logger.V(2).Info("Updated CircuitBreakers settings for service", "serviceKey", klog.KRef(sp.ID.Service.Namespace, sp.ID.Service.Name))
+		return true
+	}
+	return false
+}
+
+// applyCircuitBreakersSettings applies the CircuitBreakers settings specified
+// in the BackendConfig to the passed in composite.BackendService, keeping the
+// existing values of the settings that are not specified. A GCE API call still
+// needs to be made to actually persist the changes.
+func applyCircuitBreakersSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.CircuitBreakers
+	if be.CircuitBreakers == nil {
+		be.CircuitBreakers = &composite.CircuitBreakers{}
+	}
+	cb := be.CircuitBreakers
+	if config.MaxConnections != nil {
+		cb.MaxConnections = *config.MaxConnections
+	}
+	if config.MaxPendingRequests != nil {
+		cb.MaxPendingRequests = *config.MaxPendingRequests
+	}
+	if config.MaxRequests != nil {
+		cb.MaxRequests = *config.MaxRequests
+	}
+	if config.MaxRequestsPerConnection != nil {
+		cb.MaxRequestsPerConnection = *config.MaxRequestsPerConnection
+	}
+	if config.MaxRetries != nil {
+		cb.MaxRetries = *config.MaxRetries
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestEnsureOutlierDetection(t *testing.T) {
+	consecutiveErrors := int64(3)
+	maxEjectionPercent := int64(0)
+	intervalSec := int64(5)
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "outlier detection missing from both ends, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "specified settings are identical, GCE defaults are ignored, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors:  &consecutiveErrors,
+							MaxEjectionPercent: &maxEjectionPercent,
+							IntervalSec:        &intervalSec,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					ConsecutiveErrors:         3,
+					ConsecutiveGatewayFailure: 3,
+					Interval:                  &composite.Duration{Seconds: 5},
+					BaseEjectionTime:          &composite.Duration{Seconds: 30},
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "empty outlier detection, missing from backend service, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "outlier detection missing from backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+		},
+		{
+			desc: "settings are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							IntervalSec: &intervalSec,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					Interval: &composite.Duration{Seconds: 10},
+				},
+			},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureOutlierDetection(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			// Ensuring the settings again must be a no-op.
+			if EnsureOutlierDetection(tc.sp, tc.be, klog.TODO()) {
+				t.Errorf("%v: second EnsureOutlierDetection() = true, want false", tc.desc)
+			}
+		})
+	}
+}
+
+func TestEnsureCircuitBreakers(t *testing.T) {
+	maxConnections := int64(100)
+	maxRetries := int64(3)
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "circuit breakers missing from both ends, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "empty circuit breakers, missing from backend service, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "circuit breakers missing from backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxRetries: &maxRetries,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+		},
+		{
+			desc: "specified settings are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxConnections: &maxConnections,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					MaxConnections: 100,
+					MaxRequests:    1024,
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxConnections: &maxConnections,
+							MaxRetries:     &maxRetries,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					MaxConnections: 100,
+				},
+			},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureCircuitBreakers(tc.sp, tc.be, klog.TODO())
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			// GCE returns no circuit breakers if none of them is set.
+			if tc.be.CircuitBreakers != nil && reflect.DeepEqual(*tc.be.CircuitBreakers, composite.CircuitBreakers{}) {
+				tc.be.CircuitBreakers = nil
+			}
+			if EnsureCircuitBreakers(tc.sp, tc.be, klog.TODO()) {
+				t.Errorf("%v: second EnsureCircuitBreakers() = true, want false", tc.desc)
+			}
+		})
+	}
+}
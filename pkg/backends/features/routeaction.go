@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// defaultNumRetries is the number of retries GCE uses when a retry policy
+// does not specify one.
+const defaultNumRetries = 1
+
+// HasRouteActionSettings returns true if the ServicePort.BackendConfig specifies
+// settings that are applied to the route actions of the URL map rather than
+// to the BackendService.
+func HasRouteActionSettings(sp utils.ServicePort) bool {
+	if sp.BackendConfig == nil {
+		return false
+	}
+	spec := sp.BackendConfig.Spec
+	return spec.RetryPolicy != nil || spec.FaultInjection != nil || sp.RequestMirror != nil
+}
+
+// ApplyRouteActionSettings applies the RetryPolicy, FaultInjection and
+// RequestMirror settings specified in the ServicePort.BackendConfig to the
+// passed in composite.HttpRouteAction. mirrorLink is the resource path of the
+// backend service of ServicePort.RequestMirror.
+func ApplyRouteActionSettings(sp utils.ServicePort, action *composite.HttpRouteAction, mirrorLink string) {
+	if sp.BackendConfig == nil {
+		return
+	}
+	spec := sp.BackendConfig.Spec
+	if spec.RetryPolicy != nil {
+		action.RetryPolicy = &composite.HttpRetryPolicy{
+			RetryConditions: spec.RetryPolicy.RetryConditions,
+			NumRetries:      defaultNumRetries,
+		}
+		if spec.RetryPolicy.NumRetries != nil {
+			action.RetryPolicy.NumRetries = *spec.RetryPolicy.NumRetries
+		}
+		if spec.RetryPolicy.PerTryTimeoutSec != nil {
+			action.RetryPolicy.PerTryTimeout = &composite.Duration{Seconds: *spec.RetryPolicy.PerTryTimeoutSec}
+		}
+	}
+	if spec.FaultInjection != nil {
+		action.FaultInjectionPolicy = &composite.HttpFaultInjection{}
+		if delay := spec.FaultInjection.Delay; delay != nil {
+			action.FaultInjectionPolicy.Delay = &composite.HttpFaultDelay{
+				FixedDelay: &composite.Duration{Seconds: delay.FixedDelaySec},
+				Percentage: delay.Percentage,
+			}
+		}
+		if abort := spec.FaultInjection.Abort; abort != nil {
+			action.FaultInjectionPolicy.Abort = &composite.HttpFaultAbort{
+				HttpStatus: abort.HttpStatus,
+				Percentage: abort.Percentage,
+			}
+		}
+	}
+	if sp.RequestMirror != nil {
+		action.RequestMirrorPolicy = &composite.RequestMirrorPolicy{BackendService: mirrorLink}
+	}
+}
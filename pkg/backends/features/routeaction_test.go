@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestApplyRouteActionSettings(t *testing.T) {
+	numRetries := int64(3)
+	perTryTimeout := int64(5)
+	mirror := utils.ServicePort{}
+
+	testCases := []struct {
+		desc       string
+		sp         utils.ServicePort
+		wantHas    bool
+		wantAction *composite.HttpRouteAction
+	}{
+		{
+			desc:       "no BackendConfig",
+			sp:         utils.ServicePort{},
+			wantAction: &composite.HttpRouteAction{},
+		},
+		{
+			desc:       "no route action settings",
+			sp:         utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			wantAction: &composite.HttpRouteAction{},
+		},
+		{
+			desc: "retry policy with defaults",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						RetryPolicy: &backendconfigv1.RetryPolicyConfig{RetryConditions: []string{"5xx"}},
+					},
+				},
+			},
+			wantHas: true,
+			wantAction: &composite.HttpRouteAction{
+				RetryPolicy: &composite.HttpRetryPolicy{RetryConditions: []string{"5xx"}, NumRetries: 1},
+			},
+		},
+		{
+			desc: "retry policy",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						RetryPolicy: &backendconfigv1.RetryPolicyConfig{
+							RetryConditions:  []string{"5xx", "connect-failure"},
+							NumRetries:       &numRetries,
+							PerTryTimeoutSec: &perTryTimeout,
+						},
+					},
+				},
+			},
+			wantHas: true,
+			wantAction: &composite.HttpRouteAction{
+				RetryPolicy: &composite.HttpRetryPolicy{
+					RetryConditions: []string{"5xx", "connect-failure"},
+					NumRetries:      3,
+					PerTryTimeout:   &composite.Duration{Seconds: 5},
+				},
+			},
+		},
+		{
+			desc: "fault injection",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						FaultInjection: &backendconfigv1.FaultInjectionConfig{
+							Delay: &backendconfigv1.FaultDelayConfig{FixedDelaySec: 2, Percentage: 50},
+							Abort: &backendconfigv1.FaultAbortConfig{HttpStatus: 503, Percentage: 10},
+						},
+					},
+				},
+			},
+			wantHas: true,
+			wantAction: &composite.HttpRouteAction{
+				FaultInjectionPolicy: &composite.HttpFaultInjection{
+					Delay: &composite.HttpFaultDelay{FixedDelay: &composite.Duration{Seconds: 2}, Percentage: 50},
+					Abort: &composite.HttpFaultAbort{HttpStatus: 503, Percentage: 10},
+				},
+			},
+		},
+		{
+			desc: "request mirror",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow", ServicePort: 80},
+					},
+				},
+				RequestMirror: &mirror,
+			},
+			wantHas: true,
+			wantAction: &composite.HttpRouteAction{
+				RequestMirrorPolicy: &composite.RequestMirrorPolicy{BackendService: "global/backendServices/shadow"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := HasRouteActionSettings(tc.sp); got != tc.wantHas {
+				t.Errorf("HasRouteActionSettings() = %v, want %v", got, tc.wantHas)
+			}
+			action := &composite.HttpRouteAction{}
+			ApplyRouteActionSettings(tc.sp, action, "global/backendServices/shadow")
+			if !reflect.DeepEqual(action, tc.wantAction) {
+				t.Errorf("ApplyRouteActionSettings() = %+v, want %+v", action, tc.wantAction)
+			}
+		})
+	}
+}
@@ -31,19 +31,49 @@ import (
 // EnsureSecurityPolicy ensures the security policy link on backend service.
 // TODO(mrhohn): Emit event when attach/detach security policy to backend service.
 func EnsureSecurityPolicy(cloud *gce.Cloud, sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) error {
+	desiredPolicyName, needsUpdate, err := SecurityPolicyUpdate(sp, be, logger)
+	if err != nil || !needsUpdate {
+		return err
+	}
+	existingPolicyName, _ := utils.KeyName(be.SecurityPolicy)
+
+	if desiredPolicyName != "" {
+		logger.V(2).Info(fmt.Sprintf("Set security policy in backend service from %q to %q", existingPolicyName, desiredPolicyName), "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String())
+		if err := composite.SetSecurityPolicy(cloud, be, desiredPolicyName, logger); err != nil {
+			err := fmt.Errorf("failed to set security policy from %q to %q for backend service %s (%s:%s): %v", existingPolicyName, desiredPolicyName, be.Name, sp.ID.Service.String(), sp.ID.Port.String(), err)
+			logger.Error(err, "SetSecurityPolicy()")
+			return err
+		}
+		logger.V(2).Info(fmt.Sprintf("Successfully set security policy in backend service from %q to %q", existingPolicyName, desiredPolicyName), "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String())
+		return nil
+	}
+	logger.V(2).Info("Removing security policy in backend service", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String(), "existingPolicyName", existingPolicyName)
+	if err := composite.SetSecurityPolicy(cloud, be, desiredPolicyName, logger); err != nil {
+		err := fmt.Errorf("failed to remove security policy %q for backend service %s (%s:%s): %v", existingPolicyName, be.Name, sp.ID.Service.String(), sp.ID.Port.String(), err)
+		logger.Error(err, "SetSecurityPolicy()")
+		return err
+	}
+	logger.V(2).Info("Successfully removed security policy in backend service", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String(), "existingPolicyName", existingPolicyName)
+	return nil
+}
+
+// SecurityPolicyUpdate returns the name of the security policy that the
+// BackendConfig of the service port sets on the backend service, and whether
+// it differs from the security policy attached to the backend service.
+func SecurityPolicyUpdate(sp utils.ServicePort, be *composite.BackendService, logger klog.Logger) (string, bool, error) {
 	// It is too dangerous to remove user's security policy that may have been
 	// configured via the UI or gcloud directly rather than via Kubernetes.
 	// Treat nil security policy -> ignored
 	// Treat empty string security policy name -> remove
 	if sp.BackendConfig.Spec.SecurityPolicy == nil {
 		logger.V(2).Info("Ignoring nil Security Policy on backend service", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String())
-		return nil
+		return "", false, nil
 	}
 
 	if be.Scope != meta.Global {
 		err := fmt.Errorf("cloud armor security policies not supported for %s backend service %s", be.Scope, be.Name)
 		logger.Error(err, "EnsureSecurityPolicy()")
-		return err
+		return "", false, err
 	}
 
 	existingPolicyName, err := utils.KeyName(be.SecurityPolicy)
@@ -51,32 +81,14 @@ func EnsureSecurityPolicy(cloud *gce.Cloud, sp utils.ServicePort, be *composite.
 	if be.SecurityPolicy != "" && err != nil {
 		err := fmt.Errorf("failed to parse existing security policy name %q: %v", existingPolicyName, err)
 		logger.Error(err, "EnsureSecurityPolicy()")
-		return err
+		return "", false, err
 	}
 
 	desiredPolicyName := sp.BackendConfig.Spec.SecurityPolicy.Name
 	logger.V(2).Info(fmt.Sprintf("Current security policy: %q, desired security policy: %q", existingPolicyName, desiredPolicyName))
 	if existingPolicyName == desiredPolicyName {
 		logger.V(2).Info("SecurityPolicy on backend service is not changed", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String(), "desiredPolicyName", desiredPolicyName)
-		return nil
+		return desiredPolicyName, false, nil
 	}
-
-	if desiredPolicyName != "" {
-		logger.V(2).Info(fmt.Sprintf("Set security policy in backend service from %q to %q", existingPolicyName, desiredPolicyName), "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String())
-		if err := composite.SetSecurityPolicy(cloud, be, desiredPolicyName, logger); err != nil {
-			err := fmt.Errorf("failed to set security policy from %q to %q for backend service %s (%s:%s): %v", existingPolicyName, desiredPolicyName, be.Name, sp.ID.Service.String(), sp.ID.Port.String(), err)
-			logger.Error(err, "SetSecurityPolicy()")
-			return err
-		}
-		logger.V(2).Info(fmt.Sprintf("Successfully set security policy in backend service from %q to %q", existingPolicyName, desiredPolicyName), "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String())
-		return nil
-	}
-	logger.V(2).Info("Removing security policy in backend service", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String(), "existingPolicyName", existingPolicyName)
-	if err := composite.SetSecurityPolicy(cloud, be, desiredPolicyName, logger); err != nil {
-		err := fmt.Errorf("failed to remove security policy %q for backend service %s (%s:%s): %v", existingPolicyName, be.Name, sp.ID.Service.String(), sp.ID.Port.String(), err)
-		logger.Error(err, "SetSecurityPolicy()")
-		return err
-	}
-	logger.V(2).Info("Successfully removed security policy in backend service", "backendName", be.Name, "serviceKey", sp.ID.Service.String(), "servicePort", sp.ID.Port.String(), "existingPolicyName", existingPolicyName)
-	return nil
+	return desiredPolicyName, true, nil
 }
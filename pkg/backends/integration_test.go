@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	"k8s.io/ingress-gce/pkg/instancegroups"
@@ -79,7 +80,7 @@ func newTestJig(fakeGCE *gce.Cloud) (*Jig, error) {
 	return &Jig{
 		fakeInstancePool: fakeInstancePool,
 		linker:           NewInstanceGroupLinker(fakeInstancePool, fakeBackendPool, klog.TODO()),
-		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, fakeGCE, NewFakeProbeProvider(nil)),
+		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, fakeGCE, NewFakeProbeProvider(nil), events.RecorderProducerMock{}),
 	}, nil
 }
 
@@ -18,6 +18,7 @@ package backends
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -46,3 +47,10 @@ type NEGGetter interface {
 type ProbeProvider interface {
 	GetProbe(sp utils.ServicePort) (*api_v1.Probe, error)
 }
+
+// BackendBuckets is an interface to get and update the GCE backend buckets
+// referenced by Ingresses.
+type BackendBuckets interface {
+	GetBackendBucket(name string) (*compute.BackendBucket, error)
+	PatchBackendBucket(bucket *compute.BackendBucket) error
+}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	befeatures "k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -63,6 +64,9 @@ func NewNEGLinker(
 
 // Link implements Link.
 func (nl *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
+	if sp.ExternalNEG != nil {
+		return nl.linkExternalNEG(sp)
+	}
 	version := befeatures.VersionFromServicePort(&sp)
 
 	negSelfLinks, err := nl.getNegSelfLinks(sp, groups)
@@ -77,7 +81,7 @@ func (nl *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 	if err != nil {
 		return err
 	}
-	backendService, err := composite.GetBackendService(nl.cloud, key, version, nl.logger)
+	backendService, err := nl.backendPool.get(key, version, nl.logger)
 	if err != nil {
 		return err
 	}
@@ -118,15 +122,56 @@ func (nl *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 		mergedBackend = filteredBackends
 	}
 
-	diff := diffBackends(backendService.Backends, mergedBackend, nl.logger)
-	if diff.isEqual() {
+	// The capacity of GCE_VM_IP_PORT NEG backends is only compared if the
+	// BackendConfig has a capacity section, or had one on the previous sync
+	// as marked in the description of the backend service, so that it is
+	// reverted to the defaults when the section is removed. Otherwise, the
+	// capacity set outside of the controller is kept.
+	hasCapacity := getNegType(sp) == types.VmIpPortEndpointType && capacityConfig(&sp) != nil
+	hadCapacity := befeatures.HasFeature(backendService.Description, befeatures.FeatureCapacity)
+	compareCapacity := flags.F.EnableTrafficScaling || hasCapacity || hadCapacity
+	diff := diffBackends(backendService.Backends, mergedBackend, compareCapacity, nl.logger)
+	if diff.isEqual() && hasCapacity == hadCapacity {
 		nl.logger.V(2).Info("No changes in backends for service port", "servicePort", sp.ID)
 		return nil
 	}
 	nl.logger.V(2).Info("Backends changed for service port", "servicePort", sp.ID, "removing", diff.toRemove(), "adding", diff.toAdd(), "changed", diff.changed)
 
 	backendService.Backends = mergedBackend
-	return composite.UpdateBackendService(nl.cloud, key, backendService, nl.logger)
+	if hasCapacity != hadCapacity {
+		backendService.Description = befeatures.SetFeature(backendService.Description, befeatures.FeatureCapacity, hasCapacity)
+	}
+	return nl.backendPool.update(key, backendService, nl.logger)
+}
+
+// linkExternalNEG makes the serverless or internet NEG of the service port
+// the only backend of its backend service. The NEG is not zonal, so the
+// groups of the zones of the cluster do not apply.
+func (nl *negLinker) linkExternalNEG(sp utils.ServicePort) error {
+	version := befeatures.VersionFromServicePort(&sp)
+	key, err := composite.CreateKey(nl.cloud, sp.BackendName(), befeatures.ScopeFromServicePort(&sp))
+	if err != nil {
+		return err
+	}
+	backendService, err := nl.backendPool.get(key, version, nl.logger)
+	if err != nil {
+		return err
+	}
+
+	negKey := types.ExternalNEGKey(sp.ExternalNEG, sp.NEGName())
+	negURL := cloud.SelfLink(meta.VersionGA, nl.cloud.ProjectID(), "networkEndpointGroups", negKey)
+	// The backends of external NEGs support neither a balancing mode nor a
+	// capacity.
+	newBackends := []*composite.Backend{{Group: negURL}}
+	diff := diffBackends(backendService.Backends, newBackends, false, nl.logger)
+	if diff.isEqual() {
+		nl.logger.V(2).Info("No changes in backends for service port", "servicePort", sp.ID)
+		return nil
+	}
+	nl.logger.V(2).Info("Backends changed for service port", "servicePort", sp.ID, "removing", diff.toRemove(), "adding", diff.toAdd())
+
+	backendService.Backends = newBackends
+	return nl.backendPool.update(key, backendService, nl.logger)
 }
 
 type backendNegUrls struct {
@@ -258,7 +303,10 @@ func removeBackends(curr, toRemove []*composite.Backend) ([]*composite.Backend,
 	return ret, nil
 }
 
-func diffBackends(old, new []*composite.Backend, logger klog.Logger) *backendDiff {
+// diffBackends returns the difference between the old and the new backends.
+// The balancing mode and the capacity of the backends are only compared when
+// compareCapacity is true.
+func diffBackends(old, new []*composite.Backend, compareCapacity bool, logger klog.Logger) *backendDiff {
 	d := &backendDiff{
 		old:     sets.NewString(),
 		new:     sets.NewString(),
@@ -280,9 +328,11 @@ func diffBackends(old, new []*composite.Backend, logger klog.Logger) *backendDif
 			// value (e.g. CapacityScaler is 1.0), you will need to set that
 			// value when creating a new Backend to avoid a false positive when
 			// computing diffs.
-			if flags.F.EnableTrafficScaling {
+			if compareCapacity {
 				var changed bool
+				changed = changed || oldBe.BalancingMode != be.BalancingMode
 				changed = changed || oldBe.MaxRatePerEndpoint != be.MaxRatePerEndpoint
+				changed = changed || oldBe.MaxConnectionsPerEndpoint != be.MaxConnectionsPerEndpoint
 				changed = changed || oldBe.CapacityScaler != be.CapacityScaler
 				if changed {
 					d.changed.Insert(beGroup)
@@ -327,6 +377,14 @@ func backendsForNEGs(negSelfLinks []string, sp *utils.ServicePort) []*composite.
 					newBackend.CapacityScaler = *sp.CapacityScaler
 				}
 			}
+			if c := capacityConfig(sp); c != nil {
+				applyCapacity(newBackend, c, neg)
+			}
+			if newBackend.CapacityScaler == 0 {
+				// A capacity scaler of 0 drains the backend, it must be sent
+				// to GCE, which defaults it to 1.
+				newBackend.ForceSendFields = append(newBackend.ForceSendFields, "CapacityScaler")
+			}
 		}
 
 		backends = append(backends, newBackend)
@@ -334,6 +392,43 @@ func backendsForNEGs(negSelfLinks []string, sp *utils.ServicePort) []*composite.
 	return backends
 }
 
+// capacityConfig returns the capacity section of the BackendConfig of the
+// service port, if any.
+func capacityConfig(sp *utils.ServicePort) *backendconfigv1.CapacityConfig {
+	if sp.BackendConfig == nil {
+		return nil
+	}
+	return sp.BackendConfig.Spec.Capacity
+}
+
+// applyCapacity sets the balancing mode and the capacity of the backend of
+// the given NEG from the capacity section of a BackendConfig. The capacity
+// scaler of the zone of the NEG overrides the one of the section.
+func applyCapacity(be *composite.Backend, c *backendconfigv1.CapacityConfig, negSelfLink string) {
+	// The balancing mode is validated to be RATE.
+	if c.BalancingMode != nil {
+		be.BalancingMode = *c.BalancingMode
+	}
+	if c.MaxRatePerEndpoint != nil {
+		be.MaxRatePerEndpoint = *c.MaxRatePerEndpoint
+	}
+	if c.CapacityScaler != nil {
+		be.CapacityScaler = *c.CapacityScaler
+	}
+	if len(c.Zones) == 0 {
+		return
+	}
+	id, err := cloud.ParseResourceURL(negSelfLink)
+	if err != nil {
+		return
+	}
+	for _, z := range c.Zones {
+		if z.Zone == id.Key.Zone {
+			be.CapacityScaler = z.CapacityScaler
+		}
+	}
+}
+
 // getNegType returns NEG type based on service port config
 func getNegType(sp utils.ServicePort) types.NetworkEndpointType {
 	if sp.VMIPNEGEnabled {
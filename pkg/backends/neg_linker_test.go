@@ -23,20 +23,25 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	befeatures "k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/negannotation"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -230,6 +235,60 @@ func TestLinkWithDifferentSvcPorts(t *testing.T) {
 	}
 }
 
+func TestLinkExternalNEG(t *testing.T) {
+	t.Parallel()
+
+	svc := types.NamespacedName{Namespace: "ns", Name: "name"}
+	for _, tc := range []struct {
+		desc        string
+		externalNEG *negannotation.ExternalNegAnnotation
+		wantGroup   string
+	}{
+		{
+			desc:        "serverless NEG",
+			externalNEG: &negannotation.ExternalNegAnnotation{Serverless: &negannotation.ServerlessNeg{Region: "us-west1", CloudRun: &negannotation.CloudRunBackend{Service: "api"}}},
+			wantGroup:   "regions/us-west1/networkEndpointGroups/",
+		},
+		{
+			desc:        "internet NEG",
+			externalNEG: &negannotation.ExternalNegAnnotation{Internet: &negannotation.InternetNeg{FQDN: "origin.example.com"}},
+			wantGroup:   "global/networkEndpointGroups/",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+			linker := newTestNEGLinker(fakeNEG, fakeGCE)
+			sp := utils.ServicePort{
+				ID:           utils.ServicePortID{Service: svc},
+				Port:         443,
+				Protocol:     annotations.ProtocolHTTPS,
+				NEGEnabled:   true,
+				ExternalNEG:  tc.externalNEG,
+				BackendNamer: defaultNamer,
+			}
+			if _, err := linker.backendPool.Create(sp, "", klog.TODO()); err != nil {
+				t.Fatalf("Failed to create backend service for svcPort %v: %v", sp, err)
+			}
+
+			// The zones of the cluster do not apply to external NEGs.
+			if err := linker.Link(sp, []GroupKey{{Zone: testZone1}}); err != nil {
+				t.Fatalf("Link() = %v", err)
+			}
+			be, err := composite.GetBackendService(fakeGCE, meta.GlobalKey(sp.BackendName()), meta.VersionGA, klog.TODO())
+			if err != nil {
+				t.Fatalf("Failed to get backend service %s: %v", sp.BackendName(), err)
+			}
+			if len(be.HealthChecks) != 0 {
+				t.Errorf("Got health checks %v, want none", be.HealthChecks)
+			}
+			if len(be.Backends) != 1 || !strings.HasSuffix(be.Backends[0].Group, tc.wantGroup+sp.NEGName()) || be.Backends[0].BalancingMode != "" {
+				t.Errorf("Got backends %s, want the NEG %s%s without balancing mode", pretty.Sprint(be.Backends), tc.wantGroup, sp.NEGName())
+			}
+		})
+	}
+}
+
 func TestLinkWithNEGUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -345,7 +404,7 @@ func TestLinkWithNEGUpdates(t *testing.T) {
 						t.Fatalf("Failed to get Backend Service: %v", err)
 					}
 
-					if diff := diffBackends(updatedBe.Backends, tc.expectedBackends, klog.TODO()); !diff.isEqual() {
+					if diff := diffBackends(updatedBe.Backends, tc.expectedBackends, flags.F.EnableTrafficScaling, klog.TODO()); !diff.isEqual() {
 						t.Fatalf("Got backends %v after Link(), expected %v", updatedBe.Backends, tc.expectedBackends)
 					}
 				})
@@ -354,6 +413,121 @@ func TestLinkWithNEGUpdates(t *testing.T) {
 	}
 }
 
+// TestLinkRevertsCapacity checks that the capacity of the backends is
+// reverted to the defaults when the capacity section of the BackendConfig is
+// removed, so that a drained zone serves traffic again, and that the
+// capacity set outside of the controller is kept otherwise.
+func TestLinkRevertsCapacity(t *testing.T) {
+	t.Parallel()
+
+	svc := types.NamespacedName{Namespace: "ns", Name: "name"}
+	svcPort := utils.ServicePort{
+		ID:           utils.ServicePortID{Service: svc, Port: networkingv1.ServiceBackendPort{Number: 80}},
+		Port:         80,
+		Protocol:     annotations.ProtocolHTTP,
+		TargetPort:   intstr.FromString("port"),
+		NEGEnabled:   true,
+		BackendNamer: defaultNamer,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Capacity: &backendconfigv1.CapacityConfig{
+					MaxRatePerEndpoint: ptr.To(50.0),
+					Zones:              []backendconfigv1.ZoneCapacityConfig{{Zone: testZone2, CapacityScaler: 0}},
+				},
+			},
+		},
+	}
+	scope := befeatures.ScopeFromServicePort(&svcPort)
+	version := befeatures.VersionFromServicePort(&svcPort)
+	negName := svcPort.NEGName()
+
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+	linker := newTestNEGLinker(fakeNEG, fakeGCE)
+	svcNeg := &v1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: negName, Namespace: svc.Namespace},
+		Status: v1beta1.ServiceNetworkEndpointGroupStatus{
+			NetworkEndpointGroups: []v1beta1.NegObjectReference{
+				createNegRef(testZone1, negName, ""),
+				createNegRef(testZone2, negName, ""),
+			},
+		},
+	}
+	if err := linker.svcNegLister.Add(svcNeg); err != nil {
+		t.Fatalf("Failed to add svcneg: %v", err)
+	}
+	key, err := composite.CreateKey(fakeGCE, svcPort.BackendName(), scope)
+	if err != nil {
+		t.Fatalf("Failed to create Backend Service key: %v", err)
+	}
+	desc := svcPort.GetDescription()
+	if err := composite.CreateBackendService(fakeGCE, key, &composite.BackendService{Version: version, Scope: scope, Description: desc.String()}, klog.TODO()); err != nil {
+		t.Fatalf("Failed to create Backend Service: %v", err)
+	}
+	groups := []GroupKey{{Zone: testZone1}, {Zone: testZone2}}
+
+	// capacities returns the balancing mode, max rate and capacity scaler of
+	// the backends, by zone.
+	capacities := func() map[string]string {
+		t.Helper()
+		be, err := composite.GetBackendService(fakeGCE, key, version, klog.TODO())
+		if err != nil {
+			t.Fatalf("Failed to get Backend Service: %v", err)
+		}
+		ret := map[string]string{}
+		for _, b := range be.Backends {
+			id, err := cloud.ParseResourceURL(b.Group)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", b.Group, err)
+			}
+			ret[id.Key.Zone] = fmt.Sprintf("%s/%v/%v", b.BalancingMode, b.MaxRatePerEndpoint, b.CapacityScaler)
+		}
+		return ret
+	}
+
+	if err := linker.Link(svcPort, groups); err != nil {
+		t.Fatalf("Link() = %v", err)
+	}
+	want := map[string]string{testZone1: "RATE/50/1", testZone2: "RATE/50/0"}
+	if diff := cmp.Diff(want, capacities()); diff != "" {
+		t.Errorf("Got unexpected capacities with the capacity section (-want +got):\n%s", diff)
+	}
+
+	svcPort.BackendConfig = nil
+	if err := linker.Link(svcPort, groups); err != nil {
+		t.Fatalf("Link() = %v", err)
+	}
+	want = map[string]string{testZone1: fmt.Sprintf("RATE/%v/1", maxRPS), testZone2: fmt.Sprintf("RATE/%v/1", maxRPS)}
+	if diff := cmp.Diff(want, capacities()); diff != "" {
+		t.Errorf("Got unexpected capacities after removing the capacity section (-want +got):\n%s", diff)
+	}
+
+	// Drain a zone outside of the controller.
+	be, err := composite.GetBackendService(fakeGCE, key, version, klog.TODO())
+	if err != nil {
+		t.Fatalf("Failed to get Backend Service: %v", err)
+	}
+	if befeatures.HasFeature(be.Description, befeatures.FeatureCapacity) {
+		t.Errorf("Got description %q, want no %s feature after removing the capacity section", be.Description, befeatures.FeatureCapacity)
+	}
+	for _, b := range be.Backends {
+		if strings.Contains(b.Group, testZone2) {
+			b.CapacityScaler = 0
+			b.ForceSendFields = append(b.ForceSendFields, "CapacityScaler")
+		}
+	}
+	if err := composite.UpdateBackendService(fakeGCE, key, be, klog.TODO()); err != nil {
+		t.Fatalf("Failed to update Backend Service: %v", err)
+	}
+	if err := linker.Link(svcPort, groups); err != nil {
+		t.Fatalf("Link() = %v", err)
+	}
+	want = map[string]string{testZone1: fmt.Sprintf("RATE/%v/1", maxRPS), testZone2: fmt.Sprintf("RATE/%v/0", maxRPS)}
+	if diff := cmp.Diff(want, capacities()); diff != "" {
+		t.Errorf("Got unexpected capacities after draining a zone outside of the controller (-want +got):\n%s", diff)
+	}
+}
+
 func TestLinkWithNEGUpdatesWithMultiSubnetCluster(t *testing.T) {
 	namespace, svcName, port := "ns", "name", "port"
 	svc := types.NamespacedName{Namespace: namespace, Name: svcName}
@@ -477,7 +651,7 @@ func TestLinkWithNEGUpdatesWithMultiSubnetCluster(t *testing.T) {
 					t.Fatalf("Failed to get Backend Service: %v", err)
 				}
 
-				if diff := diffBackends(updatedBe.Backends, tc.expectedBackends, klog.TODO()); !diff.isEqual() {
+				if diff := diffBackends(updatedBe.Backends, tc.expectedBackends, flags.F.EnableTrafficScaling, klog.TODO()); !diff.isEqual() {
 					t.Fatalf("Got backends %v after Link(), expected %v", updatedBe.Backends, tc.expectedBackends)
 				}
 			})
@@ -803,7 +977,7 @@ func TestMergeBackends(t *testing.T) {
 			}
 
 			if !tc.expectError {
-				diffBackend := diffBackends(tc.expect, ret, klog.TODO())
+				diffBackend := diffBackends(tc.expect, ret, flags.F.EnableTrafficScaling, klog.TODO())
 				if !diffBackend.isEqual() {
 					t.Errorf("Expect tc.expect == ret, however got, tc.expect = %v, ret = %v", tc.expect, ret)
 				}
@@ -877,7 +1051,7 @@ func TestRemoveBackends(t *testing.T) {
 				t.Errorf("Got err %v, expect err == nil", err)
 			}
 
-			diff := diffBackends(gotBackends, tc.expectedBackends, klog.TODO())
+			diff := diffBackends(gotBackends, tc.expectedBackends, flags.F.EnableTrafficScaling, klog.TODO())
 			if !diff.isEqual() {
 				t.Errorf("Got backends %v, expected %v", gotBackends, tc.expectedBackends)
 			}
@@ -966,9 +1140,21 @@ func TestDiffBackends(t *testing.T) {
 			new:     []*composite.Backend{{Group: "a", CapacityScaler: 1.0}},
 			isEqual: true,
 		},
+		{
+			name:    "update balancing mode",
+			old:     []*composite.Backend{{Group: "a", BalancingMode: "RATE", MaxRatePerEndpoint: 1}},
+			new:     []*composite.Backend{{Group: "a", BalancingMode: "CONNECTION", MaxConnectionsPerEndpoint: 10}},
+			changed: sets.NewString("a"),
+		},
+		{
+			name:    "update max connections",
+			old:     []*composite.Backend{{Group: "a", BalancingMode: "CONNECTION", MaxConnectionsPerEndpoint: 10}},
+			new:     []*composite.Backend{{Group: "a", BalancingMode: "CONNECTION", MaxConnectionsPerEndpoint: 20}},
+			changed: sets.NewString("a"),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			diff := diffBackends(tc.old, tc.new, klog.TODO())
+			diff := diffBackends(tc.old, tc.new, true, klog.TODO())
 			if got := diff.isEqual(); got != tc.isEqual {
 				t.Errorf("diff := diffBackends(%s, %s); diff.isEqual() = %t, want %t", pretty.Sprint(tc.old), pretty.Sprint(tc.new), got, tc.isEqual)
 			}
@@ -1153,6 +1339,47 @@ func TestBackendsForNEG(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "neg endpoint (backend config capacity)",
+			negs: []*composite.NetworkEndpointGroup{
+				{
+					NetworkEndpointType: string(negtypes.VmIpPortEndpointType),
+					SelfLink:            "https://www.googleapis.com/compute/v1/projects/mock-project/zones/zone-a/networkEndpointGroups/neg1",
+				},
+				{
+					NetworkEndpointType: string(negtypes.VmIpPortEndpointType),
+					SelfLink:            "https://www.googleapis.com/compute/v1/projects/mock-project/zones/zone-b/networkEndpointGroups/neg1",
+				},
+			},
+			sp: &utils.ServicePort{
+				// The BackendConfig takes precedence over the annotations.
+				MaxRatePerEndpoint: f64(1234),
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Capacity: &backendconfigv1.CapacityConfig{
+							MaxRatePerEndpoint: f64(50),
+							CapacityScaler:     f64(0.8),
+							Zones:              []backendconfigv1.ZoneCapacityConfig{{Zone: "zone-b", CapacityScaler: 0}},
+						},
+					},
+				},
+			},
+			want: []*composite.Backend{
+				{
+					BalancingMode:      "RATE",
+					MaxRatePerEndpoint: 50,
+					CapacityScaler:     0.8,
+					Group:              "https://www.googleapis.com/compute/v1/projects/mock-project/zones/zone-a/networkEndpointGroups/neg1",
+				},
+				{
+					BalancingMode:      "RATE",
+					MaxRatePerEndpoint: 50,
+					CapacityScaler:     0,
+					Group:              "https://www.googleapis.com/compute/v1/projects/mock-project/zones/zone-b/networkEndpointGroups/neg1",
+					ForceSendFields:    []string{"CapacityScaler"},
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			negUrls := []string{}
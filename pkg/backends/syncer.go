@@ -23,8 +23,11 @@ import (
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	lbfeatures "k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog/v2"
 )
@@ -35,6 +38,9 @@ type Syncer struct {
 	healthChecker healthchecks.HealthChecker
 	prober        ProbeProvider
 	cloud         *gce.Cloud
+	// recorders emit the events about the backend services on their
+	// Services.
+	recorders events.RecorderProducer
 }
 
 func NewBackendSyncer(
@@ -42,12 +48,14 @@ func NewBackendSyncer(
 	healthChecker healthchecks.HealthChecker,
 	cloud *gce.Cloud,
 	prober ProbeProvider,
+	recorders events.RecorderProducer,
 ) *Syncer {
 	return &Syncer{
 		backendPool:   backendPool,
 		healthChecker: healthChecker,
 		cloud:         cloud,
 		prober:        prober,
+		recorders:     recorders,
 	}
 }
 
@@ -80,10 +88,15 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 	)
 	be, getErr := s.backendPool.Get(beName, version, scope, beLogger)
 
-	// Ensure health check for backend service exists.
-	hcLink, err := s.ensureHealthCheck(sp, beLogger)
-	if err != nil {
-		return fmt.Errorf("error ensuring health check: %w", err)
+	// Ensure health check for backend service exists. The backend services
+	// of external NEGs do not support health checks.
+	var hcLink string
+	var err error
+	if sp.ExternalNEG == nil {
+		hcLink, err = s.ensureHealthCheck(sp, beLogger)
+		if err != nil {
+			return fmt.Errorf("error ensuring health check: %w", err)
+		}
 	}
 
 	// Verify existence of a backend service for the proper port
@@ -100,6 +113,7 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 		}
 	}
 
+	current := drift.Snapshot(be)
 	needUpdate := ensureProtocol(be, sp)
 	needUpdate = ensureHealthCheckLink(be, hcLink) || needUpdate
 	needUpdate = ensureDescription(be, &sp) || needUpdate
@@ -111,6 +125,9 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 		needUpdate = features.EnsureCustomRequestHeaders(sp, be, beLogger) || needUpdate
 		needUpdate = features.EnsureCustomResponseHeaders(sp, be, beLogger) || needUpdate
 		needUpdate = features.EnsureLogging(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureOutlierDetection(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureCircuitBreakers(sp, be, beLogger) || needUpdate
+		needUpdate = features.EnsureLocalityLbPolicy(sp, be, beLogger) || needUpdate
 
 		updateIAP, err := features.EnsureIAP(sp, be, beLogger)
 		if err != nil {
@@ -120,9 +137,22 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 		needUpdate = updateIAP || needUpdate
 	}
 
-	if needUpdate {
-		if err := s.backendPool.Update(be, beLogger); err != nil {
-			return err
+	key, err := composite.CreateKey(s.cloud, beName, scope)
+	if err != nil {
+		return err
+	}
+	if needUpdate && !s.revertDrift(sp, key, current, be, beLogger) {
+		// Keep the changes made outside of the controller, which must not
+		// be recorded as applied by the controller either.
+		be = current.(*composite.BackendService)
+	} else {
+		if needUpdate {
+			if err := s.backendPool.Update(be, beLogger); err != nil {
+				return err
+			}
+		}
+		if !s.backendPool.plan.Enabled() {
+			s.backendPool.drift.Applied(backendServicesResource, key, be)
 		}
 	}
 
@@ -135,6 +165,9 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 		// available. meta.Key is not needed as security policy supported only for
 		// global backends.
 		be.Scope = scope
+		if s.backendPool.plan.Enabled() {
+			return s.planSecurityPolicy(sp, be, beLogger)
+		}
 		if err := features.EnsureSecurityPolicy(s.cloud, sp, be, beLogger); err != nil {
 			return err
 		}
@@ -143,6 +176,45 @@ func (s *Syncer) ensureBackendService(sp utils.ServicePort, ingLogger klog.Logge
 	return nil
 }
 
+// revertDrift reports the changes made to the backend service outside of the
+// controller, and returns false if they must not be reverted with the drift
+// policy of the service port.
+func (s *Syncer) revertDrift(sp utils.ServicePort, key *meta.Key, current interface{}, be *composite.BackendService, beLogger klog.Logger) bool {
+	d := s.backendPool.drift.Detect(backendServicesResource, key, current, be)
+	if d == nil {
+		return true
+	}
+	beLogger.Info("Backend service was changed outside of the controller", "drift", d.String(), "driftPolicy", sp.DriftPolicy, "repeated", d.Repeated)
+	svc := &v1.ObjectReference{Kind: "Service", APIVersion: "v1", Namespace: sp.ID.Service.Namespace, Name: sp.ID.Service.Name}
+	if !d.Revert(sp.DriftPolicy) {
+		if d.Repeated {
+			return false
+		}
+		s.recorders.Recorder(svc.Namespace).Eventf(svc, v1.EventTypeWarning, events.DriftDetected, "Backend service %q was changed outside of the controller, not reverting with drift policy %s: %s", key.Name, sp.DriftPolicy, d)
+		return false
+	}
+	s.recorders.Recorder(svc.Namespace).Eventf(svc, v1.EventTypeWarning, events.DriftDetected, "Backend service %q was changed outside of the controller, reverting: %s", key.Name, d)
+	return true
+}
+
+// planSecurityPolicy records the update of the security policy of the
+// backend service in plan mode.
+func (s *Syncer) planSecurityPolicy(sp utils.ServicePort, be *composite.BackendService, beLogger klog.Logger) error {
+	desiredPolicyName, needsUpdate, err := features.SecurityPolicyUpdate(sp, be, beLogger)
+	if err != nil || !needsUpdate {
+		return err
+	}
+	key, err := composite.CreateKey(s.cloud, be.Name, be.Scope)
+	if err != nil {
+		return err
+	}
+	beLogger.V(2).Info("Skipping security policy update in plan mode", "desiredPolicyName", desiredPolicyName)
+	desired := *be
+	desired.SecurityPolicy = desiredPolicyName
+	s.backendPool.plan.Record(plan.Update, backendServicesResource, key, be, &desired)
+	return nil
+}
+
 func (s *Syncer) ensureBackendSignedURLKeys(sp utils.ServicePort, be *composite.BackendService, beLogger klog.Logger) error {
 	existingKeyNames := map[string]bool{}
 	if be.CdnPolicy != nil && be.CdnPolicy.SignedUrlKeyNames != nil {
@@ -332,8 +404,16 @@ func ensureProtocol(be *composite.BackendService, p utils.ServicePort) (needsUpd
 	return true
 }
 
-// ensureHealthCheckLink updates the BackendService HealthCheck with the expected value
+// ensureHealthCheckLink updates the BackendService HealthCheck with the expected value.
+// An empty hcLink removes the health check.
 func ensureHealthCheckLink(be *composite.BackendService, hcLink string) (needsUpdate bool) {
+	if hcLink == "" {
+		if len(be.HealthChecks) == 0 {
+			return false
+		}
+		be.HealthChecks = nil
+		return true
+	}
 	existingHCLink := getHealthCheckLink(be)
 
 	if utils.EqualResourceIDs(existingHCLink, hcLink) {
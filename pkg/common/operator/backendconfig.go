@@ -5,8 +5,11 @@ import (
 
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/utils"
 
 	api_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
 )
 
 // doesServiceReferenceBackendConfig returns true if the passed in Service directly references
@@ -15,6 +18,10 @@ func doesServiceReferenceBackendConfig(svc *api_v1.Service, beConfig *backendcon
 	if svc.Namespace != beConfig.Namespace {
 		return false
 	}
+	// A BackendConfig can also be attached to the Service by its targetRef.
+	if ref := beConfig.Spec.TargetRef; flags.F.EnableBackendConfigTargetRef && ref != nil && ref.Group == "" && ref.Kind == "Service" && ref.Name == svc.Name {
+		return true
+	}
 	backendConfigNames, err := annotations.FromService(svc).GetBackendConfigs()
 	if err != nil {
 		// If the user did not provide the annotation at all, then we
@@ -36,3 +43,23 @@ func doesServiceReferenceBackendConfig(svc *api_v1.Service, beConfig *backendcon
 	}
 	return false
 }
+
+// doesIngressReferenceBackendBucketOf returns true if the passed in Ingress
+// references the backend bucket the passed in BackendConfig is attached to.
+func doesIngressReferenceBackendBucketOf(ing *v1.Ingress, beConfig *backendconfigv1.BackendConfig) bool {
+	ref := beConfig.Spec.TargetRef
+	if ing.Namespace != beConfig.Namespace || ref == nil || ref.Group != utils.BackendBucketAPIGroup || ref.Kind != utils.BackendBucketKind {
+		return false
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if name, ok := utils.BackendToBackendBucket(path.Backend); ok && name == ref.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
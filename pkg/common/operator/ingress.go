@@ -79,13 +79,17 @@ func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.B
 	var i []*v1.Ingress
 	svcs := svcsOp.ReferencesBackendConfig(beConfig).AsList()
 	for _, ing := range op.i {
+		key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
 		for _, svc := range svcs {
-			key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
 			if doesIngressReferenceService(ing, svc) && !dupes[key] {
 				i = append(i, ing)
 				dupes[key] = true
 			}
 		}
+		if doesIngressReferenceBackendBucketOf(ing, beConfig) && !dupes[key] {
+			i = append(i, ing)
+			dupes[key] = true
+		}
 	}
 	return Ingresses(i)
 }
@@ -18,6 +18,7 @@ limitations under the License.
 package composite
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -113,6 +114,82 @@ func PatchRegionalTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHtt
 	}
 }
 
+// PatchGlobalTargetHttpsProxy() patches a global target https proxy. The
+// generated cloud wrapper only supports patching regional TargetHttpsProxies,
+// so the GA compute API is called directly.
+func PatchGlobalTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("TargetHttpsProxy", "patch", key.Region, key.Zone, string(meta.VersionGA))
+
+	if key.Type() != meta.Global {
+		return fmt.Errorf("key type %v is not valid. PatchGlobalTargetHttpsProxy is only supported for global TargetHttpsProxies", key)
+	}
+	ga, err := targetHttpsProxy.ToGA()
+	if err != nil {
+		return err
+	}
+	// NullFields is not copied by ToGA, see PatchRegionalTargetHttpsProxy.
+	ga.NullFields = targetHttpsProxy.NullFields
+	logger.Info("Patching ga global TargetHttpsProxy", "name", key.Name)
+	op, err := gceCloud.ComputeServices().GA.TargetHttpsProxies.Patch(gceCloud.ProjectID(), key.Name, ga).Context(ctx).Do()
+	if err != nil {
+		return mc.Observe(err)
+	}
+	return mc.Observe(waitForGlobalOperation(ctx, gceCloud, op))
+}
+
+// GetBackendBucket() gets a backend bucket. The generated cloud wrapper does
+// not support backend buckets, so the GA compute API is called directly.
+func GetBackendBucket(gceCloud *gce.Cloud, key *meta.Key, logger klog.Logger) (*compute.BackendBucket, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "get", key.Region, key.Zone, string(meta.VersionGA))
+
+	if key.Type() != meta.Global {
+		return nil, fmt.Errorf("key type %v is not valid. Backend buckets are global resources", key)
+	}
+	logger.V(3).Info("Getting ga BackendBucket", "name", key.Name)
+	bucket, err := gceCloud.ComputeServices().GA.BackendBuckets.Get(gceCloud.ProjectID(), key.Name).Context(ctx).Do()
+	return bucket, mc.Observe(err)
+}
+
+// PatchBackendBucket() patches a backend bucket and waits for the operation
+// to complete. The generated cloud wrapper does not support backend buckets,
+// so the GA compute API is called directly.
+func PatchBackendBucket(gceCloud *gce.Cloud, key *meta.Key, bucket *compute.BackendBucket, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendBucket", "patch", key.Region, key.Zone, string(meta.VersionGA))
+
+	if key.Type() != meta.Global {
+		return fmt.Errorf("key type %v is not valid. Backend buckets are global resources", key)
+	}
+	logger.Info("Patching ga BackendBucket", "name", key.Name)
+	op, err := gceCloud.ComputeServices().GA.BackendBuckets.Patch(gceCloud.ProjectID(), key.Name, bucket).Context(ctx).Do()
+	if err != nil {
+		return mc.Observe(err)
+	}
+	return mc.Observe(waitForGlobalOperation(ctx, gceCloud, op))
+}
+
+// waitForGlobalOperation waits for a global operation returned by the GA
+// compute API, for the calls which are not supported by the generated cloud
+// wrapper and which do not wait for their operations.
+func waitForGlobalOperation(ctx context.Context, gceCloud *gce.Cloud, op *compute.Operation) error {
+	var err error
+	for op.Status != "DONE" {
+		op, err = gceCloud.ComputeServices().GA.GlobalOperations.Wait(gceCloud.ProjectID(), op.Name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return fmt.Errorf("operation %q failed: %s", op.Name, op.Error.Errors[0].Message)
+	}
+	return nil
+}
+
 // SetSslCertificateForTargetHttpsProxy() sets the SSL Certificate for a target https proxy
 func SetSslCertificateForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, sslCertURLs []string, logger klog.Logger) error {
 	ctx, cancel := cloud.ContextWithCallTimeout()
@@ -123,32 +200,39 @@ func SetSslCertificateForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, ta
 	key.Name = targetHttpsProxy.Name
 	logger.V(3).Info("setting SslCertificate for TargetHttpsProxy", "key", key)
 
+	// An empty list detaches all the certificates, which is used when the
+	// proxy switches to a certificate map.
+	var forceSendFields []string
+	if len(sslCertURLs) == 0 {
+		forceSendFields = []string{"SslCertificates"}
+	}
+
 	switch targetHttpsProxy.Version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
-			req := &computealpha.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &computealpha.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		default:
-			req := &computealpha.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &computealpha.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
-			req := &computebeta.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &computebeta.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		default:
-			req := &computebeta.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &computebeta.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
-			req := &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		default:
-			req := &compute.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
+			req := &compute.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs, ForceSendFields: forceSendFields}
 			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetSslCertificates(ctx, key, req))
 		}
 	}
@@ -192,6 +276,37 @@ func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetH
 	}
 }
 
+// SetCertificateMapForTargetHttpsProxy() sets the Certificate Manager certificate map for a target https proxy.
+// An empty certificateMapLink detaches the certificate map.
+func SetCertificateMapForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, certificateMapLink string, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("TargetHttpsProxy", "set_certificate_map", key.Region, key.Zone, string(targetHttpsProxy.Version))
+
+	// Set name in case it is not present in the key
+	key.Name = targetHttpsProxy.Name
+	logger.V(3).Info("Setting CertificateMap for TargetHttpsProxy", "key", key)
+
+	var nullFields []string
+	if certificateMapLink == "" {
+		nullFields = []string{"CertificateMap"}
+	}
+	if key.Type() == meta.Regional {
+		return fmt.Errorf("SetCertificateMap() is not supported for regional Target Https Proxies")
+	}
+	switch targetHttpsProxy.Version {
+	case meta.VersionAlpha:
+		req := &computealpha.TargetHttpsProxiesSetCertificateMapRequest{CertificateMap: certificateMapLink, NullFields: nullFields}
+		return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetCertificateMap(ctx, key, req))
+	case meta.VersionBeta:
+		req := &computebeta.TargetHttpsProxiesSetCertificateMapRequest{CertificateMap: certificateMapLink, NullFields: nullFields}
+		return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetCertificateMap(ctx, key, req))
+	default:
+		req := &compute.TargetHttpsProxiesSetCertificateMapRequest{CertificateMap: certificateMapLink, NullFields: nullFields}
+		return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetCertificateMap(ctx, key, req))
+	}
+}
+
 // SetUrlMapForTargetHttpProxy() sets the url map for a target proxy
 func SetUrlMapForTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *TargetHttpProxy, urlMapLink string, logger klog.Logger) error {
 	ctx, cancel := cloud.ContextWithCallTimeout()
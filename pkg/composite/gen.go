@@ -4829,6 +4829,10 @@ func (backendService *BackendService) ToAlpha() (*computealpha.BackendService, e
 			alpha.LogConfig.ForceSendFields = []string{"Enable", "SampleRate"}
 		}
 	}
+	// Backends keep their force send fields, e.g. a CapacityScaler of 0.
+	for i := range alpha.Backends {
+		alpha.Backends[i].ForceSendFields = backendService.Backends[i].ForceSendFields
+	}
 
 	return alpha, nil
 }
@@ -4857,6 +4861,10 @@ func (backendService *BackendService) ToBeta() (*computebeta.BackendService, err
 			beta.LogConfig.ForceSendFields = []string{"Enable", "SampleRate"}
 		}
 	}
+	// Backends keep their force send fields, e.g. a CapacityScaler of 0.
+	for i := range beta.Backends {
+		beta.Backends[i].ForceSendFields = backendService.Backends[i].ForceSendFields
+	}
 
 	return beta, nil
 }
@@ -4885,6 +4893,10 @@ func (backendService *BackendService) ToGA() (*compute.BackendService, error) {
 			ga.LogConfig.ForceSendFields = []string{"Enable", "SampleRate"}
 		}
 	}
+	// Backends keep their force send fields, e.g. a CapacityScaler of 0.
+	for i := range ga.Backends {
+		ga.Backends[i].ForceSendFields = backendService.Backends[i].ForceSendFields
+	}
 
 	return ga, nil
 }
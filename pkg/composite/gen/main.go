@@ -936,6 +936,10 @@ func ({{$type.VarName}} *{{$type.Name}}) To{{$version}}() (*compute{{$extension}
 			{{$lower}}.LogConfig.ForceSendFields = []string{"Enable", "SampleRate"}
 		}
 	}
+	// Backends keep their force send fields, e.g. a CapacityScaler of 0.
+	for i := range {{$lower}}.Backends {
+		{{$lower}}.Backends[i].ForceSendFields = {{$type.VarName}}.Backends[i].ForceSendFields
+	}
 	{{- end}}
 
 	return {{$lower}}, nil
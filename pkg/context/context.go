@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	informerv1 "k8s.io/client-go/informers/core/v1"
 	discoveryinformer "k8s.io/client-go/informers/discovery/v1"
 	informernetworking "k8s.io/client-go/informers/networking/v1"
@@ -40,6 +41,7 @@ import (
 	"k8s.io/ingress-gce/pkg/flags"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 	informerfrontendconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/informers/externalversions/frontendconfig/v1beta1"
+	ingsyncstatusclient "k8s.io/ingress-gce/pkg/ingsyncstatus/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/instancegroups"
 	l4metrics "k8s.io/ingress-gce/pkg/l4lb/metrics"
 	"k8s.io/ingress-gce/pkg/metrics"
@@ -65,12 +67,21 @@ const (
 
 // ControllerContext holds the state needed for the execution of the controller.
 type ControllerContext struct {
-	KubeClient          kubernetes.Interface
-	SvcNegClient        svcnegclient.Interface
-	SAClient            serviceattachmentclient.Interface
-	FirewallClient      firewallclient.Interface
-	EventRecorderClient kubernetes.Interface
-	NodeTopologyClient  nodetopologyclient.Interface
+	KubeClient           kubernetes.Interface
+	BackendConfigClient  backendconfigclient.Interface
+	FrontendConfigClient frontendconfigclient.Interface
+	SvcNegClient         svcnegclient.Interface
+	SAClient             serviceattachmentclient.Interface
+	FirewallClient       firewallclient.Interface
+	EventRecorderClient  kubernetes.Interface
+	NodeTopologyClient   nodetopologyclient.Interface
+
+	// IngressSyncStatusClient is nil if the IngressSyncStatus resources are
+	// not reported.
+	IngressSyncStatusClient ingsyncstatusclient.Interface
+	// GatewayClient is the client of the Gateway API resources. It is nil
+	// if the Gateway controller is disabled.
+	GatewayClient dynamic.Interface
 
 	Cloud *gce.Cloud
 
@@ -118,6 +129,7 @@ type ControllerContextConfig struct {
 	NumL4Workers      int
 	NumL4NetLBWorkers int
 	ReadOnlyMode      bool
+	IngressPlanMode   bool
 	// DefaultBackendSvcPortID is the ServicePort for the system default backend.
 	DefaultBackendSvcPort                     utils.ServicePort
 	HealthCheckPath                           string
@@ -150,6 +162,7 @@ func NewControllerContext(
 	saClient serviceattachmentclient.Interface,
 	networkClient networkclient.Interface,
 	nodeTopologyClient nodetopologyclient.Interface,
+	ingSyncStatusClient ingsyncstatusclient.Interface,
 	eventRecorderClient kubernetes.Interface,
 	cloud *gce.Cloud,
 	clusterNamer *namer.Namer,
@@ -172,11 +185,14 @@ func NewControllerContext(
 
 	context := &ControllerContext{
 		KubeClient:              kubeClient,
+		BackendConfigClient:     backendConfigClient,
+		FrontendConfigClient:    frontendConfigClient,
 		FirewallClient:          firewallClient,
 		SvcNegClient:            svcnegClient,
 		SAClient:                saClient,
 		EventRecorderClient:     eventRecorderClient,
 		NodeTopologyClient:      nodeTopologyClient,
+		IngressSyncStatusClient: ingSyncStatusClient,
 		Cloud:                   cloud,
 		ClusterNamer:            clusterNamer,
 		L4Namer:                 namer.NewL4Namer(string(kubeSystemUID), clusterNamer),
@@ -246,6 +262,7 @@ func NewControllerContext(
 		context,
 		flags.F.EnableTransparentHealthChecks,
 		context.EnableIngressRegionalExternal,
+		context.Cloud.Region(),
 		logger,
 	)
 	// The subnet specified in gce.conf is considered as the default subnet.
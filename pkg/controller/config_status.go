@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/backendconfig"
+	ctrlerrors "k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/klog/v2"
+)
+
+// configResult is the result of the last sync of an Ingress for one of the
+// BackendConfigs or FrontendConfigs it references.
+type configResult struct {
+	// invalid is the validation error of the config, if any.
+	invalid string
+	// syncErr is the error of the sync of the GCE resources of the config,
+	// if any.
+	syncErr string
+}
+
+// configResults tracks the results of the Ingresses for the configs they
+// reference, so that the conditions of a config shared by several Ingresses
+// aggregate the results of all of them, instead of reporting the result of
+// the last synced Ingress. The zero value is ready to use.
+type configResults struct {
+	mu sync.Mutex
+	// results are the results by config key and Ingress key.
+	results map[string]map[string]configResult
+}
+
+// set records the results of the Ingress of the given key by config key, and
+// returns the keys of the configs whose status may need an update: those of
+// the results, and those whose results changed. If replace is true, the
+// Ingress is also removed from the results of the other configs.
+func (r *configResults) set(ingKey string, results map[string]configResult, replace bool) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.results == nil {
+		r.results = map[string]map[string]configResult{}
+	}
+
+	var keys []string
+	if replace {
+		for configKey, byIngress := range r.results {
+			if _, ok := byIngress[ingKey]; !ok {
+				continue
+			}
+			if _, ok := results[configKey]; !ok {
+				delete(byIngress, ingKey)
+				if len(byIngress) == 0 {
+					delete(r.results, configKey)
+				}
+				keys = append(keys, configKey)
+			}
+		}
+	}
+	for configKey, result := range results {
+		byIngress, ok := r.results[configKey]
+		if !ok {
+			byIngress = map[string]configResult{}
+			r.results[configKey] = byIngress
+		}
+		byIngress[ingKey] = result
+		keys = append(keys, configKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// get returns the results of the Ingresses for the config of the given key,
+// by Ingress key.
+func (r *configResults) get(configKey string) map[string]configResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := map[string]configResult{}
+	for ingKey, result := range r.results[configKey] {
+		results[ingKey] = result
+	}
+	return results
+}
+
+// aggregatedCondition is an Accepted or Programmed condition aggregated from
+// the results of the Ingresses of a config.
+type aggregatedCondition struct {
+	status  apiv1.ConditionStatus
+	reason  string
+	message string
+}
+
+// aggregateResults returns the Accepted and Programmed conditions and the
+// sorted Ingress names of the results of a config. The config is not accepted
+// if it is invalid for some Ingress, and not programmed if it is invalid or
+// failed to sync for some Ingress. The messages name the Ingresses.
+func aggregateResults(results map[string]configResult, acceptedReason, programmedReason, invalidReason, syncFailedReason string) (aggregatedCondition, aggregatedCondition, []string) {
+	var names, invalid, failed []string
+	for ingKey, result := range results {
+		_, name, _ := strings.Cut(ingKey, "/")
+		names = append(names, name)
+		if result.invalid != "" {
+			invalid = append(invalid, fmt.Sprintf("Ingress %s: %s", name, result.invalid))
+		}
+		if result.syncErr != "" {
+			failed = append(failed, fmt.Sprintf("Ingress %s: %s", name, result.syncErr))
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(invalid)
+	sort.Strings(failed)
+
+	accepted := aggregatedCondition{status: apiv1.ConditionTrue, reason: acceptedReason}
+	programmed := aggregatedCondition{status: apiv1.ConditionTrue, reason: programmedReason}
+	switch {
+	case len(invalid) > 0:
+		accepted = aggregatedCondition{status: apiv1.ConditionFalse, reason: invalidReason, message: strings.Join(invalid, "; ")}
+		programmed = accepted
+	case len(failed) > 0:
+		programmed = aggregatedCondition{status: apiv1.ConditionFalse, reason: syncFailedReason, message: strings.Join(failed, "; ")}
+	}
+	return accepted, programmed, names
+}
+
+// updateFrontendConfigStatus records the result of the load balancer sync of
+// the Ingress for its FrontendConfig, and updates the Accepted and Programmed
+// conditions and the Ingresses of the FrontendConfig, and of the one the
+// Ingress used before.
+// A nil feConfig removes the Ingress from the FrontendConfig it used.
+// Failing to update the status does not fail the sync.
+func (lbc *LoadBalancerController) updateFrontendConfigStatus(ingKey string, feConfig *frontendconfigv1beta1.FrontendConfig, syncErr error, ingLogger klog.Logger) {
+	results := map[string]configResult{}
+	if feConfig != nil {
+		var result configResult
+		switch {
+		case loadbalancers.IsFrontendConfigError(syncErr):
+			result.invalid = syncErr.Error()
+		case syncErr != nil:
+			result.syncErr = syncErr.Error()
+		}
+		results[feConfig.Namespace+"/"+feConfig.Name] = result
+	}
+	for _, configKey := range lbc.frontendConfigResults.set(ingKey, results, true) {
+		lbc.patchFrontendConfigStatus(configKey, ingLogger)
+	}
+}
+
+// patchFrontendConfigStatus sets the aggregated conditions and Ingresses of
+// the FrontendConfig of the given key, read from the informer store. The
+// status is only patched if it changed. Failing to update the status does not
+// fail the sync.
+func (lbc *LoadBalancerController) patchFrontendConfigStatus(configKey string, ingLogger klog.Logger) {
+	if lbc.ctx.FrontendConfigClient == nil || lbc.ctx.FrontendConfigInformer == nil || lbc.plan.Enabled() {
+		return
+	}
+	current, exists, err := lbc.ctx.FrontendConfigs().GetByKey(configKey)
+	if err != nil || !exists {
+		return
+	}
+
+	accepted, programmed, names := aggregateResults(lbc.frontendConfigResults.get(configKey), frontendconfigv1beta1.AcceptedReason, frontendconfigv1beta1.ProgrammedReason, frontendconfigv1beta1.InvalidReason, frontendconfigv1beta1.SyncFailedReason)
+	now := metav1.Now()
+	status := current.Status.DeepCopy()
+	for _, c := range []struct {
+		conditionType string
+		condition     aggregatedCondition
+	}{
+		{frontendconfigv1beta1.AcceptedCondition, accepted},
+		{frontendconfigv1beta1.ProgrammedCondition, programmed},
+	} {
+		frontendconfig.SetCondition(status, frontendconfigv1beta1.Condition{
+			Type:               c.conditionType,
+			Status:             c.condition.status,
+			ObservedGeneration: current.Generation,
+			LastTransitionTime: now,
+			Reason:             c.condition.reason,
+			Message:            c.condition.message,
+		})
+	}
+	status.Ingresses = names
+	if err := frontendconfig.PatchStatus(lbc.ctx.FrontendConfigClient, current, *status); err != nil {
+		ingLogger.Error(err, "Failed to update FrontendConfig status", "frontendConfig", klog.KObj(current))
+	}
+}
+
+// updateBackendConfigStatuses records the results of the backend service
+// syncs of the Ingress by BackendConfig key, and updates the status of the
+// BackendConfigs, and of those the Ingress no longer uses. If the sync was not
+// complete, the results of the BackendConfigs that were not synced are kept.
+func (lbc *LoadBalancerController) updateBackendConfigStatuses(ingKey string, syncErrs map[string]error, complete bool, ingLogger klog.Logger) {
+	results := map[string]configResult{}
+	for configKey, err := range syncErrs {
+		var result configResult
+		if err != nil {
+			result.syncErr = err.Error()
+		}
+		results[configKey] = result
+	}
+	for _, configKey := range lbc.backendConfigResults.set(ingKey, results, complete) {
+		lbc.patchBackendConfigStatus(configKey, ingLogger)
+	}
+}
+
+// updateInvalidBackendConfigStatus marks the BackendConfigs that failed
+// validation during the translation of the Ingress as not accepted.
+func (lbc *LoadBalancerController) updateInvalidBackendConfigStatus(ingKey string, translateErrs []error, ingLogger klog.Logger) {
+	results := map[string]configResult{}
+	for _, err := range translateErrs {
+		var validationErr ctrlerrors.ErrBackendConfigValidation
+		if !errors.As(err, &validationErr) {
+			continue
+		}
+		results[validationErr.BackendConfig.Namespace+"/"+validationErr.BackendConfig.Name] = configResult{invalid: validationErr.Err.Error()}
+	}
+	for _, configKey := range lbc.backendConfigResults.set(ingKey, results, false) {
+		lbc.patchBackendConfigStatus(configKey, ingLogger)
+	}
+}
+
+// forgetConfigResults removes the results of a deleted Ingress, and updates
+// the status of the configs it referenced.
+func (lbc *LoadBalancerController) forgetConfigResults(ingKey string, ingLogger klog.Logger) {
+	for _, configKey := range lbc.backendConfigResults.set(ingKey, nil, true) {
+		lbc.patchBackendConfigStatus(configKey, ingLogger)
+	}
+	for _, configKey := range lbc.frontendConfigResults.set(ingKey, nil, true) {
+		lbc.patchFrontendConfigStatus(configKey, ingLogger)
+	}
+}
+
+// patchBackendConfigStatus sets the aggregated conditions and Ingresses of
+// the BackendConfig of the given key, read from the informer store. The
+// status is only patched if it changed. Failing to update the status does not
+// fail the sync.
+func (lbc *LoadBalancerController) patchBackendConfigStatus(configKey string, ingLogger klog.Logger) {
+	if lbc.ctx.BackendConfigClient == nil || lbc.plan.Enabled() {
+		return
+	}
+	current, exists, err := lbc.ctx.BackendConfigs().GetByKey(configKey)
+	if err != nil || !exists {
+		return
+	}
+
+	accepted, programmed, names := aggregateResults(lbc.backendConfigResults.get(configKey), backendconfigv1.AcceptedReason, backendconfigv1.ProgrammedReason, backendconfigv1.InvalidReason, backendconfigv1.SyncFailedReason)
+	now := metav1.Now()
+	status := current.Status.DeepCopy()
+	for _, c := range []struct {
+		conditionType string
+		condition     aggregatedCondition
+	}{
+		{backendconfigv1.AcceptedCondition, accepted},
+		{backendconfigv1.ProgrammedCondition, programmed},
+	} {
+		backendconfig.SetCondition(status, backendconfigv1.Condition{
+			Type:               c.conditionType,
+			Status:             c.condition.status,
+			ObservedGeneration: current.Generation,
+			LastTransitionTime: now,
+			Reason:             c.condition.reason,
+			Message:            c.condition.message,
+		})
+	}
+	status.Ingresses = names
+	if err := backendconfig.PatchStatus(lbc.ctx.BackendConfigClient, current, *status); err != nil {
+		ingLogger.Error(err, "Failed to update BackendConfig status", "backendConfig", klog.KObj(current))
+	}
+}
+
+// isFrontendConfigStatusUpdate returns true if only the status of the
+// FrontendConfig changed between old and cur.
+func isFrontendConfigStatusUpdate(old, cur *frontendconfigv1beta1.FrontendConfig) bool {
+	return old.Generation == cur.Generation && reflect.DeepEqual(old.Spec, cur.Spec) && reflect.DeepEqual(old.Annotations, cur.Annotations) && !reflect.DeepEqual(old.Status, cur.Status)
+}
+
+// isBackendConfigStatusUpdate returns true if only the status of the
+// BackendConfig changed between old and cur.
+func isBackendConfigStatusUpdate(old, cur *backendconfigv1.BackendConfig) bool {
+	return old.Generation == cur.Generation && reflect.DeepEqual(old.Spec, cur.Spec) && reflect.DeepEqual(old.Annotations, cur.Annotations) && !reflect.DeepEqual(old.Status, cur.Status)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	context2 "context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	ctrlerrors "k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// feConditionStatuses returns the status and reason of the conditions by type.
+func feConditionStatuses(conditions []frontendconfigv1beta1.Condition) map[string]string {
+	statuses := map[string]string{}
+	for _, c := range conditions {
+		statuses[c.Type] = fmt.Sprintf("%s/%s", c.Status, c.Reason)
+	}
+	return statuses
+}
+
+// beConditionStatuses returns the status and reason of the conditions by type.
+func beConditionStatuses(conditions []backendconfigv1.Condition) map[string]string {
+	statuses := map[string]string{}
+	for _, c := range conditions {
+		statuses[c.Type] = fmt.Sprintf("%s/%s", c.Status, c.Reason)
+	}
+	return statuses
+}
+
+func TestUpdateFrontendConfigStatus(t *testing.T) {
+	lbc, err := newLoadBalancerController()
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "fc", Namespace: "default", Generation: 2},
+	}
+	if _, err := lbc.ctx.FrontendConfigClient.NetworkingV1beta1().FrontendConfigs(feConfig.Namespace).Create(context2.TODO(), feConfig, meta_v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lbc.ctx.FrontendConfigInformer.GetIndexer().Add(feConfig)
+
+	// getFrontendConfig returns the FrontendConfig, and updates the informer
+	// store with it.
+	getFrontendConfig := func() *frontendconfigv1beta1.FrontendConfig {
+		t.Helper()
+		fc, err := lbc.ctx.FrontendConfigClient.NetworkingV1beta1().FrontendConfigs(feConfig.Namespace).Get(context2.TODO(), feConfig.Name, meta_v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lbc.ctx.FrontendConfigInformer.GetIndexer().Update(fc)
+		return fc
+	}
+
+	lbc.updateFrontendConfigStatus("default/ing-b", feConfig, nil, klog.TODO())
+	lbc.updateFrontendConfigStatus("default/ing-a", feConfig, nil, klog.TODO())
+	fc := getFrontendConfig()
+	want := map[string]string{
+		frontendconfigv1beta1.AcceptedCondition:   "True/" + frontendconfigv1beta1.AcceptedReason,
+		frontendconfigv1beta1.ProgrammedCondition: "True/" + frontendconfigv1beta1.ProgrammedReason,
+	}
+	if diff := cmp.Diff(want, feConditionStatuses(fc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after a successful sync (-want +got):\n%s", diff)
+	}
+	for _, c := range fc.Status.Conditions {
+		if c.ObservedGeneration != feConfig.Generation {
+			t.Errorf("Condition %s has observedGeneration %d, want %d", c.Type, c.ObservedGeneration, feConfig.Generation)
+		}
+	}
+	if diff := cmp.Diff([]string{"ing-a", "ing-b"}, fc.Status.Ingresses); diff != "" {
+		t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+	}
+
+	// The failure of an Ingress is reported until it syncs successfully,
+	// whatever the result of the other Ingresses.
+	accepted := fc.Status.Conditions[0]
+	lbc.updateFrontendConfigStatus("default/ing-a", fc, fmt.Errorf("quota exceeded"), klog.TODO())
+	lbc.updateFrontendConfigStatus("default/ing-b", fc, nil, klog.TODO())
+	fc = getFrontendConfig()
+	want[frontendconfigv1beta1.ProgrammedCondition] = "False/" + frontendconfigv1beta1.SyncFailedReason
+	if diff := cmp.Diff(want, feConditionStatuses(fc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after a failed sync (-want +got):\n%s", diff)
+	}
+	if got, wantMsg := fc.Status.Conditions[1].Message, "Ingress ing-a: quota exceeded"; got != wantMsg {
+		t.Errorf("Programmed condition message = %q, want %q", got, wantMsg)
+	}
+	if got := fc.Status.Conditions[0]; !got.LastTransitionTime.Equal(&accepted.LastTransitionTime) {
+		t.Errorf("Accepted condition transitioned at %v, want unchanged %v", got.LastTransitionTime, accepted.LastTransitionTime)
+	}
+
+	// An Ingress that no longer uses the FrontendConfig is removed from it.
+	lbc.updateFrontendConfigStatus("default/ing-a", nil, nil, klog.TODO())
+	fc = getFrontendConfig()
+	want[frontendconfigv1beta1.ProgrammedCondition] = "True/" + frontendconfigv1beta1.ProgrammedReason
+	if diff := cmp.Diff(want, feConditionStatuses(fc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after the failed Ingress was removed (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ing-b"}, fc.Status.Ingresses); diff != "" {
+		t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateBackendConfigStatus(t *testing.T) {
+	lbc, err := newLoadBalancerController()
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+
+	beConfig := &backendconfigv1.BackendConfig{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bc", Namespace: "default", Generation: 3},
+	}
+	if _, err := lbc.ctx.BackendConfigClient.CloudV1().BackendConfigs(beConfig.Namespace).Create(context2.TODO(), beConfig, meta_v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lbc.ctx.BackendConfigInformer.GetIndexer().Add(beConfig)
+	configKey := beConfig.Namespace + "/" + beConfig.Name
+
+	// getBackendConfig returns the BackendConfig, and updates the informer
+	// store with it.
+	getBackendConfig := func() *backendconfigv1.BackendConfig {
+		t.Helper()
+		bc, err := lbc.ctx.BackendConfigClient.CloudV1().BackendConfigs(beConfig.Namespace).Get(context2.TODO(), beConfig.Name, meta_v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lbc.ctx.BackendConfigInformer.GetIndexer().Update(bc)
+		return bc
+	}
+
+	lbc.updateInvalidBackendConfigStatus("default/ing", []error{
+		fmt.Errorf("unrelated error"),
+		ctrlerrors.ErrBackendConfigValidation{BackendConfig: *beConfig, Err: fmt.Errorf("invalid session affinity")},
+	}, klog.TODO())
+	bc := getBackendConfig()
+	want := map[string]string{
+		backendconfigv1.AcceptedCondition:   "False/" + backendconfigv1.InvalidReason,
+		backendconfigv1.ProgrammedCondition: "False/" + backendconfigv1.InvalidReason,
+	}
+	if diff := cmp.Diff(want, beConditionStatuses(bc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after a validation error (-want +got):\n%s", diff)
+	}
+	if got, wantMsg := bc.Status.Conditions[0].Message, "Ingress ing: invalid session affinity"; got != wantMsg {
+		t.Errorf("Accepted condition message = %q, want %q", got, wantMsg)
+	}
+	if diff := cmp.Diff([]string{"ing"}, bc.Status.Ingresses); diff != "" {
+		t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+	}
+
+	lbc.updateBackendConfigStatuses("default/ing", map[string]error{configKey: nil}, true, klog.TODO())
+	bc = getBackendConfig()
+	want = map[string]string{
+		backendconfigv1.AcceptedCondition:   "True/" + backendconfigv1.AcceptedReason,
+		backendconfigv1.ProgrammedCondition: "True/" + backendconfigv1.ProgrammedReason,
+	}
+	if diff := cmp.Diff(want, beConditionStatuses(bc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after a successful sync (-want +got):\n%s", diff)
+	}
+	for _, c := range bc.Status.Conditions {
+		if c.ObservedGeneration != beConfig.Generation {
+			t.Errorf("Condition %s has observedGeneration %d, want %d", c.Type, c.ObservedGeneration, beConfig.Generation)
+		}
+	}
+
+	// The failure of another Ingress is kept when the first Ingress syncs
+	// again, until the other Ingress is deleted.
+	lbc.updateBackendConfigStatuses("default/other", map[string]error{configKey: fmt.Errorf("quota exceeded")}, false, klog.TODO())
+	lbc.updateBackendConfigStatuses("default/ing", map[string]error{configKey: nil}, true, klog.TODO())
+	bc = getBackendConfig()
+	want[backendconfigv1.ProgrammedCondition] = "False/" + backendconfigv1.SyncFailedReason
+	if diff := cmp.Diff(want, beConditionStatuses(bc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after a failed sync of another Ingress (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ing", "other"}, bc.Status.Ingresses); diff != "" {
+		t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+	}
+
+	lbc.forgetConfigResults("default/other", klog.TODO())
+	bc = getBackendConfig()
+	want[backendconfigv1.ProgrammedCondition] = "True/" + backendconfigv1.ProgrammedReason
+	if diff := cmp.Diff(want, beConditionStatuses(bc.Status.Conditions)); diff != "" {
+		t.Errorf("Unexpected conditions after the other Ingress was deleted (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ing"}, bc.Status.Ingresses); diff != "" {
+		t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfigResultsSet(t *testing.T) {
+	var r configResults
+	if got := r.set("default/ing", map[string]configResult{"default/a": {}, "default/b": {}}, true); !reflect.DeepEqual(got, []string{"default/a", "default/b"}) {
+		t.Errorf("set() = %v, want both configs", got)
+	}
+	// Without replace, the results of the other configs are kept.
+	if got := r.set("default/ing", map[string]configResult{"default/a": {syncErr: "error"}}, false); !reflect.DeepEqual(got, []string{"default/a"}) {
+		t.Errorf("set() = %v, want [default/a]", got)
+	}
+	if got := r.get("default/b"); len(got) != 1 {
+		t.Errorf("get(default/b) = %v, want the result of the Ingress", got)
+	}
+	// With replace, the Ingress is removed from the configs it no longer
+	// uses.
+	if got := r.set("default/ing", map[string]configResult{"default/c": {}}, true); !reflect.DeepEqual(got, []string{"default/a", "default/b", "default/c"}) {
+		t.Errorf("set() = %v, want all configs", got)
+	}
+	if got := r.get("default/a"); len(got) != 0 {
+		t.Errorf("get(default/a) = %v, want no results", got)
+	}
+}
+
+func TestIsFrontendConfigStatusUpdate(t *testing.T) {
+	old := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "fc", Generation: 1},
+	}
+	statusUpdate := old.DeepCopy()
+	statusUpdate.Status.Ingresses = []string{"ing"}
+	specUpdate := statusUpdate.DeepCopy()
+	specUpdate.Generation = 2
+	specUpdate.Spec.SslPolicy = ptr.To("policy")
+
+	if !isFrontendConfigStatusUpdate(old, statusUpdate) {
+		t.Errorf("isFrontendConfigStatusUpdate() = false for a status update, want true")
+	}
+	if isFrontendConfigStatusUpdate(old, specUpdate) {
+		t.Errorf("isFrontendConfigStatusUpdate() = true for a spec update, want false")
+	}
+}
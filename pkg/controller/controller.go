@@ -25,9 +25,12 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	unversionedcore "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -40,6 +43,7 @@ import (
 	"k8s.io/ingress-gce/pkg/common/operator"
 	"k8s.io/ingress-gce/pkg/context"
 	legacytranslator "k8s.io/ingress-gce/pkg/controller/translator"
+	"k8s.io/ingress-gce/pkg/drift"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/frontendconfig"
@@ -51,6 +55,7 @@ import (
 	activecontrollermetrics "k8s.io/ingress-gce/pkg/metrics/activecontroller"
 	negmetrics "k8s.io/ingress-gce/pkg/neg/metrics"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/plan"
 	ingsync "k8s.io/ingress-gce/pkg/sync"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -84,12 +89,23 @@ type LoadBalancerController struct {
 
 	// syncer implementation for backends
 	backendSyncer *backends.Syncer
+	// bucketSyncer applies the BackendConfigs of the backend buckets.
+	bucketSyncer *backends.BucketSyncer
 	// backendLock locks the SyncBackend function to avoid conflicts between
 	// multiple ingress workers.
 	backendLock sync.Mutex
 
 	// gcLock locks the GC logics to avoid conflicts between multiple ingress workers.
 	gcLock sync.Mutex
+	// groupLocks serializes the syncs of the ingresses of each group, since
+	// they share a load balancer. They are locked before gcLock.
+	groupLocks ingressGroupLocks
+
+	// backendConfigResults and frontendConfigResults are the results of the
+	// Ingresses for the configs they reference, reported in the status of
+	// the configs.
+	backendConfigResults  configResults
+	frontendConfigResults configResults
 
 	// linker implementations for backends
 	negLinker backends.Linker
@@ -107,6 +123,21 @@ type LoadBalancerController struct {
 
 	backendPool *backends.Pool
 
+	// extraIngresses returns the Ingresses that are not in the cluster,
+	// such as those translated from Gateway API resources, whose backends
+	// are kept by the backend GC. It is nil if there are none.
+	extraIngresses func() ([]*v1.Ingress, error)
+
+	// plan records the GCE mutations of a sync instead of making them, if
+	// the controller runs in plan mode.
+	plan *plan.Recorder
+	// planLock serializes the syncs in plan mode, so that the operations
+	// recorded by plan belong to a single Ingress.
+	planLock sync.Mutex
+	// drift detects the changes made to the GCE resources outside of the
+	// controller, if enabled.
+	drift *drift.Detector
+
 	logger klog.Logger
 }
 
@@ -124,12 +155,21 @@ func NewLoadBalancerController(
 		Interface: ctx.EventRecorderClient.CoreV1().Events(""),
 	})
 
-	healthChecker := healthchecks.NewHealthChecker(ctx.Cloud, ctx.HealthCheckPath, ctx.DefaultBackendSvcPort.ID.Service, ctx, ctx.Translator, healthchecks.HealthcheckFlags{
+	var planRecorder *plan.Recorder
+	if ctx.IngressPlanMode {
+		logger.Info("Running in plan mode, GCE resources will not be mutated")
+		planRecorder = plan.NewRecorder()
+	}
+	var driftDetector *drift.Detector
+	if flags.F.EnableIngressDriftDetection {
+		driftDetector = drift.NewDetector()
+	}
+	healthChecker := healthchecks.NewHealthCheckerWithPlan(ctx.Cloud, ctx.HealthCheckPath, ctx.DefaultBackendSvcPort.ID.Service, ctx, ctx.Translator, healthchecks.HealthcheckFlags{
 		EnableTHC: flags.F.EnableTransparentHealthChecks,
 		EnableRecalculationOnBackendConfigRemoval: flags.F.EnableRecalculateUHCOnBCRemoval,
 		THCPort: int64(flags.F.THCPort),
-	})
-	backendPool := backends.NewPool(ctx.Cloud, ctx.ClusterNamer)
+	}, planRecorder, driftDetector)
+	backendPool := backends.NewPoolWithPlan(ctx.Cloud, ctx.ClusterNamer, planRecorder, driftDetector)
 
 	enableMultiSubnetClusterPhase1 := flags.F.EnableMultiSubnetClusterPhase1
 
@@ -139,14 +179,17 @@ func NewLoadBalancerController(
 		stopCh:                         stopCh,
 		hasSynced:                      ctx.HasSynced,
 		instancePool:                   ctx.InstancePool,
-		l7Pool:                         loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID, logger), logger),
-		backendSyncer:                  backends.NewBackendSyncer(backendPool, healthChecker, ctx.Cloud, ctx.Translator),
+		l7Pool:                         loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID, logger), planRecorder, driftDetector, logger),
+		backendSyncer:                  backends.NewBackendSyncer(backendPool, healthChecker, ctx.Cloud, ctx.Translator, ctx),
+		bucketSyncer:                   backends.NewBucketSyncer(backends.NewBackendBuckets(ctx.Cloud, logger), planRecorder),
 		negLinker:                      backends.NewNEGLinker(backendPool, negtypes.NewAdapter(ctx.Cloud, negmetrics.NewNegMetrics()), ctx.Cloud, ctx.SvcNegInformer.GetIndexer(), logger),
 		igLinker:                       backends.NewInstanceGroupLinker(ctx.InstancePool, backendPool, logger),
 		metrics:                        ctx.ControllerMetrics,
 		ZoneGetter:                     ctx.ZoneGetter,
 		enableMultiSubnetClusterPhase1: enableMultiSubnetClusterPhase1,
 		backendPool:                    backendPool,
+		plan:                           planRecorder,
+		drift:                          driftDetector,
 		logger:                         logger,
 	}
 
@@ -245,7 +288,9 @@ func NewLoadBalancerController(
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 		UpdateFunc: func(old, cur interface{}) {
-			if !reflect.DeepEqual(old, cur) {
+			// Status updates are written by the controller and do not
+			// require a sync.
+			if !reflect.DeepEqual(old, cur) && !isBackendConfigStatusUpdate(old.(*backendconfigv1.BackendConfig), cur.(*backendconfigv1.BackendConfig)) {
 				logger.Info("obj updated", "type", fmt.Sprintf("%T", cur))
 				beConfig := cur.(*backendconfigv1.BackendConfig)
 				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List(), logger)).AsList()
@@ -285,7 +330,9 @@ func NewLoadBalancerController(
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 		UpdateFunc: func(old, cur interface{}) {
-			if !reflect.DeepEqual(old, cur) {
+			// Status updates are written by the controller and do not
+			// require a sync.
+			if !reflect.DeepEqual(old, cur) && !isFrontendConfigStatusUpdate(old.(*frontendconfigv1beta1.FrontendConfig), cur.(*frontendconfigv1beta1.FrontendConfig)) {
 				feConfig := cur.(*frontendconfigv1beta1.FrontendConfig)
 				logger.Info("FrontendConfig updated", "feConfigName", klog.KRef(feConfig.Namespace, feConfig.Name))
 				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesFrontendConfig(feConfig).AsList()
@@ -338,6 +385,13 @@ func NewLoadBalancerController(
 	return &lbc
 }
 
+// SetExtraIngresses sets the function that returns the Ingresses that are
+// not in the cluster, such as those translated from Gateway API resources,
+// whose backends are kept by the backend GC.
+func (lbc *LoadBalancerController) SetExtraIngresses(list func() ([]*v1.Ingress, error)) {
+	lbc.extraIngresses = list
+}
+
 // SystemHealth performs a health check showing if ingress controller is healthy.
 func (lbc *LoadBalancerController) SystemHealth() error {
 	name := "k8s-ingress-svc-acct-permission-check-probe"
@@ -403,10 +457,12 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klo
 	if !ok {
 		return fmt.Errorf("expected state type to be syncState, type was %T", state)
 	}
-	ingSvcPorts := syncState.urlMap.AllServicePorts()
+	ingSvcPorts := lbc.sharedDriftPolicy(syncState.urlMap.AllServicePorts(), ingLogger)
 
 	// Only sync instance group when IG is used for this ingress
-	if len(nodePorts(ingSvcPorts)) > 0 {
+	if len(nodePorts(ingSvcPorts)) > 0 && lbc.plan.Enabled() {
+		ingLogger.Info("Skipping syncing instance groups since the controller is in plan mode")
+	} else if len(nodePorts(ingSvcPorts)) > 0 {
 		if err := lbc.syncInstanceGroup(syncState.ing, ingSvcPorts, ingLogger); err != nil {
 			ingLogger.Error(err, "Failed to sync instance group", "ingress", syncState.ing)
 			return err
@@ -415,11 +471,64 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klo
 		ingLogger.Info("Skip syncing instance groups")
 	}
 
-	// Sync the backends
-	if err := lbc.backendSyncer.Sync(ingSvcPorts, ingLogger); err != nil {
+	// Sync the backends one at a time to report the result in the status of
+	// their BackendConfig.
+	ingKey := syncState.ing.Namespace + "/" + syncState.ing.Name
+	beConfigErrs := map[string]error{}
+	for _, sp := range ingSvcPorts {
+		err := lbc.backendSyncer.Sync([]utils.ServicePort{sp}, ingLogger)
+		if sp.BackendConfig != nil {
+			configKey := sp.BackendConfig.Namespace + "/" + sp.BackendConfig.Name
+			if beConfigErrs[configKey] == nil {
+				beConfigErrs[configKey] = err
+			}
+		}
+		if err != nil {
+			lbc.updateBackendConfigStatuses(ingKey, beConfigErrs, false, ingLogger)
+			return err
+		}
+	}
+	lbc.updateBackendConfigStatuses(ingKey, beConfigErrs, true, ingLogger)
+	if err := lbc.bucketSyncer.Sync(syncState.urlMap.AllBackendBuckets(), ingLogger); err != nil {
 		return err
 	}
 
+	return lbc.linkBackends(ingSvcPorts, ingLogger)
+}
+
+// sharedDriftPolicy returns the service ports with DriftPolicyAlert reset to
+// DriftPolicyReconcile if some Ingress that references their Service does not
+// use DriftPolicyAlert. The backend services and health checks are shared by
+// all those Ingresses, and get the same policy from each of their syncs.
+func (lbc *LoadBalancerController) sharedDriftPolicy(svcPorts []utils.ServicePort, logger klog.Logger) []utils.ServicePort {
+	ings := operator.Ingresses(lbc.ctx.Ingresses().List()).Filter(utils.IsGCEIngress).AsList()
+	if lbc.extraIngresses != nil {
+		extra, err := lbc.extraIngresses()
+		if err != nil {
+			logger.V(2).Info("Ignoring the drift policies of the extra Ingresses", "err", err)
+		}
+		ings = append(ings, extra...)
+	}
+
+	result := make([]utils.ServicePort, 0, len(svcPorts))
+	for _, sp := range svcPorts {
+		if sp.DriftPolicy == annotations.DriftPolicyAlert {
+			svc := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: sp.ID.Service.Namespace, Name: sp.ID.Service.Name}}
+			for _, ing := range operator.Ingresses(ings).ReferencesService(svc).AsList() {
+				if annotations.FromIngress(ing).DriftPolicy() != annotations.DriftPolicyAlert {
+					sp.DriftPolicy = annotations.DriftPolicyReconcile
+					break
+				}
+			}
+		}
+		result = append(result, sp)
+	}
+	return result
+}
+
+// linkBackends links the backends of the given Service ports to their
+// instance groups or NEGs.
+func (lbc *LoadBalancerController) linkBackends(svcPorts []utils.ServicePort, ingLogger klog.Logger) error {
 	// Get the zones in the default subnet our groups live in.
 	// These zones will be used for instance group based backends
 	// since multi-subnet feature do not support instance groups.
@@ -446,7 +555,7 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klo
 	}
 
 	// Link backends to groups.
-	for _, sp := range ingSvcPorts {
+	for _, sp := range svcPorts {
 		var linkErr error
 		if sp.NEGEnabled {
 			// Link backend to NEG's if the backend has NEG enabled.
@@ -463,23 +572,60 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}, ingLogger klo
 	return nil
 }
 
-// syncInstanceGroup creates instance groups, syncs instances, sets named ports and updates instance group annotation
-func (lbc *LoadBalancerController) syncInstanceGroup(ing *v1.Ingress, ingSvcPorts []utils.ServicePort, ingLogger klog.Logger) error {
-	nodePorts := nodePorts(ingSvcPorts)
-	ingLogger.Info("Syncing Instance Group", "nodePorts", nodePorts)
-	igs, err := lbc.instancePool.EnsureInstanceGroupsAndPorts(lbc.ctx.ClusterNamer.InstanceGroup(), nodePorts, ingLogger)
-	if err != nil {
+// SyncExtraBackends syncs the backends of the given Service ports of the
+// Ingresses that are not in the cluster, such as those translated from
+// Gateway API resources, and links them to their instance groups or NEGs.
+// The backends may be shared with the Ingresses of the cluster, so the lock
+// of SyncBackends is held.
+func (lbc *LoadBalancerController) SyncExtraBackends(svcPorts []utils.ServicePort, logger klog.Logger) error {
+	lbc.backendLock.Lock()
+	defer lbc.backendLock.Unlock()
+
+	svcPorts = lbc.sharedDriftPolicy(svcPorts, logger)
+	if len(nodePorts(svcPorts)) > 0 && lbc.plan.Enabled() {
+		logger.Info("Skipping syncing instance groups since the controller is in plan mode")
+	} else if len(nodePorts(svcPorts)) > 0 {
+		if _, err := lbc.ensureInstanceGroups(svcPorts, logger); err != nil {
+			return err
+		}
+	}
+	if err := lbc.backendSyncer.Sync(svcPorts, logger); err != nil {
 		return err
 	}
+	return lbc.linkBackends(svcPorts, logger)
+}
 
-	nodes, err := lbc.ZoneGetter.ListNodesInDefaultSubnet(zonegetter.CandidateNodesFilter, lbc.logger)
+// GCExtraBackends runs the backend GC of the controller, which keeps the
+// backends of the Ingresses of the cluster and of the extra Ingresses. It
+// holds the lock of the GC of the Ingresses.
+func (lbc *LoadBalancerController) GCExtraBackends(logger klog.Logger) error {
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+
+	toKeep := operator.Ingresses(lbc.ctx.Ingresses().List()).Filter(func(ing *v1.Ingress) bool {
+		return !utils.NeedsCleanup(ing)
+	}).AsList()
+	return lbc.GCBackends(toKeep, logger)
+}
+
+// BackendStates returns the health of the backends of the given load
+// balancer, for its annotations.
+func (lbc *LoadBalancerController) BackendStates(l7 *loadbalancers.L7, logger klog.Logger) (map[string]string, error) {
+	return loadbalancers.GetBackendStates(l7, lbc.backendSyncer, logger)
+}
+
+// DriftDetector returns the drift detector of the controller, which is nil
+// if drift detection is disabled.
+func (lbc *LoadBalancerController) DriftDetector() *drift.Detector {
+	return lbc.drift
+}
+
+// syncInstanceGroup creates instance groups, syncs instances, sets named ports and updates instance group annotation
+func (lbc *LoadBalancerController) syncInstanceGroup(ing *v1.Ingress, ingSvcPorts []utils.ServicePort, ingLogger klog.Logger) error {
+	igs, err := lbc.ensureInstanceGroups(ingSvcPorts, ingLogger)
 	if err != nil {
 		return err
 	}
-	// Add/remove instances to the instance groups.
-	if err = lbc.instancePool.Sync(utils.GetNodeNames(nodes), ingLogger); err != nil {
-		return err
-	}
 
 	// TODO: Remove this after deprecation
 	if utils.IsGCEMultiClusterIngress(ing) {
@@ -501,18 +647,50 @@ func (lbc *LoadBalancerController) syncInstanceGroup(ing *v1.Ingress, ingSvcPort
 	return nil
 }
 
+// ensureInstanceGroups creates the instance groups with the node ports of the
+// given Service ports, and syncs their instances.
+func (lbc *LoadBalancerController) ensureInstanceGroups(svcPorts []utils.ServicePort, ingLogger klog.Logger) ([]*compute.InstanceGroup, error) {
+	nodePorts := nodePorts(svcPorts)
+	ingLogger.Info("Syncing Instance Group", "nodePorts", nodePorts)
+	igs, err := lbc.instancePool.EnsureInstanceGroupsAndPorts(lbc.ctx.ClusterNamer.InstanceGroup(), nodePorts, ingLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := lbc.ZoneGetter.ListNodesInDefaultSubnet(zonegetter.CandidateNodesFilter, lbc.logger)
+	if err != nil {
+		return nil, err
+	}
+	// Add/remove instances to the instance groups.
+	if err = lbc.instancePool.Sync(utils.GetNodeNames(nodes), ingLogger); err != nil {
+		return nil, err
+	}
+	return igs, nil
+}
+
 // GCBackends implements Controller.
 func (lbc *LoadBalancerController) GCBackends(toKeep []*v1.Ingress, ingLogger klog.Logger) error {
 	// Only GCE ingress associated resources are managed by this controller.
 	GCEIngresses := operator.Ingresses(toKeep).Filter(utils.IsGCEIngress).AsList()
-	svcPortsToKeep := lbc.ToSvcPorts(GCEIngresses)
+	var extraIngresses []*v1.Ingress
+	if lbc.extraIngresses != nil {
+		var err error
+		if extraIngresses, err = lbc.extraIngresses(); err != nil {
+			return err
+		}
+	}
+	svcPortsToKeep := lbc.ToSvcPorts(append(GCEIngresses, extraIngresses...))
 	if err := lbc.backendSyncer.GC(svcPortsToKeep, ingLogger); err != nil {
 		return err
 	}
 	// TODO(ingress#120): Move this to the backend pool so it mirrors creation
 	// Do not delete instance group if there exists a GLBC ingress.
-	if len(toKeep) == 0 {
+	if len(toKeep) == 0 && len(extraIngresses) == 0 {
 		igName := lbc.ctx.ClusterNamer.InstanceGroup()
+		if lbc.plan.Enabled() {
+			ingLogger.Info("Skipping deleting instance group since the controller is in plan mode", "instanceGroup", igName)
+			return nil
+		}
 		ingLogger.Info("Deleting instance group", "instanceGroup", igName)
 		if err := lbc.instancePool.DeleteInstanceGroup(igName, ingLogger); err != err {
 			return err
@@ -530,13 +708,20 @@ func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}, ingLogger
 		return fmt.Errorf("expected state type to be syncState, type was %T", state)
 	}
 
-	lb, err := lbc.toRuntimeInfo(syncState.ing, syncState.urlMap, ingLogger)
+	var lb *loadbalancers.L7RuntimeInfo
+	var err error
+	if len(syncState.group) > 0 {
+		lb, err = lbc.toGroupRuntimeInfo(syncState.ing, syncState.group, syncState.urlMap, ingLogger)
+	} else {
+		lb, err = lbc.toRuntimeInfo(syncState.ing, syncState.urlMap, ingLogger)
+	}
 	if err != nil {
 		return err
 	}
 
 	// Create higher-level LB resources.
 	l7, err := lbc.l7Pool.Ensure(lb)
+	lbc.updateFrontendConfigStatus(syncState.ing.Namespace+"/"+syncState.ing.Name, lb.FrontendConfig, err, ingLogger)
 	if err != nil {
 		return err
 	}
@@ -551,7 +736,25 @@ func (lbc *LoadBalancerController) GCv1LoadBalancers(toKeep []*v1.Ingress) error
 }
 
 // GCv2LoadBalancer implements Controller.
+// The load balancer of an ingress group is only deleted with the last ingress
+// of the group in the scope. Until then, the remaining ingresses are synced
+// to remove the rules of the given ingress from the load balancer. The caller
+// holds the lock of the group, so that no ingress joins the group between
+// the check for the remaining ingresses and the deletion.
 func (lbc *LoadBalancerController) GCv2LoadBalancer(ing *v1.Ingress, scope meta.KeyType) error {
+	if group := ingressGroup(ing, lbc.logger); group != "" {
+		var remaining []*v1.Ingress
+		for _, member := range lbc.otherIngressGroupMembers(group, ing) {
+			if features.ScopeFromIngress(member) == scope {
+				remaining = append(remaining, member)
+			}
+		}
+		if len(remaining) > 0 {
+			lbc.logger.Info("Skipping GC of the load balancer of the ingress group, which is still used", "ingress", common.NamespacedName(ing), "group", group, "remainingIngresses", len(remaining))
+			lbc.ingQueue.Enqueue(convert(remaining)...)
+			return nil
+		}
+	}
 	return lbc.l7Pool.GCv2(ing, scope)
 }
 
@@ -561,6 +764,10 @@ func (lbc *LoadBalancerController) EnsureDeleteV1Finalizers(toCleanup []*v1.Ingr
 		ingLogger.Info("Removing finalizers not enabled")
 		return nil
 	}
+	if lbc.plan.Enabled() {
+		ingLogger.Info("Skipping removing finalizers since the controller is in plan mode")
+		return nil
+	}
 	for _, ing := range toCleanup {
 		ingClient := lbc.ctx.KubeClient.NetworkingV1().Ingresses(ing.Namespace)
 		if err := common.EnsureDeleteFinalizer(ing, ingClient, common.FinalizerKey, ingLogger); err != nil {
@@ -577,6 +784,10 @@ func (lbc *LoadBalancerController) EnsureDeleteV2Finalizer(ing *v1.Ingress, ingL
 		ingLogger.Info("Removing finalizers not enabled")
 		return nil
 	}
+	if lbc.plan.Enabled() {
+		ingLogger.Info("Skipping removing finalizer since the controller is in plan mode")
+		return nil
+	}
 	ingClient := lbc.ctx.KubeClient.NetworkingV1().Ingresses(ing.Namespace)
 	if err := common.EnsureDeleteFinalizer(ing, ingClient, common.FinalizerKeyV2, ingLogger); err != nil {
 		ingLogger.Error(err, "Failed to ensure delete finalizer", "finalizer", common.FinalizerKeyV2)
@@ -593,9 +804,25 @@ func (lbc *LoadBalancerController) PostProcess(state interface{}, ingLogger klog
 	if !ok {
 		return fmt.Errorf("expected state type to be syncState, type was %T", state)
 	}
+	if lbc.plan.Enabled() {
+		ingLogger.Info("Skipping updating the ingress status since the controller is in plan mode")
+		return nil
+	}
+
+	if err := lbc.gcPreviousIngressGroup(syncState.ing, ingLogger); err != nil {
+		return err
+	}
 
+	backendState, err := loadbalancers.GetBackendStates(syncState.l7, lbc.backendSyncer, ingLogger)
+	if err != nil {
+		return err
+	}
 	// Update the ingress status.
-	return lbc.updateIngressStatus(syncState.l7, syncState.ing, ingLogger)
+	if err := lbc.updateIngressStatus(syncState.l7, syncState.ing, backendState, ingLogger); err != nil {
+		return err
+	}
+	lbc.updateIngressSyncStatus(syncState.ing, syncState.l7, backendState, nil, ingLogger)
+	return nil
 }
 
 // preSyncGC is intended to execute GC logic before sync if necessary. e.g. Ingress ing has deletion timestamp.
@@ -623,6 +850,9 @@ func (lbc *LoadBalancerController) preSyncGC(key string, scope meta.KeyType, ing
 		if err == nil && ingExists {
 			lbc.metrics.DeleteIngress(key)
 		}
+		if err == nil {
+			lbc.forgetConfigResults(key, ingLogger)
+		}
 		return false, err
 	}
 	return true, nil
@@ -681,10 +911,25 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	}
 	ingLogger.Info("Syncing ingress")
 
+	if lbc.plan.Enabled() {
+		lbc.planLock.Lock()
+		defer lbc.planLock.Unlock()
+		lbc.plan.Flush()
+	}
+
 	ing, ingExists, err := lbc.ctx.Ingresses().GetByKey(key)
 	if err != nil {
 		return fmt.Errorf("error getting Ingress for key %s: %v", key, err)
 	}
+	if lbc.plan.Enabled() {
+		defer lbc.publishPlan(key, ing, ingExists, ingLogger)
+	}
+	if ingExists {
+		// The ingresses of a group are synced and garbage collected one at a
+		// time, since each of them updates or deletes the load balancer
+		// shared by the group.
+		defer lbc.groupLocks.lock(ingressGroupsToLock(ing, ingLogger)...)()
+	}
 
 	// Capture GC state for ingress.
 	scope := features.ScopeFromIngress(ing)
@@ -719,11 +964,21 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	}
 
 	// Bootstrap state for GCP sync.
-	urlMap, errs, warnings := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPort.ID, lbc.ctx.ClusterNamer)
+	var urlMap *utils.GCEURLMap
+	var errs []error
+	var warnings bool
+	var group []*v1.Ingress
+	if groupName := ingressGroup(ing, ingLogger); groupName != "" {
+		urlMap, group, errs, warnings = lbc.translateIngressGroup(groupName, ing, ingLogger)
+	} else {
+		urlMap, errs, warnings = lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPort.ID, lbc.ctx.ClusterNamer)
+	}
 
 	if errs != nil {
-		msg := fmt.Errorf("invalid ingress spec: %v", utils.JoinErrs(errs))
+		lbc.updateInvalidBackendConfigStatus(key, errs, ingLogger)
+		msg := fmt.Errorf("invalid ingress spec: %w", utils.JoinErrs(errs))
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.TranslateIngress, "Translation failed: %v", msg)
+		lbc.updateIngressSyncStatus(ing, nil, nil, msg, ingLogger)
 		return msg
 	}
 
@@ -733,10 +988,11 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	}
 
 	// Sync GCP resources.
-	syncState := &syncState{urlMap, ing, nil}
+	syncState := &syncState{urlMap: urlMap, ing: ing, group: group}
 	syncErr := lbc.ingSyncer.Sync(syncState, ingLogger)
 	if syncErr != nil {
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, "Error syncing to GCP: %v", syncErr.Error())
+		lbc.updateIngressSyncStatus(ing, nil, nil, syncErr, ingLogger)
 	} else {
 		// Insert/update the ingress state for metrics after successful sync.
 		var fc *frontendconfigv1beta1.FrontendConfig
@@ -762,7 +1018,7 @@ func (lbc *LoadBalancerController) sync(key string) error {
 
 // updateIngressStatus updates the IP and annotations of a loadbalancer.
 // The annotations are parsed by kubectl describe.
-func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing *v1.Ingress, ingLogger klog.Logger) error {
+func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing *v1.Ingress, backendState map[string]string, ingLogger klog.Logger) error {
 	ingClient := lbc.ctx.KubeClient.NetworkingV1().Ingresses(ing.Namespace)
 
 	// Update IP through update/status endpoint
@@ -786,11 +1042,24 @@ func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing
 		}
 	}
 
-	newAnnotations, err := loadbalancers.GetLBAnnotations(l7, ing.ObjectMeta.DeepCopy().Annotations, lbc.backendSyncer, ingLogger)
+	newAnnotations := loadbalancers.GetLBAnnotations(l7, ing.ObjectMeta.DeepCopy().Annotations, backendState)
+	namespaces := sets.New(ing.Namespace)
+	group := ingressGroup(ing, ingLogger)
+	if group != "" {
+		for _, member := range lbc.otherIngressGroupMembers(group, ing) {
+			namespaces.Insert(member.Namespace)
+		}
+	}
+	backendHealth, err := loadbalancers.GetBackendEndpointHealth(l7, lbc.ctx.SvcNegInformer.GetIndexer(), sets.List(namespaces)...)
 	if err != nil {
 		return err
 	}
-
+	newAnnotations = loadbalancers.SetBackendHealthAnnotation(newAnnotations, backendHealth)
+	if group != "" {
+		newAnnotations[annotations.IngressGroupStatusKey] = group
+	} else {
+		delete(newAnnotations, annotations.IngressGroupStatusKey)
+	}
 	if err := updateAnnotations(lbc.ctx.KubeClient, ing, newAnnotations, ingLogger); err != nil {
 		return err
 	}
@@ -800,28 +1069,9 @@ func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing
 // toRuntimeInfo returns L7RuntimeInfo for the given ingress.
 func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1.Ingress, urlMap *utils.GCEURLMap, ingLogger klog.Logger) (*loadbalancers.L7RuntimeInfo, error) {
 	annotations := annotations.FromIngress(ing)
-	env, err := translator.NewEnv(ing, lbc.ctx.KubeClient, "", "", "")
+	tls, err := lbc.tlsCerts(ing, ingLogger)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing translator env: %v", err)
-	}
-
-	tls, errors := translator.ToTLSCerts(env)
-	for _, err := range errors {
-		if apierrors.IsNotFound(err) {
-			msg := fmt.Sprintf("Could not find TLS certificate: %v", err)
-			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, msg)
-		} else {
-			ingLogger.Error(err, "Could not get certificates")
-			return nil, err
-		}
-	}
-
-	// Setup HTTP-only if no valid TLS certs
-	// The errors are assumed to be 404s since we short-circuit otherwise
-	if len(tls) == 0 && len(errors) > 0 {
-		// TODO: this path should be removed when external certificate managers migrate to a better solution.
-		const msg = "Could not find any TLS certificates. Continuing setup for the load balancer to serve HTTP only. Note: this behavior is deprecated and will be removed in a future version of ingress-gce"
-		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, msg)
+		return nil, err
 	}
 
 	var feConfig *frontendconfigv1beta1.FrontendConfig
@@ -832,15 +1082,24 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1.Ingress, urlMap *utils.
 	// Object in cache could be changed in-flight. Deepcopy to
 	// reduce race conditions.
 	feConfig = feConfig.DeepCopy()
+	if err := frontendconfig.Validate(feConfig, loadbalancers.LBSchemeForIngress(ing)); err != nil {
+		return nil, err
+	}
 
 	staticIPName, err := annotations.StaticIPName()
 	if err != nil {
 		return nil, err
 	}
 
+	certificateMap := annotations.CertificateMap()
+	if certificateMap == "" && feConfig != nil && feConfig.Spec.CertificateMap != nil {
+		certificateMap = *feConfig.Spec.CertificateMap
+	}
+
 	return &loadbalancers.L7RuntimeInfo{
 		TLS:            tls,
 		TLSName:        annotations.UseNamedTLS(),
+		CertificateMap: certificateMap,
 		Ingress:        ing,
 		AllowHTTP:      annotations.AllowHTTP(),
 		StaticIPName:   staticIPName,
@@ -849,6 +1108,34 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1.Ingress, urlMap *utils.
 	}, nil
 }
 
+// tlsCerts returns the certificates of the TLS secrets of the given ingress.
+func (lbc *LoadBalancerController) tlsCerts(ing *v1.Ingress, ingLogger klog.Logger) ([]*translator.TLSCerts, error) {
+	env, err := translator.NewEnv(ing, lbc.ctx.KubeClient, "", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing translator env: %v", err)
+	}
+
+	tls, errors := translator.ToTLSCerts(env)
+	for _, err := range errors {
+		if apierrors.IsNotFound(err) {
+			msg := fmt.Sprintf("Could not find TLS certificate: %v", err)
+			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, msg)
+		} else {
+			ingLogger.Error(err, "Could not get certificates")
+			return nil, err
+		}
+	}
+
+	// Setup HTTP-only if no valid TLS certs
+	// The errors are assumed to be 404s since we short-circuit otherwise
+	if len(tls) == 0 && len(errors) > 0 {
+		// TODO: this path should be removed when external certificate managers migrate to a better solution.
+		const msg = "Could not find any TLS certificates. Continuing setup for the load balancer to serve HTTP only. Note: this behavior is deprecated and will be removed in a future version of ingress-gce"
+		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, msg)
+	}
+	return tls, nil
+}
+
 func updateAnnotations(client kubernetes.Interface, ing *v1.Ingress, newAnnotations map[string]string, ingLogger klog.Logger) error {
 	if reflect.DeepEqual(ing.Annotations, newAnnotations) {
 		return nil
@@ -915,6 +1202,13 @@ func (lbc *LoadBalancerController) ensureFinalizer(ing *v1.Ingress, ingLogger kl
 	if err != nil {
 		return nil, err
 	}
+	if lbc.plan.Enabled() {
+		// Plan the sync with the naming scheme that the finalizer would select.
+		ingLogger.Info("Skipping adding finalizer since the controller is in plan mode", "finalizer", finalizerKey)
+		updatedIng := ing.DeepCopy()
+		updatedIng.Finalizers = append(updatedIng.Finalizers, finalizerKey)
+		return updatedIng, nil
+	}
 	ingClient := lbc.ctx.KubeClient.NetworkingV1().Ingresses(ing.Namespace)
 	// Update ingress with finalizer so that load-balancer pool uses correct naming scheme
 	// while ensuring frontend resources. Note that this updates only the finalizer annotation
@@ -71,6 +71,12 @@ var (
 
 // newLoadBalancerController create a loadbalancer controller.
 func newLoadBalancerController() (*LoadBalancerController, error) {
+	return newLoadBalancerControllerWithPlanMode(false)
+}
+
+// newLoadBalancerControllerWithPlanMode creates a loadbalancer controller,
+// which runs in plan mode if planMode is set.
+func newLoadBalancerControllerWithPlanMode(planMode bool) (*LoadBalancerController, error) {
 	kubeClient := fake.NewSimpleClientset()
 	backendConfigClient := backendconfigclient.NewSimpleClientset()
 	frontendConfigClient := frontendconfigclient.NewSimpleClientset()
@@ -95,8 +101,9 @@ func newLoadBalancerController() (*LoadBalancerController, error) {
 		DefaultBackendSvcPort:         test.DefaultBeSvcPort,
 		HealthCheckPath:               "/",
 		EnableIngressRegionalExternal: true,
+		IngressPlanMode:               planMode,
 	}
-	ctx, err := context.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, nil, svcNegClient, nil, nil, nil, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
+	ctx, err := context.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, nil, svcNegClient, nil, nil, nil, nil, kubeClient /*kube client to be used for events*/, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig, klog.TODO())
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize controller context")
 	}
@@ -110,7 +117,7 @@ func newLoadBalancerController() (*LoadBalancerController, error) {
 		ZoneGetter: fakeZoneGetter,
 		MaxIGSize:  1000,
 	})
-	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), klog.TODO())
+	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), lbc.plan, nil, klog.TODO())
 
 	lbc.hasSynced = func() bool { return true }
 
@@ -1221,3 +1228,51 @@ func getUpdatedIngress(t *testing.T, lbc *LoadBalancerController, ing *networkin
 	}
 	return updatedIng
 }
+
+func TestSharedDriftPolicy(t *testing.T) {
+	alert := map[string]string{annotations.DriftPolicyKey: string(annotations.DriftPolicyAlert)}
+	for _, tc := range []struct {
+		desc            string
+		otherAnnotation map[string]string
+		want            annotations.DriftPolicy
+	}{
+		{
+			desc:            "all Ingresses use the Alert policy",
+			otherAnnotation: alert,
+			want:            annotations.DriftPolicyAlert,
+		},
+		{
+			desc: "another Ingress uses the Reconcile policy",
+			want: annotations.DriftPolicyReconcile,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			lbc, err := newLoadBalancerController()
+			if err != nil {
+				t.Fatalf("newLoadBalancerController() = %v", err)
+			}
+			spec := networkingv1.IngressSpec{DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}}}}
+			ing := test.NewIngress(types.NamespacedName{Namespace: "default", Name: "alert"}, spec)
+			ing.Annotations = alert
+			addIngress(lbc, ing)
+			other := test.NewIngress(types.NamespacedName{Namespace: "default", Name: "other"}, spec)
+			other.Annotations = tc.otherAnnotation
+			addIngress(lbc, other)
+
+			svcPorts := []utils.ServicePort{
+				{ID: utils.ServicePortID{Service: types.NamespacedName{Namespace: "default", Name: "web"}}, DriftPolicy: annotations.DriftPolicyAlert},
+				{ID: utils.ServicePortID{Service: types.NamespacedName{Namespace: "default", Name: "unshared"}}, DriftPolicy: annotations.DriftPolicyAlert},
+			}
+			got := lbc.sharedDriftPolicy(svcPorts, klog.TODO())
+			if got[0].DriftPolicy != tc.want {
+				t.Errorf("Got drift policy %q for the shared Service, want %q", got[0].DriftPolicy, tc.want)
+			}
+			if got[1].DriftPolicy != annotations.DriftPolicyAlert {
+				t.Errorf("Got drift policy %q for the Service of a single Ingress, want %q", got[1].DriftPolicy, annotations.DriftPolicyAlert)
+			}
+			if svcPorts[0].DriftPolicy != annotations.DriftPolicyAlert {
+				t.Errorf("sharedDriftPolicy() modified its input")
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package errors
 
 import (
+	"errors"
 	"fmt"
 
+	"google.golang.org/api/googleapi"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -69,6 +71,18 @@ func (e ErrSvcAppProtosParsing) Error() string {
 	return fmt.Sprintf("could not parse %q annotation on service %q, err: %v", annotations.ServiceApplicationProtocolKey, e.Service, e.Err)
 }
 
+// ErrSvcExternalNEG is returned when the external NEG annotation of a
+// service is malformed or not supported by the Ingress.
+type ErrSvcExternalNEG struct {
+	Service types.NamespacedName
+	Err     error
+}
+
+// Error returns the service name and the underlying error.
+func (e ErrSvcExternalNEG) Error() string {
+	return fmt.Sprintf("external NEG of service %q is not valid: %v", e.Service, e.Err)
+}
+
 // ErrSvcBackendConfig is returned when there was an error getting the
 // BackendConfig for a service port.
 type ErrSvcBackendConfig struct {
@@ -91,3 +105,77 @@ type ErrBackendConfigValidation struct {
 func (e ErrBackendConfigValidation) Error() string {
 	return fmt.Sprintf("BackendConfig %v/%v is not valid: %v", e.BackendConfig.Namespace, e.BackendConfig.Name, e.Err)
 }
+
+// ErrWeightedBackends is returned when the weighted backends of an Ingress are not valid.
+type ErrWeightedBackends struct {
+	Err error
+}
+
+// Error returns the annotation key and the underlying error.
+func (e ErrWeightedBackends) Error() string {
+	return fmt.Sprintf("invalid %q annotation, err: %v", annotations.WeightedBackendsKey, e.Err)
+}
+
+// ErrRouteMatches is returned when the route matches of an Ingress are not valid.
+type ErrRouteMatches struct {
+	Err error
+}
+
+// Error returns the annotation key and the underlying error.
+func (e ErrRouteMatches) Error() string {
+	return fmt.Sprintf("invalid %q annotation, err: %v", annotations.RouteMatchesKey, e.Err)
+}
+
+// ErrBackendBucket is returned when a backend bucket cannot serve a path of
+// an Ingress.
+type ErrBackendBucket struct {
+	Name string
+	Err  error
+}
+
+// Error returns the name of the backend bucket and the underlying error.
+func (e ErrBackendBucket) Error() string {
+	return fmt.Sprintf("backend bucket %q is not valid: %v", e.Name, e.Err)
+}
+
+const (
+	// ClassGCEAPI is the class of the errors returned by the GCE API.
+	ClassGCEAPI = "GCEAPIError"
+	// ClassUnknown is the class of the errors without a type of this
+	// package.
+	ClassUnknown = "Unknown"
+)
+
+// Class returns the name of the type of this package that err wraps, e.g.
+// ErrSvcNotFound, to report the class of the error that failed a sync in a
+// machine-readable way. It returns ClassGCEAPI for the errors of the GCE API,
+// and ClassUnknown for all other errors.
+func Class(err error) string {
+	switch {
+	case errors.As(err, &ErrBadSvcType{}):
+		return "ErrBadSvcType"
+	case errors.As(err, &ErrSvcNotFound{}):
+		return "ErrSvcNotFound"
+	case errors.As(err, &ErrSvcPortNotFound{}):
+		return "ErrSvcPortNotFound"
+	case errors.As(err, &ErrSvcAppProtosParsing{}):
+		return "ErrSvcAppProtosParsing"
+	case errors.As(err, &ErrSvcExternalNEG{}):
+		return "ErrSvcExternalNEG"
+	case errors.As(err, &ErrSvcBackendConfig{}):
+		return "ErrSvcBackendConfig"
+	case errors.As(err, &ErrBackendConfigValidation{}):
+		return "ErrBackendConfigValidation"
+	case errors.As(err, &ErrWeightedBackends{}):
+		return "ErrWeightedBackends"
+	case errors.As(err, &ErrRouteMatches{}):
+		return "ErrRouteMatches"
+	case errors.As(err, &ErrBackendBucket{}):
+		return "ErrBackendBucket"
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return ClassGCEAPI
+	}
+	return ClassUnknown
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClass(t *testing.T) {
+	svc := types.NamespacedName{Namespace: "default", Name: "svc"}
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{err: ErrSvcNotFound{Service: svc}, want: "ErrSvcNotFound"},
+		{err: fmt.Errorf("error running backend syncing routine: %w", ErrBadSvcType{Service: svc}), want: "ErrBadSvcType"},
+		{err: ErrWeightedBackends{Err: errors.New("invalid")}, want: "ErrWeightedBackends"},
+		{err: fmt.Errorf("error running load balancer syncing routine: %w", &googleapi.Error{Code: http.StatusForbidden}), want: ClassGCEAPI},
+		{err: errors.New("timeout"), want: ClassUnknown},
+	} {
+		if got := Class(tc.err); got != tc.want {
+			t.Errorf("Class(%q) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
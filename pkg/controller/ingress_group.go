@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+// ingressGroup returns the group whose load balancer serves the ingress, or
+// the empty string if the ingress has its own load balancer. Only ingresses
+// with the v2 naming scheme share the load balancer of their group.
+func ingressGroup(ing *v1.Ingress, ingLogger klog.Logger) string {
+	if namer.FrontendNamingScheme(ing, ingLogger) != namer.V2NamingScheme {
+		return ""
+	}
+	return annotations.FromIngress(ing).IngressGroup()
+}
+
+// otherIngressGroupMembers returns the ingresses of the group other than ing
+// that are not being deleted, oldest first.
+func (lbc *LoadBalancerController) otherIngressGroupMembers(group string, ing *v1.Ingress) []*v1.Ingress {
+	var members []*v1.Ingress
+	for _, member := range lbc.ctx.Ingresses().List() {
+		if member.Namespace == ing.Namespace && member.Name == ing.Name {
+			continue
+		}
+		if !utils.IsGCEIngress(member) || utils.NeedsCleanup(member) || ingressGroup(member, lbc.logger) != group {
+			continue
+		}
+		members = append(members, member)
+	}
+	sortIngressGroup(members)
+	return members
+}
+
+// sortIngressGroup sorts the ingresses of a group oldest first, which is the
+// order in which they own the hosts and paths of the load balancer.
+func sortIngressGroup(ings []*v1.Ingress) {
+	sort.Slice(ings, func(i, j int) bool {
+		if !ings[i].CreationTimestamp.Equal(&ings[j].CreationTimestamp) {
+			return ings[i].CreationTimestamp.Before(&ings[j].CreationTimestamp)
+		}
+		return common.NamespacedName(ings[i]) < common.NamespacedName(ings[j])
+	})
+}
+
+// ingressGroupLocks serializes the syncs and the garbage collection of the
+// ingresses of each group, since they update the load balancer shared by the
+// group. Ingresses of different groups are synced concurrently.
+type ingressGroupLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the given groups and returns the function unlocking them. Empty
+// groups are ignored. The groups are always locked in the same order, so
+// that syncs locking several groups do not deadlock.
+func (l *ingressGroupLocks) lock(groups ...string) func() {
+	names := sets.New(groups...)
+	names.Delete("")
+
+	var groupLocks []*sync.Mutex
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	for _, group := range sets.List(names) {
+		groupLock, ok := l.locks[group]
+		if !ok {
+			groupLock = &sync.Mutex{}
+			l.locks[group] = groupLock
+		}
+		groupLocks = append(groupLocks, groupLock)
+	}
+	l.mu.Unlock()
+
+	for _, groupLock := range groupLocks {
+		groupLock.Lock()
+	}
+	return func() {
+		for i := len(groupLocks) - 1; i >= 0; i-- {
+			groupLocks[i].Unlock()
+		}
+	}
+}
+
+// ingressGroupsToLock returns the groups whose load balancer can be updated
+// or deleted by a sync of the ingress: its group and the group it was
+// previously served by.
+func ingressGroupsToLock(ing *v1.Ingress, ingLogger klog.Logger) []string {
+	return []string{ingressGroup(ing, ingLogger), ing.Annotations[annotations.IngressGroupStatusKey]}
+}
+
+// allowedInIngressGroup returns true if the ingress can join the group owned
+// by the given ingress, which is the oldest of the group.
+func allowedInIngressGroup(owner, ing *v1.Ingress) bool {
+	if ing.Namespace == owner.Namespace {
+		return true
+	}
+	return sets.New(annotations.FromIngress(owner).IngressGroupNamespaces()...).Has(ing.Namespace)
+}
+
+// translateIngressGroup returns the URL map of the load balancer shared by
+// the ingresses of the group of ing, and the ingresses it serves. The load
+// balancer is owned by the oldest ingress of the group: it is of its class,
+// and the ingresses of other classes or of namespaces it does not allow are
+// left out of it. Only the errors and conflicts of ing are reported.
+func (lbc *LoadBalancerController) translateIngressGroup(group string, ing *v1.Ingress, ingLogger klog.Logger) (*utils.GCEURLMap, []*v1.Ingress, []error, bool) {
+	members := append(lbc.otherIngressGroupMembers(group, ing), ing)
+	sortIngressGroup(members)
+
+	owner := members[0]
+	if !allowedInIngressGroup(owner, ing) {
+		err := fmt.Errorf("namespace %q is not allowed to join ingress group %q owned by Ingress %s, which must list it in annotation %s", ing.Namespace, group, common.NamespacedName(owner), annotations.IngressGroupNamespacesKey)
+		return nil, nil, []error{err}, false
+	}
+	lbScheme := loadbalancers.LBSchemeForIngress(owner)
+	if loadbalancers.LBSchemeForIngress(ing) != lbScheme {
+		err := fmt.Errorf("the load balancer of ingress group %q is of the class of Ingress %s, which differs from the class of the Ingress", group, common.NamespacedName(owner))
+		return nil, nil, []error{err}, false
+	}
+	var served []*v1.Ingress
+	for _, member := range members {
+		if loadbalancers.LBSchemeForIngress(member) == lbScheme && allowedInIngressGroup(owner, member) {
+			served = append(served, member)
+		}
+	}
+	ingLogger.Info("Translating ingress group", "group", group, "ingresses", len(served))
+
+	urlMap, conflicts, errs, warnings := lbc.Translator.TranslateIngressGroup(served, lbc.ctx.DefaultBackendSvcPort.ID, lbc.ctx.ClusterNamer)
+	key := types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}
+	for _, conflict := range conflicts {
+		if conflict.Ingress == key {
+			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.IngressGroup, "Ingress group %q: %v", group, conflict)
+		}
+	}
+	return urlMap, served, errs[key], warnings
+}
+
+// ingressGroupFrontendConflicts returns the frontend settings of the ingress
+// which differ from those of the owner of its group, and are not used by the
+// load balancer of the group.
+func ingressGroupFrontendConflicts(owner, ing *v1.Ingress) []string {
+	ownerAnnotations, ingAnnotations := annotations.FromIngress(owner), annotations.FromIngress(ing)
+	var conflicts []string
+	for _, key := range []string{
+		annotations.FrontendConfigKey,
+		annotations.GlobalStaticIPNameKey,
+		annotations.RegionalStaticIPNameKey,
+		annotations.PreSharedCertKey,
+		annotations.CertificateMapKey,
+	} {
+		if ing.Annotations[key] != owner.Annotations[key] {
+			conflicts = append(conflicts, key)
+		}
+	}
+	if ingAnnotations.AllowHTTP() != ownerAnnotations.AllowHTTP() {
+		conflicts = append(conflicts, annotations.AllowHTTPKey)
+	}
+	return conflicts
+}
+
+// toGroupRuntimeInfo returns the L7RuntimeInfo of the load balancer shared by
+// the given ingresses of a group, synced for ing. The frontend settings are
+// those of the owner of the group, the oldest ingress, and the conflicting
+// settings of ing are reported. The certificates are those of all the
+// ingresses.
+func (lbc *LoadBalancerController) toGroupRuntimeInfo(ing *v1.Ingress, group []*v1.Ingress, urlMap *utils.GCEURLMap, ingLogger klog.Logger) (*loadbalancers.L7RuntimeInfo, error) {
+	owner := group[0]
+	if conflicts := ingressGroupFrontendConflicts(owner, ing); len(conflicts) > 0 {
+		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.IngressGroup, "Ingress group %q: annotations %s differ from those of Ingress %s, which owns the load balancer, and are ignored", ingressGroup(ing, ingLogger), strings.Join(conflicts, ", "), common.NamespacedName(owner))
+	}
+	lb, err := lbc.toRuntimeInfo(owner, urlMap, ingLogger)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, cert := range lb.TLS {
+		seen[cert.CertHash] = true
+	}
+	for _, member := range group[1:] {
+		tls, err := lbc.tlsCerts(member, ingLogger)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range tls {
+			if !seen[cert.CertHash] {
+				seen[cert.CertHash] = true
+				lb.TLS = append(lb.TLS, cert)
+			}
+		}
+	}
+	lb.Ingress = ing
+	return lb, nil
+}
+
+// gcPreviousIngressGroup deletes the load balancer that served the ingress
+// before it joined, left or moved between ingress groups, unless other
+// ingresses of its previous group still use it.
+func (lbc *LoadBalancerController) gcPreviousIngressGroup(ing *v1.Ingress, ingLogger klog.Logger) error {
+	// The ingress was not served by a load balancer yet.
+	if ing.Annotations[annotations.UrlMapKey] == "" {
+		return nil
+	}
+	previousGroup := ing.Annotations[annotations.IngressGroupStatusKey]
+	if previousGroup == ingressGroup(ing, ingLogger) {
+		return nil
+	}
+
+	// The namer of the previous load balancer is the one of the ingress in
+	// its previous group.
+	previousIng := ing.DeepCopy()
+	if previousGroup == "" {
+		delete(previousIng.Annotations, annotations.IngressGroupKey)
+	} else {
+		previousIng.Annotations[annotations.IngressGroupKey] = previousGroup
+	}
+	ingLogger.Info("Ingress changed group, cleaning up the previous load balancer", "previousGroup", previousGroup)
+
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+	return lbc.GCv2LoadBalancer(previousIng, features.ScopeFromIngress(previousIng))
+}
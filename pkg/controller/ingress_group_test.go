@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	api_v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils/common"
+)
+
+func newGroupIngress(namespace, name, group string, created time.Time, rules map[string]string) *networkingv1.Ingress {
+	ing := test.NewIngress(types.NamespacedName{Name: name, Namespace: namespace}, networkingv1.IngressSpec{})
+	ing.CreationTimestamp = meta_v1.NewTime(created)
+	ing.Finalizers = []string{common.FinalizerKeyV2}
+	ing.Annotations = map[string]string{annotations.IngressGroupKey: group}
+	for host, svc := range rules {
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/*", Backend: backend(svc, networkingv1.ServiceBackendPort{Number: 80})}},
+				},
+			},
+		})
+	}
+	return ing
+}
+
+// TestIngressGroup asserts that the ingresses of a group share a load
+// balancer, which is only deleted when the last ingress leaves the group.
+func TestIngressGroup(t *testing.T) {
+	flagSaver := test.NewFlagSaver()
+	flagSaver.Save(test.FinalizerRemoveFlag, &flags.F.FinalizerRemove)
+	defer flagSaver.Reset(test.FinalizerRemoveFlag, &flags.F.FinalizerRemove)
+	flagSaver.Save(test.FinalizerAddFlag, &flags.F.FinalizerAdd)
+	defer flagSaver.Reset(test.FinalizerAddFlag, &flags.F.FinalizerAdd)
+	flags.F.FinalizerRemove = true
+	flags.F.FinalizerAdd = true
+	// The remaining ingresses of a group are enqueued when an ingress leaves.
+	defer func(numIngressWorkers int) { flags.F.NumIngressWorkers = numIngressWorkers }(flags.F.NumIngressWorkers)
+	flags.F.NumIngressWorkers = 1
+
+	lbc, err := newLoadBalancerController()
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+	for _, svc := range []types.NamespacedName{{Namespace: "ns1", Name: "web"}, {Namespace: "ns2", Name: "api"}} {
+		addService(lbc, test.NewService(svc, api_v1.ServiceSpec{
+			Type:  api_v1.ServiceTypeNodePort,
+			Ports: []api_v1.ServicePort{{Port: 80}},
+		}))
+	}
+
+	now := time.Now()
+	ing1 := newGroupIngress("ns1", "web", "shop", now, map[string]string{"shop.com": "web"})
+	ing1.Annotations[annotations.IngressGroupNamespacesKey] = "ns2"
+	ing2 := newGroupIngress("ns2", "api", "shop", now.Add(time.Minute), map[string]string{"shop.com": "api", "api.shop.com": "api"})
+	// ns3 is not allowed by the owner of the group.
+	ing3 := newGroupIngress("ns3", "other", "shop", now.Add(2*time.Minute), map[string]string{"other.shop.com": "web"})
+	addIngress(lbc, ing1)
+	addIngress(lbc, ing2)
+	addIngress(lbc, ing3)
+	for _, ing := range []*networkingv1.Ingress{ing1, ing2} {
+		if err := lbc.sync(getKey(ing, t)); err != nil {
+			t.Fatalf("lbc.sync(%v) = %v, want nil", getKey(ing, t), err)
+		}
+	}
+	if err := lbc.sync(getKey(ing3, t)); err == nil {
+		t.Errorf("lbc.sync(%v) = nil, want an error for a namespace not allowed in the group", getKey(ing3, t))
+	}
+	if got := getUpdatedIngress(t, lbc, ing3).Annotations[annotations.UrlMapKey]; got != "" {
+		t.Errorf("Got URL map %q for %s, want none", got, common.NamespacedName(ing3))
+	}
+	deleteIngressWithFinalizer(lbc, ing3)
+
+	ing1 = getUpdatedIngress(t, lbc, ing1)
+	ing2 = getUpdatedIngress(t, lbc, ing2)
+	groupUrlMap := ing1.Annotations[annotations.UrlMapKey]
+	if groupUrlMap == "" || ing2.Annotations[annotations.UrlMapKey] != groupUrlMap {
+		t.Fatalf("Got URL maps %q and %q, want the same URL map for the ingresses of the group", groupUrlMap, ing2.Annotations[annotations.UrlMapKey])
+	}
+	for _, ing := range []*networkingv1.Ingress{ing1, ing2} {
+		if got := ing.Annotations[annotations.IngressGroupStatusKey]; got != "shop" {
+			t.Errorf("Got annotation %s = %q on %s, want %q", annotations.IngressGroupStatusKey, got, common.NamespacedName(ing), "shop")
+		}
+	}
+	key, err := composite.CreateKey(lbc.ctx.Cloud, groupUrlMap, meta.Global)
+	if err != nil {
+		t.Fatalf("composite.CreateKey(%q) = %v, want nil", groupUrlMap, err)
+	}
+	um, err := composite.GetUrlMap(lbc.ctx.Cloud, key, meta.VersionGA, lbc.logger)
+	if err != nil {
+		t.Fatalf("composite.GetUrlMap(%q) = %v, want nil", groupUrlMap, err)
+	}
+	hosts := map[string]string{}
+	for _, hostRule := range um.HostRules {
+		for _, host := range hostRule.Hosts {
+			hosts[host] = hostRule.PathMatcher
+		}
+	}
+	// shop.com is owned by ns1, so only api.shop.com is served by ns2.
+	if len(hosts) != 2 || hosts["shop.com"] == "" || hosts["api.shop.com"] == "" {
+		t.Errorf("Got host rules %+v, want shop.com and api.shop.com", um.HostRules)
+	}
+	for _, pathMatcher := range um.PathMatchers {
+		if pathMatcher.Name == hosts["shop.com"] && len(pathMatcher.PathRules) != 1 {
+			t.Errorf("Got path rules %+v for shop.com, want the path rule of %s only", pathMatcher.PathRules, common.NamespacedName(ing1))
+		}
+	}
+
+	// Deleting an ingress keeps the load balancer of the group.
+	setDeletionTimestamp(lbc, ing1)
+	if err := lbc.sync(getKey(ing1, t)); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", getKey(ing1, t), err)
+	}
+	deleteIngressWithFinalizer(lbc, ing1)
+	if lbc.ingQueue.Len() != 1 {
+		t.Errorf("lbc.ingQueue.Len() = %d, want 1 for the remaining ingress of the group", lbc.ingQueue.Len())
+	}
+	if hasUrlMap, err := lbc.l7Pool.HasUrlMap(ing2); err != nil || !hasUrlMap {
+		t.Fatalf("HasUrlMap(%s) = %t, %v, want true, nil after another ingress of the group is deleted", common.NamespacedName(ing2), hasUrlMap, err)
+	}
+
+	// Leaving the group deletes the load balancer of the group, which has
+	// no ingress left.
+	groupIng := ing2.DeepCopy()
+	delete(ing2.Annotations, annotations.IngressGroupKey)
+	updateIngress(lbc, ing2)
+	if err := lbc.sync(getKey(ing2, t)); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", getKey(ing2, t), err)
+	}
+	if hasUrlMap, err := lbc.l7Pool.HasUrlMap(groupIng); err != nil || hasUrlMap {
+		t.Errorf("HasUrlMap(group shop) = %t, %v, want false, nil after the last ingress left the group", hasUrlMap, err)
+	}
+	ing2 = getUpdatedIngress(t, lbc, ing2)
+	if got := ing2.Annotations[annotations.UrlMapKey]; got == "" || got == groupUrlMap {
+		t.Errorf("Got URL map %q, want a URL map other than the one of the group", got)
+	}
+	if got, ok := ing2.Annotations[annotations.IngressGroupStatusKey]; ok {
+		t.Errorf("Got annotation %s = %q, want none", annotations.IngressGroupStatusKey, got)
+	}
+}
+
+func TestIngressGroupFrontendConflicts(t *testing.T) {
+	owner := newGroupIngress("ns1", "web", "shop", time.Now(), nil)
+	owner.Annotations[annotations.GlobalStaticIPNameKey] = "shop-ip"
+	owner.Annotations[annotations.FrontendConfigKey] = "shop-frontend"
+
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		want        []string
+	}{
+		{
+			desc: "same frontend settings",
+			annotations: map[string]string{
+				annotations.GlobalStaticIPNameKey: "shop-ip",
+				annotations.FrontendConfigKey:     "shop-frontend",
+				annotations.AllowHTTPKey:          "true",
+			},
+		},
+		{
+			desc: "no frontend settings",
+			want: []string{annotations.FrontendConfigKey, annotations.GlobalStaticIPNameKey},
+		},
+		{
+			desc: "different static IP and HTTP",
+			annotations: map[string]string{
+				annotations.GlobalStaticIPNameKey: "other-ip",
+				annotations.FrontendConfigKey:     "shop-frontend",
+				annotations.AllowHTTPKey:          "false",
+			},
+			want: []string{annotations.GlobalStaticIPNameKey, annotations.AllowHTTPKey},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ing := newGroupIngress("ns2", "api", "shop", time.Now(), nil)
+			for key, val := range tc.annotations {
+				ing.Annotations[key] = val
+			}
+			if diff := cmp.Diff(tc.want, ingressGroupFrontendConflicts(owner, ing)); diff != "" {
+				t.Errorf("ingressGroupFrontendConflicts() returned unexpected conflicts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIngressGroupLocks(t *testing.T) {
+	var locks ingressGroupLocks
+	unlockShop := locks.lock("shop")
+
+	// Other groups are not blocked by the lock of a group.
+	done := make(chan struct{})
+	go func() {
+		locks.lock("blog")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		t.Fatalf("Locking group blog is blocked by the lock of group shop")
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		locks.lock("shop")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatalf("Locked group shop twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlockShop()
+	<-locked
+}
+
+func TestIngressGroupLocksSeveralGroups(t *testing.T) {
+	var locks ingressGroupLocks
+
+	// Ingresses moving between two groups in opposite directions lock both
+	// groups in the same order.
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			locks.lock("shop", "blog")()
+		}()
+		go func() {
+			defer wg.Done()
+			locks.lock("blog", "shop", "")()
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		t.Fatalf("Locking groups shop and blog in opposite orders deadlocked")
+	}
+
+	unlock := locks.lock("shop", "shop", "")
+	locked := make(chan struct{})
+	go func() {
+		locks.lock("blog", "shop")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatalf("Locked group shop twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	<-locked
+}
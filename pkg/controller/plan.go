@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/klog/v2"
+)
+
+// publishPlan publishes the GCE operations recorded during the sync of the
+// Ingress in plan mode, as an event and a status annotation on the Ingress
+// and as a structured log that includes the diffs of the updates.
+func (lbc *LoadBalancerController) publishPlan(key string, ing *v1.Ingress, ingExists bool, ingLogger klog.Logger) {
+	ops := lbc.plan.Flush()
+	for _, op := range ops {
+		ingLogger.Info("Planned GCE operation", "action", op.Action, "resource", op.Resource, "diff", op.Diff)
+	}
+	ingLogger.Info("Planned sync of ingress", "operations", len(ops))
+	if !ingExists {
+		return
+	}
+
+	var summary []string
+	for _, op := range ops {
+		summary = append(summary, op.String())
+	}
+	if len(ops) == 0 {
+		lbc.ctx.Recorder(ing.Namespace).Event(ing, apiv1.EventTypeNormal, events.PlanIngress, "No changes to GCP resources")
+	} else {
+		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeNormal, events.PlanIngress, "Planned %d changes to GCP resources: %s", len(ops), events.TruncatedStringList(summary))
+	}
+
+	value, err := planAnnotation(ops)
+	if err != nil {
+		ingLogger.Error(err, "Failed to marshal planned operations", "ingressKey", key)
+		return
+	}
+	newAnnotations := ing.ObjectMeta.DeepCopy().Annotations
+	if newAnnotations == nil {
+		newAnnotations = map[string]string{}
+	}
+	newAnnotations[annotations.PlanKey] = value
+	if err := updateAnnotations(lbc.ctx.KubeClient, ing, newAnnotations, ingLogger); err != nil {
+		ingLogger.Error(err, "Failed to update plan annotation", "ingressKey", key)
+	}
+}
+
+// planAnnotation returns the value of the plan status annotation, which lists
+// the operations without their diffs to keep the annotation small.
+func planAnnotation(ops []plan.Operation) (string, error) {
+	summary := []plan.Operation{}
+	for _, op := range ops {
+		op.Diff = ""
+		summary = append(summary, op)
+	}
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	context2 "context"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	api_v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+func TestPublishPlan(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		record         func(r *plan.Recorder)
+		wantAnnotation string
+	}{
+		{
+			desc:           "no changes",
+			record:         func(r *plan.Recorder) {},
+			wantAnnotation: `[]`,
+		},
+		{
+			desc: "create and update",
+			record: func(r *plan.Recorder) {
+				r.Record(plan.Create, "urlMaps", meta.GlobalKey("um"), nil, &compute.UrlMap{Name: "um"})
+				r.Record(plan.Update, "backendServices", meta.GlobalKey("be"), &compute.BackendService{TimeoutSec: 30}, &compute.BackendService{TimeoutSec: 60})
+			},
+			wantAnnotation: `[{"action":"Create","resource":"global/urlMaps/um"},{"action":"Update","resource":"global/backendServices/be"}]`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			lbc, err := newLoadBalancerController()
+			if err != nil {
+				t.Fatalf("failed to initialize load balancer controller")
+			}
+			lbc.plan = plan.NewRecorder()
+
+			defaultBackend := backend("default-backend", networkingv1.ServiceBackendPort{Number: 80})
+			ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+				networkingv1.IngressSpec{
+					DefaultBackend: &defaultBackend,
+				})
+			addIngress(lbc, ing)
+
+			tc.record(lbc.plan)
+			lbc.publishPlan(getKey(ing, t), ing, true, klog.TODO())
+
+			updatedIng, err := lbc.ctx.KubeClient.NetworkingV1().Ingresses(ing.Namespace).Get(context2.TODO(), ing.Name, meta_v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := updatedIng.Annotations[annotations.PlanKey]; got != tc.wantAnnotation {
+				t.Errorf("Annotation %s = %s, want %s", annotations.PlanKey, got, tc.wantAnnotation)
+			}
+			if ops := lbc.plan.Flush(); len(ops) != 0 {
+				t.Errorf("Got operations %v after publishPlan(), want none", ops)
+			}
+		})
+	}
+}
+
+func TestGCBackendsPlanMode(t *testing.T) {
+	lbc, err := newLoadBalancerControllerWithPlanMode(true)
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+	igName := lbc.ctx.ClusterNamer.InstanceGroup()
+	if _, err := lbc.instancePool.EnsureInstanceGroupsAndPorts(igName, []int64{30001}, klog.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	beName := lbc.ctx.ClusterNamer.IGBackend(30001)
+	if err := composite.CreateBackendService(lbc.ctx.Cloud, meta.GlobalKey(beName), &composite.BackendService{Name: beName, Version: meta.VersionGA}, klog.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lbc.GCBackends(nil, klog.TODO()); err != nil {
+		t.Fatalf("GCBackends() = %v, want nil", err)
+	}
+
+	if exist, err := lbc.instancePool.InstanceGroupsExist(igName, klog.TODO()); err != nil || !exist {
+		t.Errorf("InstanceGroupsExist(%q) = %v, %v, want true, nil", igName, exist, err)
+	}
+	if _, err := composite.GetBackendService(lbc.ctx.Cloud, meta.GlobalKey(beName), meta.VersionGA, klog.TODO()); err != nil {
+		t.Errorf("GetBackendService(%q) = %v, want nil", beName, err)
+	}
+	wantOps := []plan.Operation{{Action: plan.Delete, Resource: "global/backendServices/" + beName}}
+	if ops := lbc.plan.Flush(); !reflect.DeepEqual(ops, wantOps) {
+		t.Errorf("Planned operations = %v, want %v", ops, wantOps)
+	}
+}
+
+func TestSyncExtraBackendsPlanMode(t *testing.T) {
+	lbc, err := newLoadBalancerControllerWithPlanMode(true)
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+	svcName := types.NamespacedName{Namespace: "default", Name: "svc"}
+	addService(lbc, test.NewService(svcName, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80, NodePort: 30002}},
+	}))
+	sp := utils.ServicePort{
+		ID:           utils.ServicePortID{Service: svcName, Port: networkingv1.ServiceBackendPort{Number: 80}},
+		NodePort:     30002,
+		Protocol:     annotations.ProtocolHTTP,
+		BackendNamer: lbc.ctx.ClusterNamer,
+	}
+
+	if err := lbc.SyncExtraBackends([]utils.ServicePort{sp}, klog.TODO()); err != nil {
+		t.Fatalf("SyncExtraBackends() = %v, want nil", err)
+	}
+
+	igName := lbc.ctx.ClusterNamer.InstanceGroup()
+	if exist, err := lbc.instancePool.InstanceGroupsExist(igName, klog.TODO()); err != nil || exist {
+		t.Errorf("InstanceGroupsExist(%q) = %v, %v, want false, nil", igName, exist, err)
+	}
+	beName := sp.BackendName()
+	if _, err := composite.GetBackendService(lbc.ctx.Cloud, meta.GlobalKey(beName), meta.VersionGA, klog.TODO()); !utils.IsNotFoundError(err) {
+		t.Errorf("GetBackendService(%q) = %v, want not found", beName, err)
+	}
+}
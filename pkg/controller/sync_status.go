@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ingsyncstatusv1beta1 "k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1"
+	ctrlerrors "k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/ingress-gce/pkg/ingsyncstatus"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/klog/v2"
+)
+
+// updateIngressSyncStatus records the result of the sync of the Ingress in
+// its IngressSyncStatus. The resources and backends are only updated after a
+// successful sync, with the given l7 and health states of its backends.
+// Failing to update the status does not fail the sync.
+func (lbc *LoadBalancerController) updateIngressSyncStatus(ing *v1.Ingress, l7 *loadbalancers.L7, backendState map[string]string, syncErr error, ingLogger klog.Logger) {
+	if lbc.ctx.IngressSyncStatusClient == nil || lbc.plan.Enabled() {
+		return
+	}
+
+	now := metav1.Now()
+	err := ingsyncstatus.Ensure(lbc.ctx.IngressSyncStatusClient, ing, func(status *ingsyncstatusv1beta1.IngressSyncStatusStatus) {
+		status.ObservedGeneration = ing.Generation
+		status.LastSyncTime = now
+		if syncErr != nil {
+			status.LastSyncResult = ingsyncstatusv1beta1.SyncResultError
+			status.LastError = &ingsyncstatusv1beta1.SyncError{
+				Class:   ctrlerrors.Class(syncErr),
+				Message: syncErr.Error(),
+				Time:    now,
+			}
+			return
+		}
+		status.LastSyncResult = ingsyncstatusv1beta1.SyncResultSuccess
+		status.Resources = loadbalancers.GetLBResources(l7)
+		status.Backends = backendHealth(backendState)
+	})
+	if err != nil {
+		ingLogger.Error(err, "Failed to update IngressSyncStatus")
+	}
+}
+
+// backendHealth returns the health of the backends sorted by name. Backends
+// whose health could not be retrieved are Unknown.
+func backendHealth(backendState map[string]string) []ingsyncstatusv1beta1.BackendHealth {
+	var backends []ingsyncstatusv1beta1.BackendHealth
+	for name, state := range backendState {
+		if state == "" {
+			state = "Unknown"
+		}
+		backends = append(backends, ingsyncstatusv1beta1.BackendHealth{Name: name, Health: state})
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].Name < backends[j].Name
+	})
+	return backends
+}
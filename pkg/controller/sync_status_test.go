@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	context2 "context"
+	"testing"
+
+	api_v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ingsyncstatusv1beta1 "k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1"
+	ingsyncstatusclient "k8s.io/ingress-gce/pkg/ingsyncstatus/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/test"
+)
+
+func TestIngressSyncStatus(t *testing.T) {
+	lbc, err := newLoadBalancerController()
+	if err != nil {
+		t.Fatalf("failed to initialize load balancer controller")
+	}
+	lbc.ctx.IngressSyncStatusClient = ingsyncstatusclient.NewSimpleClientset()
+
+	someBackend := backend("my-service", networkingv1.ServiceBackendPort{Number: 80})
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		networkingv1.IngressSpec{
+			DefaultBackend: &someBackend,
+		})
+	ing.UID = "ingress-uid"
+	ing.Generation = 3
+	addIngress(lbc, ing)
+	ingStoreKey := getKey(ing, t)
+
+	getSyncStatus := func() *ingsyncstatusv1beta1.IngressSyncStatus {
+		t.Helper()
+		syncStatus, err := lbc.ctx.IngressSyncStatusClient.NetworkingV1beta1().IngressSyncStatuses(ing.Namespace).Get(context2.TODO(), ing.Name, meta_v1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Get(%s) = %v, want nil", ing.Name, err)
+		}
+		return syncStatus
+	}
+
+	// The Service of the Ingress is missing.
+	if err := lbc.sync(ingStoreKey); err == nil {
+		t.Fatalf("lbc.sync(%v) = nil, want error", ingStoreKey)
+	}
+	syncStatus := getSyncStatus()
+	if len(syncStatus.OwnerReferences) != 1 || syncStatus.OwnerReferences[0].UID != ing.UID {
+		t.Errorf("Got owner references %+v, want the Ingress", syncStatus.OwnerReferences)
+	}
+	status := syncStatus.Status
+	if status.LastSyncResult != ingsyncstatusv1beta1.SyncResultError {
+		t.Errorf("Got lastSyncResult %q, want %q", status.LastSyncResult, ingsyncstatusv1beta1.SyncResultError)
+	}
+	if status.LastError == nil || status.LastError.Class != "ErrSvcNotFound" {
+		t.Errorf("Got lastError %+v, want an error of class ErrSvcNotFound", status.LastError)
+	}
+	if status.ObservedGeneration != ing.Generation {
+		t.Errorf("Got observedGeneration %d, want %d", status.ObservedGeneration, ing.Generation)
+	}
+
+	addService(lbc, test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	}))
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", ingStoreKey, err)
+	}
+	status = getSyncStatus().Status
+	if status.LastSyncResult != ingsyncstatusv1beta1.SyncResultSuccess {
+		t.Errorf("Got lastSyncResult %q, want %q", status.LastSyncResult, ingsyncstatusv1beta1.SyncResultSuccess)
+	}
+	if status.LastError == nil {
+		t.Errorf("Got no lastError, want the error of the previous sync")
+	}
+	resourceTypes := map[string]bool{}
+	for _, r := range status.Resources {
+		resourceTypes[r.Type] = true
+	}
+	for _, resourceType := range []string{"UrlMap", "TargetHttpProxy", "ForwardingRule"} {
+		if !resourceTypes[resourceType] {
+			t.Errorf("Got resources %+v, want a %s", status.Resources, resourceType)
+		}
+	}
+	if len(status.Backends) == 0 {
+		t.Errorf("Got no backends, want the backend of the Ingress")
+	}
+}
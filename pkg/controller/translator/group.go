@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+// IngressGroupConflict is a rule of an Ingress of a group that is ignored
+// because another Ingress of the group owns it.
+type IngressGroupConflict struct {
+	// Ingress is the Ingress whose rule is ignored.
+	Ingress types.NamespacedName
+	// Host and Path are the rule. Both are empty for the default backend.
+	Host string
+	Path string
+	// Owner is the Ingress that owns the host, or the host and path.
+	Owner types.NamespacedName
+}
+
+// String returns a human readable description of the conflict.
+func (c IngressGroupConflict) String() string {
+	if c.Host == "" && c.Path == "" {
+		return fmt.Sprintf("default backend is ignored, it is already set by Ingress %s", c.Owner)
+	}
+	if c.Ingress.Namespace != c.Owner.Namespace {
+		return fmt.Sprintf("host %q path %q is ignored, the host is owned by namespace %s of Ingress %s", c.Host, c.Path, c.Owner.Namespace, c.Owner)
+	}
+	return fmt.Sprintf("host %q path %q is ignored, it is already used by Ingress %s", c.Host, c.Path, c.Owner)
+}
+
+// TranslateIngressGroup returns the URL map of the load balancer shared by
+// the given Ingresses of a group, which are merged in the given order:
+//   - a host is owned by the namespace of the first Ingress that uses it, and
+//     its paths in the other namespaces are conflicts.
+//   - a host and path are owned by the first Ingress that uses them, and the
+//     same host and path in the other Ingresses are conflicts.
+//   - the default backend is the one of the first Ingress that sets it, or
+//     the system default backend if none does.
+//
+// Ingresses that fail to translate are left out of the URL map, and their
+// errors are returned by Ingress.
+func (t *Translator) TranslateIngressGroup(ings []*v1.Ingress, systemDefaultBackend utils.ServicePortID, namer namer_util.BackendNamer) (*utils.GCEURLMap, []IngressGroupConflict, map[types.NamespacedName][]error, bool) {
+	return t.translateMergedIngresses(ings, systemDefaultBackend, namer, true)
+}
+
+// TranslateMergedIngresses is like TranslateIngressGroup, except that hosts
+// are not owned by namespace: the Ingresses of all namespaces can serve paths
+// of the same host, and a host and path are owned by the first Ingress that
+// uses them.
+func (t *Translator) TranslateMergedIngresses(ings []*v1.Ingress, systemDefaultBackend utils.ServicePortID, namer namer_util.BackendNamer) (*utils.GCEURLMap, []IngressGroupConflict, map[types.NamespacedName][]error, bool) {
+	return t.translateMergedIngresses(ings, systemDefaultBackend, namer, false)
+}
+
+func (t *Translator) translateMergedIngresses(ings []*v1.Ingress, systemDefaultBackend utils.ServicePortID, namer namer_util.BackendNamer, hostsByNamespace bool) (*utils.GCEURLMap, []IngressGroupConflict, map[types.NamespacedName][]error, bool) {
+	var conflicts []IngressGroupConflict
+	var warnings bool
+	errs := map[types.NamespacedName][]error{}
+	urlMap := utils.NewGCEURLMap(t.logger)
+
+	var hosts []string
+	hostOwners := map[string]types.NamespacedName{}
+	pathOwners := map[string]map[string]types.NamespacedName{}
+	pathRules := map[string][]utils.PathRule{}
+	var defaultBackendOwner *types.NamespacedName
+
+	for _, ing := range ings {
+		key := types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}
+		ingURLMap, ingErrs, warning := t.TranslateIngress(ing, systemDefaultBackend, namer)
+		warnings = warnings || warning
+		if len(ingErrs) > 0 {
+			errs[key] = ingErrs
+			continue
+		}
+
+		for _, hostRule := range ingURLMap.HostRules {
+			if len(hostRule.Paths) == 0 {
+				continue
+			}
+			host := hostRule.Hostname
+			owner, ok := hostOwners[host]
+			if !ok {
+				owner = key
+				hostOwners[host] = key
+				pathOwners[host] = map[string]types.NamespacedName{}
+				hosts = append(hosts, host)
+			}
+			for _, pathRule := range hostRule.Paths {
+				if hostsByNamespace && owner.Namespace != key.Namespace {
+					conflicts = append(conflicts, IngressGroupConflict{Ingress: key, Host: host, Path: pathRule.Path, Owner: owner})
+					continue
+				}
+				if pathOwner, ok := pathOwners[host][pathRule.Path]; ok {
+					conflicts = append(conflicts, IngressGroupConflict{Ingress: key, Host: host, Path: pathRule.Path, Owner: pathOwner})
+					continue
+				}
+				pathOwners[host][pathRule.Path] = key
+				pathRules[host] = append(pathRules[host], pathRule)
+			}
+		}
+
+		switch {
+		case ing.Spec.DefaultBackend == nil:
+			// The URL map of the Ingress has the system default backend.
+			if urlMap.DefaultBackend == nil {
+				urlMap.DefaultBackend = ingURLMap.DefaultBackend
+			}
+		case defaultBackendOwner != nil:
+			conflicts = append(conflicts, IngressGroupConflict{Ingress: key, Owner: *defaultBackendOwner})
+		default:
+			defaultBackendOwner = &key
+			urlMap.DefaultBackend = ingURLMap.DefaultBackend
+		}
+	}
+
+	for _, host := range hosts {
+		urlMap.PutPathRulesForHost(host, pathRules[host])
+	}
+	return urlMap, conflicts, errs, warnings
+}
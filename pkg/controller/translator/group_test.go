@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/test"
+)
+
+func groupIngress(namespace, name string, defaultBackend string, rules map[string]map[string]string) *v1.Ingress {
+	ing := &v1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if defaultBackend != "" {
+		ing.Spec.DefaultBackend = &v1.IngressBackend{Service: &v1.IngressServiceBackend{Name: defaultBackend, Port: port80}}
+	}
+	for host, paths := range rules {
+		rule := v1.IngressRule{Host: host, IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{}}}
+		for path, svc := range paths {
+			pathType := v1.PathTypeImplementationSpecific
+			rule.HTTP.Paths = append(rule.HTTP.Paths, v1.HTTPIngressPath{
+				Path:     path,
+				PathType: &pathType,
+				Backend:  v1.IngressBackend{Service: &v1.IngressServiceBackend{Name: svc, Port: port80}},
+			})
+		}
+		ing.Spec.Rules = append(ing.Spec.Rules, rule)
+	}
+	return ing
+}
+
+func TestTranslateIngressGroup(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	svcLister.Add(test.NewService(types.NamespacedName{Name: "default-http-backend", Namespace: "kube-system"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+	}))
+	for _, id := range []types.NamespacedName{{Namespace: "ns1", Name: "a"}, {Namespace: "ns1", Name: "c"}, {Namespace: "ns2", Name: "b"}} {
+		svcLister.Add(test.NewService(id, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Port: 80}},
+		}))
+	}
+
+	ing1 := types.NamespacedName{Namespace: "ns1", Name: "ing1"}
+	ing2 := types.NamespacedName{Namespace: "ns2", Name: "ing2"}
+	ing3 := types.NamespacedName{Namespace: "ns1", Name: "ing3"}
+	ings := []*v1.Ingress{
+		groupIngress("ns1", "ing1", "", map[string]map[string]string{
+			"foo.com": {"/a": "a"},
+		}),
+		groupIngress("ns2", "ing2", "b", map[string]map[string]string{
+			"foo.com": {"/b": "b"},
+			"bar.com": {"/b": "b"},
+		}),
+		groupIngress("ns1", "ing3", "c", map[string]map[string]string{
+			"foo.com": {"/a": "c", "/c": "c"},
+			"bar.com": {"/c": "c"},
+		}),
+		groupIngress("ns1", "broken", "", map[string]map[string]string{
+			"baz.com": {"/": "missing"},
+		}),
+	}
+
+	urlMap, conflicts, errs, _ := translator.TranslateIngressGroup(ings, defaultBackend.ID, defaultNamer)
+
+	for _, tc := range []struct {
+		host, path string
+		wantSvc    string
+	}{
+		{"foo.com", "/a", "a"},
+		{"foo.com", "/c", "c"},
+		{"bar.com", "/b", "b"},
+	} {
+		backend, ok := urlMap.PathExists(tc.host, tc.path)
+		if !ok || backend.ID.Service.Name != tc.wantSvc {
+			t.Errorf("PathExists(%q, %q) = %v, %t, want Service %q", tc.host, tc.path, backend.ID.Service, ok, tc.wantSvc)
+		}
+	}
+	for _, tc := range []struct{ host, path string }{{"foo.com", "/b"}, {"bar.com", "/c"}} {
+		if _, ok := urlMap.PathExists(tc.host, tc.path); ok {
+			t.Errorf("PathExists(%q, %q) = true, want false for a conflicting path", tc.host, tc.path)
+		}
+	}
+	if urlMap.HostExists("baz.com") {
+		t.Errorf("HostExists(%q) = true, want false for the Ingress that failed to translate", "baz.com")
+	}
+	if urlMap.DefaultBackend == nil || urlMap.DefaultBackend.ID.Service.Name != "b" {
+		t.Errorf("DefaultBackend = %v, want Service %q of the first Ingress that sets it", urlMap.DefaultBackend, "b")
+	}
+
+	wantConflicts := []IngressGroupConflict{
+		{Ingress: ing2, Host: "foo.com", Path: "/b", Owner: ing1},
+		{Ingress: ing3, Host: "foo.com", Path: "/a", Owner: ing1},
+		{Ingress: ing3, Host: "bar.com", Path: "/c", Owner: ing2},
+		{Ingress: ing3, Owner: ing2},
+	}
+	// The rules of the test Ingresses are built from maps, in no particular order.
+	sortConflicts := cmpopts.SortSlices(func(a, b IngressGroupConflict) bool {
+		return a.Ingress.String()+a.Host+a.Path < b.Ingress.String()+b.Host+b.Path
+	})
+	if diff := cmp.Diff(wantConflicts, conflicts, sortConflicts); diff != "" {
+		t.Errorf("TranslateIngressGroup() conflicts mismatch (-want +got):\n%s", diff)
+	}
+	if len(errs) != 1 || len(errs[types.NamespacedName{Namespace: "ns1", Name: "broken"}]) == 0 {
+		t.Errorf("TranslateIngressGroup() errs = %v, want errors of ns1/broken only", errs)
+	}
+}
+
+func TestTranslateMergedIngresses(t *testing.T) {
+	translator := fakeTranslator()
+	svcLister := translator.ServiceInformer.GetIndexer()
+	svcLister.Add(test.NewService(types.NamespacedName{Name: "default-http-backend", Namespace: "kube-system"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+	}))
+	for _, id := range []types.NamespacedName{{Namespace: "ns1", Name: "a"}, {Namespace: "ns2", Name: "b"}} {
+		svcLister.Add(test.NewService(id, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Port: 80}},
+		}))
+	}
+
+	ing1 := types.NamespacedName{Namespace: "ns1", Name: "ing1"}
+	ing2 := types.NamespacedName{Namespace: "ns2", Name: "ing2"}
+	ings := []*v1.Ingress{
+		groupIngress("ns1", "ing1", "", map[string]map[string]string{
+			"foo.com": {"/a": "a"},
+		}),
+		groupIngress("ns2", "ing2", "", map[string]map[string]string{
+			"foo.com": {"/a": "b", "/b": "b"},
+		}),
+	}
+
+	urlMap, conflicts, errs, _ := translator.TranslateMergedIngresses(ings, defaultBackend.ID, defaultNamer)
+
+	// Both namespaces serve paths of foo.com.
+	for _, tc := range []struct {
+		path    string
+		wantSvc string
+	}{
+		{"/a", "a"},
+		{"/b", "b"},
+	} {
+		backend, ok := urlMap.PathExists("foo.com", tc.path)
+		if !ok || backend.ID.Service.Name != tc.wantSvc {
+			t.Errorf("PathExists(%q, %q) = %v, %t, want Service %q", "foo.com", tc.path, backend.ID.Service, ok, tc.wantSvc)
+		}
+	}
+	wantConflicts := []IngressGroupConflict{{Ingress: ing2, Host: "foo.com", Path: "/a", Owner: ing1}}
+	if diff := cmp.Diff(wantConflicts, conflicts); diff != "" {
+		t.Errorf("TranslateMergedIngresses() conflicts mismatch (-want +got):\n%s", diff)
+	}
+	if len(errs) != 0 {
+		t.Errorf("TranslateMergedIngresses() errs = %v, want none", errs)
+	}
+}
@@ -38,10 +38,12 @@ import (
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backendconfig"
+	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/controller/errors"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/ingress-gce/pkg/validation"
 )
 
 const (
@@ -58,12 +60,17 @@ const (
 type getServicePortParams struct {
 	isL7ILB         bool
 	isL7XLBRegional bool
+	// skipRequestMirror is set when resolving the target of a request
+	// mirror, since mirrored requests are not mirrored again.
+	skipRequestMirror bool
+	driftPolicy       annotations.DriftPolicy
 }
 
 func (t *Translator) getServicePortParamsForIngress(ing *v1.Ingress) *getServicePortParams {
 	return &getServicePortParams{
 		isL7ILB:         utils.IsGCEL7ILBIngress(ing),
 		isL7XLBRegional: t.enableL7XLBRegional && utils.IsGCEL7XLBRegionalIngress(ing),
+		driftPolicy:     annotations.FromIngress(ing).DriftPolicy(),
 	}
 }
 
@@ -77,6 +84,7 @@ func NewTranslator(serviceInformer cache.SharedIndexInformer,
 	recorderGetter healthchecks.RecorderGetter,
 	enableTHC,
 	enableL7XLBRegional bool,
+	region string,
 	logger klog.Logger,
 ) *Translator {
 	return &Translator{
@@ -88,6 +96,7 @@ func NewTranslator(serviceInformer cache.SharedIndexInformer,
 		KubeClient:            kubeClient,
 		enableTHC:             enableTHC,
 		enableL7XLBRegional:   enableL7XLBRegional,
+		region:                region,
 		logger:                logger.WithName("Translator"),
 	}
 }
@@ -102,6 +111,9 @@ type Translator struct {
 	KubeClient            kubernetes.Interface
 	enableTHC             bool
 	enableL7XLBRegional   bool
+	// region is the region of the cluster, and of its regional load
+	// balancers.
+	region string
 
 	logger klog.Logger
 }
@@ -136,8 +148,28 @@ func (t *Translator) GetService(namespace, name string) (*api_v1.Service, error)
 	return t.getCachedService(dummyServicePort)
 }
 
-// maybeEnableNEG enables NEG on the service port if necessary
-func maybeEnableNEG(sp *utils.ServicePort, svc *api_v1.Service) error {
+// maybeEnableNEG enables NEG on the service port if necessary. region is the
+// region of the regional load balancers, which can only use the serverless
+// NEGs of their region.
+func maybeEnableNEG(sp *utils.ServicePort, svc *api_v1.Service, region string) error {
+	externalNEG, ok, err := negannotation.FromService(svc).ExternalNEGAnnotation()
+	if err != nil {
+		// This is a fatal error.
+		return errors.ErrSvcExternalNEG{Service: sp.ID.Service, Err: err}
+	}
+	if ok {
+		// Internet NEGs are global, and can only back global backend services.
+		if externalNEG.Internet != nil && (sp.L7ILBEnabled || sp.L7XLBRegionalEnabled) {
+			return errors.ErrSvcExternalNEG{Service: sp.ID.Service, Err: fmt.Errorf("internet NEGs are only supported by the global external load balancer")}
+		}
+		if externalNEG.Serverless != nil && (sp.L7ILBEnabled || sp.L7XLBRegionalEnabled) && externalNEG.Serverless.Region != region {
+			return errors.ErrSvcExternalNEG{Service: sp.ID.Service, Err: fmt.Errorf("serverless NEG in region %q cannot back a regional load balancer in region %q", externalNEG.Serverless.Region, region)}
+		}
+		sp.NEGEnabled = true
+		sp.ExternalNEG = externalNEG
+		return nil
+	}
+
 	negAnnotation, ok, err := negannotation.FromService(svc).NEGAnnotation()
 	if ok && err == nil {
 		sp.NEGEnabled = negAnnotation.NEGEnabledForIngress()
@@ -219,6 +251,27 @@ func (t *Translator) maybeEnableBackendConfig(sp *utils.ServicePort, svc *api_v1
 	return nil
 }
 
+// maybeSetRequestMirror resolves the service port that receives the requests
+// mirrored from sp, if its BackendConfig specifies one.
+func (t *Translator) maybeSetRequestMirror(sp *utils.ServicePort, params *getServicePortParams, namer namer_util.BackendNamer) error {
+	if params.skipRequestMirror || sp.BackendConfig == nil || sp.BackendConfig.Spec.RequestMirror == nil {
+		return nil
+	}
+	mirror := sp.BackendConfig.Spec.RequestMirror
+	mirrorID := utils.ServicePortID{
+		Service: types.NamespacedName{Namespace: sp.ID.Service.Namespace, Name: mirror.ServiceName},
+		Port:    v1.ServiceBackendPort{Number: mirror.ServicePort, Name: mirror.ServicePortName},
+	}
+	mirrorParams := *params
+	mirrorParams.skipRequestMirror = true
+	mirrorPort, err, _ := t.getServicePort(mirrorID, &mirrorParams, namer)
+	if err != nil {
+		return errors.ErrBackendConfigValidation{BackendConfig: *sp.BackendConfig, Err: fmt.Errorf("invalid request mirror: %v", err)}
+	}
+	sp.RequestMirror = mirrorPort
+	return nil
+}
+
 // setThcOptInOnSvc sets the THCOptInOnSvc for the service port as true or false depending on whether
 // Transparent Health Checks should be enabled.
 func (t *Translator) setThcOptInOnSvc(sp *utils.ServicePort, svc *api_v1.Service) (flagWarning bool) {
@@ -293,9 +346,10 @@ func (t *Translator) getServicePort(id utils.ServicePortID, params *getServicePo
 		L7ILBEnabled:         params.isL7ILB,
 		L7XLBRegionalEnabled: params.isL7XLBRegional,
 		BackendNamer:         namer,
+		DriftPolicy:          params.driftPolicy,
 	}
 
-	if err := maybeEnableNEG(svcPort, svc); err != nil {
+	if err := maybeEnableNEG(svcPort, svc, t.region); err != nil {
 		return nil, err, false
 	}
 
@@ -313,6 +367,10 @@ func (t *Translator) getServicePort(id utils.ServicePortID, params *getServicePo
 		return svcPort, err, false
 	}
 
+	if err := t.maybeSetRequestMirror(svcPort, params, namer); err != nil {
+		return svcPort, err, false
+	}
+
 	flagWarning := t.setThcOptInOnSvc(svcPort, svc)
 
 	return svcPort, nil, flagWarning
@@ -326,6 +384,20 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 	urlMap := utils.NewGCEURLMap(t.logger)
 	params := t.getServicePortParamsForIngress(ing)
 
+	weightedBackends, err := annotations.FromIngress(ing).WeightedBackends()
+	if err != nil {
+		errs = append(errs, errors.ErrWeightedBackends{Err: err})
+	}
+	// resolvedWeightedBackends caches the weighted backends resolved for a
+	// Service so that they are only looked up once per Ingress.
+	resolvedWeightedBackends := map[string][]utils.WeightedBackend{}
+
+	routeMatches, err := annotations.FromIngress(ing).RouteMatches()
+	if err != nil {
+		errs = append(errs, errors.ErrRouteMatches{Err: err})
+	}
+	resolvedRouteMatches := map[string][]utils.RouteMatch{}
+
 	for _, rule := range ing.Spec.Rules {
 		if rule.HTTP == nil {
 			continue
@@ -333,6 +405,14 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 
 		pathRules := []utils.PathRule{}
 		for _, p := range rule.HTTP.Paths {
+			if bucket, ok := utils.BackendToBackendBucket(p.Backend); ok {
+				bucketRules, err := t.getBackendBucketPathRules(ing, p, bucket, params)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				pathRules = append(pathRules, bucketRules...)
+				continue
+			}
 			svcPortID, err := utils.BackendToServicePortID(p.Backend, ing.Namespace)
 			if err != nil {
 				// Only error possible is Backend is not a Service Backend, so move to next path
@@ -354,11 +434,27 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 					errs = append(errs, err)
 					continue
 				}
+				backends, ok := resolvedWeightedBackends[svcPortID.Service.Name]
+				if !ok && len(weightedBackends[svcPortID.Service.Name]) > 0 {
+					var backendErrs []error
+					backends, backendErrs, warning = t.getWeightedBackends(ing.Namespace, svcPortID.Service.Name, weightedBackends[svcPortID.Service.Name], params, namer)
+					warnings = warnings || warning
+					errs = append(errs, backendErrs...)
+					resolvedWeightedBackends[svcPortID.Service.Name] = backends
+				}
+				matches, ok := resolvedRouteMatches[svcPortID.Service.Name]
+				if !ok && len(routeMatches[svcPortID.Service.Name]) > 0 {
+					var matchErrs []error
+					matches, matchErrs, warning = t.getRouteMatches(ing.Namespace, routeMatches[svcPortID.Service.Name], params, namer)
+					warnings = warnings || warning
+					errs = append(errs, matchErrs...)
+					resolvedRouteMatches[svcPortID.Service.Name] = matches
+				}
 				for _, path := range paths {
 					if path == "" {
 						path = DefaultPath
 					}
-					pathRules = append(pathRules, utils.PathRule{Path: path, Backend: *svcPort})
+					pathRules = append(pathRules, utils.PathRule{Path: path, Backend: *svcPort, WeightedBackends: backends, RouteMatches: matches})
 				}
 			}
 		}
@@ -398,6 +494,110 @@ func (t *Translator) TranslateIngress(ing *v1.Ingress, systemDefaultBackend util
 	return urlMap, errs, warnings
 }
 
+// getBackendBucketPathRules returns the path rules of an Ingress path served
+// by the backend bucket of the given name, with the BackendConfig attached to
+// the backend bucket in the namespace of the Ingress. Backend buckets are
+// global resources, so they are only supported by the global external load
+// balancer. The BackendConfig is only applied if the backend bucket is
+// managed by the namespace of the Ingress, see --managed-backend-buckets;
+// otherwise the path rules are returned without it, along with an error.
+func (t *Translator) getBackendBucketPathRules(ing *v1.Ingress, p v1.HTTPIngressPath, name string, params *getServicePortParams) ([]utils.PathRule, error) {
+	if params.isL7ILB || params.isL7XLBRegional {
+		return nil, errors.ErrBackendBucket{Name: name, Err: fmt.Errorf("backend buckets are only supported for the %q Ingress class", annotations.GceIngressClass)}
+	}
+	paths, err := validateAndGetPaths(p)
+	if err != nil {
+		return nil, err
+	}
+	bucket := &utils.BackendBucket{Name: name}
+	beConfig, err := backendconfig.GetBackendConfigForBackendBucket(t.BackendConfigInformer.GetIndexer(), ing.Namespace, name)
+	if err != nil {
+		err = errors.ErrBackendBucket{Name: name, Err: err}
+	} else if beConfig != nil {
+		// The flag is validated at startup.
+		managed, _ := validation.ParseManagedBackendBuckets(flags.F.ManagedBackendBuckets)
+		if managed[name] != ing.Namespace {
+			err = errors.ErrBackendBucket{Name: name, Err: fmt.Errorf("BackendConfig %s/%s cannot be applied, the backend bucket is not managed by the namespace", beConfig.Namespace, beConfig.Name)}
+		} else {
+			// Object in cache could be changed in-flight. Deepcopy to
+			// reduce race conditions.
+			bucket.BackendConfig = beConfig.DeepCopy()
+		}
+	}
+	var pathRules []utils.PathRule
+	for _, path := range paths {
+		if path == "" {
+			path = DefaultPath
+		}
+		pathRules = append(pathRules, utils.PathRule{Path: path, BackendBucket: bucket})
+	}
+	return pathRules, err
+}
+
+// getWeightedBackends returns the ServicePorts of the given weighted backends
+// of the paths of the given Service. Weighted backends are only supported by
+// the regional and internal load balancers, as the global external load
+// balancer cannot split traffic. The route action settings of the
+// BackendConfig of the Service apply to all the weighted backends of its
+// paths, so the BackendConfigs of the other weighted backends must not have
+// any.
+func (t *Translator) getWeightedBackends(namespace, svcName string, backends []annotations.WeightedBackend, params *getServicePortParams, namer namer_util.BackendNamer) ([]utils.WeightedBackend, []error, bool) {
+	if !params.isL7ILB && !params.isL7XLBRegional {
+		return nil, []error{errors.ErrWeightedBackends{Err: fmt.Errorf("weighted backends are only supported for %q and %q Ingress classes", annotations.GceL7ILBIngressClass, annotations.GceL7XLBRegionalIngressClass)}}, false
+	}
+
+	var ret []utils.WeightedBackend
+	var errs []error
+	var warnings bool
+	for _, b := range backends {
+		svcPortID := utils.ServicePortID{Service: types.NamespacedName{Namespace: namespace, Name: b.Service.Name}, Port: b.Service.Port}
+		svcPort, err, warning := t.getServicePort(svcPortID, params, namer)
+		warnings = warnings || warning
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if b.Service.Name != svcName && features.HasRouteActionSettings(*svcPort) {
+			errs = append(errs, errors.ErrBackendConfigValidation{BackendConfig: *svcPort.BackendConfig, Err: fmt.Errorf("retryPolicy, faultInjection and requestMirror are not supported for the weighted backend %s of Service %s, whose BackendConfig sets them for all its weighted backends", b.Service.Name, svcName)})
+			continue
+		}
+		ret = append(ret, utils.WeightedBackend{Backend: *svcPort, Weight: b.Weight})
+	}
+	if len(errs) > 0 {
+		return nil, errs, warnings
+	}
+	return ret, nil, warnings
+}
+
+// getRouteMatches returns the route matches with the ServicePorts of their
+// backends.
+func (t *Translator) getRouteMatches(namespace string, matches []annotations.RouteMatch, params *getServicePortParams, namer namer_util.BackendNamer) ([]utils.RouteMatch, []error, bool) {
+	var ret []utils.RouteMatch
+	var errs []error
+	var warnings bool
+	for _, m := range matches {
+		svcPortID := utils.ServicePortID{Service: types.NamespacedName{Namespace: namespace, Name: m.Service.Name}, Port: m.Service.Port}
+		svcPort, err, warning := t.getServicePort(svcPortID, params, namer)
+		warnings = warnings || warning
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		routeMatch := utils.RouteMatch{Backend: *svcPort}
+		for _, h := range m.Headers {
+			routeMatch.Headers = append(routeMatch.Headers, utils.MatchCondition{Name: h.Name, Value: h.Value})
+		}
+		for _, q := range m.QueryParams {
+			routeMatch.QueryParams = append(routeMatch.QueryParams, utils.MatchCondition{Name: q.Name, Value: q.Value})
+		}
+		ret = append(ret, routeMatch)
+	}
+	if len(errs) > 0 {
+		return nil, errs, warnings
+	}
+	return ret, nil, warnings
+}
+
 // validateAndGetPaths will validate the path based on the specified path type and will return the
 // the path rules that should be used. If no path type is provided, the path type will be assumed
 // to be ImplementationSpecific. If a non existent path type is provided, an error will be returned.
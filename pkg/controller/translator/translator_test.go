@@ -46,6 +46,7 @@ import (
 	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
+	"k8s.io/ingress-gce/pkg/negannotation"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/endpointslices"
@@ -94,6 +95,7 @@ func configuredFakeTranslator() *Translator {
 		healthchecks.NewFakeRecorderGetter(0),
 		false,
 		false,
+		"us-central1",
 		klog.TODO(),
 	)
 }
@@ -218,6 +220,37 @@ func TestTranslateIngress(t *testing.T) {
 			wantErrCount:  1,
 			wantGCEURLMap: gceURLMapFromFile(t, "ingress-null-service-backend.json"),
 		},
+		{
+			desc:                   "weighted backends",
+			ing:                    ingressFromFile(t, "ingress-weighted-backends.yaml"),
+			wantErrCount:           0,
+			wantGCEURLMap:          gceURLMapFromFile(t, "ingress-weighted-backends.json"),
+			enableL7XLBGCERegional: true,
+		},
+		{
+			desc:          "weighted backends on global external load balancer",
+			ing:           ingressFromFile(t, "ingress-weighted-backends-global.yaml"),
+			wantErrCount:  1,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-weighted-backends-global.json"),
+		},
+		{
+			desc:          "route matches",
+			ing:           ingressFromFile(t, "ingress-route-matches.yaml"),
+			wantErrCount:  0,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-route-matches.json"),
+		},
+		{
+			desc:          "backend bucket",
+			ing:           ingressFromFile(t, "ingress-backend-bucket.yaml"),
+			wantErrCount:  0,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-backend-bucket.json"),
+		},
+		{
+			desc:          "backend bucket on internal load balancer",
+			ing:           ingressFromFile(t, "ingress-backend-bucket-regional.yaml"),
+			wantErrCount:  1,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-backend-bucket-regional.json"),
+		},
 	}
 
 	for _, tc := range cases {
@@ -475,6 +508,96 @@ func TestGetServicePort(t *testing.T) {
 				NEGEnabled:           true,
 			},
 		},
+		{
+			desc: "clusterIP service with an internet NEG",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeClusterIP,
+				Ports: []apiv1.ServicePort{{Name: "https", Port: 443}},
+			},
+			annotations: map[string]string{
+				negannotation.ExternalNEGAnnotationKey: `{"internet":{"fqdn":"origin.example.com"}}`,
+			},
+			id: utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "https"}},
+			wantServicePort: &utils.ServicePort{
+				ID: utils.ServicePortID{
+					Service: types.NamespacedName{
+						Namespace: "default",
+						Name:      "foo",
+					},
+					Port: v1.ServiceBackendPort{Name: "https"},
+				},
+				Port:        443,
+				PortName:    "https",
+				Protocol:    "HTTP",
+				NEGEnabled:  true,
+				ExternalNEG: &negannotation.ExternalNegAnnotation{Internet: &negannotation.InternetNeg{FQDN: "origin.example.com"}},
+			},
+		},
+		{
+			desc: "internet NEG for gce-internal",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeClusterIP,
+				Ports: []apiv1.ServicePort{{Name: "https", Port: 443}},
+			},
+			annotations: map[string]string{
+				negannotation.ExternalNEGAnnotationKey: `{"internet":{"fqdn":"origin.example.com"}}`,
+			},
+			id:      utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "https"}},
+			params:  getServicePortParams{isL7ILB: true},
+			wantErr: true,
+		},
+		{
+			desc: "serverless NEG of the region of gce-internal",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeClusterIP,
+				Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+			},
+			annotations: map[string]string{
+				negannotation.ExternalNEGAnnotationKey: `{"serverless":{"region":"us-central1","cloudRun":{"service":"api"}}}`,
+			},
+			id:     utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "http"}},
+			params: getServicePortParams{isL7ILB: true},
+			wantServicePort: &utils.ServicePort{
+				ID: utils.ServicePortID{
+					Service: types.NamespacedName{
+						Namespace: "default",
+						Name:      "foo",
+					},
+					Port: v1.ServiceBackendPort{Name: "http"},
+				},
+				Port:         80,
+				PortName:     "http",
+				Protocol:     "HTTP",
+				NEGEnabled:   true,
+				L7ILBEnabled: true,
+				ExternalNEG:  &negannotation.ExternalNegAnnotation{Serverless: &negannotation.ServerlessNeg{Region: "us-central1", CloudRun: &negannotation.CloudRunBackend{Service: "api"}}},
+			},
+		},
+		{
+			desc: "serverless NEG of another region for gce-regional-external",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeClusterIP,
+				Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+			},
+			annotations: map[string]string{
+				negannotation.ExternalNEGAnnotationKey: `{"serverless":{"region":"us-west1","cloudRun":{"service":"api"}}}`,
+			},
+			id:      utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "http"}},
+			params:  getServicePortParams{isL7XLBRegional: true},
+			wantErr: true,
+		},
+		{
+			desc: "external NEG annotation malformed",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeNodePort,
+				Ports: []apiv1.ServicePort{{Name: "https", Port: 443}},
+			},
+			annotations: map[string]string{
+				negannotation.ExternalNEGAnnotationKey: `{"serverless":{}}`,
+			},
+			id:      utils.ServicePortID{Port: v1.ServiceBackendPort{Name: "https"}},
+			wantErr: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -501,6 +624,93 @@ func TestGetServicePort(t *testing.T) {
 	}
 }
 
+func TestTranslateIngressBackendBucketConfig(t *testing.T) {
+	firstService := test.NewService(types.NamespacedName{Name: "first-service", Namespace: "default"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Port: 80}},
+	})
+	defaultHTTPBackend := test.NewService(types.NamespacedName{Name: "default-http-backend", Namespace: "kube-system"}, apiv1.ServiceSpec{
+		Type:  apiv1.ServiceTypeNodePort,
+		Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+	})
+	targetRef := &backendconfig.PolicyTargetReference{Group: utils.BackendBucketAPIGroup, Kind: utils.BackendBucketKind, Name: "static-assets"}
+	cdnConfig := test.NewBackendConfig(types.NamespacedName{Name: "cdn", Namespace: "default"}, backendconfig.BackendConfigSpec{
+		Cdn:       &backendconfig.CDNConfig{Enabled: true},
+		TargetRef: targetRef,
+	})
+	// A BackendConfig of another namespace is not attached.
+	otherConfig := test.NewBackendConfig(types.NamespacedName{Name: "cdn", Namespace: "other"}, backendconfig.BackendConfigSpec{
+		TargetRef: targetRef,
+	})
+	conflictingConfig := test.NewBackendConfig(types.NamespacedName{Name: "no-cdn", Namespace: "default"}, backendconfig.BackendConfigSpec{
+		Cdn:       &backendconfig.CDNConfig{Enabled: false},
+		TargetRef: targetRef,
+	})
+
+	for _, tc := range []struct {
+		desc              string
+		managed           string
+		configs           []*backendconfig.BackendConfig
+		wantBackendConfig *backendconfig.BackendConfig
+		wantErr           bool
+	}{
+		{
+			desc:              "backend bucket managed by the namespace",
+			managed:           "default/static-assets",
+			configs:           []*backendconfig.BackendConfig{cdnConfig, otherConfig},
+			wantBackendConfig: cdnConfig,
+		},
+		{
+			desc:    "backend bucket managed by another namespace",
+			managed: "other/static-assets",
+			configs: []*backendconfig.BackendConfig{cdnConfig, otherConfig},
+			wantErr: true,
+		},
+		{
+			desc:    "backend bucket not managed",
+			configs: []*backendconfig.BackendConfig{cdnConfig},
+			wantErr: true,
+		},
+		{
+			desc:    "backend bucket not managed and without BackendConfig",
+			configs: []*backendconfig.BackendConfig{otherConfig},
+		},
+		{
+			desc:    "conflicting BackendConfigs",
+			managed: "default/static-assets",
+			configs: []*backendconfig.BackendConfig{cdnConfig, conflictingConfig},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			oldManaged := flags.F.ManagedBackendBuckets
+			defer func() { flags.F.ManagedBackendBuckets = oldManaged }()
+			flags.F.ManagedBackendBuckets = tc.managed
+
+			translator := fakeTranslator()
+			translator.ServiceInformer.GetIndexer().Add(firstService)
+			translator.ServiceInformer.GetIndexer().Add(defaultHTTPBackend)
+			for _, config := range tc.configs {
+				translator.BackendConfigInformer.GetIndexer().Add(config)
+			}
+
+			urlMap, errs, _ := translator.TranslateIngress(ingressFromFile(t, "ingress-backend-bucket.yaml"), defaultBackend.ID, defaultNamer)
+			if gotErr := len(errs) != 0; gotErr != tc.wantErr {
+				t.Errorf("TranslateIngress() = _, %v, want errors: %v", errs, tc.wantErr)
+			}
+			// The path is served by the backend bucket even if its
+			// BackendConfig cannot be applied.
+			buckets := urlMap.AllBackendBuckets()
+			if len(buckets) != 1 || buckets[0].Name != "static-assets" {
+				t.Fatalf("AllBackendBuckets() = %+v, want the backend bucket static-assets", buckets)
+			}
+			if diff := cmp.Diff(tc.wantBackendConfig, buckets[0].BackendConfig); diff != "" {
+				t.Errorf("Got unexpected BackendConfig of the backend bucket (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetServicePortWithBackendConfigEnabled(t *testing.T) {
 	backendConfig := test.NewBackendConfig(types.NamespacedName{Name: "config-http", Namespace: "default"}, backendconfig.BackendConfigSpec{
 		Cdn: &backendconfig.CDNConfig{
@@ -578,6 +788,133 @@ func TestGetServicePortWithBackendConfigEnabled(t *testing.T) {
 	}
 }
 
+func TestGetServicePortWithRequestMirror(t *testing.T) {
+	mirrorConfig := test.NewBackendConfig(types.NamespacedName{Name: "config-mirror", Namespace: "default"}, backendconfig.BackendConfigSpec{
+		RequestMirror: &backendconfig.RequestMirrorConfig{ServiceName: "shadow", ServicePort: 80},
+	})
+	// The shadow Service mirrors back to foo, which must not be followed.
+	shadowConfig := test.NewBackendConfig(types.NamespacedName{Name: "config-shadow", Namespace: "default"}, backendconfig.BackendConfigSpec{
+		RequestMirror: &backendconfig.RequestMirrorConfig{ServiceName: "foo", ServicePort: 80},
+	})
+
+	for _, tc := range []struct {
+		desc       string
+		addShadow  bool
+		wantErr    bool
+		wantMirror *utils.ServicePortID
+	}{
+		{
+			desc:      "mirror Service exists",
+			addShadow: true,
+			wantMirror: &utils.ServicePortID{
+				Service: types.NamespacedName{Name: "shadow", Namespace: "default"},
+				Port:    v1.ServiceBackendPort{Number: 80},
+			},
+		},
+		{
+			desc:    "mirror Service does not exist",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			translator := fakeTranslator()
+			svcLister := translator.ServiceInformer.GetIndexer()
+			backendConfigLister := translator.BackendConfigInformer.GetIndexer()
+			svcName := types.NamespacedName{Name: "foo", Namespace: "default"}
+			svc := test.NewService(svcName, apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeNodePort,
+				Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+			})
+			svc.Annotations = map[string]string{annotations.BackendConfigKey: `{"default":"config-mirror"}`}
+			svcLister.Add(svc)
+			if tc.addShadow {
+				shadow := test.NewService(types.NamespacedName{Name: "shadow", Namespace: "default"}, apiv1.ServiceSpec{
+					Type:  apiv1.ServiceTypeNodePort,
+					Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+				})
+				shadow.Annotations = map[string]string{annotations.BackendConfigKey: `{"default":"config-shadow"}`}
+				svcLister.Add(shadow)
+			}
+			backendConfigLister.Add(mirrorConfig)
+			backendConfigLister.Add(shadowConfig)
+
+			id := utils.ServicePortID{Service: svcName, Port: v1.ServiceBackendPort{Number: 80}}
+			port, gotErr, _ := translator.getServicePort(id, &getServicePortParams{isL7ILB: true}, defaultNamer)
+			if (gotErr != nil) != tc.wantErr {
+				t.Fatalf("translator.getServicePort(%+v) = _, %v, want err? %v", id, gotErr, tc.wantErr)
+			}
+			if tc.wantMirror == nil {
+				return
+			}
+			if port.RequestMirror == nil {
+				t.Fatalf("port.RequestMirror = nil, want %+v", tc.wantMirror)
+			}
+			if port.RequestMirror.ID != *tc.wantMirror {
+				t.Errorf("port.RequestMirror.ID = %+v, want %+v", port.RequestMirror.ID, *tc.wantMirror)
+			}
+			if !port.RequestMirror.L7ILBEnabled {
+				t.Errorf("port.RequestMirror.L7ILBEnabled = false, want true")
+			}
+			if port.RequestMirror.RequestMirror != nil {
+				t.Errorf("port.RequestMirror.RequestMirror = %+v, want nil", port.RequestMirror.RequestMirror)
+			}
+		})
+	}
+}
+
+func TestGetWeightedBackendsRouteActionSettings(t *testing.T) {
+	retryConfig := test.NewBackendConfig(types.NamespacedName{Name: "config-retry", Namespace: "default"}, backendconfig.BackendConfigSpec{
+		RetryPolicy: &backendconfig.RetryPolicyConfig{RetryConditions: []string{"5xx"}},
+	})
+
+	for _, tc := range []struct {
+		desc    string
+		configs map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "no BackendConfig",
+		},
+		{
+			desc:    "route action settings of the Service of the paths",
+			configs: map[string]string{"foo": "config-retry"},
+		},
+		{
+			desc:    "route action settings of another weighted backend",
+			configs: map[string]string{"canary": "config-retry"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			translator := fakeTranslator()
+			svcLister := translator.ServiceInformer.GetIndexer()
+			translator.BackendConfigInformer.GetIndexer().Add(retryConfig)
+			for _, name := range []string{"foo", "canary"} {
+				svc := test.NewService(types.NamespacedName{Name: name, Namespace: "default"}, apiv1.ServiceSpec{
+					Type:  apiv1.ServiceTypeNodePort,
+					Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+				})
+				if config, ok := tc.configs[name]; ok {
+					svc.Annotations = map[string]string{annotations.BackendConfigKey: fmt.Sprintf(`{"default":%q}`, config)}
+				}
+				svcLister.Add(svc)
+			}
+			backends := []annotations.WeightedBackend{
+				{Service: v1.IngressServiceBackend{Name: "foo", Port: v1.ServiceBackendPort{Number: 80}}, Weight: 90},
+				{Service: v1.IngressServiceBackend{Name: "canary", Port: v1.ServiceBackendPort{Number: 80}}, Weight: 10},
+			}
+
+			got, errs, _ := translator.getWeightedBackends("default", "foo", backends, &getServicePortParams{isL7ILB: true}, defaultNamer)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("translator.getWeightedBackends() = _, %v, want err? %v", errs, tc.wantErr)
+			}
+			if !tc.wantErr && len(got) != len(backends) {
+				t.Errorf("translator.getWeightedBackends() returned %d backends, want %d", len(got), len(backends))
+			}
+		})
+	}
+}
+
 func TestGetProbe(t *testing.T) {
 	translator := fakeTranslator()
 	nodePortToHealthCheck := map[utils.ServicePort]string{
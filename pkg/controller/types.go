@@ -27,4 +27,7 @@ type syncState struct {
 	urlMap *utils.GCEURLMap
 	ing    *v1.Ingress
 	l7     *loadbalancers.L7
+	// group is the ingresses of the group of ing, oldest first, if ing
+	// shares the load balancer of a group.
+	group []*v1.Ingress
 }
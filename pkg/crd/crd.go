@@ -158,6 +158,11 @@ func crd(meta *CRDMeta, namespacedScoped bool, logger klog.Logger) *apiextension
 			Schema:     validationSchema,
 			Deprecated: v.deprecated,
 		}
+		if v.statusSubresource {
+			version.Subresources = &apiextensionsv1.CustomResourceSubresources{
+				Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+			}
+		}
 		// Set storage to true for the latest version.
 		if i == 0 {
 			version.Storage = true
@@ -195,3 +195,25 @@ func TestCreateOrUpdateCRD(t *testing.T) {
 		}
 	}
 }
+
+func TestCRDStatusSubresource(t *testing.T) {
+	meta := &CRDMeta{
+		groupName: "test.group.com",
+		versions: []*Version{
+			NewVersion("v1", "pkg/apis/test/v1.Test", testGetOpenAPIDefinitions, false).WithStatusSubresource(),
+			NewVersion("v1alpha1", "pkg/apis/test/v1alpha1.Test", testGetOpenAPIDefinitions, false),
+		},
+		kind:     "Test",
+		listKind: "TestList",
+		singular: "test",
+		plural:   "tests",
+	}
+
+	crd := crd(meta, true, klog.TODO())
+	if got := crd.Spec.Versions[0].Subresources; got == nil || got.Status == nil {
+		t.Errorf("Subresources of version %s = %+v, want the status subresource", crd.Spec.Versions[0].Name, got)
+	}
+	if got := crd.Spec.Versions[1].Subresources; got != nil {
+		t.Errorf("Subresources of version %s = %+v, want nil", crd.Spec.Versions[1].Name, got)
+	}
+}
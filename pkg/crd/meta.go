@@ -54,6 +54,8 @@ type Version struct {
 	typeSource string
 	fn         common.GetOpenAPIDefinitions
 	deprecated bool
+	// statusSubresource enables the /status subresource of the version.
+	statusSubresource bool
 }
 
 // NewVersion returns a CRD API version with validation metadata.
@@ -65,3 +67,11 @@ func NewVersion(name, typeSource string, fn common.GetOpenAPIDefinitions, deprec
 		deprecated: deprecated,
 	}
 }
+
+// WithStatusSubresource enables the /status subresource for the version, so
+// that its status is only updated through it and spec changes alone bump the
+// generation of the resources.
+func (v *Version) WithStatusSubresource() *Version {
+	v.statusSubresource = true
+	return v
+}
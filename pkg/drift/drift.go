@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift detects the changes made to the GCE resources of the Ingress
+// controller outside of the controller, e.g. in the Cloud Console.
+//
+// A resource drifted if some of its fields differ from the desired state
+// while the desired values of those fields did not change since the
+// controller last applied them.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// ignoredFields are the fields set by GCE or used by the client libraries
+// only, which are not part of the desired state of a resource.
+var ignoredFields = map[string]bool{
+	"CreationTimestamp": true,
+	"Fingerprint":       true,
+	"ForceSendFields":   true,
+	"Id":                true,
+	"Kind":              true,
+	"NullFields":        true,
+	"Region":            true,
+	"ResourceType":      true,
+	"Scope":             true,
+	"SelfLink":          true,
+	"ServerResponse":    true,
+	"Version":           true,
+}
+
+// diffOptions compare links by resource path, as the controller and GCE
+// may use different endpoints and API versions in the links to the same
+// resource, and equate nil and empty lists.
+var diffOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmp.FilterPath(func(p cmp.Path) bool {
+		sf, ok := p.Last().(cmp.StructField)
+		if !ok {
+			return false
+		}
+		name := sf.Name()
+		return ignoredFields[name] || name[0] < 'A' || name[0] > 'Z'
+	}, cmp.Ignore()),
+	cmp.Comparer(func(a, b string) bool {
+		return a == b || utils.EqualResourcePaths(a, b)
+	}),
+}
+
+// Drift is a change of a GCE resource made outside of the controller.
+type Drift struct {
+	// Resource is the path of the resource relative to the project, e.g.
+	// global/urlMaps/k8s2-um-xyz.
+	Resource string
+	// Fields are the paths of the fields that differ from the desired
+	// state, e.g. HostRules[0].Hosts[1].
+	Fields []string
+	// DesiredChanged is true if the desired state of other fields changed
+	// since it was last applied, so the resource needs an update regardless
+	// of the drift.
+	DesiredChanged bool
+	// Repeated is true if the same fields were reported as drifted by the
+	// previous Detect of the resource, e.g. on every resync of an Ingress
+	// with DriftPolicyAlert. Repeated drifts are neither counted nor need
+	// to be reported again.
+	Repeated bool
+}
+
+// String returns the resource and its drifted fields.
+func (d *Drift) String() string {
+	return fmt.Sprintf("%s (fields %s)", d.Resource, strings.Join(d.Fields, ", "))
+}
+
+// Revert returns true if the resource should be updated to the desired state
+// with the given drift policy. Drifts are only left in place with
+// DriftPolicyAlert, as long as nothing else needs to be updated.
+func (d *Drift) Revert(policy annotations.DriftPolicy) bool {
+	return d.DesiredChanged || policy != annotations.DriftPolicyAlert
+}
+
+// Detector remembers the desired state that the controller last applied to
+// each resource, to tell the changes made outside of the controller from the
+// changes of the desired state. A nil Detector means that drift detection is
+// disabled, and all its methods are no-ops.
+//
+// The applied state is only kept in memory. After the controller restarts,
+// the resources are not tracked until they are synced once, so the changes
+// made before then are not detected and are reverted whatever the drift
+// policy.
+type Detector struct {
+	mu sync.Mutex
+	// applied are copies of the desired resources that were last applied,
+	// by resource path.
+	applied map[string]interface{}
+	// reported are the drifted fields last reported for each resource, by
+	// resource path, until the desired state is applied to it again.
+	reported map[string]string
+}
+
+// NewDetector returns a Detector that enables drift detection.
+func NewDetector() *Detector {
+	registerMetrics()
+	return &Detector{applied: map[string]interface{}{}, reported: map[string]string{}}
+}
+
+// Applied records the desired state of the resource of the given collection
+// (e.g. "urlMaps") and key, after it was created, updated, or found to be up
+// to date.
+func (d *Detector) Applied(resource string, key *meta.Key, desired interface{}) {
+	if d == nil || desired == nil {
+		return
+	}
+	obj, err := deepCopy(desired)
+	if err != nil {
+		// The resource is not tracked, so no drift is reported for it.
+		return
+	}
+	path := cloud.ResourcePath(resource, key)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.applied[path] = obj
+	delete(d.reported, path)
+}
+
+// Forget stops tracking the resource of the given collection and key, after
+// it was deleted.
+func (d *Detector) Forget(resource string, key *meta.Key) {
+	if d == nil {
+		return
+	}
+	path := cloud.ResourcePath(resource, key)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.applied, path)
+	delete(d.reported, path)
+}
+
+// Detect compares the current state of the resource of the given collection
+// and key with its desired state, and returns the fields changed outside of
+// the controller. It returns nil if the resource did not drift, or if the
+// controller did not apply a desired state to it yet. Drifts that were not
+// reported by the previous Detect of the resource are counted in the drift
+// metric of the collection, the others are marked as Repeated.
+//
+// Fields that are empty in the desired state are not managed by the
+// controller, and are not reported.
+func (d *Detector) Detect(resource string, key *meta.Key, current, desired interface{}) *Drift {
+	if d == nil || current == nil || desired == nil {
+		return nil
+	}
+	path := cloud.ResourcePath(resource, key)
+	d.mu.Lock()
+	applied, ok := d.applied[path]
+	d.mu.Unlock()
+	if !ok || reflect.TypeOf(applied) != reflect.TypeOf(desired) {
+		return nil
+	}
+
+	changedFields := diffFields(applied, desired)
+	drift := &Drift{Resource: path}
+	for field := range diffFields(current, desired) {
+		if changedFields[field] {
+			drift.DesiredChanged = true
+		} else {
+			drift.Fields = append(drift.Fields, field)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(drift.Fields) == 0 {
+		delete(d.reported, path)
+		return nil
+	}
+	sort.Strings(drift.Fields)
+	fields := strings.Join(drift.Fields, ",")
+	if d.reported[path] == fields {
+		drift.Repeated = true
+		return drift
+	}
+	d.reported[path] = fields
+	driftCount.WithLabelValues(resource).Inc()
+	return drift
+}
+
+// diffFields returns the paths of the fields of x that differ from the ones
+// of desired, skipping the fields that are empty in desired.
+func diffFields(x, desired interface{}) map[string]bool {
+	r := &fieldReporter{fields: map[string]bool{}}
+	cmp.Equal(x, desired, append(diffOptions, cmp.Reporter(r))...)
+	return r.fields
+}
+
+// deepCopy returns a copy of the resource through its JSON representation,
+// which holds all of its fields compared by Detect.
+func deepCopy(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(obj)
+	if t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("expected a pointer to a resource, got %T", obj)
+	}
+	copied := reflect.New(t.Elem())
+	if err := json.Unmarshal(data, copied.Interface()); err != nil {
+		return nil, err
+	}
+	return copied.Interface(), nil
+}
+
+// Snapshot returns a copy of the current state of a resource, for the
+// callers that apply the desired state to the resource in place. It returns
+// nil if the resource cannot be copied, in which case Detect reports no
+// drift.
+func Snapshot(obj interface{}) interface{} {
+	copied, err := deepCopy(obj)
+	if err != nil {
+		return nil
+	}
+	return copied
+}
+
+// fieldReporter is a cmp.Reporter that collects the paths of the fields that
+// differ.
+type fieldReporter struct {
+	path   cmp.Path
+	fields map[string]bool
+}
+
+func (r *fieldReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *fieldReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *fieldReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	x, desired := r.path.Last().Values()
+	if x.IsValid() && desired.IsValid() && desired.IsZero() {
+		return
+	}
+	if !desired.IsValid() && len(r.path) > 1 {
+		// An element that is missing from an empty desired list.
+		if _, parent := r.path[len(r.path)-2].Values(); parent.IsValid() && parent.Len() == 0 {
+			return
+		}
+	}
+	r.fields[fieldPath(r.path)] = true
+}
+
+// fieldPath formats the path of a field, e.g. PathMatchers[0].PathRules[1].
+func fieldPath(p cmp.Path) string {
+	var b strings.Builder
+	for _, step := range p {
+		switch s := step.(type) {
+		case cmp.StructField:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s.Name())
+		case cmp.SliceIndex:
+			i, j := s.SplitKeys()
+			if j >= 0 {
+				i = j
+			}
+			fmt.Fprintf(&b, "[%d]", i)
+		case cmp.MapIndex:
+			fmt.Fprintf(&b, "[%v]", s.Key())
+		}
+	}
+	return b.String()
+}
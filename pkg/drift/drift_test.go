@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+const resource = "backendServices"
+
+func newBackendService() *composite.BackendService {
+	return &composite.BackendService{
+		Name:         "be",
+		Protocol:     "HTTP",
+		TimeoutSec:   30,
+		HealthChecks: []string{"https://www.googleapis.com/compute/v1/projects/p/global/healthChecks/hc"},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	key := meta.GlobalKey("be")
+	for _, tc := range []struct {
+		desc      string
+		applied   func(be *composite.BackendService)
+		current   func(be *composite.BackendService)
+		desired   func(be *composite.BackendService)
+		wantDrift *Drift
+	}{
+		{
+			desc:    "no drift",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {},
+			desired: func(be *composite.BackendService) {},
+		},
+		{
+			desc:    "never applied",
+			current: func(be *composite.BackendService) { be.TimeoutSec = 60 },
+			desired: func(be *composite.BackendService) {},
+		},
+		{
+			desc:    "changed outside of the controller",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {
+				be.TimeoutSec = 60
+				be.Protocol = "HTTPS"
+			},
+			desired:   func(be *composite.BackendService) {},
+			wantDrift: &Drift{Resource: "global/backendServices/be", Fields: []string{"Protocol", "TimeoutSec"}},
+		},
+		{
+			desc:    "desired state changed",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {},
+			desired: func(be *composite.BackendService) { be.TimeoutSec = 60 },
+		},
+		{
+			desc:    "desired state changed and other field changed outside of the controller",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) { be.Protocol = "HTTPS" },
+			desired: func(be *composite.BackendService) { be.TimeoutSec = 60 },
+			wantDrift: &Drift{
+				Resource:       "global/backendServices/be",
+				Fields:         []string{"Protocol"},
+				DesiredChanged: true,
+			},
+		},
+		{
+			desc:    "fields not managed by the controller",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {
+				be.SelfLink = "https://www.googleapis.com/compute/v1/projects/p/global/backendServices/be"
+				be.Fingerprint = "abc"
+				be.Port = 80
+				be.Backends = []*composite.Backend{{Group: "ig"}}
+			},
+			desired: func(be *composite.BackendService) {},
+		},
+		{
+			desc:    "links with another API version",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {
+				be.HealthChecks = []string{"https://www.googleapis.com/compute/beta/projects/p/global/healthChecks/hc"}
+			},
+			desired: func(be *composite.BackendService) {},
+		},
+		{
+			desc:    "list element added outside of the controller",
+			applied: func(be *composite.BackendService) {},
+			current: func(be *composite.BackendService) {
+				be.HealthChecks = append(be.HealthChecks, "https://www.googleapis.com/compute/v1/projects/p/global/healthChecks/other")
+			},
+			desired:   func(be *composite.BackendService) {},
+			wantDrift: &Drift{Resource: "global/backendServices/be", Fields: []string{"HealthChecks[1]"}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := NewDetector()
+			if tc.applied != nil {
+				applied := newBackendService()
+				tc.applied(applied)
+				d.Applied(resource, key, applied)
+			}
+			current := newBackendService()
+			tc.current(current)
+			desired := newBackendService()
+			tc.desired(desired)
+
+			got := d.Detect(resource, key, current, desired)
+			if diff := cmp.Diff(tc.wantDrift, got); diff != "" {
+				t.Errorf("Detect() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetectAfterForget(t *testing.T) {
+	d := NewDetector()
+	key := meta.GlobalKey("be")
+	d.Applied(resource, key, newBackendService())
+	d.Forget(resource, key)
+
+	current := newBackendService()
+	current.TimeoutSec = 60
+	if got := d.Detect(resource, key, current, newBackendService()); got != nil {
+		t.Errorf("Detect() = %v after Forget(), want nil", got)
+	}
+}
+
+func TestDetectRepeated(t *testing.T) {
+	d := NewDetector()
+	key := meta.GlobalKey("repeated")
+	d.Applied(resource, key, newBackendService())
+	count := func() float64 { return testutil.ToFloat64(driftCount.WithLabelValues(resource)) }
+	start := count()
+
+	current := newBackendService()
+	current.TimeoutSec = 60
+	for i, step := range []struct {
+		desc         string
+		change       func(be *composite.BackendService)
+		applied      bool
+		wantRepeated bool
+		wantCount    float64
+	}{
+		{desc: "new drift", wantCount: 1},
+		{desc: "same drift", wantRepeated: true, wantCount: 1},
+		{desc: "other field drifted", change: func(be *composite.BackendService) { be.Protocol = "HTTPS" }, wantCount: 2},
+		{desc: "same drift after the desired state was applied", applied: true, wantCount: 3},
+	} {
+		if step.change != nil {
+			step.change(current)
+		}
+		if step.applied {
+			d.Applied(resource, key, newBackendService())
+		}
+		got := d.Detect(resource, key, current, newBackendService())
+		if got == nil || got.Repeated != step.wantRepeated {
+			t.Errorf("%d. %s: Detect() = %+v, want a drift with Repeated = %t", i, step.desc, got, step.wantRepeated)
+		}
+		if c := count() - start; c != step.wantCount {
+			t.Errorf("%d. %s: drift count = %v, want %v", i, step.desc, c, step.wantCount)
+		}
+	}
+}
+
+func TestAppliedCopiesResource(t *testing.T) {
+	d := NewDetector()
+	key := meta.GlobalKey("be")
+	be := newBackendService()
+	d.Applied(resource, key, be)
+	// The caller keeps modifying its resource after it was applied.
+	be.TimeoutSec = 60
+
+	current := newBackendService()
+	current.TimeoutSec = 90
+	got := d.Detect(resource, key, current, newBackendService())
+	if got == nil || got.DesiredChanged {
+		t.Errorf("Detect() = %+v, want a drift of the applied resource", got)
+	}
+}
+
+func TestNilDetector(t *testing.T) {
+	var d *Detector
+	key := meta.GlobalKey("be")
+	d.Applied(resource, key, newBackendService())
+	d.Forget(resource, key)
+	if got := d.Detect(resource, key, newBackendService(), newBackendService()); got != nil {
+		t.Errorf("Detect() = %v with a nil Detector, want nil", got)
+	}
+}
+
+func TestRevert(t *testing.T) {
+	for _, tc := range []struct {
+		drift  Drift
+		policy annotations.DriftPolicy
+		want   bool
+	}{
+		{drift: Drift{}, policy: annotations.DriftPolicyReconcile, want: true},
+		{drift: Drift{}, policy: "", want: true},
+		{drift: Drift{}, policy: annotations.DriftPolicyAlert, want: false},
+		{drift: Drift{DesiredChanged: true}, policy: annotations.DriftPolicyAlert, want: true},
+	} {
+		if got := tc.drift.Revert(tc.policy); got != tc.want {
+			t.Errorf("%+v.Revert(%q) = %t, want %t", tc.drift, tc.policy, got, tc.want)
+		}
+	}
+}
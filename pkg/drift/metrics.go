@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	register sync.Once
+
+	driftCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ingress_resource_drift_count",
+			Help: "Number of changes made to the GCE resources of Ingresses outside of the controller",
+		},
+		// resource is the GCE collection of the resource, e.g. urlMaps.
+		[]string{"resource"},
+	)
+)
+
+func registerMetrics() {
+	register.Do(func() {
+		prometheus.MustRegister(driftCount)
+	})
+}
@@ -29,6 +29,9 @@ const (
 	TranslateIngress  = "Translate"
 	IPChanged         = "IPChanged"
 	GarbageCollection = "GarbageCollection"
+	PlanIngress       = "Plan"
+	DriftDetected     = "Drift"
+	IngressGroup      = "IngressGroup"
 
 	SyncService = "Sync"
 )
@@ -36,6 +36,7 @@ import (
 	"k8s.io/ingress-gce/pkg/controller/translator"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/zonegetter"
 	"k8s.io/ingress-gce/pkg/validation"
@@ -62,6 +63,12 @@ type FirewallController struct {
 	hasSynced                     func() bool
 	enableIngressRegionalExternal bool
 	stopCh                        <-chan struct{}
+	// plan records the firewall mutations of a sync in plan mode.
+	plan *plan.Recorder
+	// extraIngresses returns the Ingresses that are not in the cluster, such
+	// as those translated from Gateway API resources, which the firewall
+	// rule also allows. It is nil if there are none.
+	extraIngresses func() ([]*v1.Ingress, error)
 
 	logger klog.Logger
 }
@@ -108,12 +115,18 @@ func NewFirewallController(
 		return nil, fmt.Errorf("failed to get source ranges: %w", err)
 	}
 	logger.Info("Using source ranges", "ranges", sourceRanges)
-	if enableCR {
+	var planRecorder *plan.Recorder
+	if ctx.IngressPlanMode {
+		planRecorder = plan.NewRecorder()
+	}
+	if enableCR && planRecorder.Enabled() {
+		logger.Info("Not syncing firewall CRs since the controller is in plan mode")
+	} else if enableCR {
 		firewallCRPool := NewFirewallCRPool(ctx.FirewallClient, ctx.Cloud, ctx.ClusterNamer, sourceRanges, portRanges, disableFWEnforcement, logger)
 		compositeFirewallPool.pools = append(compositeFirewallPool.pools, firewallCRPool)
 	}
 	if !disableFWEnforcement {
-		firewallPool := NewFirewallPool(ctx.Cloud, ctx.ClusterNamer, sourceRanges, portRanges, logger)
+		firewallPool := NewFirewallPool(ctx.Cloud, ctx.ClusterNamer, sourceRanges, portRanges, planRecorder, logger)
 		compositeFirewallPool.pools = append(compositeFirewallPool.pools, firewallPool)
 	}
 
@@ -125,6 +138,7 @@ func NewFirewallController(
 		hasSynced:                     ctx.HasSynced,
 		enableIngressRegionalExternal: enableRegionalXLB,
 		stopCh:                        stopCh,
+		plan:                          planRecorder,
 		logger:                        logger,
 	}
 
@@ -201,6 +215,21 @@ func NewFirewallController(
 	return fwc, nil
 }
 
+// SetExtraIngresses sets the function that returns the Ingresses that are
+// not in the cluster, such as those translated from Gateway API resources,
+// which the firewall rule also allows. The firewall rule is synced on the
+// events of the given informers of the objects they are translated from.
+func (fwc *FirewallController) SetExtraIngresses(list func() ([]*v1.Ingress, error), informers ...cache.SharedIndexInformer) {
+	fwc.extraIngresses = list
+	for _, informer := range informers {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { fwc.queue.Enqueue(queueKey) },
+			UpdateFunc: func(old, cur interface{}) { fwc.queue.Enqueue(queueKey) },
+			DeleteFunc: func(obj interface{}) { fwc.queue.Enqueue(queueKey) },
+		})
+	}
+}
+
 func lbSourceRanges(logger klog.Logger, overrideRanges string) ([]string, error) {
 	if overrideRanges != "" {
 		logger.Info("Overriding load balancer source ranges", "ranges", overrideRanges)
@@ -249,10 +278,21 @@ func (fwc *FirewallController) sync(key string) error {
 		return fmt.Errorf("waiting for stores to sync")
 	}
 	fwc.logger.V(3).Info("Syncing firewall")
+	if fwc.plan.Enabled() {
+		defer fwc.logPlan()
+	}
 
-	gceIngresses := operator.Ingresses(fwc.ctx.Ingresses().List()).Filter(func(ing *v1.Ingress) bool {
+	clusterIngresses := operator.Ingresses(fwc.ctx.Ingresses().List()).Filter(func(ing *v1.Ingress) bool {
 		return utils.IsGCEIngress(ing)
 	}).AsList()
+	gceIngresses := clusterIngresses
+	if fwc.extraIngresses != nil {
+		extraIngresses, err := fwc.extraIngresses()
+		if err != nil {
+			return err
+		}
+		gceIngresses = append(append([]*v1.Ingress(nil), clusterIngresses...), extraIngresses...)
+	}
 
 	// If there are no more ingresses, then delete the firewall rule.
 	if len(gceIngresses) == 0 {
@@ -313,7 +353,7 @@ func (fwc *FirewallController) sync(key string) error {
 			return err
 		}
 		// XPN: Raise an event on each ingress
-		for _, ing := range gceIngresses {
+		for _, ing := range clusterIngresses {
 			if annotations.FromIngress(ing).SuppressFirewallXPNError() {
 				continue
 			}
@@ -323,6 +363,14 @@ func (fwc *FirewallController) sync(key string) error {
 	return nil
 }
 
+// logPlan logs the firewall operations that the sync would have made in plan
+// mode.
+func (fwc *FirewallController) logPlan() {
+	for _, op := range fwc.plan.Flush() {
+		fwc.logger.Info("Planned firewall operation", "action", op.Action, "resource", op.Resource, "diff", op.Diff)
+	}
+}
+
 func (fwc *FirewallController) ilbFirewallSrcRange(gceIngresses []*v1.Ingress) (string, error) {
 	ilbEnabled := false
 	for _, ing := range gceIngresses {
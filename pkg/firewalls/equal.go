@@ -13,7 +13,8 @@ import (
 
 // Equal compares two Firewalls and returns true if they are the same in context of LoadBalancers.
 //
-// It will always compare Allow rules, DestinationRanges, SourceRanges and TargetTags.
+// It will always compare Allow and Deny rules, DestinationRanges, SourceRanges, TargetTags,
+// the source tags and service accounts, the target service accounts and the priority.
 // If skipDescription is set to false it will also compare contents of Description.
 //
 // Returns error when there is a port definition that isn't an int or range (int-int)
@@ -29,11 +30,43 @@ func Equal(a, b *compute.Firewall, skipDescription bool) (bool, error) {
 		return false, nil
 	case !utils.EqualStringSets(a.TargetTags, b.TargetTags):
 		return false, nil
+	case !utils.EqualStringSets(a.SourceTags, b.SourceTags):
+		return false, nil
+	case !utils.EqualStringSets(a.SourceServiceAccounts, b.SourceServiceAccounts):
+		return false, nil
+	case !utils.EqualStringSets(a.TargetServiceAccounts, b.TargetServiceAccounts):
+		return false, nil
+	case priority(a) != priority(b):
+		return false, nil
 	case !skipDescription && a.Description != b.Description:
 		return false, nil
-	default:
-		return equalAllowRules(a.Allowed, b.Allowed)
 	}
+	if eq, err := equalAllowRules(a.Allowed, b.Allowed); !eq || err != nil {
+		return eq, err
+	}
+	return equalAllowRules(deniedAsAllowed(a.Denied), deniedAsAllowed(b.Denied))
+}
+
+// defaultPriority is the priority GCE sets on firewall rules without one.
+const defaultPriority = 1000
+
+// priority returns the priority of the firewall, unset priorities being the
+// default one.
+func priority(fw *compute.Firewall) int64 {
+	if fw.Priority == 0 {
+		return defaultPriority
+	}
+	return fw.Priority
+}
+
+// deniedAsAllowed converts the denied protocols and ports to allowed ones, so
+// that they can be compared the same way.
+func deniedAsAllowed(denied []*compute.FirewallDenied) []*compute.FirewallAllowed {
+	var allowed []*compute.FirewallAllowed
+	for _, d := range denied {
+		allowed = append(allowed, &compute.FirewallAllowed{IPProtocol: d.IPProtocol, Ports: d.Ports})
+	}
+	return allowed
 }
 
 // Check if two sets of IP addresses or CIDRs are equal.
@@ -343,6 +343,58 @@ func TestEqual(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "default priority and unset priority",
+			a:    &compute.Firewall{Priority: 1000},
+			b:    &compute.Firewall{},
+			want: true,
+		},
+		{
+			desc: "different priority",
+			a:    &compute.Firewall{Priority: 900},
+			b:    &compute.Firewall{},
+			want: false,
+		},
+		{
+			desc: "different source tags",
+			a:    &compute.Firewall{SourceTags: []string{"frontend"}},
+			b:    &compute.Firewall{SourceTags: []string{"backend"}},
+			want: false,
+		},
+		{
+			desc: "different target service accounts",
+			a:    &compute.Firewall{TargetServiceAccounts: []string{"node@test-project.iam.gserviceaccount.com"}},
+			b:    &compute.Firewall{},
+			want: false,
+		},
+		{
+			desc: "same denied ports in different order",
+			a: &compute.Firewall{
+				Denied: []*compute.FirewallDenied{
+					{IPProtocol: "TCP", Ports: []string{"80", "8080-8081"}},
+				},
+			},
+			b: &compute.Firewall{
+				Denied: []*compute.FirewallDenied{
+					{IPProtocol: "TCP", Ports: []string{"8081", "8080", "80"}},
+				},
+			},
+			want: true,
+		},
+		{
+			desc: "allowed and denied ports",
+			a: &compute.Firewall{
+				Allowed: []*compute.FirewallAllowed{
+					{IPProtocol: "TCP", Ports: []string{"80"}},
+				},
+			},
+			b: &compute.Firewall{
+				Denied: []*compute.FirewallDenied{
+					{IPProtocol: "TCP", Ports: []string{"80"}},
+				},
+			},
+			want: false,
+		},
 		{
 			desc: "same ipv6 source ranges with shortcut",
 			a: &compute.Firewall{
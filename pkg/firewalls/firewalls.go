@@ -21,9 +21,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
@@ -34,6 +36,10 @@ const (
 	// DefaultFirewallName is the name to use for firewall rules created
 	// by an L7 controller when --firewall-rule is not used.
 	DefaultFirewallName = ""
+
+	// firewallsResource is the collection of the firewall rules, as used in
+	// resource paths.
+	firewallsResource = "firewalls"
 )
 
 // FirewallRules manages firewall rules.
@@ -44,6 +50,8 @@ type FirewallRules struct {
 	// TODO(rramkumar): Eliminate this variable. We should just pass in
 	// all the port ranges to open with each call to Sync()
 	nodePortRanges []string
+	// plan records the GCE mutations instead of making them in plan mode.
+	plan *plan.Recorder
 
 	logger klog.Logger
 }
@@ -51,7 +59,8 @@ type FirewallRules struct {
 // NewFirewallPool creates a new firewall rule manager.
 // cloud: the cloud object implementing Firewall.
 // namer: cluster namer.
-func NewFirewallPool(cloud Firewall, namer *namer_util.Namer, l7SrcRanges []string, nodePortRanges []string, logger klog.Logger) SingleFirewallPool {
+// planRecorder: records the mutations instead of making them if non-nil.
+func NewFirewallPool(cloud Firewall, namer *namer_util.Namer, l7SrcRanges []string, nodePortRanges []string, planRecorder *plan.Recorder, logger klog.Logger) SingleFirewallPool {
 	_, err := netset.ParseIPNets(l7SrcRanges...)
 	if err != nil {
 		klog.Fatalf("Could not parse L7 src ranges %v for firewall rule: %v", l7SrcRanges, err)
@@ -61,6 +70,7 @@ func NewFirewallPool(cloud Firewall, namer *namer_util.Namer, l7SrcRanges []stri
 		namer:          namer,
 		srcRanges:      l7SrcRanges,
 		nodePortRanges: nodePortRanges,
+		plan:           planRecorder,
 		logger:         logger.WithName("FirewallRules"),
 	}
 }
@@ -79,6 +89,11 @@ func (fr *FirewallRules) Sync(nodeNames, additionalPorts, additionalRanges []str
 	if err != nil {
 		if utils.IsNotFoundError(err) {
 			fr.logger.V(3).Info("Firewall not found, creating firewall rule", "firewallRuleName", name)
+			if fr.plan.Enabled() {
+				fr.logger.V(2).Info("Skipping firewall rule creation in plan mode", "firewallRuleName", name)
+				fr.plan.Record(plan.Create, firewallsResource, meta.GlobalKey(name), nil, expectedFirewall)
+				return nil
+			}
 			return fr.createFirewall(expectedFirewall)
 		}
 		fr.logger.Error(err, "Failed to get firewall", "firewallRuleName", name)
@@ -92,6 +107,11 @@ func (fr *FirewallRules) Sync(nodeNames, additionalPorts, additionalRanges []str
 	}
 
 	fr.logger.V(3).Info("Updating firewall rule", "firewallRuleName", name)
+	if fr.plan.Enabled() {
+		fr.logger.V(2).Info("Skipping firewall rule update in plan mode", "firewallRuleName", name)
+		fr.plan.Record(plan.Update, firewallsResource, meta.GlobalKey(name), existingFirewall, expectedFirewall)
+		return nil
+	}
 	return fr.updateFirewall(expectedFirewall)
 }
 
@@ -137,6 +157,13 @@ func (fr *FirewallRules) buildExpectedFW(nodeNames, additionalPorts, additionalR
 func (fr *FirewallRules) GC() error {
 	name := fr.namer.FirewallRule()
 	fr.logger.V(3).Info("Deleting firewall", "firewallRuleName", name)
+	if fr.plan.Enabled() {
+		if _, err := fr.cloud.GetFirewall(name); err == nil {
+			fr.logger.V(2).Info("Skipping firewall rule deletion in plan mode", "firewallRuleName", name)
+			fr.plan.Record(plan.Delete, firewallsResource, meta.GlobalKey(name), nil, nil)
+		}
+		return nil
+	}
 	return fr.deleteFirewall(name)
 }
 
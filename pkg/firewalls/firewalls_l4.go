@@ -38,6 +38,17 @@ type FirewallParams struct {
 	Allowed           []*compute.FirewallAllowed
 	L4Type            utils.L4LBType
 	Network           network.NetworkInfo
+	// Denied makes the rule deny the traffic instead of allowing Allowed.
+	Denied []*compute.FirewallDenied
+	// Priority of the rule, the GCE default priority when unset.
+	Priority int64
+	// SourceTags and SourceServiceAccounts are the sources of the rule in
+	// addition to SourceRanges.
+	SourceTags            []string
+	SourceServiceAccounts []string
+	// TargetServiceAccounts are the targets of the rule instead of the network
+	// tags of the nodes.
+	TargetServiceAccounts []string
 }
 
 func EnsureL4FirewallRule(cloud *gce.Cloud, nsName string, params *FirewallParams, sharedRule bool, fwLogger klog.Logger) (utils.ResourceSyncStatus, error) {
@@ -48,9 +59,12 @@ func EnsureL4FirewallRule(cloud *gce.Cloud, nsName string, params *FirewallParam
 		return utils.ResourceResync, err
 	}
 
-	nodeTags, err := cloud.GetNodeTags(params.NodeNames)
-	if err != nil {
-		return utils.ResourceResync, err
+	var nodeTags []string
+	if len(params.TargetServiceAccounts) == 0 {
+		nodeTags, err = cloud.GetNodeTags(params.NodeNames)
+		if err != nil {
+			return utils.ResourceResync, err
+		}
 	}
 	fwDesc, err := utils.MakeL4LBFirewallDescription(nsName, params.IP, meta.VersionGA, sharedRule)
 	if err != nil {
@@ -58,12 +72,17 @@ func EnsureL4FirewallRule(cloud *gce.Cloud, nsName string, params *FirewallParam
 	}
 
 	expectedFw := &compute.Firewall{
-		Name:         params.Name,
-		Description:  fwDesc,
-		Network:      params.Network.NetworkURL,
-		SourceRanges: params.SourceRanges,
-		TargetTags:   nodeTags,
-		Allowed:      params.Allowed,
+		Name:                  params.Name,
+		Description:           fwDesc,
+		Network:               params.Network.NetworkURL,
+		SourceRanges:          params.SourceRanges,
+		SourceTags:            params.SourceTags,
+		SourceServiceAccounts: params.SourceServiceAccounts,
+		TargetTags:            nodeTags,
+		TargetServiceAccounts: params.TargetServiceAccounts,
+		Allowed:               params.Allowed,
+		Denied:                params.Denied,
+		Priority:              params.Priority,
 	}
 	if flags.F.EnablePinhole {
 		expectedFw.DestinationRanges = params.DestinationRanges
@@ -85,6 +104,22 @@ func EnsureL4FirewallRule(cloud *gce.Cloud, nsName string, params *FirewallParam
 		return utils.ResourceResync, err
 	}
 
+	// Empty fields are omitted from the patch, send them to remove the existing sources.
+	if len(expectedFw.SourceRanges) == 0 && len(existingFw.SourceRanges) > 0 {
+		expectedFw.ForceSendFields = append(expectedFw.ForceSendFields, "SourceRanges")
+	}
+	if len(expectedFw.SourceTags) == 0 && len(existingFw.SourceTags) > 0 {
+		expectedFw.ForceSendFields = append(expectedFw.ForceSendFields, "SourceTags")
+	}
+	if len(expectedFw.SourceServiceAccounts) == 0 && len(existingFw.SourceServiceAccounts) > 0 {
+		expectedFw.ForceSendFields = append(expectedFw.ForceSendFields, "SourceServiceAccounts")
+	}
+	// An unset priority is omitted from the patch, send the default one to reset the existing priority.
+	if priority(expectedFw) != priority(existingFw) {
+		expectedFw.Priority = priority(expectedFw)
+		expectedFw.ForceSendFields = append(expectedFw.ForceSendFields, "Priority")
+	}
+
 	fwLogger.V(2).Info("EnsureL4FirewallRule: patching L4 firewall")
 	err = fa.PatchFirewall(expectedFw)
 	if utils.IsForbiddenError(err) && cloud.OnXPN() {
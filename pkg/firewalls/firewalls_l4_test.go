@@ -2,10 +2,12 @@ package firewalls
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"k8s.io/klog/v2"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -164,6 +166,75 @@ func TestEnsureL4FirewallRule(t *testing.T) {
 			},
 			expectUpdate: utils.ResourceUpdate,
 		},
+		{
+			desc:   "deny rule with source tags and priority",
+			nsName: utils.ServiceKeyFunc("test-ns", "test-name"),
+			params: &FirewallParams{
+				Name:       "test-firewall",
+				IP:         "10.0.0.1",
+				SourceTags: []string{"frontend"},
+				Denied: []*compute.FirewallDenied{
+					{
+						IPProtocol: "tcp",
+						Ports:      []string{"8080-8090"},
+					},
+				},
+				Priority:  900,
+				NodeNames: []string{"k8s-test-node"},
+				L4Type:    utils.ILB,
+				Network:   network.NetworkInfo{IsDefault: true},
+			},
+			shared: false,
+			want: &compute.Firewall{
+				Name:        "test-firewall",
+				Network:     "",
+				SourceTags:  []string{"frontend"},
+				TargetTags:  []string{"k8s-test"},
+				Description: firewallDescription,
+				Denied: []*compute.FirewallDenied{
+					{
+						IPProtocol: "tcp",
+						Ports:      []string{"8080-8090"},
+					},
+				},
+				Priority: 900,
+			},
+			expectUpdate: utils.ResourceUpdate,
+		},
+		{
+			desc:   "source and target service accounts",
+			nsName: utils.ServiceKeyFunc("test-ns", "test-name"),
+			params: &FirewallParams{
+				Name:                  "test-firewall",
+				IP:                    "10.0.0.1",
+				SourceServiceAccounts: []string{"client@test-project.iam.gserviceaccount.com"},
+				TargetServiceAccounts: []string{"node@test-project.iam.gserviceaccount.com"},
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "TCP",
+						Ports:      []string{"8080"},
+					},
+				},
+				NodeNames: []string{"k8s-test-node"},
+				L4Type:    utils.ILB,
+				Network:   network.NetworkInfo{IsDefault: true},
+			},
+			shared: false,
+			want: &compute.Firewall{
+				Name:                  "test-firewall",
+				Network:               "",
+				SourceServiceAccounts: []string{"client@test-project.iam.gserviceaccount.com"},
+				TargetServiceAccounts: []string{"node@test-project.iam.gserviceaccount.com"},
+				Description:           firewallDescription,
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "TCP",
+						Ports:      []string{"8080"},
+					},
+				},
+			},
+			expectUpdate: utils.ResourceUpdate,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -191,6 +262,144 @@ func TestEnsureL4FirewallRule(t *testing.T) {
 	}
 }
 
+func TestEnsureL4FirewallRulePatch(t *testing.T) {
+	existingRule := func() *compute.Firewall {
+		return &compute.Firewall{
+			Name:         "test-firewall",
+			SourceRanges: []string{"10.1.2.8/29"},
+			TargetTags:   []string{"k8s-test"},
+			Allowed: []*compute.FirewallAllowed{
+				{
+					IPProtocol: "tcp",
+					Ports:      []string{"8080"},
+				},
+			},
+			Priority: 900,
+		}
+	}
+	tests := []struct {
+		desc   string
+		params *FirewallParams
+		want   *compute.Firewall
+	}{
+		{
+			desc: "source ranges changed to source tags",
+			params: &FirewallParams{
+				Name:       "test-firewall",
+				SourceTags: []string{"frontend"},
+				Priority:   900,
+			},
+			want: &compute.Firewall{
+				Name:       "test-firewall",
+				SourceTags: []string{"frontend"},
+				TargetTags: []string{"k8s-test"},
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "tcp",
+						Ports:      []string{"8080"},
+					},
+				},
+				Priority: 900,
+			},
+		},
+		{
+			desc: "priority cleared",
+			params: &FirewallParams{
+				Name:         "test-firewall",
+				SourceRanges: []string{"10.1.2.8/29"},
+			},
+			want: &compute.Firewall{
+				Name:         "test-firewall",
+				SourceRanges: []string{"10.1.2.8/29"},
+				TargetTags:   []string{"k8s-test"},
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "tcp",
+						Ports:      []string{"8080"},
+					},
+				},
+				Priority: defaultPriority,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			(fakeGCE.Compute().(*cloud.MockGCE)).MockFirewalls.PatchHook = patchFirewallHook
+			createVMInstanceWithTag(t, fakeGCE, "k8s-test")
+			if err := fakeGCE.CreateFirewall(existingRule()); err != nil {
+				t.Fatalf("CreateFirewall() failed, err=%v", err)
+			}
+			tc.params.Allowed = existingRule().Allowed
+			tc.params.NodeNames = []string{"k8s-test-node"}
+			tc.params.L4Type = utils.ILB
+
+			if _, err := EnsureL4FirewallRule(fakeGCE, utils.ServiceKeyFunc("test-ns", "test-name"), tc.params, true, klog.TODO()); err != nil {
+				t.Fatalf("EnsureL4FirewallRule() failed, err=%v", err)
+			}
+			firewall, err := fakeGCE.GetFirewall(tc.params.Name)
+			if err != nil {
+				t.Fatalf("failed to get firewall err=%v", err)
+			}
+			if diff := cmp.Diff(tc.want, firewall, cmpopts.IgnoreFields(compute.Firewall{}, "SelfLink", "Description")); diff != "" {
+				t.Errorf("EnsureL4FirewallRule() diff -want +got\n%v\n", diff)
+			}
+
+			// The patched rule is up to date, so the next sync does not patch it again.
+			updateDone, err := EnsureL4FirewallRule(fakeGCE, utils.ServiceKeyFunc("test-ns", "test-name"), tc.params, true, klog.TODO())
+			if err != nil {
+				t.Fatalf("EnsureL4FirewallRule() failed, err=%v", err)
+			}
+			if updateDone != utils.ResourceResync {
+				t.Errorf("EnsureL4FirewallRule() returned %v for an up to date rule, want %v", updateDone, utils.ResourceResync)
+			}
+		})
+	}
+}
+
+// patchFirewallHook patches the firewall like GCE does: fields omitted from the
+// JSON request, such as empty ones which are not force sent, are not changed.
+func patchFirewallHook(ctx context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls, options ...cloud.Option) error {
+	existing, err := m.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	// Force sent empty lists must replace the existing ones instead of being
+	// merged with them.
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return err
+	}
+	for field := range fields {
+		switch field {
+		case "sourceRanges":
+			existing.SourceRanges = nil
+		case "sourceTags":
+			existing.SourceTags = nil
+		case "sourceServiceAccounts":
+			existing.SourceServiceAccounts = nil
+		}
+	}
+	if err := json.Unmarshal(patch, existing); err != nil {
+		return err
+	}
+	// GCE omits the empty fields of the patched firewall.
+	patched, err := json.Marshal(existing)
+	if err != nil {
+		return err
+	}
+	fw := &compute.Firewall{}
+	if err := json.Unmarshal(patched, fw); err != nil {
+		return err
+	}
+	m.Objects[*key] = &cloud.MockFirewallsObj{Obj: fw}
+	return nil
+}
+
 func createVMInstanceWithTag(t *testing.T, fakeGCE *gce.Cloud, tag string) {
 	err := fakeGCE.Compute().Instances().Insert(context.Background(),
 		meta.ZonalKey("k8s-test-node", "us-central1-b"),
@@ -46,7 +46,7 @@ func portRanges() []string {
 
 func TestFirewallPoolSync(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(false, false)
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 	if err := fp.Sync(nodes, nil, nil, true); err != nil {
 		t.Fatal(err)
@@ -65,7 +65,7 @@ func TestFirewallPoolSync(t *testing.T) {
 func TestFirewallPoolSyncNodes(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(false, false)
 	fwClient := firewallclient.NewSimpleClientset()
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	fcrp := NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, portRanges(), true, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 
@@ -98,7 +98,7 @@ func TestFirewallPoolSyncNodes(t *testing.T) {
 func TestFirewallPoolSyncSrcRanges(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(false, false)
 	fwClient := firewallclient.NewSimpleClientset()
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	fcrp := NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, portRanges(), true, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 
@@ -135,7 +135,7 @@ func TestFirewallPoolSyncPorts(t *testing.T) {
 	emptyPortRanges := make([]string, 0)
 
 	// Verify empty ports' list
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, emptyPortRanges, klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, emptyPortRanges, nil, klog.TODO())
 	fcrp := NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, emptyPortRanges, true, klog.TODO())
 
 	if err := fp.Sync(nodes, nil, nil, true); err != nil {
@@ -149,7 +149,7 @@ func TestFirewallPoolSyncPorts(t *testing.T) {
 	verifyFirewallCR(fwClient, ruleName, srcRanges, emptyPortRanges, true, t)
 
 	// Verify a preset ports' list
-	fp = NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp = NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	fcrp = NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, portRanges(), true, klog.TODO())
 
 	if err := fp.Sync(nodes, nil, nil, true); err != nil {
@@ -205,7 +205,7 @@ func TestFirewallPoolSyncPorts(t *testing.T) {
 
 func TestFirewallPoolSyncGetServerError(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(false, false)
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 	// Sync to create the firewall.
 	if err := fp.Sync(nodes, nil, nil, true); err != nil {
@@ -252,7 +252,7 @@ func TestFirewallPoolSyncRanges(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			fwp := NewFakeFirewallsProvider(false, false)
 			fwClient := firewallclient.NewSimpleClientset()
-			fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+			fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 			fcrp := NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, portRanges(), true, klog.TODO())
 			nodes := []string{"node-a", "node-b", "node-c"}
 
@@ -274,7 +274,7 @@ func TestFirewallPoolSyncRanges(t *testing.T) {
 func TestFirewallPoolGC(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(false, false)
 	fwClient := firewallclient.NewSimpleClientset()
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	fcrp := NewFirewallCRPool(fwClient, fwp, defaultNamer, srcRanges, portRanges(), true, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 
@@ -310,7 +310,7 @@ func TestFirewallPoolGC(t *testing.T) {
 func TestSyncOnXPNWithPermission(t *testing.T) {
 	// Fake XPN cluster with permission
 	fwp := NewFakeFirewallsProvider(true, false)
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 
 	if err := fp.Sync(nodes, nil, nil, true); err != nil {
@@ -324,7 +324,7 @@ func TestSyncOnXPNWithPermission(t *testing.T) {
 // Specific errors should be returned.
 func TestSyncXPNReadOnly(t *testing.T) {
 	fwp := NewFakeFirewallsProvider(true, true)
-	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), klog.TODO())
+	fp := NewFirewallPool(fwp, defaultNamer, srcRanges, portRanges(), nil, klog.TODO())
 	nodes := []string{"node-a", "node-b", "node-c"}
 
 	err := fp.Sync(nodes, nil, nil, true)
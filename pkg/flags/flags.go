@@ -94,6 +94,7 @@ var F = struct {
 	KubeClientQPS             float32
 	KubeClientBurst           int
 	ReadOnlyMode              bool
+	IngressPlanMode           bool
 
 	// Feature flags should be named Enablexxx.
 	EnableNonGCPMode                          bool
@@ -132,6 +133,10 @@ var F = struct {
 	EnableIGMultiSubnetCluster                bool
 	EnableMultiSubnetCluster                  bool
 	EnableMultiSubnetClusterPhase1            bool
+	EnableIngressDriftDetection               bool
+	EnableIngressSyncStatus                   bool
+	EnableGatewayController                   bool
+	EnableBackendConfigTargetRef              bool
 	NodeTopologyCRName                        string
 	EnableWeightedL4ILB                       bool
 	EnableWeightedL4NetLB                     bool
@@ -145,6 +150,7 @@ var F = struct {
 	EnableIPV6OnlyNEG                         bool
 	MultiProjectOwnerLabelKey                 string
 	OverrideHealthCheckSourceCIDRs            string
+	ManagedBackendBuckets                     string
 	ManageL4LBLogging                         bool
 	EnableNEGsForIngress                      bool
 	L4ILBLegacyHeadStartTime                  time.Duration
@@ -357,8 +363,14 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.BoolVar(&F.EnableIPV6OnlyNEG, "enable-ipv6-only-neg", false, "Enable support for IPV6 Only NEG's.")
 	flag.StringVar(&F.MultiProjectOwnerLabelKey, "multi-project-owner-label-key", "multiproject.gke.io/owner", "The label key for multi-project owner, which is used to identify the owner of objects in multi-project mode.")
 	flag.StringVar(&F.OverrideHealthCheckSourceCIDRs, "override-health-check-src-cidrs", "", "Overrides the default source IP ranges used when configuring firewall rules to allow health check probes for L7 load balancers. Provide the ranges as a comma-separated list of CIDRs. Example: --override-health-check-src-cidrs=130.211.0.0/22,35.191.0.0/16")
+	flag.StringVar(&F.ManagedBackendBuckets, "managed-backend-buckets", "", "Comma-separated list of namespace/name of the backend buckets whose CDN settings can be set by the BackendConfigs of the namespace. A backend bucket can only be managed by one namespace. Example: --managed-backend-buckets=web/assets,shop/static")
 	flag.BoolVar(&F.ManageL4LBLogging, "manage-l4lb-logging", false, "Manage L4 ILB/NetLB logging.")
 	flag.BoolVar(&F.ReadOnlyMode, "read-only-controllers", false, "When enabled, this flag runs the IG, NEG, L4 ILB, and L4 NetLB controllers in a read-only mode. This prevents them from executing any mutating API calls (e.g., create, update, delete), allowing you to safely observe controller behavior without modifying resources. The Ingress controller is exempt from this mode.")
+	flag.BoolVar(&F.IngressPlanMode, "ingress-plan-mode", false, "When enabled, the Ingress and firewall controllers compute the GCE resources of the Ingresses and diff them against the cloud, but do not execute any mutating API calls. The operations that would have been made are published as events, a status annotation on the Ingress and structured logs. The Gateway controller does not support plan mode, and cannot be enabled with it.")
+	flag.BoolVar(&F.EnableIngressDriftDetection, "enable-ingress-drift-detection", false, "Detect the changes made to the URL maps, backend services and health checks of Ingresses outside of the controller, and report them in events and the ingress_resource_drift_count metric. Changes are reverted unless the Ingress has the networking.gke.io/drift-policy: Alert annotation. The state applied by the controller is kept in memory, so the changes made before the first sync of a resource after a restart are reverted.")
+	flag.BoolVar(&F.EnableIngressSyncStatus, "enable-ingress-sync-status", false, "Report the result of the last sync of each Ingress, with its error class, GCE resources and backend health, in an IngressSyncStatus resource owned by the Ingress.")
+	flag.BoolVar(&F.EnableGatewayController, "enable-gateway-controller", false, "Program GCE L7 load balancers for the Gateways of the gke-l7-gxlb, gke-l7-rilb and gke-l7-regional-external-managed GatewayClasses, and the HTTPRoutes attached to them. Requires the Gateway API CRDs and the Ingress controller.")
+	flag.BoolVar(&F.EnableBackendConfigTargetRef, "enable-backendconfig-target-ref", false, "Attach the BackendConfigs with a Service targetRef to the ports of the Service that do not reference a BackendConfig with the cloud.google.com/backend-config annotation. This applies to the Services of both Ingresses and Gateways.")
 	flag.BoolVar(&F.EnableNEGsForIngress, "enable-negs-for-ingress", true, "Allow the NEG controller to create NEGs for Ingress services.")
 	flag.DurationVar(&F.L4ILBLegacyHeadStartTime, "prevent-legacy-race-l4-ilb", 0*time.Second, "Delay before processing new L4 ILB services without existing finalizers. This gives the legacy controller a head start to claim the service, preventing a race condition upon service creation.")
 	flag.BoolVar(&F.EnableIPv6NodeNEGEndpoints, "enable-ipv6-node-neg-endpoints", false, "Enable populating IPv6 addresses for Node IPs in GCE_VM_IP NEGs.")
@@ -377,6 +389,10 @@ func Validate() {
 	if err := validation.ValidateHealthCheckSourceCIDRs(F.OverrideHealthCheckSourceCIDRs); err != nil {
 		klog.Fatalf("Invalid --override-health-check-src-cidrs flag: %v", err)
 	}
+
+	if err := validation.ValidateManagedBackendBuckets(F.ManagedBackendBuckets); err != nil {
+		klog.Fatalf("Invalid --managed-backend-buckets flag: %v", err)
+	}
 }
 
 type RateLimitSpecs struct {
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"k8s.io/apimachinery/pkg/util/validation"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+// maxErrorPagePathLength is the maximum length of the path of a custom
+// error page allowed by GCE.
+const maxErrorPagePathLength = 1024
+
+// Validate returns an error if the given FrontendConfig has settings that
+// a load balancer with the given LoadBalancingScheme cannot be configured
+// with.
+func Validate(feConfig *frontendconfigv1beta1.FrontendConfig, lbScheme string) error {
+	if feConfig == nil {
+		return nil
+	}
+
+	if err := ValidateCustomErrorResponses(feConfig.Spec.CustomErrorResponses, lbScheme); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateCustomErrorResponses validates the custom error responses, which
+// are translated into the default custom error response policy of the URL
+// map of a load balancer with the given LoadBalancingScheme. GCE does not
+// support custom error responses on classic Application Load Balancers,
+// which use the EXTERNAL scheme.
+func ValidateCustomErrorResponses(config *frontendconfigv1beta1.CustomErrorResponsesConfig, lbScheme string) error {
+	if config == nil {
+		return nil
+	}
+	if lbScheme == string(cloud.SchemeExternal) {
+		return fmt.Errorf("customErrorResponses are not supported by load balancers with the %s scheme", lbScheme)
+	}
+	if errs := validation.IsDNS1035Label(config.ErrorBackendBucket); len(errs) > 0 {
+		return fmt.Errorf("invalid customErrorResponses.errorBackendBucket %q: %s", config.ErrorBackendBucket, strings.Join(errs, ", "))
+	}
+	if len(config.Rules) == 0 {
+		return fmt.Errorf("customErrorResponses.rules must not be empty")
+	}
+
+	matched := map[string]bool{}
+	for _, rule := range config.Rules {
+		if len(rule.ResponseCodes) == 0 {
+			return fmt.Errorf("responseCodes of the custom error response rule for path %q must not be empty", rule.Path)
+		}
+		for _, code := range rule.ResponseCodes {
+			if !isResponseCodeMatch(code) {
+				return fmt.Errorf("unsupported responseCode: %q, should be 4xx, 5xx or a status code between 400 and 599", code)
+			}
+			if matched[code] {
+				return fmt.Errorf("responseCode %q is matched by more than one custom error response rule", code)
+			}
+			matched[code] = true
+		}
+		if !strings.HasPrefix(rule.Path, "/") || len(rule.Path) > maxErrorPagePathLength {
+			return fmt.Errorf("unsupported path: %q, should start with / and be at most %d characters long", rule.Path, maxErrorPagePathLength)
+		}
+		if rule.OverrideResponseCode != 0 && (rule.OverrideResponseCode < 200 || rule.OverrideResponseCode > 599) {
+			return fmt.Errorf("unsupported overrideResponseCode: %d, should be between 200 and 599", rule.OverrideResponseCode)
+		}
+	}
+	return nil
+}
+
+// isResponseCodeMatch returns true if code is one of the 4xx and 5xx status
+// classes, or a status code between 400 and 599.
+func isResponseCodeMatch(code string) bool {
+	if code == "4xx" || code == "5xx" {
+		return true
+	}
+	n, err := strconv.Atoi(code)
+	return err == nil && n >= 400 && n <= 599 && strconv.Itoa(n) == code
+}
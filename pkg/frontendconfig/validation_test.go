@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"strings"
+	"testing"
+
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+func TestValidateCustomErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	validRules := []frontendconfigv1beta1.CustomErrorResponseRule{
+		{ResponseCodes: []string{"404"}, Path: "/404.html"},
+		{ResponseCodes: []string{"4xx", "5xx"}, Path: "/error.html", OverrideResponseCode: 200},
+	}
+
+	testCases := []struct {
+		desc   string
+		config *frontendconfigv1beta1.CustomErrorResponsesConfig
+		// lbScheme defaults to INTERNAL_MANAGED
+		lbScheme string
+		wantErr  string
+	}{
+		{
+			desc: "no custom error responses",
+		},
+		{
+			desc:   "valid custom error responses",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: validRules},
+		},
+		{
+			desc:     "external managed load balancer",
+			config:   &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: validRules},
+			lbScheme: "EXTERNAL_MANAGED",
+		},
+		{
+			desc:     "classic load balancer",
+			config:   &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: validRules},
+			lbScheme: "EXTERNAL",
+			wantErr:  "not supported by load balancers with the EXTERNAL scheme",
+		},
+		{
+			desc:    "missing backend bucket",
+			config:  &frontendconfigv1beta1.CustomErrorResponsesConfig{Rules: validRules},
+			wantErr: "invalid customErrorResponses.errorBackendBucket",
+		},
+		{
+			desc:    "invalid backend bucket name",
+			config:  &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "Error_Pages", Rules: validRules},
+			wantErr: "invalid customErrorResponses.errorBackendBucket",
+		},
+		{
+			desc:    "no rules",
+			config:  &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages"},
+			wantErr: "rules must not be empty",
+		},
+		{
+			desc: "rule without response codes",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{Path: "/error.html"},
+			}},
+			wantErr: "responseCodes of the custom error response rule",
+		},
+		{
+			desc: "status code out of range",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"302"}, Path: "/error.html"},
+			}},
+			wantErr: `unsupported responseCode: "302"`,
+		},
+		{
+			desc: "unsupported status class",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"3xx"}, Path: "/error.html"},
+			}},
+			wantErr: `unsupported responseCode: "3xx"`,
+		},
+		{
+			desc: "non canonical status code",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"+404"}, Path: "/error.html"},
+			}},
+			wantErr: `unsupported responseCode: "+404"`,
+		},
+		{
+			desc: "status code matched by two rules",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"5xx"}, Path: "/5xx.html"},
+				{ResponseCodes: []string{"5xx"}, Path: "/error.html"},
+			}},
+			wantErr: `responseCode "5xx" is matched by more than one`,
+		},
+		{
+			desc: "relative path",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"404"}, Path: "404.html"},
+			}},
+			wantErr: `unsupported path: "404.html"`,
+		},
+		{
+			desc: "path too long",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"404"}, Path: "/" + strings.Repeat("a", maxErrorPagePathLength)},
+			}},
+			wantErr: "unsupported path",
+		},
+		{
+			desc: "override response code out of range",
+			config: &frontendconfigv1beta1.CustomErrorResponsesConfig{ErrorBackendBucket: "error-pages", Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+				{ResponseCodes: []string{"404"}, Path: "/404.html", OverrideResponseCode: 600},
+			}},
+			wantErr: "unsupported overrideResponseCode: 600",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			feConfig := &frontendconfigv1beta1.FrontendConfig{
+				Spec: frontendconfigv1beta1.FrontendConfigSpec{CustomErrorResponses: tc.config},
+			}
+			lbScheme := tc.lbScheme
+			if lbScheme == "" {
+				lbScheme = "INTERNAL_MANAGED"
+			}
+			err := Validate(feConfig, lbScheme)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/ingress-gce/pkg/fuzz"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+// MutualTLS is a feature in FrontendConfig that supports validating client
+// certificates with a server TLS policy.
+var MutualTLS = &MutualTLSFeature{}
+
+// MutualTLSFeature implements the associated feature.
+type MutualTLSFeature struct{}
+
+// NewValidator implements fuzz.Feature.
+func (*MutualTLSFeature) NewValidator() fuzz.FeatureValidator {
+	return &mutualTLSValidator{}
+}
+
+// Name implements fuzz.Feature.
+func (*MutualTLSFeature) Name() string {
+	return "MutualTLS"
+}
+
+// mutualTLSValidator is a validator for MutualTLSFeature.
+type mutualTLSValidator struct {
+	fuzz.NullValidator
+
+	env    fuzz.ValidatorEnv
+	ing    *v1.Ingress
+	region string
+	// policy is the name of the expected server TLS policy, nil if the
+	// FrontendConfig does not set it.
+	policy *string
+}
+
+// Name implements fuzz.FeatureValidator.
+func (*mutualTLSValidator) Name() string {
+	return "MutualTLS"
+}
+
+// ConfigureAttributes implements fuzz.FeatureValidator.
+func (v *mutualTLSValidator) ConfigureAttributes(env fuzz.ValidatorEnv, ing *v1.Ingress, a *fuzz.IngressValidatorAttributes) error {
+	fc, err := fuzz.FrontendConfigForIngress(ing, env)
+	if err != nil {
+		return err
+	}
+	// Capture the env for use later in CheckResponse.
+	v.env = env
+	v.ing = ing
+	v.region = a.Region
+	v.policy = nil
+	if fc != nil && fc.Spec.ServerTlsPolicy != nil {
+		v.policy = fc.Spec.ServerTlsPolicy
+		a.CheckHTTPS = true
+	}
+	return nil
+}
+
+// CheckResponse implements fuzz.FeatureValidator. The validator does not
+// present a client certificate, so responses are only received when the
+// mTLS policy of the server TLS policy allows missing client certificates.
+// It checks that the target https proxy references the server TLS policy.
+func (v *mutualTLSValidator) CheckResponse(host, path string, resp *http.Response, body []byte) (fuzz.CheckResponseAction, error) {
+	if v.policy == nil || resp.Request.URL.Scheme != "https" {
+		return fuzz.CheckResponseContinue, nil
+	}
+
+	proxyName := v.env.FrontendNamerFactory().Namer(v.ing).TargetProxy(namer.HTTPSProtocol)
+	var got string
+	if v.region != "" {
+		proxy, err := v.env.Cloud().RegionTargetHttpsProxies().Get(context.Background(), meta.RegionalKey(proxyName, v.region))
+		if err != nil {
+			return fuzz.CheckResponseContinue, fmt.Errorf("error getting target https proxy %q: %v", proxyName, err)
+		}
+		got = proxy.ServerTlsPolicy
+	} else {
+		proxy, err := v.env.Cloud().TargetHttpsProxies().Get(context.Background(), meta.GlobalKey(proxyName))
+		if err != nil {
+			return fuzz.CheckResponseContinue, fmt.Errorf("error getting target https proxy %q: %v", proxyName, err)
+		}
+		got = proxy.ServerTlsPolicy
+	}
+
+	if *v.policy == "" {
+		if got != "" {
+			return fuzz.CheckResponseContinue, fmt.Errorf("target https proxy %q has server TLS policy %q, want none", proxyName, got)
+		}
+		return fuzz.CheckResponseContinue, nil
+	}
+	location := "global"
+	if v.region != "" {
+		location = v.region
+	}
+	want := fmt.Sprintf("/locations/%s/serverTlsPolicies/%s", location, *v.policy)
+	if !strings.HasSuffix(got, want) {
+		return fuzz.CheckResponseContinue, fmt.Errorf("target https proxy %q has server TLS policy %q, want policy %q in %s", proxyName, got, *v.policy, location)
+	}
+	return fuzz.CheckResponseContinue, nil
+}
@@ -236,6 +236,19 @@ func verifyNegRegionBackend(env fuzz.ValidatorEnv, bsName, backendKeyword, urlMa
 				return nil
 			}
 		}
+		for _, rule := range pathMatcher.RouteRules {
+			if rule.Service != "" && strings.Contains(rule.Service, beService.Name) {
+				return nil
+			}
+			if rule.RouteAction == nil {
+				continue
+			}
+			for _, wbs := range rule.RouteAction.WeightedBackendServices {
+				if strings.Contains(wbs.BackendService, beService.Name) {
+					return nil
+				}
+			}
+		}
 	}
 
 	return fmt.Errorf("backend service %q is not used by UrlMap %q", bsName, urlMapName)
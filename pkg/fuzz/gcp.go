@@ -577,6 +577,25 @@ func GCLBForVIP(ctx context.Context, c cloud.Cloud, params *GCLBForVIPParams) (*
 			}
 			bsKeys = append(bsKeys, resID.Key)
 		}
+
+		for _, rr := range pm.RouteRules {
+			var services []string
+			if rr.Service != "" {
+				services = append(services, rr.Service)
+			}
+			if rr.RouteAction != nil {
+				for _, wbs := range rr.RouteAction.WeightedBackendServices {
+					services = append(services, wbs.BackendService)
+				}
+			}
+			for _, service := range services {
+				resID, err := cloud.ParseResourceURL(service)
+				if err != nil {
+					return nil, err
+				}
+				bsKeys = append(bsKeys, resID.Key)
+			}
+		}
 	}
 
 	for _, bsKey := range bsKeys {
@@ -846,6 +865,25 @@ func RegionalGCLBForVIP(ctx context.Context, c cloud.Cloud, gclb *GCLB, params *
 			}
 			bsKeys = append(bsKeys, resID.Key)
 		}
+
+		for _, rr := range pm.RouteRules {
+			var services []string
+			if rr.Service != "" {
+				services = append(services, rr.Service)
+			}
+			if rr.RouteAction != nil {
+				for _, wbs := range rr.RouteAction.WeightedBackendServices {
+					services = append(services, wbs.BackendService)
+				}
+			}
+			for _, service := range services {
+				resID, err := cloud.ParseResourceURL(service)
+				if err != nil {
+					return err
+				}
+				bsKeys = append(bsKeys, resID.Key)
+			}
+		}
 	}
 
 	for _, bsKey := range bsKeys {
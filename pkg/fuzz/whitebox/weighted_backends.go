@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package whitebox
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfig "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/fuzz"
+)
+
+// Implements a whitebox test to check that the UrlMap splits traffic with the
+// weights of the weighted backends annotation.
+type weightedBackendsTest struct {
+}
+
+// Name implements WhiteboxTest.
+func (t *weightedBackendsTest) Name() string {
+	return "WeightedBackendsTest"
+}
+
+// Test implements WhiteboxTest.
+func (t *weightedBackendsTest) Test(ing *v1.Ingress, fc *frontendconfig.FrontendConfig, gclb *fuzz.GCLB) error {
+	weightedBackends, err := annotations.FromIngress(ing).WeightedBackends()
+	if err != nil {
+		return err
+	}
+
+	var expectedWeights []int64
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			for _, wb := range weightedBackends[path.Backend.Service.Name] {
+				expectedWeights = append(expectedWeights, wb.Weight)
+			}
+		}
+	}
+
+	var foundWeights []int64
+	for _, um := range gclb.URLMap {
+		switch {
+		case um.GA != nil:
+			for _, pm := range um.GA.PathMatchers {
+				for _, rr := range pm.RouteRules {
+					if rr.RouteAction == nil {
+						continue
+					}
+					for _, wbs := range rr.RouteAction.WeightedBackendServices {
+						foundWeights = append(foundWeights, wbs.Weight)
+					}
+				}
+			}
+		case um.Beta != nil:
+			for _, pm := range um.Beta.PathMatchers {
+				for _, rr := range pm.RouteRules {
+					if rr.RouteAction == nil {
+						continue
+					}
+					for _, wbs := range rr.RouteAction.WeightedBackendServices {
+						foundWeights = append(foundWeights, wbs.Weight)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(expectedWeights, func(i, j int) bool { return expectedWeights[i] < expectedWeights[j] })
+	sort.Slice(foundWeights, func(i, j int) bool { return foundWeights[i] < foundWeights[j] })
+	if len(expectedWeights) != len(foundWeights) || (len(expectedWeights) > 0 && !reflect.DeepEqual(expectedWeights, foundWeights)) {
+		return fmt.Errorf("expected weighted backends with weights %v, got %v", expectedWeights, foundWeights)
+	}
+
+	return nil
+}
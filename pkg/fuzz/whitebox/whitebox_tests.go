@@ -24,4 +24,5 @@ var AllTests = []fuzz.WhiteboxTest{
 	&numForwardingRulesTest{},
 	&numTargetProxiesTest{},
 	&redirectURLMapTest{},
+	&weightedBackendsTest{},
 }
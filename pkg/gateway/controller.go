@@ -0,0 +1,597 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	context2 "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/context"
+	legacytranslator "k8s.io/ingress-gce/pkg/controller/translator"
+	"k8s.io/ingress-gce/pkg/drift"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	activecontrollermetrics "k8s.io/ingress-gce/pkg/metrics/activecontroller"
+	"k8s.io/ingress-gce/pkg/translator"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ingressClassStatusKey records on a Gateway the Ingress class of its load
+// balancer, so that the load balancer is deleted when the class changes.
+const ingressClassStatusKey = annotations.StatusPrefix + "/ingress-class"
+
+// IngressBackends syncs and garbage collects the backends of the Gateways,
+// which are shared with the Ingresses. It is implemented by the Ingress
+// controller, so that the backends are synced and garbage collected under
+// its locks.
+type IngressBackends interface {
+	// SyncExtraBackends syncs the backends of the given Service ports and
+	// links them to their instance groups or NEGs.
+	SyncExtraBackends(svcPorts []utils.ServicePort, logger klog.Logger) error
+	// GCExtraBackends deletes the backends that are used neither by the
+	// Ingresses nor by the Gateways.
+	GCExtraBackends(logger klog.Logger) error
+	// BackendStates returns the health of the backends of a load balancer.
+	BackendStates(l7 *loadbalancers.L7, logger klog.Logger) (map[string]string, error)
+	// DriftDetector returns the drift detector of the Ingress controller,
+	// which also tracks the URL maps of the Gateways. It is nil if drift
+	// detection is disabled.
+	DriftDetector() *drift.Detector
+}
+
+// Controller programs GCE L7 load balancers for the Gateways of the
+// GatewayClasses of the controller. The HTTPRoutes attached to a Gateway are
+// translated into Ingresses, which are merged into the URL map of the load
+// balancer of the Gateway. The backends are synced by the Ingress
+// controller, and the frontend with an L7 load balancer pool of the
+// controller.
+type Controller struct {
+	ctx       *context.ControllerContext
+	client    dynamic.Interface
+	informers *Informers
+
+	classQueue   utils.TaskQueue
+	gatewayQueue utils.TaskQueue
+
+	translator *legacytranslator.Translator
+	l7Pool     loadbalancers.LoadBalancerPool
+	backends   IngressBackends
+
+	// gatewayBackends are the backends of each Gateway at its last sync.
+	// The backend GC only runs when a Gateway stops using some backends,
+	// as the Ingress controller runs it on its own syncs.
+	gatewayBackends     map[string]sets.Set[string]
+	gatewayBackendsLock sync.Mutex
+
+	stopCh <-chan struct{}
+	logger klog.Logger
+}
+
+// NewController returns a Gateway controller, which watches the Gateway API
+// resources with the GatewayClient of ctx and syncs the backends of the
+// Gateways with ingBackends. The Gateway controller does not support plan
+// mode, so an error is returned if the Ingress controller runs in plan mode.
+func NewController(ctx *context.ControllerContext, ingBackends IngressBackends, stopCh <-chan struct{}, logger klog.Logger) (*Controller, error) {
+	if ctx.IngressPlanMode {
+		return nil, fmt.Errorf("the Gateway controller does not support plan mode")
+	}
+	logger = logger.WithName("GatewayController")
+
+	c := &Controller{
+		ctx:             ctx,
+		client:          ctx.GatewayClient,
+		informers:       NewInformers(ctx.GatewayClient, ctx.ResyncPeriod),
+		translator:      ctx.Translator,
+		l7Pool:          loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, &gatewayRecorderProducer{ctx}, namer.NewGatewayFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID), nil, ingBackends.DriftDetector(), logger),
+		backends:        ingBackends,
+		gatewayBackends: map[string]sets.Set[string]{},
+		stopCh:          stopCh,
+		logger:          logger,
+	}
+	c.classQueue = utils.NewPeriodicTaskQueue("gateway", "gatewayclasses", c.syncClass, logger)
+	c.gatewayQueue = utils.NewPeriodicTaskQueue("gateway", "gateways", c.syncGateway, logger)
+
+	c.informers.GatewayClass.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueClass,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueClass(cur) },
+		DeleteFunc: c.enqueueClass,
+	})
+	c.informers.Gateway.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.gatewayQueue.Enqueue(obj) },
+		UpdateFunc: func(old, cur interface{}) { c.gatewayQueue.Enqueue(cur) },
+		DeleteFunc: func(obj interface{}) { c.gatewayQueue.Enqueue(obj) },
+	})
+	c.informers.HTTPRoute.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueRouteParents,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueRouteParents(old)
+			c.enqueueRouteParents(cur)
+		},
+		DeleteFunc: c.enqueueRouteParents,
+	})
+
+	// Changes of the Services and BackendConfigs of the routes are applied
+	// to all Gateways, which are few.
+	ctx.ServiceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueAllGateways() },
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				c.enqueueAllGateways()
+			}
+		},
+	})
+	if ctx.BackendConfigInformer != nil {
+		ctx.BackendConfigInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { c.enqueueAllGateways() },
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					c.enqueueAllGateways()
+				}
+			},
+			DeleteFunc: func(obj interface{}) { c.enqueueAllGateways() },
+		})
+	}
+	return c, nil
+}
+
+// Informers returns the informers of the Gateway API resources of the
+// controller.
+func (c *Controller) Informers() *Informers {
+	return c.informers
+}
+
+// Run runs the controller until its stop channel is closed.
+func (c *Controller) Run() {
+	c.informers.Run(c.stopCh)
+	wait.PollUntil(5*time.Second, func() (bool, error) {
+		c.logger.V(2).Info("Waiting for initial sync")
+		return c.hasSynced(), nil
+	}, c.stopCh)
+
+	c.logger.Info("Starting Gateway controller")
+	activecontrollermetrics.RecordRunningController(activecontrollermetrics.GatewayControllerLabel)
+	defer func() {
+		c.logger.Info("Shutting down Gateway controller")
+		c.classQueue.Shutdown()
+		c.gatewayQueue.Shutdown()
+		activecontrollermetrics.RecordStoppedController(activecontrollermetrics.GatewayControllerLabel)
+	}()
+	go c.classQueue.Run()
+	go c.gatewayQueue.Run()
+	<-c.stopCh
+}
+
+func (c *Controller) hasSynced() bool {
+	return c.ctx.HasSynced() && c.informers.HasSynced()
+}
+
+func (c *Controller) enqueueClass(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		c.logger.Error(err, "Failed to get the key of a GatewayClass")
+		return
+	}
+	c.classQueue.Enqueue(obj)
+	for _, gw := range c.informers.gateways() {
+		if string(gw.Spec.GatewayClassName) == key {
+			c.gatewayQueue.Enqueue(cache.ExplicitKey(gatewayKey(gw)))
+		}
+	}
+}
+
+// enqueueRouteParents enqueues the Gateways referenced by the parentRefs of
+// an HTTPRoute.
+func (c *Controller) enqueueRouteParents(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	route := &gatewayv1.HTTPRoute{}
+	if err := fromUnstructured(obj, route); err != nil {
+		c.logger.Error(err, "Failed to convert HTTPRoute")
+		return
+	}
+	for _, ref := range route.Spec.ParentRefs {
+		namespace := route.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		c.gatewayQueue.Enqueue(cache.ExplicitKey(namespace + "/" + string(ref.Name)))
+	}
+}
+
+func (c *Controller) enqueueAllGateways() {
+	for _, gw := range c.informers.gateways() {
+		c.gatewayQueue.Enqueue(cache.ExplicitKey(gatewayKey(gw)))
+	}
+}
+
+func gatewayKey(gw *gatewayv1.Gateway) string {
+	return gw.Namespace + "/" + gw.Name
+}
+
+// syncClass reports in the status of a GatewayClass of the controller
+// whether the class is supported.
+func (c *Controller) syncClass(key string) error {
+	obj, exists, err := c.informers.GatewayClass.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	gc := &gatewayv1.GatewayClass{}
+	if err := fromUnstructured(obj, gc); err != nil {
+		return err
+	}
+	if gc.Spec.ControllerName != ControllerName {
+		return nil
+	}
+	status := gatewayClassStatus(gc)
+	if equality.Semantic.DeepEqual(gc.Status, status) {
+		return nil
+	}
+	return c.patch(GatewayClassGVR, "", gc.Name, map[string]interface{}{"status": status}, "status")
+}
+
+// syncGateway syncs the load balancer of a Gateway, and reports its status
+// and the status of the HTTPRoutes attached to it.
+func (c *Controller) syncGateway(key string) error {
+	gwLogger := c.logger.WithValues("gatewayKey", key)
+	obj, exists, err := c.informers.Gateway.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	// The load balancer of a deleted Gateway is deleted before its
+	// finalizer is removed.
+	if !exists {
+		return nil
+	}
+	gw := &gatewayv1.Gateway{}
+	if err := fromUnstructured(obj, gw); err != nil {
+		return err
+	}
+
+	ingressClass, ok := c.informers.ingressClass(gw)
+	if gw.DeletionTimestamp != nil || !ok {
+		if !hasFinalizer(gw) {
+			return nil
+		}
+		gwLogger.Info("Deleting the load balancer of the Gateway")
+		return c.cleanup(gw, gwLogger)
+	}
+	if !hasFinalizer(gw) {
+		finalizers := append(append([]string(nil), gw.Finalizers...), FinalizerKey)
+		if err := c.patchMetadata(gw, map[string]interface{}{"finalizers": finalizers}); err != nil {
+			return err
+		}
+	}
+
+	gwLogger.Info("Syncing Gateway", "ingressClass", ingressClass)
+	state := newGatewayState(gw, ingressClass, c.informers.routes())
+	if previousClass := gw.Annotations[ingressClassStatusKey]; previousClass != "" && previousClass != ingressClass {
+		gwLogger.Info("GatewayClass changed, deleting the previous load balancer", "previousIngressClass", previousClass)
+		if err := c.gcLoadBalancer(gw, previousClass); err != nil {
+			return err
+		}
+	}
+
+	var l7 *loadbalancers.L7
+	var svcPorts []utils.ServicePort
+	var syncErr error
+	if state.addressReason != "" {
+		// The load balancer is not programmed until the address is fixed,
+		// which triggers another sync.
+		syncErr = errors.New(state.addressMessage)
+	} else {
+		l7, svcPorts, syncErr = c.syncLoadBalancer(state, gwLogger)
+	}
+
+	var ip string
+	if l7 != nil {
+		ip = l7.GetIP()
+		if err := c.updateAnnotations(gw, l7, ingressClass, gwLogger); err != nil {
+			return err
+		}
+	}
+	if err := c.patchStatus(GatewayGVR, gw.Namespace, gw.Name, gw.Status, state.status(ip, syncErr)); err != nil {
+		return err
+	}
+	if err := c.updateRouteStatuses(gw, state.routes); err != nil {
+		return err
+	}
+	if err := c.gcBackends(key, svcPorts, false, gwLogger); err != nil {
+		return err
+	}
+	if state.addressReason != "" {
+		return nil
+	}
+	return syncErr
+}
+
+// syncLoadBalancer syncs the backends and the load balancer of the Gateway.
+// It returns the Service ports of the backends, which are synced even if the
+// load balancer fails to sync.
+func (c *Controller) syncLoadBalancer(state *gatewayState, gwLogger klog.Logger) (*loadbalancers.L7, []utils.ServicePort, error) {
+	urlMap, _, errs, _ := c.translator.TranslateMergedIngresses(state.ingresses(), c.ctx.DefaultBackendSvcPort.ID, c.ctx.ClusterNamer)
+	if gwErrs := state.setTranslationErrors(errs); len(gwErrs) > 0 {
+		return nil, nil, utils.JoinErrs(gwErrs)
+	}
+	svcPorts := urlMap.AllServicePorts()
+	if err := c.backends.SyncExtraBackends(svcPorts, gwLogger); err != nil {
+		return nil, svcPorts, err
+	}
+
+	ing := state.loadBalancerIngress()
+	env, err := translator.NewEnv(ing, c.ctx.KubeClient, "", "", "")
+	if err != nil {
+		return nil, svcPorts, fmt.Errorf("error initializing translator env: %w", err)
+	}
+	tls, tlsErrs := translator.ToTLSCerts(env)
+	if len(tlsErrs) > 0 {
+		return nil, svcPorts, utils.JoinErrs(tlsErrs)
+	}
+	feConfig, err := frontendconfig.FrontendConfigForIngress(c.ctx.FrontendConfigs().List(), ing)
+	if err != nil {
+		return nil, svcPorts, err
+	}
+	if err := frontendconfig.Validate(feConfig, loadbalancers.LBSchemeForIngress(ing)); err != nil {
+		return nil, svcPorts, err
+	}
+	l7, err := c.l7Pool.Ensure(&loadbalancers.L7RuntimeInfo{
+		TLS:            tls,
+		Ingress:        ing,
+		AllowHTTP:      state.allowHTTP(),
+		StaticIPName:   state.staticIPName,
+		UrlMap:         urlMap,
+		FrontendConfig: feConfig.DeepCopy(),
+	})
+	return l7, svcPorts, err
+}
+
+// gcBackends runs the backend GC of the Ingress controller if the Gateway of
+// the given key stopped using some backends since its last sync, or if it was
+// not synced since the controller started. A deleted Gateway uses no
+// backends.
+func (c *Controller) gcBackends(key string, svcPorts []utils.ServicePort, deleted bool, gwLogger klog.Logger) error {
+	current := sets.New[string]()
+	for _, sp := range svcPorts {
+		current.Insert(sp.BackendName())
+	}
+
+	c.gatewayBackendsLock.Lock()
+	previous, ok := c.gatewayBackends[key]
+	c.gatewayBackendsLock.Unlock()
+	if ok && current.IsSuperset(previous) {
+		c.setGatewayBackends(key, current, deleted)
+		return nil
+	}
+	gwLogger.V(2).Info("Running the backend GC", "removedBackends", sets.List(previous.Difference(current)))
+	if err := c.backends.GCExtraBackends(gwLogger); err != nil {
+		return err
+	}
+	c.setGatewayBackends(key, current, deleted)
+	return nil
+}
+
+// setGatewayBackends records the backends of the Gateway of the given key, or
+// forgets them if it was deleted.
+func (c *Controller) setGatewayBackends(key string, backends sets.Set[string], deleted bool) {
+	c.gatewayBackendsLock.Lock()
+	defer c.gatewayBackendsLock.Unlock()
+	if deleted {
+		delete(c.gatewayBackends, key)
+		return
+	}
+	c.gatewayBackends[key] = backends
+}
+
+// cleanup deletes the load balancer of the Gateway and its backends, removes
+// the statuses of the routes set for the Gateway, and then the finalizer of
+// the Gateway.
+func (c *Controller) cleanup(gw *gatewayv1.Gateway, gwLogger klog.Logger) error {
+	if ingressClass := gw.Annotations[ingressClassStatusKey]; ingressClass != "" {
+		if err := c.gcLoadBalancer(gw, ingressClass); err != nil {
+			return err
+		}
+	}
+	if err := c.gcBackends(gatewayKey(gw), nil, true, gwLogger); err != nil {
+		return err
+	}
+	if err := c.updateRouteStatuses(gw, nil); err != nil {
+		return err
+	}
+	var finalizers []string
+	for _, f := range gw.Finalizers {
+		if f != FinalizerKey {
+			finalizers = append(finalizers, f)
+		}
+	}
+	return c.patchMetadata(gw, map[string]interface{}{"finalizers": finalizers})
+}
+
+// gcLoadBalancer deletes the load balancer of the Gateway of the given
+// Ingress class.
+func (c *Controller) gcLoadBalancer(gw *gatewayv1.Gateway, ingressClass string) error {
+	ing := gatewayIngress(gw, ingressClass, nil)
+	return c.l7Pool.GCv2(ing, features.ScopeFromIngress(ing))
+}
+
+// updateAnnotations records the GCE resources of the load balancer and the
+// Ingress class of the Gateway in its annotations.
+func (c *Controller) updateAnnotations(gw *gatewayv1.Gateway, l7 *loadbalancers.L7, ingressClass string, gwLogger klog.Logger) error {
+	backendState, err := c.backends.BackendStates(l7, gwLogger)
+	if err != nil {
+		return err
+	}
+	existing := map[string]string{}
+	for k, v := range gw.Annotations {
+		existing[k] = v
+	}
+	newAnnotations := loadbalancers.GetLBAnnotations(l7, existing, backendState)
+	newAnnotations[ingressClassStatusKey] = ingressClass
+
+	// The annotations removed by GetLBAnnotations are removed with null
+	// values of the merge patch.
+	patch := map[string]interface{}{}
+	for k := range gw.Annotations {
+		if _, ok := newAnnotations[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, v := range newAnnotations {
+		if old, ok := gw.Annotations[k]; !ok || old != v {
+			patch[k] = v
+		}
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	return c.patch(GatewayGVR, gw.Namespace, gw.Name, map[string]interface{}{"metadata": map[string]interface{}{"annotations": patch}})
+}
+
+// updateRouteStatuses sets the statuses of the HTTPRoutes for the Gateway to
+// those of the given attached routes, and removes them from the other routes.
+func (c *Controller) updateRouteStatuses(gw *gatewayv1.Gateway, attached []*routeState) error {
+	statuses := map[types.NamespacedName][]gatewayv1.RouteParentStatus{}
+	for _, rs := range attached {
+		statuses[types.NamespacedName{Namespace: rs.route.Namespace, Name: rs.route.Name}] = rs.parentStatuses(gw)
+	}
+	var errs []error
+	for _, route := range c.informers.routes() {
+		updated := &gatewayv1.HTTPRoute{ObjectMeta: route.ObjectMeta, Status: route.Status}
+		setRouteParentStatuses(updated, gw, statuses[types.NamespacedName{Namespace: route.Namespace, Name: route.Name}])
+		if equality.Semantic.DeepEqual(route.Status, updated.Status) {
+			continue
+		}
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": route.ResourceVersion},
+			"status":   map[string]interface{}{"parents": updated.Status.Parents},
+		}
+		if err := c.patch(HTTPRouteGVR, route.Namespace, route.Name, patch, "status"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+// patchStatus patches the status of an object if it changed.
+func (c *Controller) patchStatus(gvr schema.GroupVersionResource, namespace, name string, existing, status interface{}) error {
+	if equality.Semantic.DeepEqual(existing, status) {
+		return nil
+	}
+	return c.patch(gvr, namespace, name, map[string]interface{}{"status": status}, "status")
+}
+
+// patchMetadata patches the metadata of the Gateway, if it did not change
+// since it was read.
+func (c *Controller) patchMetadata(gw *gatewayv1.Gateway, metadata map[string]interface{}) error {
+	metadata["resourceVersion"] = gw.ResourceVersion
+	return c.patch(GatewayGVR, gw.Namespace, gw.Name, map[string]interface{}{"metadata": metadata})
+}
+
+// patch applies a JSON merge patch to an object, or to one of its
+// subresources.
+func (c *Controller) patch(gvr schema.GroupVersionResource, namespace, name string, patch map[string]interface{}, subresources ...string) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	var client dynamic.ResourceInterface = c.client.Resource(gvr)
+	if namespace != "" {
+		client = c.client.Resource(gvr).Namespace(namespace)
+	}
+	_, err = client.Patch(context2.TODO(), name, types.MergePatchType, data, metav1.PatchOptions{}, subresources...)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func hasFinalizer(gw *gatewayv1.Gateway) bool {
+	for _, f := range gw.Finalizers {
+		if f == FinalizerKey {
+			return true
+		}
+	}
+	return false
+}
+
+// gatewayRecorderProducer records the events of the L7 load balancer pool,
+// which are about the Ingress of a Gateway, on the Gateway.
+type gatewayRecorderProducer struct {
+	recorders events.RecorderProducer
+}
+
+func (p *gatewayRecorderProducer) Recorder(ns string) record.EventRecorder {
+	return &gatewayRecorder{p.recorders.Recorder(ns)}
+}
+
+type gatewayRecorder struct {
+	record.EventRecorder
+}
+
+func (r *gatewayRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(gatewayReference(object), eventtype, reason, message)
+}
+
+func (r *gatewayRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(gatewayReference(object), eventtype, reason, messageFmt, args...)
+}
+
+func (r *gatewayRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(gatewayReference(object), annotations, eventtype, reason, messageFmt, args...)
+}
+
+// gatewayReference returns a reference to the Gateway of the Ingress that
+// stands for it.
+func gatewayReference(object runtime.Object) runtime.Object {
+	ing, ok := object.(*v1.Ingress)
+	if !ok {
+		return object
+	}
+	return &apiv1.ObjectReference{
+		APIVersion: GatewayGVR.GroupVersion().String(),
+		Kind:       string(kindGateway),
+		Namespace:  ing.Namespace,
+		Name:       ing.Name,
+		UID:        ing.UID,
+	}
+}
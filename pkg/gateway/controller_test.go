@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	ingctx "k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/drift"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// fakeBackends records the calls of the Gateway controller to the Ingress
+// controller.
+type fakeBackends struct {
+	synced  [][]utils.ServicePort
+	gcCalls int
+}
+
+func (f *fakeBackends) SyncExtraBackends(svcPorts []utils.ServicePort, logger klog.Logger) error {
+	f.synced = append(f.synced, svcPorts)
+	return nil
+}
+
+func (f *fakeBackends) GCExtraBackends(logger klog.Logger) error {
+	f.gcCalls++
+	return nil
+}
+
+func (f *fakeBackends) BackendStates(l7 *loadbalancers.L7, logger klog.Logger) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (f *fakeBackends) DriftDetector() *drift.Detector {
+	return nil
+}
+
+// fakePatch is a patch applied with the fake dynamic client.
+type fakePatch struct {
+	resource    string
+	name        string
+	subresource string
+	patch       map[string]interface{}
+}
+
+// fakeDynamicClient records the patches of the controller. The other
+// methods of the dynamic client are not implemented.
+type fakeDynamicClient struct {
+	patches []fakePatch
+}
+
+func (f *fakeDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &fakeResourceClient{client: f, gvr: gvr}
+}
+
+type fakeResourceClient struct {
+	dynamic.NamespaceableResourceInterface
+	client *fakeDynamicClient
+	gvr    schema.GroupVersionResource
+}
+
+func (r *fakeResourceClient) Namespace(string) dynamic.ResourceInterface {
+	return r
+}
+
+func (r *fakeResourceClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	p := fakePatch{resource: r.gvr.Resource, name: name}
+	if len(subresources) > 0 {
+		p.subresource = subresources[0]
+	}
+	if err := json.Unmarshal(data, &p.patch); err != nil {
+		return nil, err
+	}
+	r.client.patches = append(r.client.patches, p)
+	return &unstructured.Unstructured{}, nil
+}
+
+func newTestController(t *testing.T) (*Controller, *fakeBackends, *fakeDynamicClient) {
+	t.Helper()
+	client := &fakeDynamicClient{}
+	backends := &fakeBackends{}
+	c := &Controller{
+		client:          client,
+		informers:       NewInformers(client, 0),
+		backends:        backends,
+		gatewayBackends: map[string]sets.Set[string]{},
+		logger:          klog.TODO(),
+	}
+	addObject(t, c.informers.GatewayClass, &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gke-l7-gxlb"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: ControllerName},
+	})
+	return c, backends, client
+}
+
+func addObject(t *testing.T, informer cache.SharedIndexInformer, obj interface{}) {
+	t.Helper()
+	if err := informer.GetStore().Add(toUnstructured(t, obj)); err != nil {
+		t.Fatalf("Add(%+v) = %v", obj, err)
+	}
+}
+
+func toUnstructured(t *testing.T, obj interface{}) *unstructured.Unstructured {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("ToUnstructured(%+v) = %v", obj, err)
+	}
+	return &unstructured.Unstructured{Object: content}
+}
+
+func backendPorts(names ...string) []utils.ServicePort {
+	var svcPorts []utils.ServicePort
+	for _, name := range names {
+		svcPorts = append(svcPorts, utils.ServicePort{
+			ID:           utils.ServicePortID{Service: types.NamespacedName{Namespace: "default", Name: name}},
+			Port:         80,
+			NEGEnabled:   true,
+			BackendNamer: namer.NewNamer("uid1", "", klog.TODO()),
+		})
+	}
+	return svcPorts
+}
+
+func TestGCBackends(t *testing.T) {
+	c, backends, _ := newTestController(t)
+	for _, step := range []struct {
+		desc     string
+		key      string
+		backends []string
+		deleted  bool
+		wantGC   bool
+	}{
+		{desc: "first sync", key: "infra/gw", backends: []string{"a", "b"}, wantGC: true},
+		{desc: "same backends", key: "infra/gw", backends: []string{"a", "b"}},
+		{desc: "added backend", key: "infra/gw", backends: []string{"a", "b", "c"}},
+		{desc: "first sync of another Gateway", key: "infra/other", backends: []string{"a"}, wantGC: true},
+		{desc: "removed backend", key: "infra/gw", backends: []string{"b", "c"}, wantGC: true},
+		{desc: "deleted Gateway", key: "infra/gw", deleted: true, wantGC: true},
+		{desc: "sync after the Gateway was recreated", key: "infra/gw", backends: []string{"b"}, wantGC: true},
+	} {
+		before := backends.gcCalls
+		if err := c.gcBackends(step.key, backendPorts(step.backends...), step.deleted, klog.TODO()); err != nil {
+			t.Fatalf("%s: gcBackends() = %v", step.desc, err)
+		}
+		if gotGC := backends.gcCalls > before; gotGC != step.wantGC {
+			t.Errorf("%s: ran the backend GC = %t, want %t", step.desc, gotGC, step.wantGC)
+		}
+	}
+}
+
+// finalizerPatches returns the finalizers set by the metadata patches of the
+// Gateway.
+func finalizerPatches(client *fakeDynamicClient) [][]string {
+	var finalizers [][]string
+	for _, p := range client.patches {
+		metadata, ok := p.patch["metadata"].(map[string]interface{})
+		if p.resource != GatewayGVR.Resource || !ok {
+			continue
+		}
+		if f, ok := metadata["finalizers"]; ok {
+			var got []string
+			if list, ok := f.([]interface{}); ok {
+				for _, item := range list {
+					got = append(got, item.(string))
+				}
+			}
+			finalizers = append(finalizers, got)
+		}
+	}
+	return finalizers
+}
+
+func TestSyncGatewayWithUnsupportedAddress(t *testing.T) {
+	c, backends, client := newTestController(t)
+	gw := testGateway()
+	gw.Spec.Addresses = []gatewayv1.GatewayAddress{{Value: "1.2.3.4"}}
+	addObject(t, c.informers.Gateway, gw)
+
+	// The load balancer of the Gateway is not programmed, but the Gateway
+	// gets the finalizer and its status.
+	if err := c.syncGateway(gatewayKey(gw)); err != nil {
+		t.Fatalf("syncGateway() = %v", err)
+	}
+	if diff := cmp.Diff([][]string{{FinalizerKey}}, finalizerPatches(client)); diff != "" {
+		t.Errorf("Got unexpected finalizer patches (-want +got):\n%s", diff)
+	}
+	if len(backends.synced) != 0 {
+		t.Errorf("Synced backends %+v, want none", backends.synced)
+	}
+	// The first sync garbage collects the backends the Gateway might have
+	// used before the controller started.
+	if backends.gcCalls != 1 {
+		t.Errorf("Ran the backend GC %d times, want 1", backends.gcCalls)
+	}
+}
+
+func TestSyncGatewayCleanup(t *testing.T) {
+	now := metav1.Now()
+	for _, tc := range []struct {
+		desc           string
+		className      string
+		deleted        bool
+		finalizers     []string
+		wantFinalizers [][]string
+		wantGC         int
+	}{
+		{
+			desc:           "deleted Gateway",
+			className:      "gke-l7-gxlb",
+			deleted:        true,
+			finalizers:     []string{"other", FinalizerKey},
+			wantFinalizers: [][]string{{"other"}},
+			wantGC:         1,
+		},
+		{
+			desc:           "Gateway of another class",
+			className:      "other",
+			finalizers:     []string{FinalizerKey},
+			wantFinalizers: [][]string{nil},
+			wantGC:         1,
+		},
+		{
+			desc:      "deleted Gateway without the finalizer",
+			className: "gke-l7-gxlb",
+			deleted:   true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, backends, client := newTestController(t)
+			gw := testGateway()
+			gw.Spec.GatewayClassName = gatewayv1.ObjectName(tc.className)
+			gw.Finalizers = tc.finalizers
+			if tc.deleted {
+				gw.DeletionTimestamp = &now
+			}
+			addObject(t, c.informers.Gateway, gw)
+			if err := c.gcBackends(gatewayKey(gw), backendPorts("a"), false, klog.TODO()); err != nil {
+				t.Fatalf("gcBackends() = %v", err)
+			}
+			backends.gcCalls = 0
+
+			if err := c.syncGateway(gatewayKey(gw)); err != nil {
+				t.Fatalf("syncGateway() = %v", err)
+			}
+			if diff := cmp.Diff(tc.wantFinalizers, finalizerPatches(client)); diff != "" {
+				t.Errorf("Got unexpected finalizer patches (-want +got):\n%s", diff)
+			}
+			if backends.gcCalls != tc.wantGC {
+				t.Errorf("Ran the backend GC %d times, want %d", backends.gcCalls, tc.wantGC)
+			}
+			if _, ok := c.gatewayBackends[gatewayKey(gw)]; ok == (tc.wantGC > 0) {
+				t.Errorf("Recorded backends of the Gateway = %t, want %t", ok, tc.wantGC == 0)
+			}
+		})
+	}
+}
+
+func TestNewControllerPlanMode(t *testing.T) {
+	ctx := &ingctx.ControllerContext{ControllerContextConfig: ingctx.ControllerContextConfig{IngressPlanMode: true}}
+	if c, err := NewController(ctx, &fakeBackends{}, nil, klog.TODO()); err == nil {
+		t.Errorf("NewController() = %v, nil, want error in plan mode", c)
+	}
+}
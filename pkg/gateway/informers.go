@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/utils"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Informers are the informers of the Gateway API resources, which are
+// watched with the dynamic client.
+type Informers struct {
+	GatewayClass cache.SharedIndexInformer
+	Gateway      cache.SharedIndexInformer
+	HTTPRoute    cache.SharedIndexInformer
+}
+
+// NewInformers returns the informers of the GatewayClasses, and of the
+// Gateways and HTTPRoutes of all namespaces.
+func NewInformers(client dynamic.Interface, resyncPeriod time.Duration) *Informers {
+	return &Informers{
+		GatewayClass: newInformer(client, GatewayClassGVR, resyncPeriod),
+		Gateway:      newInformer(client, GatewayGVR, resyncPeriod),
+		HTTPRoute:    newInformer(client, HTTPRouteGVR, resyncPeriod),
+	}
+}
+
+func newInformer(client dynamic.Interface, gvr schema.GroupVersionResource, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.Resource(gvr).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Resource(gvr).Watch(context.TODO(), options)
+			},
+		},
+		&unstructured.Unstructured{},
+		resyncPeriod,
+		utils.NewNamespaceIndexer(),
+	)
+}
+
+// Run runs the informers until stopCh is closed.
+func (i *Informers) Run(stopCh <-chan struct{}) {
+	go i.GatewayClass.Run(stopCh)
+	go i.Gateway.Run(stopCh)
+	go i.HTTPRoute.Run(stopCh)
+}
+
+// HasSynced returns true if all the informers have synced.
+func (i *Informers) HasSynced() bool {
+	return i.GatewayClass.HasSynced() && i.Gateway.HasSynced() && i.HTTPRoute.HasSynced()
+}
+
+// Ingresses returns the Ingresses that the HTTPRoutes attached to the
+// Gateways of the controller translate to, including the Ingress of the
+// default backend of each Gateway. Gateways being deleted are left out. They
+// let the controllers that track the Services used by Ingresses, such as the
+// NEG and firewall controllers, also track those used by Gateways. It returns
+// an error until the informers have synced.
+func (i *Informers) Ingresses() ([]*v1.Ingress, error) {
+	if !i.HasSynced() {
+		return nil, fmt.Errorf("waiting for the Gateway API informers to sync")
+	}
+	routes := i.routes()
+	var ings []*v1.Ingress
+	for _, gw := range i.gateways() {
+		ingressClass, ok := i.ingressClass(gw)
+		if !ok || gw.DeletionTimestamp != nil {
+			continue
+		}
+		ings = append(ings, newGatewayState(gw, ingressClass, routes).ingresses()...)
+	}
+	return ings, nil
+}
+
+// ingressClass returns the Ingress class of the load balancer of the
+// Gateway, and false if the Gateway is not of a GatewayClass of the
+// controller.
+func (i *Informers) ingressClass(gw *gatewayv1.Gateway) (string, bool) {
+	obj, exists, err := i.GatewayClass.GetStore().GetByKey(string(gw.Spec.GatewayClassName))
+	if err != nil || !exists {
+		return "", false
+	}
+	gc := &gatewayv1.GatewayClass{}
+	if err := fromUnstructured(obj, gc); err != nil || gc.Spec.ControllerName != ControllerName {
+		return "", false
+	}
+	ingressClass, ok := gatewayClasses[gc.Name]
+	return ingressClass, ok
+}
+
+func (i *Informers) gateways() []*gatewayv1.Gateway {
+	var gws []*gatewayv1.Gateway
+	for _, obj := range i.Gateway.GetStore().List() {
+		gw := &gatewayv1.Gateway{}
+		if err := fromUnstructured(obj, gw); err == nil {
+			gws = append(gws, gw)
+		}
+	}
+	return gws
+}
+
+func (i *Informers) routes() []*gatewayv1.HTTPRoute {
+	var routes []*gatewayv1.HTTPRoute
+	for _, obj := range i.HTTPRoute.GetStore().List() {
+		route := &gatewayv1.HTTPRoute{}
+		if err := fromUnstructured(obj, route); err == nil {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// fromUnstructured converts an unstructured object of an informer to out.
+func fromUnstructured(obj interface{}, out interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), out)
+}
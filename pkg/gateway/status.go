@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlerrors "k8s.io/ingress-gce/pkg/controller/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	conditionAccepted         = "Accepted"
+	conditionProgrammed       = "Programmed"
+	conditionResolvedRefs     = "ResolvedRefs"
+	conditionPartiallyInvalid = "PartiallyInvalid"
+
+	reasonAccepted                   = "Accepted"
+	reasonProgrammed                 = "Programmed"
+	reasonResolvedRefs               = "ResolvedRefs"
+	reasonInvalid                    = "Invalid"
+	reasonPending                    = "Pending"
+	reasonInvalidParameters          = "InvalidParameters"
+	reasonListenersNotValid          = "ListenersNotValid"
+	reasonUnsupportedAddress         = "UnsupportedAddress"
+	reasonPortUnavailable            = "PortUnavailable"
+	reasonUnsupportedProtocol        = "UnsupportedProtocol"
+	reasonInvalidRouteKinds          = "InvalidRouteKinds"
+	reasonInvalidCertificateRef      = "InvalidCertificateRef"
+	reasonRefNotPermitted            = "RefNotPermitted"
+	reasonNoMatchingParent           = "NoMatchingParent"
+	reasonNotAllowedByListeners      = "NotAllowedByListeners"
+	reasonNoMatchingListenerHostname = "NoMatchingListenerHostname"
+	reasonUnsupportedValue           = "UnsupportedValue"
+	reasonInvalidKind                = "InvalidKind"
+	reasonBackendNotFound            = "BackendNotFound"
+)
+
+// newCondition returns a condition of an object of the given generation.
+func newCondition(conditionType string, ok bool, reason, message string, generation int64) metav1.Condition {
+	status := metav1.ConditionFalse
+	if ok {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// mergeConditions returns the given conditions, with the transition time of
+// the existing conditions whose status did not change.
+func mergeConditions(existing, conditions []metav1.Condition) []metav1.Condition {
+	var ret []metav1.Condition
+	for _, c := range conditions {
+		if old := meta.FindStatusCondition(existing, c.Type); old != nil && old.Status == c.Status {
+			c.LastTransitionTime = old.LastTransitionTime
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+// gatewayClassStatus returns the status of a GatewayClass of the controller,
+// which is accepted if its name is one of the supported classes.
+func gatewayClassStatus(gc *gatewayv1.GatewayClass) gatewayv1.GatewayClassStatus {
+	accepted := newCondition(conditionAccepted, true, reasonAccepted, "", gc.Generation)
+	if _, ok := gatewayClasses[gc.Name]; !ok {
+		accepted = newCondition(conditionAccepted, false, reasonInvalidParameters, fmt.Sprintf("GatewayClass %q is not supported, the supported classes are %s", gc.Name, strings.Join(supportedGatewayClasses(), ", ")), gc.Generation)
+	}
+	return gatewayv1.GatewayClassStatus{Conditions: mergeConditions(gc.Status.Conditions, []metav1.Condition{accepted})}
+}
+
+// setTranslationErrors reports the errors of the translation of the Ingresses
+// of the rules of the routes. It returns the errors that are not those of a
+// rule.
+func (gs *gatewayState) setTranslationErrors(errs map[types.NamespacedName][]error) []error {
+	var gatewayErrs []error
+	for key, ingErrs := range errs {
+		rs, ok := gs.ruleOwners[key]
+		if !ok {
+			gatewayErrs = append(gatewayErrs, ingErrs...)
+			continue
+		}
+		rule := strings.TrimPrefix(key.Name, rs.route.Name+"-")
+		var invalid []string
+		for _, err := range ingErrs {
+			var svcNotFound ctrlerrors.ErrSvcNotFound
+			var svcPortNotFound ctrlerrors.ErrSvcPortNotFound
+			if errors.As(err, &svcNotFound) || errors.As(err, &svcPortNotFound) {
+				if rs.refReason == "" {
+					rs.refReason = reasonBackendNotFound
+				}
+				rs.refMessages = append(rs.refMessages, fmt.Sprintf("rule %s: %v", rule, err))
+				continue
+			}
+			invalid = append(invalid, err.Error())
+		}
+		if len(invalid) > 0 {
+			rs.invalidRules = append(rs.invalidRules, fmt.Sprintf("rule %s: %s", rule, strings.Join(invalid, "; ")))
+		}
+	}
+	return gatewayErrs
+}
+
+// status returns the status of the Gateway given the IP address of its load
+// balancer and the error of its sync.
+func (gs *gatewayState) status(ip string, syncErr error) gatewayv1.GatewayStatus {
+	gw := gs.gateway
+	accepted := newCondition(conditionAccepted, true, reasonAccepted, "", gw.Generation)
+	switch {
+	case gs.addressReason != "":
+		accepted = newCondition(conditionAccepted, false, gs.addressReason, gs.addressMessage, gw.Generation)
+	case gs.invalidListeners() > 0:
+		accepted = newCondition(conditionAccepted, true, reasonListenersNotValid, fmt.Sprintf("%d of %d listeners are not valid", gs.invalidListeners(), len(gs.listeners)), gw.Generation)
+	}
+	var programmed metav1.Condition
+	switch {
+	case syncErr != nil:
+		programmed = newCondition(conditionProgrammed, false, reasonInvalid, syncErr.Error(), gw.Generation)
+	case ip == "":
+		programmed = newCondition(conditionProgrammed, false, reasonPending, "the load balancer has no IP address yet", gw.Generation)
+	default:
+		programmed = newCondition(conditionProgrammed, true, reasonProgrammed, "", gw.Generation)
+	}
+
+	status := gatewayv1.GatewayStatus{Conditions: mergeConditions(gw.Status.Conditions, []metav1.Condition{accepted, programmed})}
+	if ip != "" {
+		addrType := gatewayv1.IPAddressType
+		status.Addresses = []gatewayv1.GatewayStatusAddress{{Type: &addrType, Value: ip}}
+	}
+	group := gatewayv1.Group(GroupName)
+	for _, ls := range gs.listeners {
+		var existing []metav1.Condition
+		for _, old := range gw.Status.Listeners {
+			if old.Name == ls.listener.Name {
+				existing = old.Conditions
+			}
+		}
+		conditions := append([]metav1.Condition(nil), ls.conditions...)
+		if ls.valid && programmed.Status == metav1.ConditionTrue {
+			conditions = append(conditions, newCondition(conditionProgrammed, true, reasonProgrammed, "", gw.Generation))
+		} else {
+			conditions = append(conditions, newCondition(conditionProgrammed, false, reasonInvalid, "the listener is not programmed", gw.Generation))
+		}
+		status.Listeners = append(status.Listeners, gatewayv1.ListenerStatus{
+			Name:           ls.listener.Name,
+			SupportedKinds: []gatewayv1.RouteGroupKind{{Group: &group, Kind: kindHTTPRoute}},
+			AttachedRoutes: ls.attachedRoutes,
+			Conditions:     mergeConditions(existing, conditions),
+		})
+	}
+	return status
+}
+
+// invalidListeners returns the number of Listeners that routes cannot attach
+// to.
+func (gs *gatewayState) invalidListeners() int {
+	var n int
+	for _, ls := range gs.listeners {
+		if !ls.valid {
+			n++
+		}
+	}
+	return n
+}
+
+// parentStatuses returns the statuses of the route for its parentRefs to the
+// Gateway.
+func (rs *routeState) parentStatuses(gw *gatewayv1.Gateway) []gatewayv1.RouteParentStatus {
+	var ret []gatewayv1.RouteParentStatus
+	generation := rs.route.Generation
+	for _, p := range rs.parents {
+		var conditions []metav1.Condition
+		switch {
+		case p.reason != "":
+			conditions = append(conditions, newCondition(conditionAccepted, false, p.reason, p.message, generation))
+		case rs.rules > 0 && len(rs.invalidRules) == rs.rules:
+			conditions = append(conditions, newCondition(conditionAccepted, false, reasonUnsupportedValue, strings.Join(rs.invalidRules, "; "), generation))
+		default:
+			conditions = append(conditions, newCondition(conditionAccepted, true, reasonAccepted, "", generation))
+			if len(rs.invalidRules) > 0 {
+				conditions = append(conditions, newCondition(conditionPartiallyInvalid, true, reasonUnsupportedValue, strings.Join(rs.invalidRules, "; "), generation))
+			}
+		}
+		if len(rs.refMessages) > 0 {
+			conditions = append(conditions, newCondition(conditionResolvedRefs, false, rs.refReason, strings.Join(rs.refMessages, "; "), generation))
+		} else {
+			conditions = append(conditions, newCondition(conditionResolvedRefs, true, reasonResolvedRefs, "", generation))
+		}
+
+		var existing []metav1.Condition
+		for _, old := range rs.route.Status.Parents {
+			if old.ControllerName == ControllerName && reflect.DeepEqual(old.ParentRef, p.ref) {
+				existing = old.Conditions
+			}
+		}
+		ret = append(ret, gatewayv1.RouteParentStatus{ParentRef: p.ref, ControllerName: ControllerName, Conditions: mergeConditions(existing, conditions)})
+	}
+	return ret
+}
+
+// setRouteParentStatuses replaces the statuses of the route set by the
+// controller for its parentRefs to the Gateway with the given statuses.
+func setRouteParentStatuses(route *gatewayv1.HTTPRoute, gw *gatewayv1.Gateway, statuses []gatewayv1.RouteParentStatus) {
+	var parents []gatewayv1.RouteParentStatus
+	for _, p := range route.Status.Parents {
+		if p.ControllerName == ControllerName && referencesGateway(p.ParentRef, route.Namespace, gw) {
+			continue
+		}
+		parents = append(parents, p)
+	}
+	route.Status.Parents = append(parents, statuses...)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	ctrlerrors "k8s.io/ingress-gce/pkg/controller/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func checkCondition(t *testing.T, desc string, conditions []metav1.Condition, conditionType string, wantStatus metav1.ConditionStatus, wantReason string) {
+	t.Helper()
+	c := meta.FindStatusCondition(conditions, conditionType)
+	if c == nil {
+		t.Errorf("%s: condition %s not found in %+v", desc, conditionType, conditions)
+		return
+	}
+	if c.Status != wantStatus || c.Reason != wantReason {
+		t.Errorf("%s: got %s=%s with reason %q (%s), want %s with reason %q", desc, conditionType, c.Status, c.Reason, c.Message, wantStatus, wantReason)
+	}
+}
+
+func TestGatewayStatus(t *testing.T) {
+	for _, tc := range []struct {
+		desc            string
+		addresses       []gatewayv1.GatewayAddress
+		ip              string
+		syncErr         error
+		wantAccepted    metav1.ConditionStatus
+		wantAcceptedRsn string
+		wantProgrammed  string
+	}{
+		{
+			desc:            "programmed",
+			ip:              "1.2.3.4",
+			wantAccepted:    metav1.ConditionTrue,
+			wantAcceptedRsn: reasonListenersNotValid,
+			wantProgrammed:  reasonProgrammed,
+		},
+		{
+			desc:            "no IP address yet",
+			wantAccepted:    metav1.ConditionTrue,
+			wantAcceptedRsn: reasonListenersNotValid,
+			wantProgrammed:  reasonPending,
+		},
+		{
+			desc:            "sync error",
+			ip:              "1.2.3.4",
+			syncErr:         fmt.Errorf("quota exceeded"),
+			wantAccepted:    metav1.ConditionTrue,
+			wantAcceptedRsn: reasonListenersNotValid,
+			wantProgrammed:  reasonInvalid,
+		},
+		{
+			desc:            "unsupported address",
+			addresses:       []gatewayv1.GatewayAddress{{Value: "1.2.3.4"}},
+			wantAccepted:    metav1.ConditionFalse,
+			wantAcceptedRsn: reasonUnsupportedAddress,
+			wantProgrammed:  reasonPending,
+		},
+	} {
+		gw := testGateway()
+		gw.Spec.Addresses = tc.addresses
+		state := newGatewayState(gw, annotations.GceIngressClass, nil)
+		status := state.status(tc.ip, tc.syncErr)
+
+		checkCondition(t, tc.desc, status.Conditions, conditionAccepted, tc.wantAccepted, tc.wantAcceptedRsn)
+		wantProgrammed := metav1.ConditionFalse
+		if tc.wantProgrammed == reasonProgrammed {
+			wantProgrammed = metav1.ConditionTrue
+		}
+		checkCondition(t, tc.desc, status.Conditions, conditionProgrammed, wantProgrammed, tc.wantProgrammed)
+		if got := len(status.Addresses) > 0; got != (tc.ip != "") {
+			t.Errorf("%s: got addresses %+v for IP %q", tc.desc, status.Addresses, tc.ip)
+		}
+		if len(status.Listeners) != 3 {
+			t.Fatalf("%s: got %d listener statuses, want 3", tc.desc, len(status.Listeners))
+		}
+		listenerReason := reasonInvalid
+		if wantProgrammed == metav1.ConditionTrue {
+			listenerReason = reasonProgrammed
+		}
+		checkCondition(t, tc.desc, status.Listeners[0].Conditions, conditionProgrammed, wantProgrammed, listenerReason)
+		checkCondition(t, tc.desc, status.Listeners[2].Conditions, conditionAccepted, metav1.ConditionFalse, reasonPortUnavailable)
+		checkCondition(t, tc.desc, status.Listeners[2].Conditions, conditionProgrammed, metav1.ConditionFalse, reasonInvalid)
+	}
+}
+
+func TestRouteParentStatuses(t *testing.T) {
+	now := time.Now()
+	gw := testGateway()
+	otherStatus := gatewayv1.RouteParentStatus{ParentRef: gatewayv1.ParentReference{Name: "other"}, ControllerName: "example.com/other"}
+
+	route := testRoute("infra", "shop", now, gatewayv1.ParentReference{Name: "gw"}, []gatewayv1.Hostname{"shop.example.com"},
+		backendRule("/a", serviceRef("a", 80)),
+		backendRule("/b", serviceRef("b", 80)),
+	)
+	route.Spec.Rules[1].Filters = []gatewayv1.HTTPRouteFilter{{Type: "RequestMirror"}}
+	route.Status.Parents = []gatewayv1.RouteParentStatus{otherStatus}
+
+	state := newGatewayState(gw, annotations.GceIngressClass, []*gatewayv1.HTTPRoute{route})
+	gatewayErrs := state.setTranslationErrors(map[types.NamespacedName][]error{
+		{Namespace: "infra", Name: "shop-0"}:     {ctrlerrors.ErrSvcNotFound{Service: types.NamespacedName{Namespace: "infra", Name: "a"}}},
+		{Namespace: "infra", Name: "gw-default"}: {fmt.Errorf("default backend error")},
+	})
+	if len(gatewayErrs) != 1 {
+		t.Errorf("Got errors %v not of a rule, want the error of the default backend", gatewayErrs)
+	}
+
+	statuses := state.routes[0].parentStatuses(gw)
+	if len(statuses) != 1 {
+		t.Fatalf("Got statuses %+v, want one", statuses)
+	}
+	conditions := statuses[0].Conditions
+	checkCondition(t, "route", conditions, conditionAccepted, metav1.ConditionTrue, reasonAccepted)
+	checkCondition(t, "route", conditions, conditionPartiallyInvalid, metav1.ConditionTrue, reasonUnsupportedValue)
+	checkCondition(t, "route", conditions, conditionResolvedRefs, metav1.ConditionFalse, reasonBackendNotFound)
+
+	setRouteParentStatuses(route, gw, statuses)
+	if len(route.Status.Parents) != 2 || route.Status.Parents[0].ControllerName != otherStatus.ControllerName {
+		t.Errorf("Got parents %+v, want the status of the other controller followed by ours", route.Status.Parents)
+	}
+	setRouteParentStatuses(route, gw, nil)
+	if len(route.Status.Parents) != 1 {
+		t.Errorf("Got parents %+v, want only the status of the other controller", route.Status.Parents)
+	}
+
+	// A route whose rules are all invalid is not accepted.
+	route.Spec.Rules = route.Spec.Rules[1:]
+	state = newGatewayState(gw, annotations.GceIngressClass, []*gatewayv1.HTTPRoute{route})
+	checkCondition(t, "invalid route", state.routes[0].parentStatuses(gw)[0].Conditions, conditionAccepted, metav1.ConditionFalse, reasonUnsupportedValue)
+}
+
+func TestMergeConditions(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	existing := []metav1.Condition{
+		{Type: conditionAccepted, Status: metav1.ConditionTrue, LastTransitionTime: old},
+		{Type: conditionProgrammed, Status: metav1.ConditionTrue, LastTransitionTime: old},
+	}
+	got := mergeConditions(existing, []metav1.Condition{
+		newCondition(conditionAccepted, true, reasonAccepted, "", 1),
+		newCondition(conditionProgrammed, false, reasonInvalid, "", 1),
+	})
+	if !got[0].LastTransitionTime.Equal(&old) {
+		t.Errorf("Got transition time %v of an unchanged condition, want %v", got[0].LastTransitionTime, old)
+	}
+	if got[1].LastTransitionTime.Equal(&old) {
+		t.Errorf("Got transition time %v of a changed condition, want a new one", got[1].LastTransitionTime)
+	}
+}
@@ -0,0 +1,638 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// maxWeight is the maximum weight of a backend in the weighted backends
+// annotation.
+const maxWeight = 1000
+
+// gatewayClasses maps the GatewayClasses supported by the controller to the
+// Ingress class of their load balancer.
+var gatewayClasses = map[string]string{
+	"gke-l7-gxlb":                      annotations.GceIngressClass,
+	"gke-l7-rilb":                      annotations.GceL7ILBIngressClass,
+	"gke-l7-regional-external-managed": annotations.GceL7XLBRegionalIngressClass,
+}
+
+// supportedGatewayClasses returns the names of the supported GatewayClasses.
+func supportedGatewayClasses() []string {
+	var names []string
+	for name := range gatewayClasses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listenerState is the result of the validation of a Listener.
+type listenerState struct {
+	listener gatewayv1.Listener
+	// conditions are the Accepted and ResolvedRefs conditions.
+	conditions []metav1.Condition
+	// valid is true if routes can attach to the Listener.
+	valid bool
+	// attachedRoutes is the number of routes attached to the Listener.
+	attachedRoutes int32
+}
+
+// routeParent is the attachment of an HTTPRoute to the Gateway through one of
+// its parentRefs.
+type routeParent struct {
+	ref gatewayv1.ParentReference
+	// hostnames are the hostnames served through the Listeners the route is
+	// attached to. The empty hostname matches all hosts.
+	hostnames []string
+	// reason and message of the Accepted condition if the route is not
+	// attached, or empty if it is.
+	reason, message string
+}
+
+// routeState is the translation of an HTTPRoute attached to the Gateway.
+type routeState struct {
+	route   *gatewayv1.HTTPRoute
+	parents []*routeParent
+	// ingresses are the Ingresses of the rules of the route, one per rule.
+	ingresses []*v1.Ingress
+	// refReason and refMessages report the backends of the rules that could
+	// not be resolved in the ResolvedRefs condition.
+	refReason   string
+	refMessages []string
+	// invalidRules are the messages of the rules that are not programmed.
+	invalidRules []string
+	// rules is the number of rules of the route.
+	rules int
+}
+
+// attached returns true if the route is attached to the Gateway through at
+// least one of its parentRefs.
+func (rs *routeState) attached() bool {
+	for _, p := range rs.parents {
+		if p.reason == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// gatewayState is the translation of a Gateway and of the HTTPRoutes that
+// reference it into Ingresses, which are merged into the URL map of the load
+// balancer of the Gateway.
+type gatewayState struct {
+	gateway      *gatewayv1.Gateway
+	ingressClass string
+	listeners    []*listenerState
+	routes       []*routeState
+	// addressReason and addressMessage report an unsupported address.
+	addressReason, addressMessage string
+	staticIPName                  string
+	// ruleOwners maps the Ingress of a rule to its route.
+	ruleOwners map[types.NamespacedName]*routeState
+}
+
+// newGatewayState translates the Gateway and the given HTTPRoutes, which are
+// those of all namespaces, for a load balancer of the given Ingress class.
+// Routes are merged oldest first, so the oldest route wins when several
+// routes use the same host and path.
+func newGatewayState(gw *gatewayv1.Gateway, ingressClass string, routes []*gatewayv1.HTTPRoute) *gatewayState {
+	state := &gatewayState{gateway: gw, ingressClass: ingressClass, ruleOwners: map[types.NamespacedName]*routeState{}}
+	for _, l := range gw.Spec.Listeners {
+		state.listeners = append(state.listeners, validateListener(gw, l))
+	}
+	state.validateAddresses()
+
+	routes = append([]*gatewayv1.HTTPRoute(nil), routes...)
+	sort.Slice(routes, func(i, j int) bool {
+		if !routes[i].CreationTimestamp.Equal(&routes[j].CreationTimestamp) {
+			return routes[i].CreationTimestamp.Before(&routes[j].CreationTimestamp)
+		}
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+	for _, route := range routes {
+		rs := state.attachRoute(route)
+		if rs == nil {
+			continue
+		}
+		state.routes = append(state.routes, rs)
+		if !rs.attached() {
+			continue
+		}
+		rs.translateRules(ingressClass)
+		for _, ing := range rs.ingresses {
+			state.ruleOwners[types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}] = rs
+		}
+	}
+	return state
+}
+
+// validateListener validates a Listener of the Gateway. The load balancer
+// serves HTTP on port 80 and HTTPS on port 443, with the certificates of
+// Secrets of the namespace of the Gateway.
+func validateListener(gw *gatewayv1.Gateway, l gatewayv1.Listener) *listenerState {
+	ls := &listenerState{listener: l}
+	accepted := func(ok bool, reason, message string) {
+		ls.conditions = append(ls.conditions, newCondition(conditionAccepted, ok, reason, message, gw.Generation))
+	}
+	resolved := func(ok bool, reason, message string) {
+		ls.conditions = append(ls.conditions, newCondition(conditionResolvedRefs, ok, reason, message, gw.Generation))
+	}
+
+	switch {
+	case l.Protocol == gatewayv1.HTTPProtocolType && l.Port != 80:
+		accepted(false, reasonPortUnavailable, "HTTP is only served on port 80")
+	case l.Protocol == gatewayv1.HTTPSProtocolType && l.Port != 443:
+		accepted(false, reasonPortUnavailable, "HTTPS is only served on port 443")
+	case l.Protocol != gatewayv1.HTTPProtocolType && l.Protocol != gatewayv1.HTTPSProtocolType:
+		accepted(false, reasonUnsupportedProtocol, fmt.Sprintf("protocol %q is not supported, only HTTP and HTTPS are", l.Protocol))
+	case l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil &&
+		*l.AllowedRoutes.Namespaces.From != gatewayv1.NamespacesFromSame && *l.AllowedRoutes.Namespaces.From != gatewayv1.NamespacesFromAll:
+		accepted(false, reasonUnsupportedValue, fmt.Sprintf("allowed routes from %q namespaces are not supported, only Same and All are", *l.AllowedRoutes.Namespaces.From))
+	default:
+		accepted(true, reasonAccepted, "")
+	}
+
+	if message := validateRouteKinds(l); message != "" {
+		resolved(false, reasonInvalidRouteKinds, message)
+	} else if l.Protocol == gatewayv1.HTTPSProtocolType {
+		reason, message := validateCertificateRefs(gw, l)
+		resolved(reason == "", orDefault(reason, reasonResolvedRefs), message)
+	} else {
+		resolved(true, reasonResolvedRefs, "")
+	}
+
+	ls.valid = true
+	for _, c := range ls.conditions {
+		if c.Status != metav1.ConditionTrue {
+			ls.valid = false
+		}
+	}
+	return ls
+}
+
+// validateRouteKinds returns an error message if the Listener does not allow
+// HTTPRoutes.
+func validateRouteKinds(l gatewayv1.Listener) string {
+	if l.AllowedRoutes == nil || len(l.AllowedRoutes.Kinds) == 0 {
+		return ""
+	}
+	for _, k := range l.AllowedRoutes.Kinds {
+		if k.Kind == kindHTTPRoute && (k.Group == nil || *k.Group == GroupName) {
+			return ""
+		}
+	}
+	return "only HTTPRoutes are supported"
+}
+
+// validateCertificateRefs returns the reason and message of the ResolvedRefs
+// condition of an HTTPS Listener whose certificates are invalid.
+func validateCertificateRefs(gw *gatewayv1.Gateway, l gatewayv1.Listener) (string, string) {
+	if l.TLS == nil || len(l.TLS.CertificateRefs) == 0 {
+		return reasonInvalidCertificateRef, "HTTPS listeners require certificateRefs"
+	}
+	if l.TLS.Mode != nil && *l.TLS.Mode != gatewayv1.TLSModeTerminate {
+		return reasonInvalidCertificateRef, fmt.Sprintf("TLS mode %q is not supported, only Terminate is", *l.TLS.Mode)
+	}
+	for _, ref := range l.TLS.CertificateRefs {
+		if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != kindSecret) {
+			return reasonInvalidCertificateRef, fmt.Sprintf("certificate %q is not a Secret", ref.Name)
+		}
+		if ref.Namespace != nil && string(*ref.Namespace) != gw.Namespace {
+			return reasonRefNotPermitted, fmt.Sprintf("Secret %s/%s is not in the namespace of the Gateway", *ref.Namespace, ref.Name)
+		}
+	}
+	return "", ""
+}
+
+// validateAddresses validates the addresses of the Gateway. A single
+// NamedAddress, the name of a reserved static IP address, is supported.
+func (gs *gatewayState) validateAddresses() {
+	for _, addr := range gs.gateway.Spec.Addresses {
+		addrType := gatewayv1.IPAddressType
+		if addr.Type != nil {
+			addrType = *addr.Type
+		}
+		switch {
+		case addrType != gatewayv1.NamedAddressType:
+			gs.addressReason, gs.addressMessage = reasonUnsupportedAddress, fmt.Sprintf("address type %q is not supported, only NamedAddress is", addrType)
+			return
+		case gs.staticIPName != "":
+			gs.addressReason, gs.addressMessage = reasonUnsupportedAddress, "only one address is supported"
+			return
+		default:
+			gs.staticIPName = addr.Value
+		}
+	}
+}
+
+// attachRoute attaches the route to the Listeners of the Gateway its
+// parentRefs select. It returns nil if the route does not reference the
+// Gateway.
+func (gs *gatewayState) attachRoute(route *gatewayv1.HTTPRoute) *routeState {
+	var rs *routeState
+	for _, ref := range route.Spec.ParentRefs {
+		if !referencesGateway(ref, route.Namespace, gs.gateway) {
+			continue
+		}
+		if rs == nil {
+			rs = &routeState{route: route, rules: len(route.Spec.Rules)}
+		}
+		rs.parents = append(rs.parents, gs.attachParent(route, ref))
+	}
+	return rs
+}
+
+// referencesGateway returns true if the parentRef of a route of the given
+// namespace references the Gateway.
+func referencesGateway(ref gatewayv1.ParentReference, routeNamespace string, gw *gatewayv1.Gateway) bool {
+	if ref.Group != nil && *ref.Group != GroupName {
+		return false
+	}
+	if ref.Kind != nil && *ref.Kind != kindGateway {
+		return false
+	}
+	namespace := routeNamespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	return namespace == gw.Namespace && string(ref.Name) == gw.Name
+}
+
+// attachParent attaches the route to the Listeners selected by one of its
+// parentRefs.
+func (gs *gatewayState) attachParent(route *gatewayv1.HTTPRoute, ref gatewayv1.ParentReference) *routeParent {
+	parent := &routeParent{ref: ref}
+	var matched, allowed []*listenerState
+	for _, ls := range gs.listeners {
+		if ref.SectionName != nil && *ref.SectionName != ls.listener.Name {
+			continue
+		}
+		if ref.Port != nil && *ref.Port != ls.listener.Port {
+			continue
+		}
+		matched = append(matched, ls)
+		if ls.valid && allowsNamespace(ls.listener, gs.gateway.Namespace, route.Namespace) {
+			allowed = append(allowed, ls)
+		}
+	}
+	if len(matched) == 0 {
+		parent.reason, parent.message = reasonNoMatchingParent, "no listener of the Gateway matches the parentRef"
+		return parent
+	}
+	if len(allowed) == 0 {
+		parent.reason, parent.message = reasonNotAllowedByListeners, "the listeners of the Gateway do not allow the route"
+		return parent
+	}
+
+	seen := map[string]bool{}
+	var attached []*listenerState
+	for _, ls := range allowed {
+		hostnames := intersectHostnames(ls.listener.Hostname, route.Spec.Hostnames)
+		if len(hostnames) == 0 {
+			continue
+		}
+		attached = append(attached, ls)
+		for _, h := range hostnames {
+			if !seen[h] {
+				seen[h] = true
+				parent.hostnames = append(parent.hostnames, h)
+			}
+		}
+	}
+	if len(attached) == 0 {
+		parent.reason, parent.message = reasonNoMatchingListenerHostname, "no hostname of the route matches the hostname of a listener"
+		return parent
+	}
+	for _, ls := range attached {
+		ls.attachedRoutes++
+	}
+	return parent
+}
+
+// allowsNamespace returns true if the Listener allows routes of the given
+// namespace.
+func allowsNamespace(l gatewayv1.Listener, gatewayNamespace, routeNamespace string) bool {
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil &&
+		*l.AllowedRoutes.Namespaces.From == gatewayv1.NamespacesFromAll {
+		return true
+	}
+	return gatewayNamespace == routeNamespace
+}
+
+// intersectHostnames returns the hostnames of a route served through a
+// Listener with the given hostname. The empty hostname matches all hosts.
+func intersectHostnames(listenerHostname *gatewayv1.Hostname, hostnames []gatewayv1.Hostname) []string {
+	routeHostnames := make([]string, 0, len(hostnames))
+	for _, h := range hostnames {
+		routeHostnames = append(routeHostnames, string(h))
+	}
+	if listenerHostname == nil || *listenerHostname == "" {
+		if len(routeHostnames) == 0 {
+			return []string{""}
+		}
+		return routeHostnames
+	}
+	listener := string(*listenerHostname)
+	if len(routeHostnames) == 0 {
+		return []string{listener}
+	}
+	var ret []string
+	for _, h := range routeHostnames {
+		switch {
+		case hostnameMatches(listener, h):
+			ret = append(ret, h)
+		case hostnameMatches(h, listener):
+			// The route hostname is a wildcard that matches the listener.
+			ret = append(ret, listener)
+		}
+	}
+	return ret
+}
+
+// hostnameMatches returns true if hostname matches pattern, which may be a
+// wildcard hostname such as *.example.com. A wildcard hostname matches a
+// wildcard pattern of one of its parent domains.
+func hostnameMatches(pattern, hostname string) bool {
+	if pattern == hostname {
+		return true
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return false
+	}
+	return strings.HasSuffix(hostname, pattern[1:])
+}
+
+// hostnames returns the hostnames served by the route through all of its
+// attached parentRefs.
+func (rs *routeState) hostnames() []string {
+	seen := map[string]bool{}
+	var ret []string
+	for _, p := range rs.parents {
+		if p.reason != "" {
+			continue
+		}
+		for _, h := range p.hostnames {
+			if !seen[h] {
+				seen[h] = true
+				ret = append(ret, h)
+			}
+		}
+	}
+	return ret
+}
+
+// translateRules translates each rule of the route into an Ingress of the
+// namespace of the route, which serves the paths of the rule on the hostnames
+// of the route. The rules that cannot be translated are left out.
+func (rs *routeState) translateRules(ingressClass string) {
+	hostnames := rs.hostnames()
+	for i, rule := range rs.route.Spec.Rules {
+		paths, err := rulePaths(rule)
+		if err != nil {
+			rs.invalidRules = append(rs.invalidRules, fmt.Sprintf("rule %d: %v", i, err))
+			continue
+		}
+		backend, weighted, refErr := ruleBackends(rs.route.Namespace, rule)
+		if refErr != nil {
+			if rs.refReason == "" {
+				rs.refReason = refErr.reason
+			}
+			rs.refMessages = append(rs.refMessages, fmt.Sprintf("rule %d: %s", i, refErr.message))
+			continue
+		}
+
+		ing := &v1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         rs.route.Namespace,
+				Name:              fmt.Sprintf("%s-%d", rs.route.Name, i),
+				CreationTimestamp: rs.route.CreationTimestamp,
+				Annotations:       map[string]string{annotations.IngressClassKey: ingressClass},
+			},
+		}
+		if len(weighted) > 0 {
+			value, err := json.Marshal(map[string][]annotations.WeightedBackend{backend.Service.Name: weighted})
+			if err != nil {
+				rs.invalidRules = append(rs.invalidRules, fmt.Sprintf("rule %d: %v", i, err))
+				continue
+			}
+			ing.Annotations[annotations.WeightedBackendsKey] = string(value)
+		}
+		for _, host := range hostnames {
+			ingPaths := make([]v1.HTTPIngressPath, 0, len(paths))
+			for _, p := range paths {
+				p.Backend = backend
+				ingPaths = append(ingPaths, p)
+			}
+			ing.Spec.Rules = append(ing.Spec.Rules, v1.IngressRule{
+				Host:             host,
+				IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{Paths: ingPaths}},
+			})
+		}
+		rs.ingresses = append(rs.ingresses, ing)
+	}
+}
+
+// rulePaths returns the Ingress paths of the matches of a rule. Only path
+// matches are supported.
+func rulePaths(rule gatewayv1.HTTPRouteRule) ([]v1.HTTPIngressPath, error) {
+	if len(rule.Filters) > 0 {
+		return nil, fmt.Errorf("filter %q is not supported", rule.Filters[0].Type)
+	}
+	matches := rule.Matches
+	if len(matches) == 0 {
+		matches = []gatewayv1.HTTPRouteMatch{{}}
+	}
+	var paths []v1.HTTPIngressPath
+	for _, m := range matches {
+		if len(m.Headers) > 0 || len(m.QueryParams) > 0 || m.Method != nil {
+			return nil, fmt.Errorf("header, query parameter and method matches are not supported")
+		}
+		matchType, value := gatewayv1.PathMatchPathPrefix, "/"
+		if m.Path != nil {
+			if m.Path.Type != nil {
+				matchType = *m.Path.Type
+			}
+			if m.Path.Value != nil {
+				value = *m.Path.Value
+			}
+		}
+		var pathType v1.PathType
+		switch matchType {
+		case gatewayv1.PathMatchPathPrefix:
+			pathType = v1.PathTypePrefix
+		case gatewayv1.PathMatchExact:
+			pathType = v1.PathTypeExact
+		default:
+			return nil, fmt.Errorf("path match type %q is not supported", matchType)
+		}
+		paths = append(paths, v1.HTTPIngressPath{Path: value, PathType: &pathType})
+	}
+	return paths, nil
+}
+
+// backendRefError is an error resolving a backend of a rule, reported in the
+// ResolvedRefs condition of the route.
+type backendRefError struct {
+	reason  string
+	message string
+}
+
+// ruleBackends returns the Ingress backend of a rule, and the weighted
+// backends that split its traffic if it has several backends.
+func ruleBackends(namespace string, rule gatewayv1.HTTPRouteRule) (v1.IngressBackend, []annotations.WeightedBackend, *backendRefError) {
+	if len(rule.BackendRefs) == 0 {
+		return v1.IngressBackend{}, nil, &backendRefError{reasonBackendNotFound, "the rule has no backendRefs"}
+	}
+	var weighted []annotations.WeightedBackend
+	for _, ref := range rule.BackendRefs {
+		if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != kindService) {
+			return v1.IngressBackend{}, nil, &backendRefError{reasonInvalidKind, fmt.Sprintf("backend %q is not a Service", ref.Name)}
+		}
+		if ref.Namespace != nil && string(*ref.Namespace) != namespace {
+			return v1.IngressBackend{}, nil, &backendRefError{reasonRefNotPermitted, fmt.Sprintf("Service %s/%s is not in the namespace of the route", *ref.Namespace, ref.Name)}
+		}
+		if ref.Port == nil {
+			return v1.IngressBackend{}, nil, &backendRefError{reasonUnsupportedValue, fmt.Sprintf("Service %q has no port", ref.Name)}
+		}
+		if len(ref.Filters) > 0 {
+			return v1.IngressBackend{}, nil, &backendRefError{reasonUnsupportedValue, fmt.Sprintf("filter %q of Service %q is not supported", ref.Filters[0].Type, ref.Name)}
+		}
+		weight := int64(1)
+		if ref.Weight != nil {
+			weight = int64(*ref.Weight)
+		}
+		weighted = append(weighted, annotations.WeightedBackend{
+			Service: v1.IngressServiceBackend{Name: string(ref.Name), Port: v1.ServiceBackendPort{Number: int32(*ref.Port)}},
+			Weight:  weight,
+		})
+	}
+	backend := v1.IngressBackend{Service: &weighted[0].Service}
+	if len(weighted) == 1 {
+		return backend, nil, nil
+	}
+	scaleWeights(weighted)
+	return backend, weighted, nil
+}
+
+// scaleWeights scales the weights of the backends down to the range [0, 1000]
+// of the weighted backends annotation, from the range [0, 1000000] of the
+// Gateway API.
+func scaleWeights(weighted []annotations.WeightedBackend) {
+	var max int64
+	for _, b := range weighted {
+		if b.Weight > max {
+			max = b.Weight
+		}
+	}
+	if max <= maxWeight {
+		return
+	}
+	for i := range weighted {
+		weighted[i].Weight = (weighted[i].Weight*maxWeight + max/2) / max
+	}
+}
+
+// ingresses returns the Ingresses of the rules of the attached routes, oldest
+// route first, followed by the Ingress of the Gateway itself, which sets the
+// default backend of the load balancer.
+func (gs *gatewayState) ingresses() []*v1.Ingress {
+	var ings []*v1.Ingress
+	for _, rs := range gs.routes {
+		ings = append(ings, rs.ingresses...)
+	}
+	return append(ings, gs.defaultBackendIngress())
+}
+
+// defaultBackendIngress returns an Ingress without rules, which is translated
+// to the system default backend.
+func (gs *gatewayState) defaultBackendIngress() *v1.Ingress {
+	return &v1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   gs.gateway.Namespace,
+			Name:        gs.gateway.Name + "-default",
+			Annotations: map[string]string{annotations.IngressClassKey: gs.ingressClass},
+		},
+	}
+}
+
+// loadBalancerIngress returns the Ingress that stands for the Gateway in the
+// L7 load balancer pool. It has the namespace, name, UID and annotations of
+// the Gateway, the Ingress class of its GatewayClass, and the TLS Secrets of
+// its valid HTTPS Listeners.
+func (gs *gatewayState) loadBalancerIngress() *v1.Ingress {
+	return gatewayIngress(gs.gateway, gs.ingressClass, gs.listeners)
+}
+
+func gatewayIngress(gw *gatewayv1.Gateway, ingressClass string, listeners []*listenerState) *v1.Ingress {
+	ing := &v1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   gw.Namespace,
+			Name:        gw.Name,
+			UID:         gw.UID,
+			Annotations: map[string]string{},
+		},
+	}
+	for k, v := range gw.Annotations {
+		ing.Annotations[k] = v
+	}
+	ing.Annotations[annotations.IngressClassKey] = ingressClass
+
+	seen := map[string]bool{}
+	for _, ls := range listeners {
+		if !ls.valid || ls.listener.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		for _, ref := range ls.listener.TLS.CertificateRefs {
+			if !seen[string(ref.Name)] {
+				seen[string(ref.Name)] = true
+				ing.Spec.TLS = append(ing.Spec.TLS, v1.IngressTLS{SecretName: string(ref.Name)})
+			}
+		}
+	}
+	return ing
+}
+
+// allowHTTP returns true if the Gateway has a valid HTTP Listener.
+func (gs *gatewayState) allowHTTP() bool {
+	for _, ls := range gs.listeners {
+		if ls.valid && ls.listener.Protocol == gatewayv1.HTTPProtocolType {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testGateway() *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "gw", UID: "gw-uid", Generation: 2},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "gke-l7-gxlb",
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:          "http",
+					Hostname:      ptr(gatewayv1.Hostname("*.example.com")),
+					Port:          80,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: ptr(gatewayv1.NamespacesFromAll)}},
+				},
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "cert"}}},
+				},
+				{
+					Name:     "other-port",
+					Port:     8080,
+					Protocol: gatewayv1.HTTPProtocolType,
+				},
+			},
+		},
+	}
+}
+
+func testRoute(namespace, name string, created time.Time, ref gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules ...gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created), Generation: 1},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{ref}},
+			Hostnames:       hostnames,
+			Rules:           rules,
+		},
+	}
+}
+
+func backendRule(path string, backends ...gatewayv1.HTTPBackendRef) gatewayv1.HTTPRouteRule {
+	return gatewayv1.HTTPRouteRule{
+		Matches:     []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: ptr(gatewayv1.PathMatchPathPrefix), Value: ptr(path)}}},
+		BackendRefs: backends,
+	}
+}
+
+func serviceRef(name string, port gatewayv1.PortNumber) gatewayv1.HTTPBackendRef {
+	return weightedServiceRef(name, port, nil)
+}
+
+func weightedServiceRef(name string, port gatewayv1.PortNumber, weight *int32) gatewayv1.HTTPBackendRef {
+	return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: ptr(port)},
+		Weight:                 weight,
+	}}
+}
+
+func TestValidateListeners(t *testing.T) {
+	state := newGatewayState(testGateway(), annotations.GceIngressClass, nil)
+	for i, want := range []bool{true, true, false} {
+		if got := state.listeners[i].valid; got != want {
+			t.Errorf("listener %q valid = %t, want %t (conditions %+v)", state.listeners[i].listener.Name, got, want, state.listeners[i].conditions)
+		}
+	}
+	accepted := state.listeners[2].conditions[0]
+	if accepted.Type != conditionAccepted || accepted.Reason != reasonPortUnavailable {
+		t.Errorf("Got condition %+v for a listener on port 8080, want %s=False with reason %s", accepted, conditionAccepted, reasonPortUnavailable)
+	}
+	if !state.allowHTTP() {
+		t.Errorf("allowHTTP() = false, want true")
+	}
+	ing := state.loadBalancerIngress()
+	if ing.Name != "gw" || ing.UID != "gw-uid" || len(ing.Spec.TLS) != 1 || ing.Spec.TLS[0].SecretName != "cert" {
+		t.Errorf("loadBalancerIngress() = %+v, want the Gateway with the TLS Secret cert", ing)
+	}
+}
+
+func TestAttachRoutes(t *testing.T) {
+	now := time.Now()
+	gwRef := gatewayv1.ParentReference{Namespace: ptr(gatewayv1.Namespace("infra")), Name: "gw"}
+	for _, tc := range []struct {
+		desc       string
+		route      *gatewayv1.HTTPRoute
+		wantReason string
+		wantHosts  []string
+	}{
+		{
+			desc:      "route attached to all listeners",
+			route:     testRoute("infra", "all", now, gatewayv1.ParentReference{Name: "gw"}, []gatewayv1.Hostname{"foo.example.com"}),
+			wantHosts: []string{"foo.example.com"},
+		},
+		{
+			desc:      "route of another namespace attached to the http listener",
+			route:     testRoute("app", "http", now, gatewayv1.ParentReference{Namespace: ptr(gatewayv1.Namespace("infra")), Name: "gw", SectionName: ptr(gatewayv1.SectionName("http"))}, nil),
+			wantHosts: []string{"*.example.com"},
+		},
+		{
+			desc:       "route of another namespace not allowed by the https listener",
+			route:      testRoute("app", "https", now, gatewayv1.ParentReference{Namespace: ptr(gatewayv1.Namespace("infra")), Name: "gw", SectionName: ptr(gatewayv1.SectionName("https"))}, nil),
+			wantReason: reasonNotAllowedByListeners,
+		},
+		{
+			desc:       "route of a missing listener",
+			route:      testRoute("infra", "missing", now, gatewayv1.ParentReference{Name: "gw", SectionName: ptr(gatewayv1.SectionName("missing"))}, nil),
+			wantReason: reasonNoMatchingParent,
+		},
+		{
+			desc:       "route of an invalid listener",
+			route:      testRoute("infra", "invalid", now, gatewayv1.ParentReference{Name: "gw", SectionName: ptr(gatewayv1.SectionName("other-port"))}, nil),
+			wantReason: reasonNotAllowedByListeners,
+		},
+		{
+			desc:       "route with a hostname of no listener",
+			route:      testRoute("app", "hostname", now, gwRef, []gatewayv1.Hostname{"foo.other.com"}),
+			wantReason: reasonNoMatchingListenerHostname,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			state := newGatewayState(testGateway(), annotations.GceIngressClass, []*gatewayv1.HTTPRoute{tc.route})
+			if len(state.routes) != 1 || len(state.routes[0].parents) != 1 {
+				t.Fatalf("Got routes %+v, want the route with one parent", state.routes)
+			}
+			parent := state.routes[0].parents[0]
+			if parent.reason != tc.wantReason {
+				t.Errorf("Got reason %q (%s), want %q", parent.reason, parent.message, tc.wantReason)
+			}
+			if diff := cmp.Diff(tc.wantHosts, parent.hostnames); diff != "" {
+				t.Errorf("Got unexpected hostnames (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	other := testRoute("infra", "other", now, gatewayv1.ParentReference{Name: "other-gw"}, nil)
+	if state := newGatewayState(testGateway(), annotations.GceIngressClass, []*gatewayv1.HTTPRoute{other}); len(state.routes) != 0 {
+		t.Errorf("Got routes %+v, want none for a route of another Gateway", state.routes)
+	}
+}
+
+func TestTranslateRules(t *testing.T) {
+	now := time.Now()
+	headerRule := backendRule("/h", serviceRef("h", 80))
+	headerRule.Matches[0].Headers = []gatewayv1.HTTPHeaderMatch{{Name: "version", Value: "2"}}
+	crossNamespaceRule := backendRule("/x", serviceRef("x", 80))
+	crossNamespaceRule.BackendRefs[0].Namespace = ptr(gatewayv1.Namespace("app"))
+	route := testRoute("infra", "shop", now, gatewayv1.ParentReference{Name: "gw", SectionName: ptr(gatewayv1.SectionName("https"))}, []gatewayv1.Hostname{"shop.com"},
+		backendRule("/a", serviceRef("a", 80)),
+		backendRule("/b", weightedServiceRef("b1", 80, ptr(int32(1))), weightedServiceRef("b2", 80, ptr(int32(3)))),
+		headerRule,
+		crossNamespaceRule,
+	)
+	older := testRoute("app", "older", now.Add(-time.Minute), gatewayv1.ParentReference{Namespace: ptr(gatewayv1.Namespace("infra")), Name: "gw", SectionName: ptr(gatewayv1.SectionName("http"))}, []gatewayv1.Hostname{"api.example.com"},
+		backendRule("/", serviceRef("api", 8080)),
+	)
+
+	state := newGatewayState(testGateway(), annotations.GceL7ILBIngressClass, []*gatewayv1.HTTPRoute{route, older})
+	ings := state.ingresses()
+	var names []string
+	for _, ing := range ings {
+		names = append(names, ing.Namespace+"/"+ing.Name)
+		if got := ing.Annotations[annotations.IngressClassKey]; got != annotations.GceL7ILBIngressClass {
+			t.Errorf("Got Ingress class %q on %s, want %q", got, ing.Name, annotations.GceL7ILBIngressClass)
+		}
+	}
+	// The rules of the oldest route come first, and the Ingress of the
+	// default backend last.
+	wantNames := []string{"app/older-0", "infra/shop-0", "infra/shop-1", "infra/gw-default"}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Fatalf("Got unexpected Ingresses (-want +got):\n%s", diff)
+	}
+
+	pathType := v1.PathTypePrefix
+	wantRules := []v1.IngressRule{{
+		Host: "shop.com",
+		IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{Paths: []v1.HTTPIngressPath{{
+			Path:     "/b",
+			PathType: &pathType,
+			Backend:  v1.IngressBackend{Service: &v1.IngressServiceBackend{Name: "b1", Port: v1.ServiceBackendPort{Number: 80}}},
+		}}}},
+	}}
+	if diff := cmp.Diff(wantRules, ings[2].Spec.Rules); diff != "" {
+		t.Errorf("Got unexpected rules of the weighted rule (-want +got):\n%s", diff)
+	}
+	var weighted map[string][]annotations.WeightedBackend
+	if err := json.Unmarshal([]byte(ings[2].Annotations[annotations.WeightedBackendsKey]), &weighted); err != nil {
+		t.Fatalf("Failed to parse the weighted backends annotation: %v", err)
+	}
+	if b := weighted["b1"]; len(b) != 2 || b[0].Weight != 1 || b[1].Weight != 3 {
+		t.Errorf("Got weighted backends %+v, want b1 and b2 with weights 1 and 3", weighted)
+	}
+
+	rs := state.ruleOwners[types.NamespacedName{Namespace: "infra", Name: "shop-1"}]
+	if rs == nil || rs.route.Name != "shop" {
+		t.Fatalf("Got owner %+v of shop-1, want route shop", rs)
+	}
+	if len(rs.invalidRules) != 1 {
+		t.Errorf("Got invalid rules %v, want the rule with a header match", rs.invalidRules)
+	}
+	if rs.refReason != reasonRefNotPermitted || len(rs.refMessages) != 1 {
+		t.Errorf("Got ref reason %q and messages %v, want %q for the backend of another namespace", rs.refReason, rs.refMessages, reasonRefNotPermitted)
+	}
+}
+
+func TestScaleWeights(t *testing.T) {
+	weighted := []annotations.WeightedBackend{{Weight: 500000}, {Weight: 1000000}, {Weight: 0}}
+	scaleWeights(weighted)
+	var got []int64
+	for _, b := range weighted {
+		got = append(got, b.Weight)
+	}
+	if diff := cmp.Diff([]int64{500, 1000, 0}, got); diff != "" {
+		t.Errorf("Got unexpected weights (-want +got):\n%s", diff)
+	}
+}
+
+func TestIntersectHostnames(t *testing.T) {
+	for _, tc := range []struct {
+		listener *gatewayv1.Hostname
+		route    []gatewayv1.Hostname
+		want     []string
+	}{
+		{nil, nil, []string{""}},
+		{nil, []gatewayv1.Hostname{"a.com"}, []string{"a.com"}},
+		{ptr(gatewayv1.Hostname("a.com")), nil, []string{"a.com"}},
+		{ptr(gatewayv1.Hostname("*.a.com")), []gatewayv1.Hostname{"x.a.com", "b.com", "*.x.a.com"}, []string{"x.a.com", "*.x.a.com"}},
+		{ptr(gatewayv1.Hostname("x.a.com")), []gatewayv1.Hostname{"*.a.com"}, []string{"x.a.com"}},
+		{ptr(gatewayv1.Hostname("a.com")), []gatewayv1.Hostname{"b.com"}, nil},
+	} {
+		got := intersectHostnames(tc.listener, tc.route)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("intersectHostnames(%v, %v) mismatch (-want +got):\n%s", tc.listener, tc.route, diff)
+		}
+	}
+}
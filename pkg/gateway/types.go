@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+// The controller reads and writes the gateway.networking.k8s.io/v1 resources
+// with the dynamic client, and converts them from and to the types of the
+// upstream Gateway API.
+
+const (
+	// GroupName is the API group of the Gateway API.
+	GroupName = gatewayv1.GroupName
+	// ControllerName is the controller name of the GatewayClasses managed by
+	// this controller.
+	ControllerName gatewayv1.GatewayController = "networking.gke.io/gateway"
+	// FinalizerKey is the finalizer added to the Gateways of this controller
+	// until their load balancer is deleted.
+	FinalizerKey = "networking.gke.io/gateway-finalizer"
+
+	kindGateway   gatewayv1.Kind = "Gateway"
+	kindHTTPRoute gatewayv1.Kind = "HTTPRoute"
+	kindService   gatewayv1.Kind = "Service"
+	kindSecret    gatewayv1.Kind = "Secret"
+)
+
+var (
+	// GatewayClassGVR is the resource of GatewayClasses.
+	GatewayClassGVR = gatewayv1.SchemeGroupVersion.WithResource("gatewayclasses")
+	// GatewayGVR is the resource of Gateways.
+	GatewayGVR = gatewayv1.SchemeGroupVersion.WithResource("gateways")
+	// HTTPRouteGVR is the resource of HTTPRoutes.
+	HTTPRouteGVR = gatewayv1.SchemeGroupVersion.WithResource("httproutes")
+)
@@ -27,15 +27,23 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/healthcheck"
 	"k8s.io/klog/v2"
 )
 
+// healthChecksResource is the collection of the health checks, as used in
+// resource paths.
+const healthChecksResource = "healthChecks"
+
 // HealthChecks manages health checks.
 type HealthChecks struct {
 	cloud HealthCheckProvider
@@ -48,6 +56,11 @@ type HealthChecks struct {
 	serviceGetter     ServiceGetter
 	clusterInfo       healthcheck.ClusterInfo
 	healthcheckFlags  HealthcheckFlags
+	// plan records the GCE mutations instead of making them in plan mode.
+	plan *plan.Recorder
+	// drift detects the changes made to the health checks outside of the
+	// controller.
+	drift *drift.Detector
 }
 
 type HealthcheckFlags struct {
@@ -60,6 +73,14 @@ type HealthcheckFlags struct {
 // cloud: the cloud object implementing SingleHealthCheck.
 // defaultHealthCheckPath: is the HTTP path to use for health checks.
 func NewHealthChecker(cloud HealthCheckProvider, healthCheckPath string, defaultBackendSvc types.NamespacedName, recorderGetter RecorderGetter, serviceGetter ServiceGetter, flags HealthcheckFlags) *HealthChecks {
+	return NewHealthCheckerWithPlan(cloud, healthCheckPath, defaultBackendSvc, recorderGetter, serviceGetter, flags, nil, nil)
+}
+
+// NewHealthCheckerWithPlan creates a new health checker.
+// It is similar to NewHealthChecker() but records the GCE mutations instead
+// of making them if planRecorder is non-nil, and detects the changes made
+// outside of the controller if driftDetector is non-nil.
+func NewHealthCheckerWithPlan(cloud HealthCheckProvider, healthCheckPath string, defaultBackendSvc types.NamespacedName, recorderGetter RecorderGetter, serviceGetter ServiceGetter, flags HealthcheckFlags, planRecorder *plan.Recorder, driftDetector *drift.Detector) *HealthChecks {
 	ci := generateClusterInfo(cloud.(*gce.Cloud))
 	return &HealthChecks{
 		cloud:             cloud,
@@ -69,6 +90,8 @@ func NewHealthChecker(cloud HealthCheckProvider, healthCheckPath string, default
 		serviceGetter:     serviceGetter,
 		clusterInfo:       ci,
 		healthcheckFlags:  flags,
+		plan:              planRecorder,
+		drift:             driftDetector,
 	}
 }
 
@@ -157,7 +180,7 @@ func (h *HealthChecks) SyncServicePort(sp *utils.ServicePort, probe *v1.Probe, b
 	hc := h.new(*sp, spLogger)
 	if sp.THCConfiguration.THCOptInOnSvc {
 		spLogger.Info("ServicePort has Transparent Health Checks enabled")
-		return h.sync(hc, nil, sp.THCConfiguration, spLogger)
+		return h.sync(hc, nil, sp.THCConfiguration, sp.DriftPolicy, spLogger)
 	}
 	if probe != nil {
 		spLogger.Info("Applying httpGet settings of readinessProbe to health check on port", "port", fmt.Sprintf("%+v", sp))
@@ -171,7 +194,7 @@ func (h *HealthChecks) SyncServicePort(sp *utils.ServicePort, probe *v1.Probe, b
 	if bchcc != nil {
 		spLogger.Info("ServicePort has BackendConfig healthcheck override")
 	}
-	return h.sync(hc, bchcc, sp.THCConfiguration, spLogger)
+	return h.sync(hc, bchcc, sp.THCConfiguration, sp.DriftPolicy, spLogger)
 }
 
 // emitTHCEvents emits Events about successful or attempted THC configuration.
@@ -231,7 +254,9 @@ func (h *HealthChecks) shouldRecalculateHC(existingHC *translator.HealthCheck, b
 // sync retrieves a health check based on port, checks type and settings and updates/creates if necessary.
 // sync is only called by the backends.Add func - it's not a pool like other resources.
 // We assume that backendConfigHCConfig cannot be non-nil and thcOptIn be true simultaneously.
-func (h *HealthChecks) sync(hc *translator.HealthCheck, backendConfigHCConfig *backendconfigv1.HealthCheckConfig, thcConf utils.THCConfiguration, spLogger klog.Logger) (string, error) {
+// Changes made to the health check outside of the controller are not reverted
+// with DriftPolicyAlert.
+func (h *HealthChecks) sync(hc *translator.HealthCheck, backendConfigHCConfig *backendconfigv1.HealthCheckConfig, thcConf utils.THCConfiguration, driftPolicy annotations.DriftPolicy, spLogger klog.Logger) (string, error) {
 	hcLogger := spLogger.WithValues("healthCheckName", hc.Name)
 	if backendConfigHCConfig != nil && thcConf.THCOptInOnSvc {
 		hcLogger.Info("BackendConfig exists and thcOptIn true simultaneously. Ignoring transparent health check.")
@@ -249,6 +274,9 @@ func (h *HealthChecks) sync(hc *translator.HealthCheck, backendConfigHCConfig *b
 	existingHC, err := h.Get(hc.Name, hc.Version(), scope, hcLogger)
 	if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
 		hcLogger.Info("Health check does not exist, creating", "healthCheck", fmt.Sprintf("%+v", hc), "backendConfigHCConfig", fmt.Sprintf("%+v", backendConfigHCConfig))
+		if h.plan.Enabled() {
+			return h.planCreate(hc, backendConfigHCConfig, scope, hcLogger)
+		}
 		if err = h.create(hc, backendConfigHCConfig, hcLogger); err != nil {
 			hcLogger.Error(err, "Health check creation error")
 			return "", err
@@ -315,18 +343,123 @@ func (h *HealthChecks) sync(hc *translator.HealthCheck, backendConfigHCConfig *b
 				hcLogger.Info(message)
 			}
 		}
+		if !h.revertDrift(filter(existingHC), filter(hc), scope, driftPolicy, hcLogger) {
+			return existingHC.SelfLink, nil
+		}
+		if h.plan.Enabled() {
+			return existingHC.SelfLink, h.planUpdate(existingHC, hc, scope, hcLogger)
+		}
 		err := h.update(hc, hcLogger)
 		if err != nil {
 			hcLogger.Error(err, "Health check update error")
+		} else {
+			h.applied(filter(hc), scope)
 		}
 		h.emitTHCEvents(hc, thcConf.THCEvents, hcLogger)
 		return existingHC.SelfLink, err
 	}
 
 	hcLogger.Info("Health check already exists and needs no update")
+	if !h.plan.Enabled() {
+		h.applied(filter(hc), scope)
+	}
 	return existingHC.SelfLink, nil
 }
 
+// revertDrift reports the changes made to the health check outside of the
+// controller, and returns false if they must not be reverted with the drift
+// policy.
+func (h *HealthChecks) revertDrift(existingHC, hc *translator.HealthCheck, scope meta.KeyType, policy annotations.DriftPolicy, hcLogger klog.Logger) bool {
+	if h.drift == nil {
+		return true
+	}
+	current, err := existingHC.ToComputeHealthCheck()
+	if err != nil {
+		return true
+	}
+	desired, err := hc.ToComputeHealthCheck()
+	if err != nil {
+		return true
+	}
+	key, err := composite.CreateKey(h.cloud.(*gce.Cloud), hc.Name, scope)
+	if err != nil {
+		return true
+	}
+	d := h.drift.Detect(healthChecksResource, key, current, desired)
+	if d == nil {
+		return true
+	}
+	revert := d.Revert(policy)
+	if !revert && d.Repeated {
+		return false
+	}
+	message := fmt.Sprintf("Health check %q was changed outside of the controller, reverting: %s", hc.Name, d)
+	if !revert {
+		message = fmt.Sprintf("Health check %q was changed outside of the controller, not reverting with drift policy %s: %s", hc.Name, policy, d)
+	}
+	if hc.Service != nil {
+		h.recorderGetter.Recorder(hc.Service.Namespace).Event(hc.Service, v1.EventTypeWarning, events.DriftDetected, message)
+	}
+	hcLogger.Info(message)
+	return revert
+}
+
+// applied records the health check as the desired state last applied by the
+// controller.
+func (h *HealthChecks) applied(hc *translator.HealthCheck, scope meta.KeyType) {
+	if h.drift == nil {
+		return
+	}
+	desired, err := hc.ToComputeHealthCheck()
+	if err != nil {
+		return
+	}
+	key, err := composite.CreateKey(h.cloud.(*gce.Cloud), hc.Name, scope)
+	if err != nil {
+		return
+	}
+	h.drift.Applied(healthChecksResource, key, desired)
+}
+
+// planCreate records the creation of the health check in plan mode, and
+// returns the self link it would have.
+func (h *HealthChecks) planCreate(hc *translator.HealthCheck, bchcc *backendconfigv1.HealthCheckConfig, scope meta.KeyType, hcLogger klog.Logger) (string, error) {
+	if bchcc != nil {
+		hc.UpdateFromBackendConfig(bchcc, hcLogger)
+	}
+	desired, err := hc.ToComputeHealthCheck()
+	if err != nil {
+		return "", err
+	}
+	cloud := h.cloud.(*gce.Cloud)
+	key, err := composite.CreateKey(cloud, hc.Name, scope)
+	if err != nil {
+		return "", err
+	}
+	hcLogger.V(2).Info("Skipping health check creation in plan mode")
+	h.plan.Record(plan.Create, healthChecksResource, key, nil, desired)
+	return plan.SelfLink(cloud.ProjectID(), healthChecksResource, key), nil
+}
+
+// planUpdate records the update of the health check in plan mode.
+func (h *HealthChecks) planUpdate(existingHC, hc *translator.HealthCheck, scope meta.KeyType, hcLogger klog.Logger) error {
+	current, err := existingHC.ToComputeHealthCheck()
+	if err != nil {
+		return err
+	}
+	desired, err := hc.ToComputeHealthCheck()
+	if err != nil {
+		return err
+	}
+	key, err := composite.CreateKey(h.cloud.(*gce.Cloud), hc.Name, scope)
+	if err != nil {
+		return err
+	}
+	hcLogger.V(2).Info("Skipping health check update in plan mode")
+	h.plan.Record(plan.Update, healthChecksResource, key, current, desired)
+	return nil
+}
+
 func (h *HealthChecks) isDescriptionOnlyUpdateNeeded(changes *fieldDiffs, existingHC *translator.HealthCheck, backendConfigHCConfig *backendconfigv1.HealthCheckConfig, hcLogger klog.Logger) bool {
 	if flags.F.EnableUpdateCustomHealthCheckDescription {
 		// BackendConfig exists, but the health check has had a wrong description.
@@ -493,6 +626,18 @@ func (h *HealthChecks) getHealthCheckLink(name string, version meta.Version, sco
 // Delete deletes the health check by port.
 func (h *HealthChecks) Delete(name string, scope meta.KeyType, beLogger klog.Logger) error {
 	hcLogger := beLogger.WithValues("healthCheckName", name)
+	if h.plan.Enabled() {
+		if _, err := h.Get(name, meta.VersionGA, scope, hcLogger); err != nil {
+			return nil
+		}
+		key, err := composite.CreateKey(h.cloud.(*gce.Cloud), name, scope)
+		if err != nil {
+			return err
+		}
+		hcLogger.V(2).Info("Skipping health check deletion in plan mode")
+		h.plan.Record(plan.Delete, healthChecksResource, key, nil, nil)
+		return nil
+	}
 	if scope == meta.Regional {
 		cloud := h.cloud.(*gce.Cloud)
 		key, err := composite.CreateKey(cloud, name, meta.Regional)
@@ -510,6 +655,7 @@ func (h *HealthChecks) Delete(name string, scope meta.KeyType, beLogger klog.Log
 			}
 			return err
 		}
+		h.drift.Forget(healthChecksResource, key)
 		return nil
 	}
 
@@ -524,6 +670,7 @@ func (h *HealthChecks) Delete(name string, scope meta.KeyType, beLogger klog.Log
 		}
 		return err
 	}
+	h.drift.Forget(healthChecksResource, meta.GlobalKey(name))
 	return nil
 }
 
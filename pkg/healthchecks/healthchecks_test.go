@@ -447,7 +447,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 
 	// Change to HTTPS
 	hc.Type = string(annotations.ProtocolHTTPS)
-	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, klog.TODO())
+	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, annotations.DriftPolicyReconcile, klog.TODO())
 	if err != nil {
 		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
 	}
@@ -465,7 +465,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 
 	// Change to HTTP2
 	hc.Type = string(annotations.ProtocolHTTP2)
-	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, klog.TODO())
+	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, annotations.DriftPolicyReconcile, klog.TODO())
 	if err != nil {
 		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
 	}
@@ -484,7 +484,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	// Change to NEG Health Check
 	hc.ForNEG = true
 	hc.PortSpecification = "USE_SERVING_PORT"
-	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, klog.TODO())
+	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, annotations.DriftPolicyReconcile, klog.TODO())
 
 	if err != nil {
 		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
@@ -505,7 +505,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	hc.Port = 3000
 	hc.PortSpecification = ""
 
-	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, klog.TODO())
+	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{}, annotations.DriftPolicyReconcile, klog.TODO())
 	if err != nil {
 		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
 	}
@@ -570,7 +570,7 @@ func TestEnableTHC(t *testing.T) {
 	translator.OverwriteWithTHC(hc, thcPort, klog.TODO())
 	hc.Name = oldName
 	// Enable Transparent Health Checks
-	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{THCOptInOnSvc: true}, klog.TODO())
+	_, err = healthChecks.sync(hc, nil, utils.THCConfiguration{THCOptInOnSvc: true}, annotations.DriftPolicyReconcile, klog.TODO())
 	if err != nil {
 		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
 	}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingsyncstatus
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ingsyncstatusv1beta1 "k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1"
+	ingsyncstatusclient "k8s.io/ingress-gce/pkg/ingsyncstatus/client/clientset/versioned"
+)
+
+// TimestampRefreshInterval is the minimum interval between the updates of an
+// IngressSyncStatus that would only change its timestamps, so that the
+// resyncs of an Ingress with the same result do not write it every time.
+const TimestampRefreshInterval = 10 * time.Minute
+
+// Ensure creates or updates the IngressSyncStatus of the Ingress, with the
+// status set by update from the current one. The IngressSyncStatus has the
+// name and namespace of the Ingress, which owns it so that it is deleted
+// with the Ingress. Updates that only change the timestamps of the status
+// are skipped for TimestampRefreshInterval after the last sync time.
+func Ensure(client ingsyncstatusclient.Interface, ing *v1.Ingress, update func(status *ingsyncstatusv1beta1.IngressSyncStatusStatus)) error {
+	statusClient := client.NetworkingV1beta1().IngressSyncStatuses(ing.Namespace)
+	current, err := statusClient.Get(context.TODO(), ing.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		syncStatus := &ingsyncstatusv1beta1.IngressSyncStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            ing.Name,
+				Namespace:       ing.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ing, v1.SchemeGroupVersion.WithKind("Ingress"))},
+			},
+		}
+		update(&syncStatus.Status)
+		_, err = statusClient.Create(context.TODO(), syncStatus, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	syncStatus := current.DeepCopy()
+	update(&syncStatus.Status)
+	if onlyTimestampsChanged(current.Status, syncStatus.Status) && syncStatus.Status.LastSyncTime.Sub(current.Status.LastSyncTime.Time) < TimestampRefreshInterval {
+		return nil
+	}
+	_, err = statusClient.Update(context.TODO(), syncStatus, metav1.UpdateOptions{})
+	return err
+}
+
+// onlyTimestampsChanged returns true if the statuses only differ by their
+// last sync time and the time of their last error.
+func onlyTimestampsChanged(old, cur ingsyncstatusv1beta1.IngressSyncStatusStatus) bool {
+	withoutTimestamps := func(status ingsyncstatusv1beta1.IngressSyncStatusStatus) *ingsyncstatusv1beta1.IngressSyncStatusStatus {
+		copied := status.DeepCopy()
+		copied.LastSyncTime = metav1.Time{}
+		if copied.LastError != nil {
+			copied.LastError.Time = metav1.Time{}
+		}
+		return copied
+	}
+	return equality.Semantic.DeepEqual(withoutTimestamps(old), withoutTimestamps(cur))
+}
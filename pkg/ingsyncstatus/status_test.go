@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingsyncstatus
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ingsyncstatusv1beta1 "k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1"
+	"k8s.io/ingress-gce/pkg/ingsyncstatus/client/clientset/versioned/fake"
+)
+
+func TestEnsureSkipsTimestampOnlyUpdates(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	ing := &v1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "default", UID: "uid"}}
+	start := time.Now()
+
+	for _, step := range []struct {
+		desc       string
+		elapsed    time.Duration
+		result     ingsyncstatusv1beta1.SyncResult
+		errMessage string
+		wantWrite  bool
+	}{
+		{desc: "first sync", result: ingsyncstatusv1beta1.SyncResultSuccess, wantWrite: true},
+		{desc: "resync with the same result", elapsed: time.Minute, result: ingsyncstatusv1beta1.SyncResultSuccess},
+		{desc: "failed sync", elapsed: 2 * time.Minute, result: ingsyncstatusv1beta1.SyncResultError, errMessage: "quota exceeded", wantWrite: true},
+		{desc: "resync with the same error", elapsed: 3 * time.Minute, result: ingsyncstatusv1beta1.SyncResultError, errMessage: "quota exceeded"},
+		{desc: "resync with another error", elapsed: 4 * time.Minute, result: ingsyncstatusv1beta1.SyncResultError, errMessage: "backend not found", wantWrite: true},
+		{desc: "resync after the refresh interval", elapsed: 4*time.Minute + TimestampRefreshInterval, result: ingsyncstatusv1beta1.SyncResultError, errMessage: "backend not found", wantWrite: true},
+	} {
+		client.ClearActions()
+		now := metav1.NewTime(start.Add(step.elapsed))
+		err := Ensure(client, ing, func(status *ingsyncstatusv1beta1.IngressSyncStatusStatus) {
+			status.LastSyncTime = now
+			status.LastSyncResult = step.result
+			if step.errMessage != "" {
+				status.LastError = &ingsyncstatusv1beta1.SyncError{Class: "Error", Message: step.errMessage, Time: now}
+			}
+		})
+		if err != nil {
+			t.Fatalf("%s: Ensure() = %v", step.desc, err)
+		}
+		var gotWrite bool
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "create" || action.GetVerb() == "update" {
+				gotWrite = true
+			}
+		}
+		if gotWrite != step.wantWrite {
+			t.Errorf("%s: wrote the IngressSyncStatus = %t, want %t", step.desc, gotWrite, step.wantWrite)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4annotations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FirewallPolicyAnnotationKey is annotated on a L4 LoadBalancer Service to
+	// configure the firewall rules of the traffic to the nodes, instead of the
+	// single rule allowing the loadBalancerSourceRanges to all service ports.
+	// The value is a JSON FirewallPolicy, e.g.
+	// {"rules":[{"name":"deny","action":"deny","priority":900,"sourceRanges":["10.1.0.0/16"]},
+	//  {"name":"web","action":"allow","sourceTags":["frontend"],"ports":[{"protocol":"TCP","ports":["80","8080-8090"]}]}]}
+	FirewallPolicyAnnotationKey = "networking.gke.io/l4-firewall-policy"
+	// FirewallPolicyRulesKey is the annotation key used by l4 controller to
+	// record the comma separated names of the firewall rules of the firewall policy.
+	FirewallPolicyRulesKey      = ServiceStatusPrefix + "/" + FirewallPolicyRulesResource
+	FirewallPolicyRulesResource = "firewall-policy-rules"
+
+	// FirewallPolicyActionAllow is the action of the rules allowing traffic.
+	FirewallPolicyActionAllow = "allow"
+	// FirewallPolicyActionDeny is the action of the rules denying traffic.
+	FirewallPolicyActionDeny = "deny"
+
+	// maxFirewallPolicyRules is the maximum number of rules of a firewall policy.
+	maxFirewallPolicyRules = 10
+	// maxFirewallPriority is the lowest priority of a GCE firewall rule.
+	maxFirewallPriority = 65535
+)
+
+// firewallPolicyRuleNameRegex matches the rule names, which are suffixes of
+// the GCE firewall rule names.
+var firewallPolicyRuleNameRegex = regexp.MustCompile(`^[a-z]([a-z0-9]{0,7})$`)
+
+// FirewallPolicy is the value of the FirewallPolicyAnnotationKey annotation.
+type FirewallPolicy struct {
+	Rules []FirewallPolicyRule `json:"rules"`
+}
+
+// FirewallPolicyRule is translated into a GCE firewall rule of the traffic to
+// the nodes of the L4 load balancer.
+type FirewallPolicyRule struct {
+	// Name identifies the rule, at most 8 lowercase letters and digits.
+	Name string `json:"name"`
+	// Action is either "allow" or "deny".
+	Action string `json:"action"`
+	// Priority of the GCE firewall rule, 1000 when unset.
+	Priority int64 `json:"priority,omitempty"`
+	// SourceRanges are the IPv4 ranges the rule applies to. When no source is
+	// set, the rule applies to the loadBalancerSourceRanges of the service.
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+	// SourceTags are the network tags of the VMs the rule applies to, only
+	// for internal load balancers.
+	SourceTags []string `json:"sourceTags,omitempty"`
+	// SourceServiceAccounts are the service accounts of the VMs the rule
+	// applies to, only for internal load balancers. GCE does not allow them with
+	// network tags, so TargetServiceAccounts must be set.
+	SourceServiceAccounts []string `json:"sourceServiceAccounts,omitempty"`
+	// TargetServiceAccounts are the service accounts of the nodes, the rule
+	// targets the nodes with the cluster network tags when unset.
+	TargetServiceAccounts []string `json:"targetServiceAccounts,omitempty"`
+	// Ports the rule applies to, all the ports of the service when empty.
+	Ports []FirewallPolicyPorts `json:"ports,omitempty"`
+}
+
+// FirewallPolicyPorts are the ports or port ranges, e.g. "8080-8090", of a protocol.
+type FirewallPolicyPorts struct {
+	Protocol string   `json:"protocol"`
+	Ports    []string `json:"ports,omitempty"`
+}
+
+// FirewallPolicy returns the firewall policy of the FirewallPolicyAnnotationKey
+// annotation, or nil if the service does not have it. An error is returned if
+// the policy is invalid, or uses sources only supported by internal load
+// balancers when internal is false.
+func (svc *Service) FirewallPolicy(internal bool) (*FirewallPolicy, error) {
+	val, ok := svc.v[FirewallPolicyAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	policy := &FirewallPolicy{}
+	if err := json.Unmarshal([]byte(val), policy); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %w", FirewallPolicyAnnotationKey, err)
+	}
+	if err := policy.validate(internal); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", FirewallPolicyAnnotationKey, err)
+	}
+	return policy, nil
+}
+
+// HasAllowRules returns true if the policy has a rule allowing traffic, in
+// which case it replaces the rule allowing the loadBalancerSourceRanges.
+func (p *FirewallPolicy) HasAllowRules() bool {
+	if p == nil {
+		return false
+	}
+	for _, rule := range p.Rules {
+		if rule.Action == FirewallPolicyActionAllow {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *FirewallPolicy) validate(internal bool) error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rules")
+	}
+	if len(p.Rules) > maxFirewallPolicyRules {
+		return fmt.Errorf("%d rules, at most %d are allowed", len(p.Rules), maxFirewallPolicyRules)
+	}
+	names := make(map[string]bool)
+	for _, rule := range p.Rules {
+		if !firewallPolicyRuleNameRegex.MatchString(rule.Name) {
+			return fmt.Errorf("invalid rule name %q, must be at most 8 lowercase letters and digits starting with a letter", rule.Name)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if err := rule.validate(internal); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *FirewallPolicyRule) validate(internal bool) error {
+	if r.Action != FirewallPolicyActionAllow && r.Action != FirewallPolicyActionDeny {
+		return fmt.Errorf("invalid action %q, must be %q or %q", r.Action, FirewallPolicyActionAllow, FirewallPolicyActionDeny)
+	}
+	if r.Priority < 0 || r.Priority > maxFirewallPriority {
+		return fmt.Errorf("invalid priority %d, must be between 1 and %d", r.Priority, maxFirewallPriority)
+	}
+	for _, sourceRange := range r.SourceRanges {
+		prefix, err := netip.ParsePrefix(sourceRange)
+		if err != nil {
+			return fmt.Errorf("invalid source range %q: %w", sourceRange, err)
+		}
+		if !prefix.Addr().Is4() {
+			return fmt.Errorf("invalid source range %q, only IPv4 ranges are supported", sourceRange)
+		}
+	}
+	if !internal && (len(r.SourceTags) > 0 || len(r.SourceServiceAccounts) > 0) {
+		return fmt.Errorf("source tags and service accounts are only supported by internal load balancers")
+	}
+	if len(r.SourceTags) > 0 && len(r.SourceServiceAccounts) > 0 {
+		return fmt.Errorf("source tags and source service accounts cannot be used together")
+	}
+	if len(r.SourceServiceAccounts) > 0 && len(r.TargetServiceAccounts) == 0 {
+		return fmt.Errorf("source service accounts require target service accounts")
+	}
+	if len(r.SourceTags) > 0 && len(r.TargetServiceAccounts) > 0 {
+		return fmt.Errorf("source tags cannot be used with target service accounts")
+	}
+	for _, ports := range r.Ports {
+		protocol := strings.ToUpper(ports.Protocol)
+		if protocol != "TCP" && protocol != "UDP" {
+			return fmt.Errorf("invalid protocol %q, must be TCP or UDP", ports.Protocol)
+		}
+		for _, port := range ports.Ports {
+			if !validPortRange(port) {
+				return fmt.Errorf("invalid port %q, must be a port or a port range like 8080-8090", port)
+			}
+		}
+	}
+	return nil
+}
+
+// validPortRange returns true if p is a port or an increasing range of ports.
+func validPortRange(p string) bool {
+	start, end, isRange := strings.Cut(p, "-")
+	if !isRange {
+		end = start
+	}
+	startPort, err := strconv.Atoi(start)
+	if err != nil || startPort < 1 || startPort > 65535 {
+		return false
+	}
+	endPort, err := strconv.Atoi(end)
+	if err != nil || endPort < startPort || endPort > 65535 {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4annotations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	api_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFirewallPolicy(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		annotation *string
+		internal   bool
+		want       *FirewallPolicy
+		wantErr    bool
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc:       "deny and allow rules",
+			annotation: strPtr(`{"rules":[{"name":"deny","action":"deny","priority":900,"sourceRanges":["10.1.0.0/16"]},{"name":"web","action":"allow","ports":[{"protocol":"TCP","ports":["80","8080-8090"]}]}]}`),
+			want: &FirewallPolicy{
+				Rules: []FirewallPolicyRule{
+					{Name: "deny", Action: FirewallPolicyActionDeny, Priority: 900, SourceRanges: []string{"10.1.0.0/16"}},
+					{Name: "web", Action: FirewallPolicyActionAllow, Ports: []FirewallPolicyPorts{{Protocol: "TCP", Ports: []string{"80", "8080-8090"}}}},
+				},
+			},
+		},
+		{
+			desc:       "source tags for internal load balancer",
+			annotation: strPtr(`{"rules":[{"name":"frontend","action":"allow","sourceTags":["frontend"]}]}`),
+			internal:   true,
+			want: &FirewallPolicy{
+				Rules: []FirewallPolicyRule{
+					{Name: "frontend", Action: FirewallPolicyActionAllow, SourceTags: []string{"frontend"}},
+				},
+			},
+		},
+		{
+			desc:       "source service accounts for internal load balancer",
+			annotation: strPtr(`{"rules":[{"name":"sa","action":"allow","sourceServiceAccounts":["client@project.iam.gserviceaccount.com"],"targetServiceAccounts":["node@project.iam.gserviceaccount.com"]}]}`),
+			internal:   true,
+			want: &FirewallPolicy{
+				Rules: []FirewallPolicyRule{
+					{
+						Name:                  "sa",
+						Action:                FirewallPolicyActionAllow,
+						SourceServiceAccounts: []string{"client@project.iam.gserviceaccount.com"},
+						TargetServiceAccounts: []string{"node@project.iam.gserviceaccount.com"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "source tags for external load balancer",
+			annotation: strPtr(`{"rules":[{"name":"frontend","action":"allow","sourceTags":["frontend"]}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "source service accounts without target service accounts",
+			annotation: strPtr(`{"rules":[{"name":"sa","action":"allow","sourceServiceAccounts":["client@project.iam.gserviceaccount.com"]}]}`),
+			internal:   true,
+			wantErr:    true,
+		},
+		{
+			desc:       "source tags with target service accounts",
+			annotation: strPtr(`{"rules":[{"name":"sa","action":"allow","sourceTags":["frontend"],"targetServiceAccounts":["node@project.iam.gserviceaccount.com"]}]}`),
+			internal:   true,
+			wantErr:    true,
+		},
+		{
+			desc:       "invalid json",
+			annotation: strPtr(`{"rules":[`),
+			wantErr:    true,
+		},
+		{
+			desc:       "no rules",
+			annotation: strPtr(`{"rules":[]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "invalid rule name",
+			annotation: strPtr(`{"rules":[{"name":"Web-Rule","action":"allow"}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "duplicate rule names",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"allow"},{"name":"web","action":"deny"}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "invalid action",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"reject"}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "invalid priority",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"allow","priority":70000}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "IPv6 source range",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"allow","sourceRanges":["2001:db8::/32"]}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "invalid protocol",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"allow","ports":[{"protocol":"SCTP","ports":["80"]}]}]}`),
+			wantErr:    true,
+		},
+		{
+			desc:       "decreasing port range",
+			annotation: strPtr(`{"rules":[{"name":"web","action":"allow","ports":[{"protocol":"TCP","ports":["90-80"]}]}]}`),
+			wantErr:    true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			svc := &api_v1.Service{}
+			if tC.annotation != nil {
+				svc.ObjectMeta = v1.ObjectMeta{
+					Annotations: map[string]string{FirewallPolicyAnnotationKey: *tC.annotation},
+				}
+			}
+			got, err := FromService(svc).FirewallPolicy(tC.internal)
+			if gotErr := err != nil; gotErr != tC.wantErr {
+				t.Fatalf("FirewallPolicy(%t) returned error %v, want error: %t", tC.internal, err, tC.wantErr)
+			}
+			if diff := cmp.Diff(tC.want, got); diff != "" {
+				t.Errorf("FirewallPolicy(%t) returned unexpected policy (-want +got):\n%s", tC.internal, diff)
+			}
+		})
+	}
+}
+
+func TestFirewallPolicyHasAllowRules(t *testing.T) {
+	var nilPolicy *FirewallPolicy
+	if nilPolicy.HasAllowRules() {
+		t.Errorf("HasAllowRules() = true for a nil policy, want false")
+	}
+	denyOnly := &FirewallPolicy{Rules: []FirewallPolicyRule{{Name: "deny", Action: FirewallPolicyActionDeny}}}
+	if denyOnly.HasAllowRules() {
+		t.Errorf("HasAllowRules() = true for a policy with only deny rules, want false")
+	}
+	withAllow := &FirewallPolicy{Rules: []FirewallPolicyRule{{Name: "deny", Action: FirewallPolicyActionDeny}, {Name: "web", Action: FirewallPolicyActionAllow}}}
+	if !withAllow.HasAllowRules() {
+		t.Errorf("HasAllowRules() = false for a policy with an allow rule, want true")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4resources
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/cloud-provider-gcp/providers/gce"
+	"k8s.io/ingress-gce/pkg/firewalls"
+	"k8s.io/ingress-gce/pkg/l4annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+// firewallPolicySyncer ensures and deletes the firewall rules of the L4
+// firewall policy of a service, for both ILB and NetLB services.
+type firewallPolicySyncer struct {
+	service  *corev1.Service
+	cloud    *gce.Cloud
+	namer    namer.L4ResourcesNamer
+	recorder record.EventRecorder
+	logger   klog.Logger
+}
+
+// ensure ensures the firewall rules of the policy, and deletes the rules which
+// are no longer needed. Rules are only deleted once the new ones are ensured,
+// so that services migrate between the rule allowing the load balancer source
+// ranges and the policy, or between versions of a policy rule, without
+// dropping traffic. It returns the resource in error along with the error.
+func (s *firewallPolicySyncer) ensure(policy *l4annotations.FirewallPolicy, nodesParams *firewalls.FirewallParams, resourceUpdates *ResourceUpdates, annotations map[string]string) (string, error) {
+	var ruleNames []string
+	for _, params := range firewallPolicyParams(policy, s.service, s.namer, nodesParams) {
+		fwLogger := s.logger.WithValues("firewallName", params.Name)
+		fwLogger.V(2).Info("Ensuring firewall policy rule for L4 Service")
+		fwSyncStatus, err := firewalls.EnsureL4LBFirewallForNodes(s.service, params, s.cloud, s.recorder, fwLogger)
+		resourceUpdates.SetFirewallForNodes(fwSyncStatus)
+		if err != nil {
+			return l4annotations.FirewallPolicyRulesResource, err
+		}
+		ruleNames = append(ruleNames, params.Name)
+	}
+	if len(ruleNames) > 0 {
+		annotations[l4annotations.FirewallPolicyRulesKey] = strings.Join(ruleNames, ",")
+	}
+
+	if _, ok := s.service.Annotations[l4annotations.FirewallRuleKey]; ok && policy.HasAllowRules() {
+		if err := s.deleteFirewall(s.namer.L4Firewall(s.service.Namespace, s.service.Name)); err != nil {
+			return l4annotations.FirewallRuleResource, err
+		}
+	}
+	for _, name := range staleFirewallPolicyRules(s.service, ruleNames) {
+		if err := s.deleteFirewall(name); err != nil {
+			return l4annotations.FirewallPolicyRulesResource, err
+		}
+	}
+	return "", nil
+}
+
+// delete deletes the firewall rules of the policy recorded in the service
+// annotations, or all the possible rules if shouldIgnoreAnnotations is set.
+func (s *firewallPolicySyncer) delete(shouldIgnoreAnnotations bool) error {
+	ruleNames := firewallPolicyRuleNames(s.service)
+	if shouldIgnoreAnnotations {
+		ruleNames = allFirewallPolicyRules(s.service, s.namer)
+	}
+	for _, name := range ruleNames {
+		if err := s.deleteFirewall(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *firewallPolicySyncer) deleteFirewall(name string) error {
+	fwLogger := s.logger.WithValues("firewallName", name)
+	fwLogger.V(2).Info("Deleting firewall for L4 Service")
+	err := firewalls.EnsureL4FirewallRuleDeleted(s.cloud, name, fwLogger)
+	if fwErr, ok := err.(*firewalls.FirewallXPNError); ok {
+		s.recorder.Eventf(s.service, corev1.EventTypeNormal, "XPN", fwErr.Message)
+		return nil
+	}
+	return err
+}
+
+// checkFirewallPolicyIPFamilies returns a user error if the service has the L4
+// firewall policy annotation and needs an IPv6 load balancer, since the policy
+// only applies to the IPv4 firewall rules.
+func checkFirewallPolicyIPFamilies(svc *corev1.Service, enableDualStack bool) error {
+	if _, ok := svc.Annotations[l4annotations.FirewallPolicyAnnotationKey]; !ok || !enableDualStack || !utils.NeedsIPv6(svc) {
+		return nil
+	}
+	return utils.NewUserError(fmt.Errorf("annotation %s is only supported by IPv4 services, got IP families %v", l4annotations.FirewallPolicyAnnotationKey, svc.Spec.IPFamilies))
+}
+
+// firewallPolicyParams returns the params of the firewall rules of the L4
+// firewall policy of the service. The rules are based on the params of the
+// rule allowing the load balancer source ranges to the nodes: rules without
+// sources apply to its source ranges, and rules without ports to its ports.
+func firewallPolicyParams(policy *l4annotations.FirewallPolicy, svc *corev1.Service, l4Namer namer.L4ResourcesNamer, nodesParams *firewalls.FirewallParams) []*firewalls.FirewallParams {
+	if policy == nil {
+		return nil
+	}
+	var params []*firewalls.FirewallParams
+	for _, rule := range policy.Rules {
+		ruleParams := &firewalls.FirewallParams{
+			Name:                  firewallPolicyRuleName(svc, l4Namer, rule),
+			IP:                    nodesParams.IP,
+			SourceRanges:          rule.SourceRanges,
+			SourceTags:            rule.SourceTags,
+			SourceServiceAccounts: rule.SourceServiceAccounts,
+			TargetServiceAccounts: rule.TargetServiceAccounts,
+			DestinationRanges:     nodesParams.DestinationRanges,
+			NodeNames:             nodesParams.NodeNames,
+			Priority:              rule.Priority,
+			L4Type:                nodesParams.L4Type,
+			Network:               nodesParams.Network,
+		}
+		if len(rule.SourceRanges) == 0 && len(rule.SourceTags) == 0 && len(rule.SourceServiceAccounts) == 0 {
+			ruleParams.SourceRanges = nodesParams.SourceRanges
+		}
+
+		allowed := nodesParams.Allowed
+		if len(rule.Ports) > 0 {
+			allowed = nil
+			for _, ports := range rule.Ports {
+				allowed = append(allowed, &compute.FirewallAllowed{
+					IPProtocol: strings.ToLower(ports.Protocol),
+					Ports:      ports.Ports,
+				})
+			}
+		}
+		if rule.Action == l4annotations.FirewallPolicyActionDeny {
+			for _, a := range allowed {
+				ruleParams.Denied = append(ruleParams.Denied, &compute.FirewallDenied{IPProtocol: a.IPProtocol, Ports: a.Ports})
+			}
+		} else {
+			ruleParams.Allowed = allowed
+		}
+		params = append(params, ruleParams)
+	}
+	return params
+}
+
+// firewallPolicyRuleName returns the name of the GCE firewall rule of a rule of
+// the firewall policy. The action and the kind of targets of a GCE firewall
+// rule cannot be patched, so they are part of the name: changing them creates
+// a new firewall rule, and the old one is deleted once the new one exists.
+func firewallPolicyRuleName(svc *corev1.Service, l4Namer namer.L4ResourcesNamer, rule l4annotations.FirewallPolicyRule) string {
+	kind := "a"
+	if rule.Action == l4annotations.FirewallPolicyActionDeny {
+		kind = "d"
+	}
+	if len(rule.TargetServiceAccounts) > 0 {
+		kind += "s"
+	}
+	return l4Namer.L4FirewallPolicyRule(svc.Namespace, svc.Name, rule.Name+"-"+kind)
+}
+
+// firewallPolicyRuleNames returns the names of the firewall rules of the L4
+// firewall policy recorded in the service annotations.
+func firewallPolicyRuleNames(svc *corev1.Service) []string {
+	val, ok := svc.Annotations[l4annotations.FirewallPolicyRulesKey]
+	if !ok || val == "" {
+		return nil
+	}
+	return strings.Split(val, ",")
+}
+
+// staleFirewallPolicyRules returns the names of the firewall rules recorded in
+// the service annotations which are not rules of the current firewall policy.
+func staleFirewallPolicyRules(svc *corev1.Service, ruleNames []string) []string {
+	return sets.List(sets.New(firewallPolicyRuleNames(svc)...).Delete(ruleNames...))
+}
+
+// allFirewallPolicyRules returns the names of the firewall rules recorded in
+// the service annotations and of the rules of the current firewall policy,
+// which are deleted with the service even if they were never recorded.
+func allFirewallPolicyRules(svc *corev1.Service, l4Namer namer.L4ResourcesNamer) []string {
+	names := sets.New(firewallPolicyRuleNames(svc)...)
+	// The policy is parsed as for an internal load balancer, which is the
+	// least strict, since the rules are deleted anyway.
+	if policy, err := l4annotations.FromService(svc).FirewallPolicy(true); err == nil && policy != nil {
+		for _, rule := range policy.Rules {
+			names.Insert(firewallPolicyRuleName(svc, l4Namer, rule))
+		}
+	}
+	return sets.List(names)
+}
@@ -284,6 +284,12 @@ func (l4 *L4) deleteIPv4ResourcesAnnotationBased(result *L4ILBSyncResult, should
 			result.Error = err
 		}
 	}
+
+	if err := l4.newFirewallPolicySyncer().delete(shouldIgnoreAnnotations); err != nil {
+		l4.svcLogger.Error(err, "Failed to delete firewall policy rules for internal loadbalancer service")
+		result.GCEResourceInError = l4annotations.FirewallPolicyRulesResource
+		result.Error = err
+	}
 }
 
 func (l4 *L4) deleteIPv4ForwardingRule() error {
@@ -425,6 +431,13 @@ func (l4 *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service
 		}
 	}
 
+	// The firewall policy is not applied to the IPv6 firewall rules, so it must not be bypassed over IPv6.
+	if err := checkFirewallPolicyIPFamilies(l4.Service, l4.enableDualStack); err != nil {
+		result.GCEResourceInError = l4annotations.FirewallRuleResource
+		result.Error = err
+		return result
+	}
+
 	hcLink := l4.provideHealthChecks(nodeNames, result)
 	if result.Error != nil {
 		return result
@@ -763,14 +776,39 @@ func (l4 *L4) ensureIPv4NodesFirewall(nodeNames []string, ipAddress string, resu
 		Network:           l4.network,
 	}
 
-	fwSyncStatus, err := firewalls.EnsureL4LBFirewallForNodes(l4.Service, &nodesFWRParams, l4.cloud, l4.recorder, fwLogger)
-	result.ResourceUpdates.SetFirewallForNodes(fwSyncStatus)
+	policy, err := l4annotations.FromService(l4.Service).FirewallPolicy(true)
 	if err != nil {
 		result.GCEResourceInError = l4annotations.FirewallRuleResource
-		result.Error = err
+		result.Error = utils.NewUserError(err)
 		return
 	}
-	result.Annotations[l4annotations.FirewallRuleKey] = firewallName
+	// A firewall policy allowing traffic replaces the rule allowing the load balancer source ranges.
+	if !policy.HasAllowRules() {
+		fwSyncStatus, err := firewalls.EnsureL4LBFirewallForNodes(l4.Service, &nodesFWRParams, l4.cloud, l4.recorder, fwLogger)
+		result.ResourceUpdates.SetFirewallForNodes(fwSyncStatus)
+		if err != nil {
+			result.GCEResourceInError = l4annotations.FirewallRuleResource
+			result.Error = err
+			return
+		}
+		result.Annotations[l4annotations.FirewallRuleKey] = firewallName
+	}
+	if resourceInError, err := l4.newFirewallPolicySyncer().ensure(policy, &nodesFWRParams, &result.ResourceUpdates, result.Annotations); err != nil {
+		result.GCEResourceInError = resourceInError
+		result.Error = err
+	}
+}
+
+// newFirewallPolicySyncer returns the syncer of the firewall rules of the L4
+// firewall policy of the service.
+func (l4 *L4) newFirewallPolicySyncer() *firewallPolicySyncer {
+	return &firewallPolicySyncer{
+		service:  l4.Service,
+		cloud:    l4.cloud,
+		namer:    l4.namer,
+		recorder: l4.recorder,
+		logger:   l4.svcLogger,
+	}
 }
 
 func (l4 *L4) getServiceSubnetworkURL(options gce.ILBOptions) (string, error) {
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/api/compute/v1"
 	ga "google.golang.org/api/compute/v1"
 	"k8s.io/ingress-gce/pkg/backends"
@@ -2752,6 +2753,188 @@ func TestDisableILBIngressFirewall(t *testing.T) {
 	}
 }
 
+func TestEnsureIPv4FirewallPolicy(t *testing.T) {
+	t.Parallel()
+	fakeGCE := getFakeGCECloud(gce.DefaultTestClusterValues())
+	nodeNames := []string{"test-node-1"}
+	// create a test VM so that target tags can be found
+	createVMInstanceWithTag(t, fakeGCE, "test-node-1", "test-node-1")
+
+	svc := test.NewL4ILBService(false, 8080)
+	namer := namer_util.NewL4Namer(kubeSystemUID, nil)
+	l4ilbParams := &L4ILBParams{
+		Service:         svc,
+		Cloud:           fakeGCE,
+		Namer:           namer,
+		Recorder:        record.NewFakeRecorder(100),
+		NetworkResolver: network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+	}
+	l4 := NewL4Handler(l4ilbParams, klog.TODO())
+	l4.network = *network.DefaultNetwork(fakeGCE)
+
+	nodesFirewallName := l4.namer.L4Firewall(svc.Namespace, svc.Name)
+	denyRuleName := l4.namer.L4FirewallPolicyRule(svc.Namespace, svc.Name, "deny-d")
+	webRuleName := l4.namer.L4FirewallPolicyRule(svc.Namespace, svc.Name, "web-a")
+	webDenyRuleName := l4.namer.L4FirewallPolicyRule(svc.Namespace, svc.Name, "web-d")
+
+	// sync ensures the nodes firewall and records the resource annotations
+	// on the service, as the L4 controller does.
+	sync := func(policy string) {
+		t.Helper()
+		if policy == "" {
+			delete(svc.Annotations, l4annotations.FirewallPolicyAnnotationKey)
+		} else {
+			svc.Annotations[l4annotations.FirewallPolicyAnnotationKey] = policy
+		}
+		syncResult := &L4ILBSyncResult{
+			Annotations: make(map[string]string),
+		}
+		l4.ensureIPv4NodesFirewall(nodeNames, "10.0.0.7", syncResult)
+		if syncResult.Error != nil {
+			t.Fatalf("ensureIPv4NodesFirewall() error %+v", syncResult)
+		}
+		for _, key := range []string{l4annotations.FirewallRuleKey, l4annotations.FirewallPolicyRulesKey} {
+			delete(svc.Annotations, key)
+		}
+		for key, val := range syncResult.Annotations {
+			svc.Annotations[key] = val
+		}
+	}
+	assertFirewalls := func(desc string, existing, deleted []string) {
+		t.Helper()
+		for _, name := range existing {
+			if _, err := fakeGCE.GetFirewall(name); err != nil {
+				t.Errorf("%s: GetFirewall(%s) returned error %v, want nil", desc, name, err)
+			}
+		}
+		for _, name := range deleted {
+			if err := verifyFirewallNotExists(fakeGCE, name); err != nil {
+				t.Errorf("%s: verifyFirewallNotExists(_, %s) returned error %v, want nil", desc, name, err)
+			}
+		}
+	}
+
+	sync("")
+	assertFirewalls("no policy", []string{nodesFirewallName}, []string{denyRuleName, webRuleName})
+
+	sync(`{"rules":[{"name":"deny","action":"deny","priority":900,"sourceRanges":["10.1.0.0/16"]},` +
+		`{"name":"web","action":"allow","sourceTags":["frontend"],"ports":[{"protocol":"TCP","ports":["8080-8090"]}]}]}`)
+	assertFirewalls("policy with allow rule", []string{denyRuleName, webRuleName}, []string{nodesFirewallName})
+	if got, want := svc.Annotations[l4annotations.FirewallPolicyRulesKey], denyRuleName+","+webRuleName; got != want {
+		t.Errorf("Annotation %s = %q, want %q", l4annotations.FirewallPolicyRulesKey, got, want)
+	}
+	if _, ok := svc.Annotations[l4annotations.FirewallRuleKey]; ok {
+		t.Errorf("Annotation %s is set, want unset when the firewall policy allows traffic", l4annotations.FirewallRuleKey)
+	}
+
+	denyRule, err := fakeGCE.GetFirewall(denyRuleName)
+	if err != nil {
+		t.Fatalf("GetFirewall(%s) error %v", denyRuleName, err)
+	}
+	expectedDenyRule := &compute.Firewall{
+		Name:         denyRuleName,
+		SourceRanges: []string{"10.1.0.0/16"},
+		Denied:       []*compute.FirewallDenied{{IPProtocol: "TCP", Ports: []string{"8080"}}},
+		Priority:     900,
+		TargetTags:   []string{"test-node-1"},
+	}
+	if diff := cmp.Diff(expectedDenyRule, denyRule, cmpopts.IgnoreFields(compute.Firewall{}, "Description", "Network", "DestinationRanges", "SelfLink")); diff != "" {
+		t.Errorf("Deny rule firewall invalid (-want +got):\n%s", diff)
+	}
+	webRule, err := fakeGCE.GetFirewall(webRuleName)
+	if err != nil {
+		t.Fatalf("GetFirewall(%s) error %v", webRuleName, err)
+	}
+	expectedWebRule := &compute.Firewall{
+		Name:       webRuleName,
+		SourceTags: []string{"frontend"},
+		Allowed:    []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"8080-8090"}}},
+		TargetTags: []string{"test-node-1"},
+	}
+	if diff := cmp.Diff(expectedWebRule, webRule, cmpopts.IgnoreFields(compute.Firewall{}, "Description", "Network", "DestinationRanges", "SelfLink")); diff != "" {
+		t.Errorf("Allow rule firewall invalid (-want +got):\n%s", diff)
+	}
+
+	// The action of a GCE firewall rule cannot be patched, a new rule replaces the old one.
+	sync(`{"rules":[{"name":"deny","action":"deny","priority":900,"sourceRanges":["10.1.0.0/16"]},` +
+		`{"name":"web","action":"deny","sourceTags":["frontend"]}]}`)
+	assertFirewalls("policy with only deny rules", []string{nodesFirewallName, denyRuleName, webDenyRuleName}, []string{webRuleName})
+
+	sync(`{"rules":[{"name":"deny","action":"deny","priority":900,"sourceRanges":["10.1.0.0/16"]}]}`)
+	assertFirewalls("policy with only deny rule", []string{nodesFirewallName, denyRuleName}, []string{webRuleName, webDenyRuleName})
+
+	sync("")
+	assertFirewalls("policy removed", []string{nodesFirewallName}, []string{denyRuleName, webRuleName})
+	if _, ok := svc.Annotations[l4annotations.FirewallPolicyRulesKey]; ok {
+		t.Errorf("Annotation %s is set, want unset without firewall policy", l4annotations.FirewallPolicyRulesKey)
+	}
+
+	// Rules of the policy are deleted with the service, even if they were
+	// never recorded in the annotations.
+	sync(`{"rules":[{"name":"web","action":"allow","sourceTags":["frontend"]}]}`)
+	delete(svc.Annotations, l4annotations.FirewallPolicyRulesKey)
+	syncResult := &L4ILBSyncResult{}
+	l4.deleteIPv4ResourcesOnDelete(syncResult)
+	if syncResult.Error != nil {
+		t.Fatalf("deleteIPv4ResourcesOnDelete() error %+v", syncResult)
+	}
+	assertFirewalls("service deleted", nil, []string{nodesFirewallName, webRuleName})
+}
+
+func TestEnsureIPv4FirewallPolicyInvalid(t *testing.T) {
+	t.Parallel()
+	fakeGCE := getFakeGCECloud(gce.DefaultTestClusterValues())
+	nodeNames := []string{"test-node-1"}
+	createVMInstanceWithTag(t, fakeGCE, "test-node-1", "test-node-1")
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Annotations[l4annotations.FirewallPolicyAnnotationKey] = `{"rules":[{"name":"web","action":"reject"}]}`
+	l4ilbParams := &L4ILBParams{
+		Service:         svc,
+		Cloud:           fakeGCE,
+		Namer:           namer_util.NewL4Namer(kubeSystemUID, nil),
+		Recorder:        record.NewFakeRecorder(100),
+		NetworkResolver: network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+	}
+	l4 := NewL4Handler(l4ilbParams, klog.TODO())
+	syncResult := &L4ILBSyncResult{
+		Annotations: make(map[string]string),
+	}
+
+	l4.ensureIPv4NodesFirewall(nodeNames, "10.0.0.7", syncResult)
+	if !IsUserError(syncResult.Error) {
+		t.Errorf("ensureIPv4NodesFirewall() error = %v, want a user error", syncResult.Error)
+	}
+	firewallName := l4.namer.L4Firewall(svc.Namespace, svc.Name)
+	if err := verifyFirewallNotExists(fakeGCE, firewallName); err != nil {
+		t.Errorf("verifyFirewallNotExists(_, %s) returned error %v, want nil", firewallName, err)
+	}
+}
+
+func TestEnsureInternalLoadBalancerFirewallPolicyIPv6(t *testing.T) {
+	t.Parallel()
+	for _, ipFamilies := range [][]v1.IPFamily{
+		{v1.IPv4Protocol, v1.IPv6Protocol},
+		{v1.IPv6Protocol},
+	} {
+		t.Run(fmt.Sprintf("%v", ipFamilies), func(t *testing.T) {
+			t.Parallel()
+			nodeNames := []string{"test-node-1"}
+			svc := test.NewL4ILBDualStackService(8080, v1.ProtocolTCP, ipFamilies, v1.ServiceExternalTrafficPolicyTypeCluster)
+			svc.Annotations[l4annotations.FirewallPolicyAnnotationKey] = `{"rules":[{"name":"deny","action":"deny","sourceRanges":["10.1.0.0/16"]}]}`
+			l4 := mustSetupILBTestHandler(t, svc, nodeNames)
+
+			result := l4.EnsureInternalLoadBalancer(nodeNames, svc)
+			if !IsUserError(result.Error) {
+				t.Errorf("EnsureInternalLoadBalancer() error = %v, want a user error since the firewall policy is not supported with IPv6", result.Error)
+			}
+			if err := verifyFirewallNotExists(l4.cloud, l4.namer.L4IPv6Firewall(svc.Namespace, svc.Name)); err != nil {
+				t.Errorf("verifyFirewallNotExists() returned error %v, want nil", err)
+			}
+		})
+	}
+}
+
 func mustSetupILBTestHandler(t *testing.T, svc *v1.Service, nodeNames []string) *L4 {
 	vals := gce.DefaultTestClusterValues()
 
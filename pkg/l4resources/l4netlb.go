@@ -275,6 +275,15 @@ func (l4netlb *L4NetLB) EnsureFrontend(nodeNames []string, svc *corev1.Service,
 		}
 	}
 
+	// The firewall policy is not applied to the IPv6 firewall rules, so it must not be bypassed over IPv6.
+	if err := checkFirewallPolicyIPFamilies(svc, l4netlb.enableDualStack); err != nil {
+		result.GCEResourceInError = l4annotations.FirewallRuleResource
+		result.Error = err
+		result.MetricsLegacyState.IsUserError = true
+		result.MetricsState.Status = metrics.StatusUserError
+		return result
+	}
+
 	hcLink := l4netlb.provideHealthChecks(nodeNames, result)
 	if result.Error != nil {
 		return result
@@ -551,15 +560,39 @@ func (l4netlb *L4NetLB) ensureIPv4NodesFirewall(nodeNames []string, ipAddress st
 		NodeNames:         nodeNames,
 		Network:           l4netlb.networkInfo,
 	}
-	var firewallForNodesUpdateStatus utils.ResourceSyncStatus
-	firewallForNodesUpdateStatus, result.Error = firewalls.EnsureL4LBFirewallForNodes(l4netlb.Service, &nodesFWRParams, l4netlb.cloud, l4netlb.recorder, fwLogger)
-	result.GCEResourceUpdate.SetFirewallForNodes(firewallForNodesUpdateStatus)
-	if result.Error != nil {
+	policy, err := l4annotations.FromService(l4netlb.Service).FirewallPolicy(false)
+	if err != nil {
 		result.GCEResourceInError = l4annotations.FirewallRuleResource
-		result.Error = err
+		result.Error = utils.NewUserError(err)
 		return
 	}
-	result.Annotations[l4annotations.FirewallRuleKey] = firewallName
+	// A firewall policy allowing traffic replaces the rule allowing the load balancer source ranges.
+	if !policy.HasAllowRules() {
+		var firewallForNodesUpdateStatus utils.ResourceSyncStatus
+		firewallForNodesUpdateStatus, result.Error = firewalls.EnsureL4LBFirewallForNodes(l4netlb.Service, &nodesFWRParams, l4netlb.cloud, l4netlb.recorder, fwLogger)
+		result.GCEResourceUpdate.SetFirewallForNodes(firewallForNodesUpdateStatus)
+		if result.Error != nil {
+			result.GCEResourceInError = l4annotations.FirewallRuleResource
+			return
+		}
+		result.Annotations[l4annotations.FirewallRuleKey] = firewallName
+	}
+	if resourceInError, err := l4netlb.newFirewallPolicySyncer().ensure(policy, &nodesFWRParams, &result.GCEResourceUpdate, result.Annotations); err != nil {
+		result.GCEResourceInError = resourceInError
+		result.Error = err
+	}
+}
+
+// newFirewallPolicySyncer returns the syncer of the firewall rules of the L4
+// firewall policy of the service.
+func (l4netlb *L4NetLB) newFirewallPolicySyncer() *firewallPolicySyncer {
+	return &firewallPolicySyncer{
+		service:  l4netlb.Service,
+		cloud:    l4netlb.cloud,
+		namer:    l4netlb.namer,
+		recorder: l4netlb.recorder,
+		logger:   l4netlb.svcLogger,
+	}
 }
 
 // EnsureLoadBalancerDeleted performs a cleanup of all GCE resources for the given loadbalancer service.
@@ -643,6 +676,12 @@ func (l4netlb *L4NetLB) deleteIPv4ResourcesAnnotationBased(result *L4NetLBSyncRe
 			result.Error = err
 		}
 	}
+
+	if err := l4netlb.newFirewallPolicySyncer().delete(shouldIgnoreAnnotations); err != nil {
+		l4netlb.svcLogger.Error(err, "Failed to delete firewall policy rules for NetLB RBS service")
+		result.GCEResourceInError = l4annotations.FirewallPolicyRulesResource
+		result.Error = err
+	}
 }
 
 func (l4netlb *L4NetLB) deleteIPv4ForwardingRule() error {
@@ -2064,3 +2064,37 @@ func assertAddressOldReservedHook(t *testing.T, gceCloud *gce.Cloud) func(ctx co
 		return false, nil
 	}
 }
+
+func TestEnsureNetLBFirewallPolicySourceTags(t *testing.T) {
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4NetLBRBSService(8080)
+	svc.Annotations = map[string]string{
+		l4annotations.FirewallPolicyAnnotationKey: `{"rules":[{"name":"web","action":"allow","sourceTags":["frontend"]}]}`,
+	}
+	l4NetLBParams := &L4NetLBParams{
+		Service:         svc,
+		Cloud:           fakeGCE,
+		Namer:           namer_util.NewL4Namer(kubeSystemUID, nil),
+		Recorder:        record.NewFakeRecorder(100),
+		NetworkResolver: network.NewFakeResolver(network.DefaultNetwork(fakeGCE)),
+	}
+	l4netlb := NewL4NetLB(l4NetLBParams, klog.TODO())
+	if _, err := test.CreateAndInsertNodes(l4netlb.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Fatalf("Unexpected error when adding nodes %v", err)
+	}
+
+	result := &L4NetLBSyncResult{
+		Annotations: make(map[string]string),
+	}
+	l4netlb.ensureIPv4NodesFirewall(nodeNames, "1.2.3.4", result)
+	if !IsUserError(result.Error) {
+		t.Errorf("ensureIPv4NodesFirewall() error = %v, want a user error since source tags are not supported by external load balancers", result.Error)
+	}
+	fwName := l4netlb.namer.L4Firewall(svc.Namespace, svc.Name)
+	if err := verifyFirewallNotExists(fakeGCE, fwName); err != nil {
+		t.Errorf("verifyFirewallNotExists(_, %s) returned error %v, want nil", fwName, err)
+	}
+}
@@ -33,6 +33,7 @@ var L4ResourceAnnotationKeys = []string{
 	l4annotations.HealthcheckKey,
 	l4annotations.FirewallRuleKey,
 	l4annotations.FirewallRuleForHealthcheckKey,
+	l4annotations.FirewallPolicyRulesKey,
 }
 
 var l4IPv6ResourceAnnotationKeys = []string{
@@ -23,16 +23,28 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 )
 
 // checkStaticIP reserves a regional or global static IP allocated to the Forwarding Rule.
 func (l7 *L7) checkStaticIP() (err error) {
+	managedStaticIPName := l7.namer.ForwardingRule(namer.HTTPProtocol)
+	// In plan mode, the forwarding rule may only be planned for creation, in
+	// which case GCE has not allocated its ephemeral IP yet.
+	if l7.fw != nil && l7.fw.IPAddress == "" && l7.plan.Enabled() {
+		key, err := l7.CreateKey(managedStaticIPName)
+		if err != nil {
+			return err
+		}
+		l7.ip = l7.newStaticAddress(managedStaticIPName)
+		l7.planned(plan.Create, addressesResource, key, nil, l7.ip)
+		return nil
+	}
 	if l7.fw == nil || l7.fw.IPAddress == "" {
 		return fmt.Errorf("will not create static IP without a forwarding rule")
 	}
-	managedStaticIPName := l7.namer.ForwardingRule(namer.HTTPProtocol)
 	// Don't manage staticIPs if the user has specified an IP.
 	address, manageStaticIP, err := l7.getEffectiveIP()
 	if err != nil {
@@ -57,6 +69,10 @@ func (l7 *L7) checkStaticIP() (err error) {
 		l7.logger.V(3).Info("Creating static ip", "ipName", managedStaticIPName)
 		address := l7.newStaticAddress(managedStaticIPName)
 
+		if l7.planned(plan.Create, addressesResource, key, nil, address) {
+			l7.ip = address
+			return nil
+		}
 		err = composite.CreateAddress(l7.cloud, key, address, l7.logger)
 		if err != nil {
 			if utils.IsHTTPErrorCode(err, http.StatusConflict) ||
@@ -22,6 +22,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -29,6 +30,19 @@ import (
 const SslCertificateMissing = "SslCertificateMissing"
 
 func (l7 *L7) checkSSLCert() error {
+	// The target https proxy uses the certificate map instead of SSL
+	// certificates. The certificates still attached to it are detached, and
+	// the ones managed by the load balancer are then deleted.
+	if l7.runtimeInfo.CertificateMap != "" {
+		l7.sslCerts = nil
+		existingSecretsSslCerts, err := l7.getIngressManagedSslCerts()
+		if err != nil {
+			return err
+		}
+		l7.oldSSLCerts = existingSecretsSslCerts
+		return nil
+	}
+
 	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
 	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(l7.runtimeInfo.Ingress)
 	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
@@ -103,6 +117,11 @@ func (l7 *L7) createSslCertificates(existingCerts, translatorCerts []*composite.
 			l7.logger.Error(err, "l7.CreateKey", "certName", translatorCert.Name)
 			return nil, err
 		}
+		if l7.planned(plan.Create, sslCertificatesResource, key, nil, translatorCert) {
+			translatorCert.SelfLink = l7.plannedSelfLink(sslCertificatesResource, key)
+			result = append(result, translatorCert)
+			continue
+		}
 		err = composite.CreateSslCertificate(l7.cloud, key, translatorCert, l7.logger)
 		if err != nil {
 			l7.logger.Error(err, "Failed to create new sslCertificate for LB", "certName", translatorCert.Name, "l7", l7)
@@ -213,6 +232,9 @@ func (l7 *L7) deleteOldSSLCerts() {
 		}
 		l7.logger.V(3).Info("Cleaning up old SSL Certificate", "certName", cert.Name)
 		key, _ := l7.CreateKey(cert.Name)
+		if l7.planned(plan.Delete, sslCertificatesResource, key, nil, nil) {
+			continue
+		}
 		if certErr := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificate(l7.cloud, key, l7.Versions().SslCertificate, l7.logger)); certErr != nil {
 			l7.logger.Error(certErr, "Old cert delete failed", "certName", cert.Name)
 		}
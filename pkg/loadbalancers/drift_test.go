@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// fakeRecorderProducer returns the same recorder for all namespaces.
+type fakeRecorderProducer struct {
+	recorder *record.FakeRecorder
+}
+
+func (f fakeRecorderProducer) Recorder(ns string) record.EventRecorder {
+	return f.recorder
+}
+
+func TestURLMapDrift(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		driftPolicy string
+		wantRevert  bool
+	}{
+		{
+			desc:       "default policy reverts the change",
+			wantRevert: true,
+		},
+		{
+			desc:        "alert policy keeps the change",
+			driftPolicy: string(annotations.DriftPolicyAlert),
+			wantRevert:  false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			j := newTestJig(t)
+			j.pool.drift = drift.NewDetector()
+			recorder := record.NewFakeRecorder(100)
+			j.pool.recorderProducer = fakeRecorderProducer{recorder: recorder}
+
+			gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+			gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+			gceUrlMap.PutPathRulesForHost("foo.example.com", []utils.PathRule{{Path: "/foo", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+			ing := newIngress()
+			if tc.driftPolicy != "" {
+				ing.Annotations = map[string]string{annotations.DriftPolicyKey: tc.driftPolicy}
+			}
+			lbInfo := &L7RuntimeInfo{
+				AllowHTTP: true,
+				UrlMap:    gceUrlMap,
+				Ingress:   ing,
+			}
+			if _, err := j.pool.Ensure(lbInfo); err != nil {
+				t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+			}
+
+			// Change the host of the URL map outside of the controller.
+			key := meta.GlobalKey(j.feNamer.UrlMap())
+			um, err := composite.GetUrlMap(j.fakeGCE, key, defaultVersion, klog.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			um.HostRules[0].Hosts = []string{"bar.example.com"}
+			if err := composite.UpdateUrlMap(j.fakeGCE, key, um, klog.TODO()); err != nil {
+				t.Fatal(err)
+			}
+			drainEvents(recorder)
+
+			if _, err := j.pool.Ensure(lbInfo); err != nil {
+				t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+			}
+
+			um, err = composite.GetUrlMap(j.fakeGCE, key, defaultVersion, klog.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := um.HostRules[0].Hosts[0] == "foo.example.com"; got != tc.wantRevert {
+				t.Errorf("Got host %q after the sync, want the change reverted = %t", um.HostRules[0].Hosts[0], tc.wantRevert)
+			}
+
+			events := drainEvents(recorder)
+			var driftEvent string
+			for _, e := range events {
+				if strings.Contains(e, "Warning Drift") {
+					driftEvent = e
+				}
+			}
+			if !strings.Contains(driftEvent, "HostRules[0].Hosts[0]") {
+				t.Errorf("Got events %v, want a drift event for HostRules[0].Hosts[0]", events)
+			}
+
+			// The drift is only reported once, whether it was reverted or
+			// left in place.
+			if _, err := j.pool.Ensure(lbInfo); err != nil {
+				t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+			}
+			for _, e := range drainEvents(recorder) {
+				if strings.Contains(e, "Warning Drift") {
+					t.Errorf("Got drift event %q on the next sync, want none", e)
+				}
+			}
+
+			// A change of the desired state is not a drift, and is applied
+			// with any policy.
+			gceUrlMap.PutPathRulesForHost("baz.example.com", []utils.PathRule{{Path: "/baz", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+			if _, err := j.pool.Ensure(lbInfo); err != nil {
+				t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+			}
+			um, err = composite.GetUrlMap(j.fakeGCE, key, defaultVersion, klog.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(um.HostRules) != 2 {
+				t.Errorf("Got %d host rules after the desired state changed, want 2", len(um.HostRules))
+			}
+		})
+	}
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -85,11 +86,13 @@ func (l7 *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		l7.logger.Info("Recreating forwarding rule %v(%v), so it has %v(%v)",
 			"existingIp", existing.IPAddress, "existingPortRange", existing.PortRange,
 			"targetIp", fr.IPAddress, "targetPortRange", fr.PortRange)
-		if err = utils.IgnoreHTTPNotFound(composite.DeleteForwardingRule(l7.cloud, key, version, l7.logger)); err != nil {
-			return nil, err
+		if !l7.planned(plan.Delete, forwardingRulesResource, key, nil, nil) {
+			if err = utils.IgnoreHTTPNotFound(composite.DeleteForwardingRule(l7.cloud, key, version, l7.logger)); err != nil {
+				return nil, err
+			}
+			l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "ForwardingRule %q deleted", key.Name)
 		}
 		existing = nil
-		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "ForwardingRule %q deleted", key.Name)
 	}
 	if existing == nil {
 		// This is a special case where exactly one of http or https forwarding rule
@@ -110,6 +113,10 @@ func (l7 *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		}
 		l7.logger.V(3).Info("Creating forwarding rule for proxy and ip", "proxy", proxyLink, "ip", ip, "protocol", protocol)
 
+		if l7.planned(plan.Create, forwardingRulesResource, key, nil, fr) {
+			fr.SelfLink = l7.plannedSelfLink(forwardingRulesResource, key)
+			return fr, nil
+		}
 		if err = composite.CreateForwardingRule(l7.cloud, key, fr, l7.logger); err != nil {
 			return nil, err
 		}
@@ -134,6 +141,11 @@ func (l7 *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		if err != nil {
 			return nil, err
 		}
+		desired := *existing
+		desired.Target = proxyLink
+		if l7.planned(plan.Update, forwardingRulesResource, key, existing, &desired) {
+			return existing, nil
+		}
 		if err := composite.SetProxyForForwardingRule(l7.cloud, key, existing, proxyLink, l7.logger); err != nil {
 			return nil, err
 		}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"k8s.io/ingress-gce/pkg/translator"
@@ -27,14 +28,20 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	ingsyncstatusv1beta1 "k8s.io/ingress-gce/pkg/apis/ingsyncstatus/v1beta1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
@@ -42,6 +49,27 @@ import (
 
 var errAllProtocolsDisabled = errors.New("invalid configuration: both HTTP and HTTPS are disabled (kubernetes.io/ingress.allow-http is false and there is no valid TLS configuration); your Ingress will not be able to serve any traffic")
 
+// frontendConfigError is returned when a setting of the FrontendConfig
+// cannot be applied to the load balancer.
+type frontendConfigError struct {
+	err error
+}
+
+func (e *frontendConfigError) Error() string {
+	return e.err.Error()
+}
+
+func (e *frontendConfigError) Unwrap() error {
+	return e.err
+}
+
+// IsFrontendConfigError returns true if the error was caused by a setting of
+// the FrontendConfig that the load balancer does not support.
+func IsFrontendConfigError(err error) bool {
+	var feErr *frontendConfigError
+	return errors.As(err, &feErr)
+}
+
 // L7RuntimeInfo is info passed to this module from the controller runtime.
 type L7RuntimeInfo struct {
 	// IP is the desired ip of the loadbalancer, eg from a staticIP.
@@ -50,6 +78,9 @@ type L7RuntimeInfo struct {
 	TLS []*translator.TLSCerts
 	// TLSName is the name of the preshared cert to use. Multiple certs can be specified as a comma-separated string
 	TLSName string
+	// CertificateMap is the name or URL of the Certificate Manager certificate
+	// map to use. When set, it is used instead of TLS and TLSName.
+	CertificateMap string
 	// Ingress is the processed Ingress API object.
 	Ingress *v1.Ingress
 	// AllowHTTP will not setup :80, if TLS is nil and AllowHTTP is set,
@@ -102,6 +133,11 @@ type L7 struct {
 	recorder record.EventRecorder
 	// resource type stores the KeyType of the resources in the loadbalancer (e.g. Regional)
 	scope meta.KeyType
+	// plan records the GCE mutations instead of making them in plan mode.
+	plan *plan.Recorder
+	// drift detects the changes made to the load balancer outside of the
+	// controller.
+	drift *drift.Detector
 
 	logger klog.Logger
 }
@@ -139,7 +175,7 @@ func (l7 *L7) UrlMap() *composite.UrlMap {
 }
 
 func (l7 *L7) edgeHop() error {
-	sslConfigured := l7.runtimeInfo.TLS != nil || l7.runtimeInfo.TLSName != ""
+	sslConfigured := l7.runtimeInfo.TLS != nil || l7.runtimeInfo.TLSName != "" || l7.runtimeInfo.CertificateMap != ""
 	// Return an error if user configuration species that both HTTP & HTTPS are not to be configured.
 	if !l7.runtimeInfo.AllowHTTP && !sslConfigured {
 		return errAllProtocolsDisabled
@@ -156,7 +192,7 @@ func (l7 *L7) edgeHop() error {
 	}
 
 	if err := l7.ensureRedirectURLMap(); err != nil {
-		return fmt.Errorf("ensureRedirectUrlMap() = %v", err)
+		return fmt.Errorf("ensureRedirectUrlMap() = %w", err)
 	}
 
 	if l7.runtimeInfo.AllowHTTP {
@@ -257,6 +293,12 @@ func (l7 *L7) deleteForwardingRule(versions *features.ResourceVersions, protocol
 	if err != nil {
 		return err
 	}
+	if l7.plannedDelete(forwardingRulesResource, key, func() error {
+		_, err := composite.GetForwardingRule(l7.cloud, key, versions.ForwardingRule, l7.logger)
+		return err
+	}) {
+		return nil
+	}
 	if err := utils.IgnoreHTTPNotFound(composite.DeleteForwardingRule(l7.cloud, key, versions.ForwardingRule, l7.logger)); err != nil {
 		return err
 	}
@@ -273,10 +315,22 @@ func (l7 *L7) deleteTargetProxy(versions *features.ResourceVersions, protocol na
 	}
 	switch protocol {
 	case namer.HTTPProtocol:
+		if l7.plannedDelete(targetHttpProxiesResource, key, func() error {
+			_, err := composite.GetTargetHttpProxy(l7.cloud, key, versions.TargetHttpProxy, l7.logger)
+			return err
+		}) {
+			return nil
+		}
 		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpProxy(l7.cloud, key, versions.TargetHttpProxy, l7.logger)); err != nil {
 			return err
 		}
 	case namer.HTTPSProtocol:
+		if l7.plannedDelete(targetHttpsProxiesResource, key, func() error {
+			_, err := composite.GetTargetHttpsProxy(l7.cloud, key, versions.TargetHttpsProxy, l7.logger)
+			return err
+		}) {
+			return nil
+		}
 		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpsProxy(l7.cloud, key, versions.TargetHttpsProxy, l7.logger)); err != nil {
 			return err
 		}
@@ -331,6 +385,9 @@ func (l7 *L7) deleteSSLCertificates(sslCertificates []*composite.SslCertificate,
 		if err != nil {
 			return err
 		}
+		if l7.planned(plan.Delete, sslCertificatesResource, key, nil, nil) {
+			continue
+		}
 		if err := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificate(l7.cloud, key, versions.SslCertificate, l7.logger)); err != nil {
 			l7.logger.Error(err, "Old cert delete failed")
 			certErr = err
@@ -346,6 +403,9 @@ func (l7 *L7) deleteStaticIP() error {
 	ip, err := l7.cloud.GetGlobalAddress(frName)
 	if ip != nil && utils.IgnoreHTTPNotFound(err) == nil {
 		l7.logger.V(2).Info("Deleting static IP", "ipName", ip.Name, "ipAddress", ip.Address)
+		if l7.planned(plan.Delete, addressesResource, meta.GlobalKey(ip.Name), nil, nil) {
+			return nil
+		}
 		if err := utils.IgnoreHTTPNotFound(l7.cloud.DeleteGlobalAddress(ip.Name)); err != nil {
 			return err
 		}
@@ -377,8 +437,11 @@ func (l7 *L7) Cleanup(versions *features.ResourceVersions) error {
 	if err != nil {
 		return err
 	}
-	if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMap(l7.cloud, key, versions.UrlMap, l7.logger)); err != nil {
-		return err
+	if !l7.plannedDelete(urlMapsResource, key, l7.getUrlMapFunc(key, versions)) {
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMap(l7.cloud, key, versions.UrlMap, l7.logger)); err != nil {
+			return err
+		}
+		l7.drift.Forget(urlMapsResource, key)
 	}
 
 	// Delete RedirectUrlMap if exists
@@ -392,12 +455,23 @@ func (l7 *L7) Cleanup(versions *features.ResourceVersions) error {
 	if err != nil {
 		return err
 	}
+	if l7.plannedDelete(urlMapsResource, key, l7.getUrlMapFunc(key, versions)) {
+		return nil
+	}
 	if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMap(l7.cloud, key, versions.UrlMap, l7.logger)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// getUrlMapFunc returns a function that gets the URL map of the key.
+func (l7 *L7) getUrlMapFunc(key *meta.Key, versions *features.ResourceVersions) func() error {
+	return func() error {
+		_, err := composite.GetUrlMap(l7.cloud, key, versions.UrlMap, l7.logger)
+		return err
+	}
+}
+
 func (l7 *L7) getFrontendAnnotations(existing map[string]string) map[string]string {
 	if existing == nil {
 		existing = map[string]string{}
@@ -450,11 +524,18 @@ func (l7 *L7) getFrontendAnnotations(existing map[string]string) map[string]stri
 	} else {
 		delete(existing, annotations.SSLCertKey)
 	}
+	if l7.tps != nil && l7.tps.ServerTlsPolicy != "" {
+		existing[annotations.ServerTlsPolicyKey] = l7.tps.ServerTlsPolicy
+	} else {
+		delete(existing, annotations.ServerTlsPolicyKey)
+	}
 	return existing
 }
 
-// GetLBAnnotations returns the annotations of an l7. This includes it's current status.
-func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer *backends.Syncer, ingLogger klog.Logger) (map[string]string, error) {
+// GetBackendStates returns the health state of each backend service of an
+// l7, by name. The state of a backend whose health could not be retrieved is
+// empty.
+func GetBackendStates(l7 *L7, backendSyncer *backends.Syncer, ingLogger klog.Logger) (map[string]string, error) {
 	backends, err := getBackendNames(l7.um)
 	if err != nil {
 		return nil, err
@@ -469,6 +550,83 @@ func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer *backend
 		}
 		backendState[beName] = state
 	}
+	return backendState, nil
+}
+
+// BackendEndpointHealth is the health of the endpoints of a NEG backend,
+// summed over the zones of its NEGs. LastPollTime is the oldest time the
+// health of a zone was polled.
+type BackendEndpointHealth struct {
+	Healthy       int32        `json:"healthy"`
+	Unhealthy     int32        `json:"unhealthy"`
+	UnhealthyPods []string     `json:"unhealthyPods,omitempty"`
+	LastPollTime  *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// GetBackendEndpointHealth returns the health of the endpoints of the NEG
+// backends of an l7, by name, as reported in the status of their
+// ServiceNetworkEndpointGroups in the given namespaces. The load balancer of
+// an ingress group serves the services of the namespaces of all its
+// ingresses. Backends without reported endpoint health are omitted.
+func GetBackendEndpointHealth(l7 *L7, svcNegLister cache.Indexer, namespaces ...string) (map[string]BackendEndpointHealth, error) {
+	backends, err := getBackendNames(l7.um)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]BackendEndpointHealth{}
+	for _, beName := range backends {
+		var svcNeg *negv1beta1.ServiceNetworkEndpointGroup
+		for _, namespace := range namespaces {
+			obj, exists, err := svcNegLister.GetByKey(namespace + "/" + beName)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				svcNeg = obj.(*negv1beta1.ServiceNetworkEndpointGroup)
+				break
+			}
+		}
+		if svcNeg == nil {
+			continue
+		}
+		if len(svcNeg.Status.EndpointHealth) == 0 {
+			continue
+		}
+		var health BackendEndpointHealth
+		for _, h := range svcNeg.Status.EndpointHealth {
+			health.Healthy += h.HealthyEndpoints
+			health.Unhealthy += h.UnhealthyEndpoints
+			health.UnhealthyPods = append(health.UnhealthyPods, h.UnhealthyPods...)
+			if !h.LastPollTime.IsZero() && (health.LastPollTime == nil || h.LastPollTime.Before(health.LastPollTime)) {
+				health.LastPollTime = h.LastPollTime.DeepCopy()
+			}
+		}
+		sort.Strings(health.UnhealthyPods)
+		ret[beName] = health
+	}
+	return ret, nil
+}
+
+// SetBackendHealthAnnotation records the given health of the endpoints of
+// the NEG backends in the annotations of an l7, or removes it if there is
+// none.
+func SetBackendHealthAnnotation(existing map[string]string, health map[string]BackendEndpointHealth) map[string]string {
+	if len(health) == 0 {
+		delete(existing, annotations.BackendHealthKey)
+		return existing
+	}
+	b, err := json.Marshal(health)
+	if err != nil {
+		delete(existing, annotations.BackendHealthKey)
+		return existing
+	}
+	existing[annotations.BackendHealthKey] = string(b)
+	return existing
+}
+
+// GetLBAnnotations returns the annotations of an l7. This includes it's
+// current status, with the given health states of its backends.
+func GetLBAnnotations(l7 *L7, existing map[string]string, backendState map[string]string) map[string]string {
 	jsonBackendState := "Unknown"
 	b, err := json.Marshal(backendState)
 	if err == nil {
@@ -478,7 +636,44 @@ func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer *backend
 	existing = l7.getFrontendAnnotations(existing)
 	// TODO: We really want to know *when* a backend flipped states.
 	existing[fmt.Sprintf("%v/backends", annotations.StatusPrefix)] = jsonBackendState
-	return existing, nil
+	return existing
+}
+
+// GetLBResources returns the GCE resources of the frontend of an l7, which
+// are recorded in the annotations by GetLBAnnotations.
+func GetLBResources(l7 *L7) []ingsyncstatusv1beta1.GCEResource {
+	var resources []ingsyncstatusv1beta1.GCEResource
+	add := func(resourceType, name string) {
+		resources = append(resources, ingsyncstatusv1beta1.GCEResource{Type: resourceType, Name: name})
+	}
+	if l7.um != nil {
+		add("UrlMap", l7.um.Name)
+	}
+	if l7.redirectUm != nil {
+		add("UrlMap", l7.redirectUm.Name)
+	}
+	if l7.tp != nil {
+		add("TargetHttpProxy", l7.tp.Name)
+	}
+	if l7.tps != nil {
+		add("TargetHttpsProxy", l7.tps.Name)
+		if l7.tps.ServerTlsPolicy != "" {
+			add("ServerTlsPolicy", l7.tps.ServerTlsPolicy)
+		}
+	}
+	if l7.fw != nil {
+		add("ForwardingRule", l7.fw.Name)
+	}
+	if l7.fws != nil {
+		add("ForwardingRule", l7.fws.Name)
+	}
+	if l7.ip != nil {
+		add("Address", l7.ip.Name)
+	}
+	for _, cert := range l7.sslCerts {
+		add("SslCertificate", cert.Name)
+	}
+	return resources
 }
 
 // GCEResourceName retrieves the name of the gce resource created for this
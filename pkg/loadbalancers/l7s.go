@@ -26,8 +26,10 @@ import (
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/common/operator"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/drift"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
@@ -42,6 +44,11 @@ type L7s struct {
 	recorderProducer events.RecorderProducer
 	// namerFactory creates frontend naming policy for ingress/ load balancer.
 	namerFactory namer_util.IngressFrontendNamerFactory
+	// plan records the GCE mutations instead of making them in plan mode.
+	plan *plan.Recorder
+	// drift detects the changes made to the load balancers outside of the
+	// controller.
+	drift *drift.Detector
 
 	logger klog.Logger
 }
@@ -50,12 +57,17 @@ type L7s struct {
 // - cloud: implements LoadBalancers. Used to sync L7 loadbalancer resources
 //
 //	with the cloud.
-func NewLoadBalancerPool(cloud *gce.Cloud, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory, logger klog.Logger) LoadBalancerPool {
+//
+// - planRecorder: records the GCE mutations instead of making them if non-nil.
+// - driftDetector: detects the changes made outside of the controller if non-nil.
+func NewLoadBalancerPool(cloud *gce.Cloud, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory, planRecorder *plan.Recorder, driftDetector *drift.Detector, logger klog.Logger) LoadBalancerPool {
 	return &L7s{
 		cloud:            cloud,
 		v1NamerHelper:    v1NamerHelper,
 		recorderProducer: recorderProducer,
 		namerFactory:     namerFactory,
+		plan:             planRecorder,
+		drift:            driftDetector,
 		logger:           logger.WithName("L7Pool"),
 	}
 }
@@ -69,6 +81,8 @@ func (l7s *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
 		recorder:    l7s.recorderProducer.Recorder(ri.Ingress.Namespace),
 		scope:       features.ScopeFromIngress(ri.Ingress),
 		ingress:     *ri.Ingress,
+		plan:        l7s.plan,
+		drift:       l7s.drift,
 		logger:      l7s.logger,
 	}
 
@@ -79,7 +93,7 @@ func (l7s *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
 	}
 
 	if err := lb.edgeHop(); err != nil {
-		return nil, fmt.Errorf("loadbalancer %v does not exist: %v", lb.String(), err)
+		return nil, fmt.Errorf("loadbalancer %v does not exist: %w", lb.String(), err)
 	}
 	return lb, nil
 }
@@ -95,6 +109,8 @@ func (l7s *L7s) delete(namer namer_util.IngressFrontendNamer, versions *features
 		cloud:       l7s.cloud,
 		namer:       namer,
 		scope:       scope,
+		plan:        l7s.plan,
+		drift:       l7s.drift,
 		logger:      l7s.logger,
 	}
 
@@ -124,6 +140,9 @@ func (l7s *L7s) list(key *meta.Key, version meta.Version) ([]*composite.UrlMap,
 }
 
 // GCv2 implements LoadBalancerPool.
+// Note that the load balancer of an ingress group is deleted with any of its
+// ingresses, so the caller must only GC it once the group has no ingresses
+// left in the scope.
 func (l7s *L7s) GCv2(ing *v1.Ingress, scope meta.KeyType) error {
 	ingKey := common.NamespacedName(ing)
 	l7s.logger.V(2).Info("GCv2", "key", ingKey)
@@ -177,7 +196,7 @@ func (l7s *L7s) DidRegionalClassChange(ing *v1.Ingress, ingLogger klog.Logger) (
 	}
 
 	namer := l7s.namerFactory.Namer(ing)
-	currentLBScheme := lbSchemeForIngress(ing)
+	currentLBScheme := LBSchemeForIngress(ing)
 	ingLogger.WithName("DidRegionalClassChange")
 	ingLogger.Info("Checking ingress for class name change")
 
@@ -202,7 +221,9 @@ func (l7s *L7s) DidRegionalClassChange(ing *v1.Ingress, ingLogger klog.Logger) (
 	return false, nil
 }
 
-func lbSchemeForIngress(ing *v1.Ingress) string {
+// LBSchemeForIngress returns the LoadBalancingScheme of the forwarding rules
+// of the given ingress.
+func LBSchemeForIngress(ing *v1.Ingress) string {
 	if utils.IsGCEL7XLBRegionalIngress(ing) {
 		return "EXTERNAL_MANAGED"
 	} else if utils.IsGCEL7ILBIngress(ing) {
@@ -287,10 +308,22 @@ func (l7s *L7s) Shutdown(ings []*v1.Ingress) error {
 	v2Ings := operator.Ingresses(ings).Filter(func(ing *v1.Ingress) bool {
 		return namer_util.FrontendNamingScheme(ing, l7s.logger) == namer_util.V2NamingScheme
 	}).AsList()
+	// The ingresses of a group share a load balancer, which is deleted once.
+	type scopedLoadBalancer struct {
+		name  namer_util.LoadBalancerName
+		scope meta.KeyType
+	}
+	deleted := make(map[scopedLoadBalancer]bool)
 	for _, ing := range v2Ings {
-		if err := l7s.GCv2(ing, features.ScopeFromIngress(ing)); err != nil {
+		lb := scopedLoadBalancer{name: l7s.namerFactory.Namer(ing).LoadBalancer(), scope: features.ScopeFromIngress(ing)}
+		if deleted[lb] {
+			continue
+		}
+		if err := l7s.GCv2(ing, lb.scope); err != nil {
 			errs = append(errs, err)
+			continue
 		}
+		deleted[lb] = true
 	}
 	if errs != nil {
 		return fmt.Errorf("error deleting load-balancers for v2 naming policy: %v", utils.JoinErrs(errs))
@@ -18,11 +18,14 @@ package loadbalancers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
@@ -33,9 +36,11 @@ import (
 	"google.golang.org/api/googleapi"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
@@ -121,6 +126,17 @@ func newTestJig(t *testing.T) *testJig {
 		tps.SslPolicy = ref.SslPolicy
 		return nil
 	}
+	mockGCE.MockTargetHttpsProxies.SetCertificateMapHook = func(ctx context.Context, key *meta.Key, request *compute.TargetHttpsProxiesSetCertificateMapRequest, proxies *cloud.MockTargetHttpsProxies, _ ...cloud.Option) error {
+		tps, err := proxies.Get(ctx, key)
+		if err != nil {
+			return &googleapi.Error{
+				Code:    http.StatusNotFound,
+				Message: fmt.Sprintf("Key: %s was not found in TargetHttpsProxies", key.String()),
+			}
+		}
+		tps.CertificateMap = request.CertificateMap
+		return nil
+	}
 	mockGCE.MockGlobalForwardingRules.InsertHook = InsertGlobalForwardingRuleHook
 
 	ing := newIngress()
@@ -150,7 +166,7 @@ func newIngress() *networkingv1.Ingress {
 }
 
 func newFakeLoadBalancerPool(cloud *gce.Cloud, t *testing.T, namer *namer_util.Namer) L7s {
-	return L7s{cloud, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), klog.TODO()}
+	return L7s{cloud, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "", klog.TODO()), nil, nil, klog.TODO()}
 }
 
 func newILBIngress() *networkingv1.Ingress {
@@ -1130,6 +1146,82 @@ func TestFrontendConfigSslPolicy(t *testing.T) {
 	}
 }
 
+func TestFrontendConfigServerTlsPolicy(t *testing.T) {
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP:      false,
+		TLS:            []*translator.TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:         gceUrlMap,
+		Ingress:        newIngress(),
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{ServerTlsPolicy: ptr.To("mtls-policy")}},
+	}
+
+	l7, err := j.pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+	}
+
+	tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionGA, klog.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("//networksecurity.googleapis.com/projects/%s/locations/global/serverTlsPolicies/mtls-policy", j.fakeGCE.ProjectID())
+	if tps.ServerTlsPolicy != want {
+		t.Errorf("tps server tls policy = %q, want %q", tps.ServerTlsPolicy, want)
+	}
+	if got := l7.getFrontendAnnotations(nil)[annotations.ServerTlsPolicyKey]; got != want {
+		t.Errorf("%s annotation = %q, want %q", annotations.ServerTlsPolicyKey, got, want)
+	}
+
+	// Global target https proxies are patched through the compute API.
+	key := meta.GlobalKey(l7.tps.Name)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != fmt.Sprintf("/compute/v1/projects/%s/global/targetHttpsProxies/%s", j.fakeGCE.ProjectID(), key.Name) {
+			http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+			return
+		}
+		patch := &compute.TargetHttpsProxy{}
+		if err := json.NewDecoder(r.Body).Decode(patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		proxy, err := j.fakeGCE.Compute().TargetHttpsProxies().Get(context.TODO(), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		proxy.ServerTlsPolicy = patch.ServerTlsPolicy
+		j.fakeGCE.Compute().(*cloud.MockGCE).MockTargetHttpsProxies.Objects[*key] = &cloud.MockTargetHttpsProxiesObj{Obj: proxy}
+		json.NewEncoder(w).Encode(&compute.Operation{Name: "patch-operation", Status: "DONE"})
+	}))
+	defer server.Close()
+	j.fakeGCE.ComputeServices().GA.BasePath = server.URL + "/compute/v1/"
+
+	for _, tc := range []struct {
+		policy *string
+		want   string
+	}{
+		{policy: ptr.To("other-policy"), want: fmt.Sprintf("//networksecurity.googleapis.com/projects/%s/locations/global/serverTlsPolicies/other-policy", j.fakeGCE.ProjectID())},
+		{policy: ptr.To(""), want: ""},
+	} {
+		lbInfo.FrontendConfig.Spec.ServerTlsPolicy = tc.policy
+		if _, err := j.pool.Ensure(lbInfo); err != nil {
+			t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+		}
+		tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, key, meta.VersionGA, klog.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tps.ServerTlsPolicy != tc.want {
+			t.Errorf("tps server tls policy = %q, want %q", tps.ServerTlsPolicy, tc.want)
+		}
+	}
+}
+
 func TestFrontendConfigRedirects(t *testing.T) {
 	j := newTestJig(t)
 	ing := newIngress()
@@ -1179,6 +1271,50 @@ func TestFrontendConfigRedirects(t *testing.T) {
 	}
 }
 
+func TestFrontendConfigCustomErrorResponses(t *testing.T) {
+	feConfig := &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+		CustomErrorResponses: &frontendconfigv1beta1.CustomErrorResponsesConfig{
+			ErrorBackendBucket: "error-pages",
+			Rules:              []frontendconfigv1beta1.CustomErrorResponseRule{{ResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+		},
+	}}
+
+	for _, tc := range []struct {
+		desc    string
+		ing     *networkingv1.Ingress
+		wantErr bool
+	}{
+		{desc: "classic load balancer", ing: newIngress(), wantErr: true},
+		{desc: "internal load balancer", ing: newILBIngress()},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			j := newTestJig(t)
+			gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+			gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+			lbInfo := &L7RuntimeInfo{
+				AllowHTTP:      true,
+				UrlMap:         gceUrlMap,
+				Ingress:        tc.ing,
+				FrontendConfig: feConfig,
+			}
+
+			l7, err := j.pool.Ensure(lbInfo)
+			if tc.wantErr {
+				if err == nil || !IsFrontendConfigError(err) {
+					t.Fatalf("j.pool.Ensure(%v) = %v, want a FrontendConfig error", lbInfo, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+			}
+			if l7.um.DefaultCustomErrorResponsePolicy == nil {
+				t.Errorf("l7.um.DefaultCustomErrorResponsePolicy is nil, want the custom error response policy")
+			}
+		})
+	}
+}
+
 func TestEnsureSslPolicy(t *testing.T) {
 	t.Parallel()
 	j := newTestJig(t)
@@ -1267,7 +1403,7 @@ func verifyURLMap(t *testing.T, j *testJig, feNamer namer_util.IngressFrontendNa
 	if err != nil || um == nil {
 		t.Errorf("j.fakeGCE.GetUrlMap(%q) = %v, %v; want _, nil", name, um, err)
 	}
-	wantComputeURLMap := translator.ToCompositeURLMap(wantGCEURLMap, feNamer, key)
+	wantComputeURLMap := translator.ToCompositeURLMap(wantGCEURLMap, feNamer, key, nil)
 	if !mapsEqual(wantComputeURLMap, um) {
 		t.Errorf("mapsEqual() = false, got\n%+v\n  want\n%+v", um, wantComputeURLMap)
 	}
@@ -1697,6 +1833,88 @@ func TestSecretBasedToPreSharedCertUpdate(t *testing.T) {
 	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
 }
 
+func TestSecretBasedCertToCertificateMapUpdate(t *testing.T) {
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	ing := newIngress()
+	feNamer := namer_util.NewFrontendNamerFactory(j.namer, "", klog.TODO()).Namer(ing)
+	certName1 := feNamer.SSLCertName(translator.GetCertHash("cert"))
+	certName2 := feNamer.SSLCertName(translator.GetCertHash("cert2"))
+
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: false,
+		TLS:       []*translator.TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:    gceUrlMap,
+		Ingress:   ing,
+	}
+
+	// Sync secret based cert.
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	expectCerts := map[string]string{certName1: lbInfo.TLS[0].Cert}
+	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
+
+	// Switch to the certificate map. The secret based cert is detached from
+	// the proxy and deleted.
+	lbInfo.TLS = nil
+	lbInfo.CertificateMap = "my-cert-map"
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	verifyCertAndProxyLink(map[string]string{}, map[string]string{}, j, t)
+	wantCertificateMap := fmt.Sprintf("//certificatemanager.googleapis.com/projects/%s/locations/global/certificateMaps/my-cert-map", j.fakeGCE.ProjectID())
+	verifyProxyCertificateMap(wantCertificateMap, j, t)
+
+	// The certificate map is not updated if GCE refers to its project by
+	// number.
+	key, err := composite.CreateKey(j.fakeGCE, j.feNamer.TargetProxy(namer_util.HTTPSProtocol), defaultScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, key, defaultVersion, klog.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	numberedCertificateMap := "//certificatemanager.googleapis.com/projects/123456/locations/global/certificateMaps/my-cert-map"
+	if err := composite.SetCertificateMapForTargetHttpsProxy(j.fakeGCE, key, tps, numberedCertificateMap, klog.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	verifyProxyCertificateMap(numberedCertificateMap, j, t)
+
+	// Switch back to a secret based cert.
+	lbInfo.TLS = []*translator.TLSCerts{createCert("key2", "cert2", "name")}
+	lbInfo.CertificateMap = ""
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	expectCerts = map[string]string{certName2: lbInfo.TLS[0].Cert}
+	verifyCertAndProxyLink(expectCerts, expectCerts, j, t)
+	verifyProxyCertificateMap("", j, t)
+}
+
+func verifyProxyCertificateMap(want string, j *testJig, t *testing.T) {
+	t.Helper()
+
+	key, err := composite.CreateKey(j.fakeGCE, j.feNamer.TargetProxy(namer_util.HTTPSProtocol), defaultScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, key, defaultVersion, klog.TODO())
+	if err != nil {
+		t.Fatalf("expected https proxy to exist: %v, err: %v", key.Name, err)
+	}
+	if tps.CertificateMap != want {
+		t.Errorf("tps.CertificateMap = %q, want %q", tps.CertificateMap, want)
+	}
+}
+
 // TestSecretBasedToPreSharedCertUpdateWithErrors tries to incorrectly update from secret-based cert
 // to pre-shared cert, verifying that the secret-based cert is retained.
 func TestSecretBasedToPreSharedCertUpdateWithErrors(t *testing.T) {
@@ -1980,3 +2198,79 @@ func verifyLBAnnotations(t *testing.T, l7 *L7, ingAnnotations map[string]string)
 		t.Errorf("Expected ssl cert annotation to not exist")
 	}
 }
+
+func TestBackendEndpointHealth(t *testing.T) {
+	t.Parallel()
+
+	backendURL := func(name string) string {
+		return cloud.SelfLink(meta.VersionGA, "mock-project", "backendServices", meta.GlobalKey(name))
+	}
+	l7 := &L7{um: &composite.UrlMap{
+		DefaultService: backendURL("neg-default"),
+		PathMatchers: []*composite.PathMatcher{{
+			DefaultService: backendURL("neg-default"),
+			PathRules: []*composite.PathRule{
+				{Service: backendURL("neg-api")},
+				{Service: backendURL("neg-shop")},
+				{Service: backendURL("k8s-be-30000--uid1")},
+			},
+		}},
+	}}
+	// The backend health is as old as the health of its least recently
+	// polled zone.
+	polled := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	stalePoll := metav1.NewTime(polled.Add(-time.Hour))
+	svcNegLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	svcNegLister.Add(&negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "neg-api"},
+		Status: negv1beta1.ServiceNetworkEndpointGroupStatus{
+			EndpointHealth: []negv1beta1.NegEndpointHealth{
+				{Name: "neg-api", Zone: "zone-a", HealthyEndpoints: 2, UnhealthyEndpoints: 1, UnhealthyPods: []string{namespace + "/api-2"}, LastPollTime: polled},
+				{Name: "neg-api", Zone: "zone-b", HealthyEndpoints: 1, UnhealthyEndpoints: 1, UnhealthyPods: []string{namespace + "/api-1"}, LastPollTime: stalePoll},
+			},
+		},
+	})
+	// The health of this NEG has not been reported yet.
+	svcNegLister.Add(&negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "neg-default"},
+	})
+	// This NEG is of a service of another ingress of an ingress group.
+	svcNegLister.Add(&negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "neg-shop"},
+		Status: negv1beta1.ServiceNetworkEndpointGroupStatus{
+			EndpointHealth: []negv1beta1.NegEndpointHealth{
+				{Name: "neg-shop", Zone: "zone-a", HealthyEndpoints: 1, LastPollTime: polled},
+			},
+		},
+	})
+
+	health, err := GetBackendEndpointHealth(l7, svcNegLister, namespace)
+	if err != nil {
+		t.Fatalf("GetBackendEndpointHealth() = %v", err)
+	}
+	want := map[string]BackendEndpointHealth{
+		"neg-api": {Healthy: 3, Unhealthy: 2, UnhealthyPods: []string{namespace + "/api-1", namespace + "/api-2"}, LastPollTime: &stalePoll},
+	}
+	if diff := cmp.Diff(want, health); diff != "" {
+		t.Errorf("GetBackendEndpointHealth() returned unexpected health (-want +got):\n%s", diff)
+	}
+
+	groupHealth, err := GetBackendEndpointHealth(l7, svcNegLister, namespace, "shop")
+	if err != nil {
+		t.Fatalf("GetBackendEndpointHealth() = %v", err)
+	}
+	want["neg-shop"] = BackendEndpointHealth{Healthy: 1, LastPollTime: &polled}
+	if diff := cmp.Diff(want, groupHealth); diff != "" {
+		t.Errorf("GetBackendEndpointHealth() returned unexpected health for the namespaces of an ingress group (-want +got):\n%s", diff)
+	}
+
+	existing := SetBackendHealthAnnotation(map[string]string{}, health)
+	wantAnnotation := `{"neg-api":{"healthy":3,"unhealthy":2,"unhealthyPods":["namespace1/api-1","namespace1/api-2"],"lastPollTime":"2026-01-02T02:04:05Z"}}`
+	if got := existing[annotations.BackendHealthKey]; got != wantAnnotation {
+		t.Errorf("Got annotation %s = %q, want %q", annotations.BackendHealthKey, got, wantAnnotation)
+	}
+	existing = SetBackendHealthAnnotation(existing, nil)
+	if _, ok := existing[annotations.BackendHealthKey]; ok {
+		t.Errorf("Annotation %s still exists after there is no backend health", annotations.BackendHealthKey)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/plan"
+)
+
+// Collections of the load balancer resources, as used in resource paths.
+const (
+	urlMapsResource            = "urlMaps"
+	targetHttpProxiesResource  = "targetHttpProxies"
+	targetHttpsProxiesResource = "targetHttpsProxies"
+	forwardingRulesResource    = "forwardingRules"
+	sslCertificatesResource    = "sslCertificates"
+	addressesResource          = "addresses"
+)
+
+// planned records the operation and returns true if the load balancer is
+// synced in plan mode, in which case the caller must skip the GCE call.
+func (l7 *L7) planned(action plan.Action, resource string, key *meta.Key, current, desired interface{}) bool {
+	if !l7.plan.Enabled() {
+		return false
+	}
+	l7.logger.V(2).Info("Skipping GCE mutation in plan mode", "action", action, "resource", resource, "key", key)
+	l7.plan.Record(action, resource, key, current, desired)
+	return true
+}
+
+// plannedDelete is like planned for a deletion, which is only recorded if
+// get finds the resource.
+func (l7 *L7) plannedDelete(resource string, key *meta.Key, get func() error) bool {
+	if !l7.plan.Enabled() {
+		return false
+	}
+	if get() == nil {
+		l7.planned(plan.Delete, resource, key, nil, nil)
+	}
+	return true
+}
+
+// plannedSelfLink returns the self link of a resource planned for creation.
+func (l7 *L7) plannedSelfLink(resource string, key *meta.Key) string {
+	return plan.SelfLink(l7.cloud.ProjectID(), resource, key)
+}
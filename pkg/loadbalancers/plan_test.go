@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog/v2"
+)
+
+func TestPlanModeCreateHTTPLoadBalancer(t *testing.T) {
+	j := newTestJig(t)
+	j.pool.plan = plan.NewRecorder()
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+	}
+
+	ums, err := composite.ListUrlMaps(j.fakeGCE, meta.GlobalKey(""), defaultVersion, klog.TODO(), filter.None)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ums) != 0 {
+		t.Errorf("Got %d URL maps in plan mode, want none", len(ums))
+	}
+
+	want := []string{
+		"Create global/urlMaps/" + j.feNamer.UrlMap(),
+		"Create global/targetHttpProxies/" + j.feNamer.TargetProxy(namer_util.HTTPProtocol),
+		"Create global/forwardingRules/" + j.feNamer.ForwardingRule(namer_util.HTTPProtocol),
+	}
+	verifyPlannedOperations(t, j.pool.plan.Flush(), want)
+}
+
+func TestPlanModeCreateHTTPSLoadBalancer(t *testing.T) {
+	j := newTestJig(t)
+	j.pool.plan = plan.NewRecorder()
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		TLSName:   "my-cert",
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+
+	// The ephemeral IP of the planned HTTP forwarding rule is not allocated,
+	// which must not fail the promotion to a static IP.
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+	}
+
+	want := []string{
+		"Create global/urlMaps/" + j.feNamer.UrlMap(),
+		"Create global/targetHttpProxies/" + j.feNamer.TargetProxy(namer_util.HTTPProtocol),
+		"Create global/forwardingRules/" + j.feNamer.ForwardingRule(namer_util.HTTPProtocol),
+		"Create global/addresses/" + j.feNamer.ForwardingRule(namer_util.HTTPProtocol),
+		"Create global/targetHttpsProxies/" + j.feNamer.TargetProxy(namer_util.HTTPSProtocol),
+		"Create global/forwardingRules/" + j.feNamer.ForwardingRule(namer_util.HTTPSProtocol),
+	}
+	verifyPlannedOperations(t, j.pool.plan.Flush(), want)
+}
+
+func TestPlanModeUpdateURLMap(t *testing.T) {
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap(klog.TODO())
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+	}
+
+	j.pool.plan = plan.NewRecorder()
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v, want nil", err)
+	}
+
+	um, err := composite.GetUrlMap(j.fakeGCE, meta.GlobalKey(j.feNamer.UrlMap()), defaultVersion, klog.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(um.HostRules) != 0 {
+		t.Errorf("Got host rules %+v in the URL map in plan mode, want none", um.HostRules)
+	}
+
+	ops := j.pool.plan.Flush()
+	verifyPlannedOperations(t, ops, []string{"Update global/urlMaps/" + j.feNamer.UrlMap()})
+	if len(ops) == 1 && ops[0].Diff == "" {
+		t.Errorf("Got no diff for the update of the URL map")
+	}
+}
+
+func verifyPlannedOperations(t *testing.T, ops []plan.Operation, want []string) {
+	t.Helper()
+	if len(ops) != len(want) {
+		t.Fatalf("Got %d planned operations %v, want %v", len(ops), ops, want)
+	}
+	for i, op := range ops {
+		if op.String() != want[i] {
+			t.Errorf("ops[%d] = %q, want %q", i, op.String(), want[i])
+		}
+	}
+}
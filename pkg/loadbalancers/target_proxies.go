@@ -22,6 +22,7 @@ import (
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -76,6 +77,11 @@ func (l7 *L7) checkProxy() (err error) {
 		if err != nil {
 			return err
 		}
+		if l7.planned(plan.Create, targetHttpProxiesResource, key, nil, proxy) {
+			proxy.SelfLink = l7.plannedSelfLink(targetHttpProxiesResource, key)
+			l7.tp = proxy
+			return nil
+		}
 		if err = composite.CreateTargetHttpProxy(l7.cloud, key, proxy, l7.logger); err != nil {
 			return err
 		}
@@ -94,10 +100,14 @@ func (l7 *L7) checkProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetUrlMapForTargetHttpProxy(l7.cloud, key, currentProxy, proxy.UrlMap, l7.logger); err != nil {
-			return err
+		desired := *currentProxy
+		desired.UrlMap = proxy.UrlMap
+		if !l7.planned(plan.Update, targetHttpProxiesResource, key, currentProxy, &desired) {
+			if err := composite.SetUrlMapForTargetHttpProxy(l7.cloud, key, currentProxy, proxy.UrlMap, l7.logger); err != nil {
+				return err
+			}
+			l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
 		}
-		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
 	}
 	l7.tp = currentProxy
 	return nil
@@ -107,9 +117,9 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 	isL7ILB := utils.IsGCEL7ILBIngress(l7.runtimeInfo.Ingress)
 	isL7XLBRegional := utils.IsGCEL7XLBRegionalIngress(l7.runtimeInfo.Ingress)
 	tr := translator.NewTranslator(isL7ILB, isL7XLBRegional, l7.namer)
-	env := &translator.Env{FrontendConfig: l7.runtimeInfo.FrontendConfig, Region: l7.cloud.Region()}
+	env := &translator.Env{FrontendConfig: l7.runtimeInfo.FrontendConfig, Region: l7.cloud.Region(), Project: l7.cloud.ProjectID(), CertificateMap: l7.runtimeInfo.CertificateMap}
 
-	if len(l7.sslCerts) == 0 {
+	if len(l7.sslCerts) == 0 && env.CertificateMap == "" {
 		l7.logger.V(2).Info("No SSL certificates for load-balancer, will not create HTTPS Proxy.", "l7", l7)
 		return nil
 	}
@@ -138,6 +148,11 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 	if currentProxy == nil {
 		l7.logger.V(3).Info("Creating new https Proxy for urlmap", "urlMapName", l7.um.Name)
 
+		if l7.planned(plan.Create, targetHttpsProxiesResource, key, nil, proxy) {
+			proxy.SelfLink = l7.plannedSelfLink(targetHttpsProxiesResource, key)
+			l7.tps = proxy
+			return nil
+		}
 		if err = composite.CreateTargetHttpsProxy(l7.cloud, key, proxy, l7.logger); err != nil {
 			return err
 		}
@@ -162,12 +177,35 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetUrlMapForTargetHttpsProxy(l7.cloud, key, currentProxy, proxy.UrlMap, l7.logger); err != nil {
+		desired := *currentProxy
+		desired.UrlMap = proxy.UrlMap
+		if !l7.planned(plan.Update, targetHttpsProxiesResource, key, currentProxy, &desired) {
+			if err := composite.SetUrlMapForTargetHttpsProxy(l7.cloud, key, currentProxy, proxy.UrlMap, l7.logger); err != nil {
+				return err
+			}
+			l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
+		}
+	}
+
+	if !translator.EqualCertificateMaps(currentProxy.CertificateMap, proxy.CertificateMap) {
+		l7.logger.V(2).Info("Https Proxy has the wrong certificate map, overwriting",
+			"proxyName", currentProxy.Name, "newCertificateMap", proxy.CertificateMap, "existingCertificateMap", currentProxy.CertificateMap)
+		key, err := l7.CreateKey(currentProxy.Name)
+		if err != nil {
 			return err
 		}
-		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
+		desired := *currentProxy
+		desired.CertificateMap = proxy.CertificateMap
+		if !l7.planned(plan.Update, targetHttpsProxiesResource, key, currentProxy, &desired) {
+			if err := composite.SetCertificateMapForTargetHttpsProxy(l7.cloud, key, currentProxy, proxy.CertificateMap, l7.logger); err != nil {
+				return err
+			}
+			l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certificate map updated", key.Name)
+		}
 	}
 
+	// There are no ssl certs while a certificate map is attached, so the
+	// certs of the proxy are detached once it uses the certificate map.
 	if !l7.compareCerts(currentProxy.SslCertificates) {
 		l7.logger.V(2).Info("Https Proxy has the wrong ssl certs, overwriting",
 			"proxyName", currentProxy.Name, "newCerts", toCertNames(l7.sslCerts), "existingCerts", currentProxy.SslCertificates)
@@ -179,10 +217,14 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetSslCertificateForTargetHttpsProxy(l7.cloud, key, currentProxy, sslCertURLs, l7.logger); err != nil {
-			return err
+		desired := *currentProxy
+		desired.SslCertificates = sslCertURLs
+		if !l7.planned(plan.Update, targetHttpsProxiesResource, key, currentProxy, &desired) {
+			if err := composite.SetSslCertificateForTargetHttpsProxy(l7.cloud, key, currentProxy, sslCertURLs, l7.logger); err != nil {
+				return err
+			}
+			l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certs updated", key.Name)
 		}
-		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certs updated", key.Name)
 	}
 
 	if sslPolicySet {
@@ -191,6 +233,12 @@ func (l7 *L7) checkHttpsProxy() (err error) {
 		}
 	}
 
+	if feConfig := l7.runtimeInfo.FrontendConfig; feConfig != nil && feConfig.Spec.ServerTlsPolicy != nil {
+		if err := l7.ensureServerTlsPolicy(currentProxy, proxy.ServerTlsPolicy); err != nil {
+			return err
+		}
+	}
+
 	l7.tps = currentProxy
 	return nil
 }
@@ -218,6 +266,11 @@ func (l7 *L7) ensureSslPolicy(env *translator.Env, currentProxy *composite.Targe
 		if err != nil {
 			return err
 		}
+		desired := *currentProxy
+		desired.SslPolicy = policyLink
+		if l7.planned(plan.Update, targetHttpsProxiesResource, key, currentProxy, &desired) {
+			return nil
+		}
 		if l7.scope == meta.Regional {
 			if err := ensureRegionalSslPolicy(l7.cloud, key, currentProxy, policyLink, l7.logger); err != nil {
 				l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "Regional TargetHttpsProxy %q SSLPolicy updated", key.Name)
@@ -233,6 +286,44 @@ func (l7 *L7) ensureSslPolicy(env *translator.Env, currentProxy *composite.Targe
 	return nil
 }
 
+// ensureServerTlsPolicy ensures that the server TLS policy described in the
+// frontendconfig is properly applied to the proxy.
+func (l7 *L7) ensureServerTlsPolicy(currentProxy *composite.TargetHttpsProxy, policyLink string) error {
+	if translator.EqualServerTlsPolicies(policyLink, currentProxy.ServerTlsPolicy) {
+		return nil
+	}
+	l7.logger.Info("ensureServerTlsPolicy", "newPolicyLink", policyLink, "currentPolicyLink", currentProxy.ServerTlsPolicy)
+	key, err := l7.CreateKey(currentProxy.Name)
+	if err != nil {
+		return err
+	}
+	patchProxy := &composite.TargetHttpsProxy{
+		Fingerprint:     currentProxy.Fingerprint,
+		ServerTlsPolicy: policyLink,
+	}
+	if policyLink == "" {
+		patchProxy.NullFields = []string{"ServerTlsPolicy"}
+	}
+	desired := *currentProxy
+	desired.ServerTlsPolicy = policyLink
+	if l7.planned(plan.Update, targetHttpsProxiesResource, key, currentProxy, &desired) {
+		return nil
+	}
+	if l7.scope == meta.Regional {
+		if err := composite.PatchRegionalTargetHttpsProxy(l7.cloud, key, patchProxy, l7.logger); err != nil {
+			return err
+		}
+		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "Regional TargetHttpsProxy %q ServerTlsPolicy updated", key.Name)
+	} else {
+		if err := composite.PatchGlobalTargetHttpsProxy(l7.cloud, key, patchProxy, l7.logger); err != nil {
+			return err
+		}
+		l7.recorder.Eventf(l7.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetHttpsProxy %q ServerTlsPolicy updated", key.Name)
+	}
+	currentProxy.ServerTlsPolicy = policyLink
+	return nil
+}
+
 // ensureRegionalSslPolicy updates sslPolicy for regional HTTPs Proxy.
 // Regional HTTPs Proxies do not support setSslPolicy, and require using patch
 // method.
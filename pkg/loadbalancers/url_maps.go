@@ -18,12 +18,15 @@ package loadbalancers
 
 import (
 	"fmt"
+	"reflect"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
+	"k8s.io/ingress-gce/pkg/plan"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -42,9 +45,15 @@ func (l7 *L7) ensureComputeURLMap() error {
 	if err != nil {
 		return err
 	}
-	expectedMap := translator.ToCompositeURLMap(l7.runtimeInfo.UrlMap, l7.namer, key)
+	expectedMap := translator.ToCompositeURLMap(l7.runtimeInfo.UrlMap, l7.namer, key, l7.runtimeInfo.FrontendConfig)
 	key.Name = expectedMap.Name
 
+	if feConfig := l7.runtimeInfo.FrontendConfig; feConfig != nil {
+		if err := frontendconfig.ValidateCustomErrorResponses(feConfig.Spec.CustomErrorResponses, LBSchemeForIngress(&l7.ingress)); err != nil {
+			return &frontendConfigError{err}
+		}
+	}
+
 	expectedMap.Version = l7.Versions().UrlMap
 	currentMap, err := composite.GetUrlMap(l7.cloud, key, expectedMap.Version, l7.logger)
 	if utils.IgnoreHTTPNotFound(err) != nil {
@@ -55,10 +64,15 @@ func (l7 *L7) ensureComputeURLMap() error {
 		// Check for transitions between elb and ilb
 
 		l7.logger.V(2).Info("Creating URLMap", "urlMapName", expectedMap.Name)
+		if l7.planned(plan.Create, urlMapsResource, key, nil, expectedMap) {
+			l7.um = expectedMap
+			return nil
+		}
 		if err := composite.CreateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
 			return fmt.Errorf("CreateUrlMap: %v", err)
 		}
 		l7.recorder.Eventf(&l7.ingress, apiv1.EventTypeNormal, events.SyncIngress, "UrlMap %q created", key.Name)
+		l7.drift.Applied(urlMapsResource, key, expectedMap)
 		l7.um = expectedMap
 
 		return nil
@@ -66,17 +80,36 @@ func (l7 *L7) ensureComputeURLMap() error {
 
 	if mapsEqual(currentMap, expectedMap) {
 		l7.logger.V(4).Info("URLMap for load-balancer is unchanged", "l7", l7)
+		l7.drift.Applied(urlMapsResource, key, expectedMap)
 		l7.um = currentMap
 		return nil
 	}
 
+	if d := l7.drift.Detect(urlMapsResource, key, currentMap, expectedMap); d != nil {
+		policy := annotations.FromIngress(&l7.ingress).DriftPolicy()
+		l7.logger.Info("URLMap was changed outside of the controller", "drift", d.String(), "driftPolicy", policy, "repeated", d.Repeated)
+		if !d.Revert(policy) {
+			if !d.Repeated {
+				l7.recorder.Eventf(&l7.ingress, apiv1.EventTypeWarning, events.DriftDetected, "UrlMap %q was changed outside of the controller, not reverting with drift policy %s: %s", key.Name, policy, d)
+			}
+			l7.um = currentMap
+			return nil
+		}
+		l7.recorder.Eventf(&l7.ingress, apiv1.EventTypeWarning, events.DriftDetected, "UrlMap %q was changed outside of the controller, reverting: %s", key.Name, d)
+	}
+
 	l7.logger.V(2).Info("Updating URLMap for load-balancer", "l7", l7)
 	expectedMap.Fingerprint = currentMap.Fingerprint
+	if l7.planned(plan.Update, urlMapsResource, key, currentMap, expectedMap) {
+		l7.um = expectedMap
+		return nil
+	}
 	if err := composite.UpdateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
 		return fmt.Errorf("UpdateURLMap: %v", err)
 	}
 
 	l7.recorder.Eventf(&l7.ingress, apiv1.EventTypeNormal, events.SyncIngress, "UrlMap %q updated", key.Name)
+	l7.drift.Applied(urlMapsResource, key, expectedMap)
 	l7.um = expectedMap
 
 	return nil
@@ -95,13 +128,13 @@ func (l7 *L7) ensureRedirectURLMap() error {
 
 	// Cannot enable for internal ingress
 	if expectedMap != nil && isL7ILB {
-		return fmt.Errorf("error: cannot enable HTTPS Redirects with L7 ILB")
+		return &frontendConfigError{fmt.Errorf("error: cannot enable HTTPS Redirects with L7 ILB")}
 	}
 
 	// Cannot enable on older naming schemes
 	if !namerSupported {
 		if expectedMap != nil {
-			return fmt.Errorf("error: cannot enable HTTPS Redirects with the V1 Ingress naming scheme.  Please recreate your ingress to use the newest naming scheme.")
+			return &frontendConfigError{fmt.Errorf("error: cannot enable HTTPS Redirects with the V1 Ingress naming scheme.  Please recreate your ingress to use the newest naming scheme.")}
 		}
 		return nil
 	}
@@ -117,7 +150,7 @@ func (l7 *L7) ensureRedirectURLMap() error {
 		status, ok := l7.ingress.Annotations[annotations.RedirectUrlMapKey]
 		if !ok || status == "" {
 			return nil
-		} else {
+		} else if !l7.plannedDelete(urlMapsResource, key, l7.getUrlMapFunc(key, l7.Versions())) {
 			if err := composite.DeleteUrlMap(l7.cloud, key, l7.Versions().UrlMap, l7.logger); err != nil {
 				// Do not block LB sync if this fails
 				l7.logger.Error(err, "DeleteUrlMap", "key", key)
@@ -134,13 +167,17 @@ func (l7 *L7) ensureRedirectURLMap() error {
 	}
 
 	if currentMap == nil {
-		if err := composite.CreateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
-			return err
+		if !l7.planned(plan.Create, urlMapsResource, key, nil, expectedMap) {
+			if err := composite.CreateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
+				return err
+			}
 		}
 	} else if compareRedirectUrlMaps(expectedMap, currentMap) {
 		expectedMap.Fingerprint = currentMap.Fingerprint
-		if err := composite.UpdateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
-			return err
+		if !l7.planned(plan.Update, urlMapsResource, key, currentMap, expectedMap) {
+			if err := composite.UpdateUrlMap(l7.cloud, key, expectedMap, l7.logger); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -175,6 +212,33 @@ func getBackendNames(computeURLMap *composite.UrlMap) ([]string, error) {
 			}
 			beNames.Insert(name)
 		}
+
+		for _, routeRule := range pathMatcher.RouteRules {
+			if routeRule.Service != "" {
+				name, err = utils.KeyName(routeRule.Service)
+				if err != nil {
+					return nil, err
+				}
+				beNames.Insert(name)
+			}
+			if routeRule.RouteAction == nil {
+				continue
+			}
+			for _, wbs := range routeRule.RouteAction.WeightedBackendServices {
+				name, err = utils.KeyName(wbs.BackendService)
+				if err != nil {
+					return nil, err
+				}
+				beNames.Insert(name)
+			}
+			if mirror := routeRule.RouteAction.RequestMirrorPolicy; mirror != nil {
+				name, err = utils.KeyName(mirror.BackendService)
+				if err != nil {
+					return nil, err
+				}
+				beNames.Insert(name)
+			}
+		}
 	}
 	// The default Service recorded in the urlMap is a link to the backend.
 	// Note that this can either be user specified, or the L7 controller's
@@ -248,6 +312,131 @@ func mapsEqual(a, b *composite.UrlMap) bool {
 				return false
 			}
 		}
+		if !routeRulesEqual(a.RouteRules, b.RouteRules) {
+			return false
+		}
+	}
+	if !customErrorResponsePoliciesEqual(a.DefaultCustomErrorResponsePolicy, b.DefaultCustomErrorResponsePolicy) {
+		return false
+	}
+	return true
+}
+
+// routeRulesEqual compares the route rules of two path matchers.
+func routeRulesEqual(a, b []*composite.HttpRouteRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		a := a[i]
+		b := b[i]
+		if a.Priority != b.Priority {
+			return false
+		}
+		if !equalServiceLinks(a.Service, b.Service) {
+			return false
+		}
+		if len(a.MatchRules) != len(b.MatchRules) {
+			return false
+		}
+		for i := range a.MatchRules {
+			a := a.MatchRules[i]
+			b := b.MatchRules[i]
+			if a.PrefixMatch != b.PrefixMatch || a.FullPathMatch != b.FullPathMatch {
+				return false
+			}
+			if len(a.HeaderMatches) != len(b.HeaderMatches) {
+				return false
+			}
+			for i := range a.HeaderMatches {
+				a := a.HeaderMatches[i]
+				b := b.HeaderMatches[i]
+				if a.HeaderName != b.HeaderName || a.ExactMatch != b.ExactMatch || a.PresentMatch != b.PresentMatch {
+					return false
+				}
+			}
+			if len(a.QueryParameterMatches) != len(b.QueryParameterMatches) {
+				return false
+			}
+			for i := range a.QueryParameterMatches {
+				a := a.QueryParameterMatches[i]
+				b := b.QueryParameterMatches[i]
+				if a.Name != b.Name || a.ExactMatch != b.ExactMatch || a.PresentMatch != b.PresentMatch {
+					return false
+				}
+			}
+		}
+		if !reflect.DeepEqual(a.HeaderAction, b.HeaderAction) {
+			return false
+		}
+		aAction, bAction := a.RouteAction, b.RouteAction
+		if aAction == nil {
+			aAction = &composite.HttpRouteAction{}
+		}
+		if bAction == nil {
+			bAction = &composite.HttpRouteAction{}
+		}
+		if !reflect.DeepEqual(aAction.UrlRewrite, bAction.UrlRewrite) {
+			return false
+		}
+		if !reflect.DeepEqual(aAction.RetryPolicy, bAction.RetryPolicy) {
+			return false
+		}
+		if !reflect.DeepEqual(aAction.FaultInjectionPolicy, bAction.FaultInjectionPolicy) {
+			return false
+		}
+		if (aAction.RequestMirrorPolicy == nil) != (bAction.RequestMirrorPolicy == nil) {
+			return false
+		}
+		if aAction.RequestMirrorPolicy != nil && !equalServiceLinks(aAction.RequestMirrorPolicy.BackendService, bAction.RequestMirrorPolicy.BackendService) {
+			return false
+		}
+		aWeighted, bWeighted := aAction.WeightedBackendServices, bAction.WeightedBackendServices
+		if len(aWeighted) != len(bWeighted) {
+			return false
+		}
+		for i := range aWeighted {
+			if aWeighted[i].Weight != bWeighted[i].Weight {
+				return false
+			}
+			if !utils.EqualResourcePaths(aWeighted[i].BackendService, bWeighted[i].BackendService) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalServiceLinks is like utils.EqualResourcePaths except that two empty
+// links are equal, as route rules only set a service without a route action.
+func equalServiceLinks(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return utils.EqualResourcePaths(a, b)
+}
+
+// customErrorResponsePoliciesEqual compares the custom error response
+// policies of two URL maps.
+func customErrorResponsePoliciesEqual(a, b *composite.CustomErrorResponsePolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !equalServiceLinks(a.ErrorService, b.ErrorService) {
+		return false
+	}
+	if len(a.ErrorResponseRules) != len(b.ErrorResponseRules) {
+		return false
+	}
+	for i := range a.ErrorResponseRules {
+		a := a.ErrorResponseRules[i]
+		b := b.ErrorResponseRules[i]
+		if !reflect.DeepEqual(a.MatchResponseCodes, b.MatchResponseCodes) {
+			return false
+		}
+		if a.Path != b.Path || a.OverrideResponseCode != b.OverrideResponseCode {
+			return false
+		}
 	}
 	return true
 }
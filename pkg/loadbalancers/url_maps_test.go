@@ -39,6 +39,134 @@ func TestComputeURLMapEquals(t *testing.T) {
 	if mapsEqual(m, diffDefault) {
 		t.Errorf("mapsEqual(%+v, %+v) = true, want false", m, diffDefault)
 	}
+
+	// Test route rules.
+	weighted := testCompositeURLMap()
+	weighted.PathMatchers[0].PathRules = nil
+	weighted.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	if mapsEqual(m, weighted) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", m, weighted)
+	}
+	sameWeighted := testCompositeURLMap()
+	sameWeighted.PathMatchers[0].PathRules = nil
+	sameWeighted.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	if !mapsEqual(weighted, sameWeighted) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", weighted, sameWeighted)
+	}
+	diffWeights := testCompositeURLMap()
+	diffWeights.PathMatchers[0].PathRules = nil
+	diffWeights.PathMatchers[0].RouteRules = testWeightedRouteRules(50, 50)
+	if mapsEqual(weighted, diffWeights) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, diffWeights)
+	}
+
+	// Test route rule header matches.
+	headerMatch := testCompositeURLMap()
+	headerMatch.PathMatchers[0].PathRules = nil
+	headerMatch.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	headerMatch.PathMatchers[0].RouteRules[1].MatchRules[0].HeaderMatches = []*composite.HttpHeaderMatch{{HeaderName: "x-canary", ExactMatch: "true"}}
+	if mapsEqual(weighted, headerMatch) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, headerMatch)
+	}
+	queryMatch := testCompositeURLMap()
+	queryMatch.PathMatchers[0].PathRules = nil
+	queryMatch.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	queryMatch.PathMatchers[0].RouteRules[1].MatchRules[0].QueryParameterMatches = []*composite.HttpQueryParameterMatch{{Name: "beta", PresentMatch: true}}
+	if mapsEqual(weighted, queryMatch) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, queryMatch)
+	}
+
+	// Test route rule URL rewrites and header actions.
+	rewrite := testCompositeURLMap()
+	rewrite.PathMatchers[0].PathRules = nil
+	rewrite.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	rewrite.PathMatchers[0].RouteRules[1].RouteAction = &composite.HttpRouteAction{UrlRewrite: &composite.UrlRewrite{PathPrefixRewrite: "/v2"}}
+	if mapsEqual(weighted, rewrite) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, rewrite)
+	}
+	headerAction := testCompositeURLMap()
+	headerAction.PathMatchers[0].PathRules = nil
+	headerAction.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	headerAction.PathMatchers[0].RouteRules[0].HeaderAction = &composite.HttpHeaderAction{RequestHeadersToRemove: []string{"cookie"}}
+	if mapsEqual(weighted, headerAction) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, headerAction)
+	}
+
+	// Test route rule retry policies, fault injection and request mirroring.
+	retry := testCompositeURLMap()
+	retry.PathMatchers[0].PathRules = nil
+	retry.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	retry.PathMatchers[0].RouteRules[0].RouteAction.RetryPolicy = &composite.HttpRetryPolicy{RetryConditions: []string{"5xx"}, NumRetries: 3}
+	if mapsEqual(weighted, retry) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, retry)
+	}
+	fault := testCompositeURLMap()
+	fault.PathMatchers[0].PathRules = nil
+	fault.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	fault.PathMatchers[0].RouteRules[0].RouteAction.FaultInjectionPolicy = &composite.HttpFaultInjection{Abort: &composite.HttpFaultAbort{HttpStatus: 503, Percentage: 10}}
+	if mapsEqual(weighted, fault) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, fault)
+	}
+	mirror := testCompositeURLMap()
+	mirror.PathMatchers[0].PathRules = nil
+	mirror.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	mirror.PathMatchers[0].RouteRules[0].RouteAction.RequestMirrorPolicy = &composite.RequestMirrorPolicy{BackendService: "global/backendServices/k8s-be-32600--uid1"}
+	if mapsEqual(weighted, mirror) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", weighted, mirror)
+	}
+	sameMirror := testCompositeURLMap()
+	sameMirror.PathMatchers[0].PathRules = nil
+	sameMirror.PathMatchers[0].RouteRules = testWeightedRouteRules(90, 10)
+	sameMirror.PathMatchers[0].RouteRules[0].RouteAction.RequestMirrorPolicy = &composite.RequestMirrorPolicy{BackendService: "https://www.googleapis.com/compute/v1/projects/p/global/backendServices/k8s-be-32600--uid1"}
+	if !mapsEqual(mirror, sameMirror) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", mirror, sameMirror)
+	}
+
+	// Test custom error responses.
+	customErrors := testCompositeURLMap()
+	customErrors.DefaultCustomErrorResponsePolicy = &composite.CustomErrorResponsePolicy{
+		ErrorService:       "global/backendBuckets/error-pages",
+		ErrorResponseRules: []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+	}
+	if mapsEqual(m, customErrors) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", m, customErrors)
+	}
+	sameCustomErrors := testCompositeURLMap()
+	sameCustomErrors.DefaultCustomErrorResponsePolicy = &composite.CustomErrorResponsePolicy{
+		ErrorService:       "https://www.googleapis.com/compute/v1/projects/p/global/backendBuckets/error-pages",
+		ErrorResponseRules: []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html"}},
+	}
+	if !mapsEqual(customErrors, sameCustomErrors) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", customErrors, sameCustomErrors)
+	}
+	diffCustomErrors := testCompositeURLMap()
+	diffCustomErrors.DefaultCustomErrorResponsePolicy = &composite.CustomErrorResponsePolicy{
+		ErrorService:       "global/backendBuckets/error-pages",
+		ErrorResponseRules: []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{{MatchResponseCodes: []string{"5xx"}, Path: "/5xx.html", OverrideResponseCode: 503}},
+	}
+	if mapsEqual(customErrors, diffCustomErrors) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", customErrors, diffCustomErrors)
+	}
+}
+
+func testWeightedRouteRules(webWeight, canaryWeight int64) []*composite.HttpRouteRule {
+	return []*composite.HttpRouteRule{
+		{
+			Priority:   1,
+			MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/web"}},
+			RouteAction: &composite.HttpRouteAction{
+				WeightedBackendServices: []*composite.WeightedBackendService{
+					{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: webWeight},
+					{BackendService: "global/backendServices/k8s-be-32100--uid1", Weight: canaryWeight},
+				},
+			},
+		},
+		{
+			Priority:   2,
+			MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/other"}},
+			Service:    "global/backendServices/k8s-be-32500--uid1",
+		},
+	}
 }
 
 func testCompositeURLMap() *composite.UrlMap {
@@ -113,6 +241,52 @@ func TestGetBackendNames(t *testing.T) {
 			},
 			wantNames: []string{"service-A", "service-B", "service-C"},
 		},
+		"UrlMap with route rules": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendServices/service-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultService: "global/backendServices/service-B",
+						RouteRules: []*composite.HttpRouteRule{
+							{
+								Priority: 1,
+								Service:  "global/backendServices/service-C",
+							},
+							{
+								Priority: 2,
+								RouteAction: &composite.HttpRouteAction{
+									WeightedBackendServices: []*composite.WeightedBackendService{
+										{BackendService: "global/backendServices/service-D", Weight: 90},
+										{BackendService: "global/backendServices/service-E", Weight: 10},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B", "service-C", "service-D", "service-E"},
+		},
+		"UrlMap with request mirror": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendServices/service-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultService: "global/backendServices/service-B",
+						RouteRules: []*composite.HttpRouteRule{
+							{
+								Priority: 1,
+								Service:  "global/backendServices/service-C",
+								RouteAction: &composite.HttpRouteAction{
+									RequestMirrorPolicy: &composite.RequestMirrorPolicy{BackendService: "global/backendServices/service-D"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B", "service-C", "service-D"},
+		},
 		"Invalid DefaultService": {
 			urlMap: &composite.UrlMap{
 				DefaultService: "/global/backendServices/service-A",
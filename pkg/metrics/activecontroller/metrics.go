@@ -33,6 +33,7 @@ const (
 	IGControllerLabel      runningControllerName = "IG"
 	PSCControllerLabel     runningControllerName = "PSC"
 	IngressControllerLabel runningControllerName = "Ingress"
+	GatewayControllerLabel runningControllerName = "Gateway"
 )
 
 var (
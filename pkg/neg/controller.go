@@ -36,7 +36,9 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/cloud-provider/service/helpers"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	svcnegv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/backendconfig"
 	"k8s.io/ingress-gce/pkg/controller/translator"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/l4annotations"
@@ -116,6 +118,15 @@ type Controller struct {
 	// enableNEGsForIngress indicates whether the NEG controller will create NEGs for Ingress services
 	enableNEGsForIngress bool
 
+	// extraIngresses returns the Ingresses that are not in the cluster, such
+	// as those translated from Gateway API resources, whose Services also
+	// get NEGs. It is nil if there are none.
+	extraIngresses func() ([]*v1.Ingress, error)
+
+	// backendConfigLister lists the BackendConfigs, whose request mirrors
+	// also get NEGs. It is nil if BackendConfigs are disabled.
+	backendConfigLister cache.Indexer
+
 	stopCh <-chan struct{}
 	logger klog.Logger
 
@@ -216,6 +227,7 @@ func NewController(
 			podInformer.GetIndexer(),
 			cloud,
 			manager,
+			manager,
 			zoneGetter,
 			enableDualStackNEG,
 			flags.F.EnableMultiSubnetCluster && !flags.F.EnableMultiSubnetClusterPhase1,
@@ -297,8 +309,8 @@ func NewController(
 					logger.V(4).Info("Ignoring update for ingress based on annotation", "ingress", klog.KObj(curIng), "annotation", annotations.IngressClassKey)
 					return
 				}
-				keys := gatherIngressServiceKeys(oldIng)
-				keys = keys.Union(gatherIngressServiceKeys(curIng))
+				keys := negController.gatherIngressServiceKeys(oldIng)
+				keys = keys.Union(negController.gatherIngressServiceKeys(curIng))
 				for _, key := range keys.List() {
 					negController.enqueueService(cache.ExplicitKey(key))
 				}
@@ -392,6 +404,7 @@ func (c *Controller) Run() {
 	}, c.stopCh)
 
 	c.logger.V(2).Info("Starting network endpoint group controller")
+	c.ensureExistingExternalNEGs()
 	activecontrollermetrics.RecordRunningController(activecontrollermetrics.NEGControllerLabel)
 	defer func() {
 		c.logger.V(2).Info("Shutting down network endpoint group controller")
@@ -416,6 +429,26 @@ func (c *Controller) Run() {
 	<-c.stopCh
 }
 
+// ensureExistingExternalNEGs ensures the external NEGs of the services in the
+// synced informer cache, so that garbage collection does not delete the NEGs
+// of services that the service worker has not processed yet.
+func (c *Controller) ensureExistingExternalNEGs() {
+	for _, obj := range c.serviceLister.List() {
+		service, ok := obj.(*apiv1.Service)
+		if !ok {
+			continue
+		}
+		externalNEG, found, err := negannotation.FromService(service).ExternalNEGAnnotation()
+		if err != nil || !found {
+			continue
+		}
+		if err := c.manager.EnsureExternalNEGs(service, externalNEG); err != nil {
+			c.logger.Error(err, "Failed to ensure external NEGs of service", "service", klog.KObj(service))
+		}
+	}
+	c.manager.SetExternalNEGsSynced()
+}
+
 func (c *Controller) IsHealthy() error {
 	// log the last node sync
 	c.logger.V(5).Info("Last node sync time", "time", c.nodeSyncTracker.Get())
@@ -552,6 +585,25 @@ func (c *Controller) processService(key string) error {
 	if service == nil {
 		return fmt.Errorf("cannot convert to Service (%T)", obj)
 	}
+	externalNEG, found, err := negannotation.FromService(service).ExternalNEGAnnotation()
+	if err != nil {
+		c.recorder.Eventf(service, apiv1.EventTypeWarning, "ExternalNEGInvalid", err.Error())
+		return err
+	}
+	if found {
+		// The endpoints of the NEGs of the service are outside of the
+		// cluster, so the service has no NEGs synced from its endpoints.
+		c.logger.V(2).Info("Syncing external NEGs of service", "service", key)
+		c.manager.StopSyncer(namespace, name)
+		if err := c.manager.EnsureExternalNEGs(service, externalNEG); err != nil {
+			c.recorder.Eventf(service, apiv1.EventTypeWarning, "SyncExternalNEGFailed", err.Error())
+			return err
+		}
+		return c.syncNegStatusAnnotation(namespace, name, make(negtypes.PortInfoMap))
+	}
+	if err := c.manager.EnsureExternalNEGs(service, nil); err != nil {
+		return err
+	}
 	negUsage := metricscollector.NegServiceState{}
 	svcPortInfoMap := make(negtypes.PortInfoMap)
 	networkInfo, err := c.networkResolver.ServiceNetwork(service)
@@ -648,8 +700,15 @@ func (c *Controller) mergeIngressPortInfo(service *apiv1.Service, name types.Nam
 	// handle NEGs used by ingress
 	if negAnnotation != nil && negAnnotation.NEGEnabledForIngress() {
 		// Only service ports referenced by ingress are synced for NEG
-		ings := getIngressServicesFromStore(c.ingressLister, service)
-		ingressSvcPortTuples := gatherPortMappingUsedByIngress(ings, service, c.logger)
+		ings := c.getIngressServicesFromStore(service)
+		if c.extraIngresses != nil {
+			extraIngresses, err := c.extraIngresses()
+			if err != nil {
+				return err
+			}
+			ings = append(ings, c.getIngressServices(extraIngresses, service)...)
+		}
+		ingressSvcPortTuples := c.gatherPortMappingUsedByIngress(ings, service)
 		ingressPortInfoMap := negtypes.NewPortInfoMap(name.Namespace, name.Name, ingressSvcPortTuples, c.namer, true, nil, networkInfo)
 		if err := portInfoMap.Merge(ingressPortInfoMap); err != nil {
 			return fmt.Errorf("failed to merge service ports referenced by ingress (%v): %w", ingressPortInfoMap, err)
@@ -779,10 +838,20 @@ func (c *Controller) mergeDefaultBackendServicePortInfoMap(key string, service *
 		return nil
 	}
 
+	ings := make([]*v1.Ingress, 0, len(c.ingressLister.List()))
+	for _, m := range c.ingressLister.List() {
+		ings = append(ings, m.(*v1.Ingress))
+	}
+	if c.extraIngresses != nil {
+		extraIngresses, err := c.extraIngresses()
+		if err != nil {
+			return err
+		}
+		ings = append(ings, extraIngresses...)
+	}
 	scanIngress := func(qualify func(*v1.Ingress) bool) error {
-		for _, m := range c.ingressLister.List() {
-			ing := *m.(*v1.Ingress)
-			if qualify(&ing) && ing.Spec.DefaultBackend == nil {
+		for _, ing := range ings {
+			if qualify(ing) && ing.Spec.DefaultBackend == nil {
 				svcPortTupleSet := make(negtypes.SvcPortTupleSet)
 				svcPortTupleSet.Insert(negtypes.SvcPortTuple{
 					Name:       c.defaultBackendService.ID.Port.Name,
@@ -936,7 +1005,7 @@ func (c *Controller) enqueueService(obj interface{}) {
 
 func (c *Controller) enqueueIngressServices(ing *v1.Ingress) {
 	// enqueue services referenced by ingress
-	keys := gatherIngressServiceKeys(ing)
+	keys := c.gatherIngressServiceKeys(ing)
 	for key := range keys {
 		c.enqueueService(cache.ExplicitKey(key))
 	}
@@ -948,6 +1017,31 @@ func (c *Controller) enqueueIngressServices(ing *v1.Ingress) {
 	}
 }
 
+// SetExtraIngresses sets the function that returns the Ingresses that are
+// not in the cluster, such as those translated from Gateway API resources,
+// whose Services also get NEGs. Their Services are enqueued on the events of
+// the given informers of the objects they are translated from.
+func (c *Controller) SetExtraIngresses(list func() ([]*v1.Ingress, error), informers ...cache.SharedIndexInformer) {
+	c.extraIngresses = list
+	enqueue := func() {
+		ings, err := list()
+		if err != nil {
+			c.logger.V(2).Info("Failed to list extra ingresses", "err", err)
+			return
+		}
+		for _, ing := range ings {
+			c.enqueueIngressServices(ing)
+		}
+	}
+	for _, informer := range informers {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { enqueue() },
+			UpdateFunc: func(old, cur interface{}) { enqueue() },
+			DeleteFunc: func(obj interface{}) { enqueue() },
+		})
+	}
+}
+
 func (c *Controller) enqueueNodeTopology(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -966,17 +1060,95 @@ func (c *Controller) gc() {
 	}
 }
 
+// SetBackendConfigInformer sets the informer of the BackendConfigs. The
+// Services that receive the requests mirrored by the BackendConfigs of the
+// Ingress backends also get NEGs, and are enqueued on BackendConfig events.
+func (c *Controller) SetBackendConfigInformer(informer cache.SharedIndexInformer) {
+	c.backendConfigLister = informer.GetIndexer()
+	enqueueMirror := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		beConfig, ok := obj.(*backendconfigv1.BackendConfig)
+		if !ok || beConfig.Spec.RequestMirror == nil {
+			return
+		}
+		c.enqueueService(cache.ExplicitKey(utils.ServiceKeyFunc(beConfig.Namespace, beConfig.Spec.RequestMirror.ServiceName)))
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueMirror,
+		UpdateFunc: func(old, cur interface{}) {
+			enqueueMirror(old)
+			enqueueMirror(cur)
+		},
+		DeleteFunc: enqueueMirror,
+	})
+}
+
+// traverseIngressBackends is like utils.TraverseIngressBackends, and also
+// processes the Services that receive the requests mirrored by the
+// BackendConfigs of the backends.
+func (c *Controller) traverseIngressBackends(ing *v1.Ingress, process func(id utils.ServicePortID) bool) {
+	var mirrors []utils.ServicePortID
+	stopped := false
+	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+		if process(id) {
+			stopped = true
+			return true
+		}
+		if mirror := c.requestMirror(id); mirror != nil {
+			mirrors = append(mirrors, *mirror)
+		}
+		return false
+	})
+	if stopped {
+		return
+	}
+	for _, id := range mirrors {
+		if process(id) {
+			return
+		}
+	}
+}
+
+// requestMirror returns the Service port that receives the requests mirrored
+// by the BackendConfig of the given backend, or nil if there is none. Invalid
+// BackendConfigs are reported when the Ingress is translated.
+func (c *Controller) requestMirror(id utils.ServicePortID) *utils.ServicePortID {
+	if c.backendConfigLister == nil {
+		return nil
+	}
+	obj, exists, err := c.serviceLister.GetByKey(id.Service.String())
+	if err != nil || !exists {
+		return nil
+	}
+	svc := obj.(*apiv1.Service)
+	svcPort := translator.ServicePort(*svc, id.Port)
+	if svcPort == nil {
+		return nil
+	}
+	beConfig, err := backendconfig.GetBackendConfigForServicePort(c.backendConfigLister, svc, svcPort)
+	if err != nil || beConfig == nil || beConfig.Spec.RequestMirror == nil {
+		return nil
+	}
+	mirror := beConfig.Spec.RequestMirror
+	return &utils.ServicePortID{
+		Service: types.NamespacedName{Namespace: svc.Namespace, Name: mirror.ServiceName},
+		Port:    v1.ServiceBackendPort{Number: mirror.ServicePort, Name: mirror.ServicePortName},
+	}
+}
+
 // gatherPortMappingUsedByIngress returns a map containing port:targetport
 // of all service ports of the service that are referenced by ingresses
-func gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service, logger klog.Logger) negtypes.SvcPortTupleSet {
+func (c *Controller) gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service) negtypes.SvcPortTupleSet {
 	ingressSvcPortTuples := make(negtypes.SvcPortTupleSet)
 	for _, ing := range ings {
 		if utils.IsGLBCIngress(&ing) {
-			utils.TraverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
+			c.traverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
 				if id.Service.Name == svc.Name && id.Service.Namespace == svc.Namespace {
 					servicePort := translator.ServicePort(*svc, id.Port)
 					if servicePort == nil {
-						logger.Error(nil, "Port not found in service", "port", fmt.Sprintf("%+v", id.Port), "service", id.Service.String())
+						c.logger.Error(nil, "Port not found in service", "port", fmt.Sprintf("%+v", id.Port), "service", id.Service.String())
 						return false
 					}
 					ingressSvcPortTuples.Insert(negtypes.SvcPortTuple{
@@ -993,27 +1165,37 @@ func gatherPortMappingUsedByIngress(ings []v1.Ingress, svc *apiv1.Service, logge
 }
 
 // gatherIngressServiceKeys returns all service key (formatted as namespace/name) referenced in the ingress
-func gatherIngressServiceKeys(ing *v1.Ingress) sets.String {
+func (c *Controller) gatherIngressServiceKeys(ing *v1.Ingress) sets.String {
 	set := sets.NewString()
 	if ing == nil {
 		return set
 	}
-	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+	c.traverseIngressBackends(ing, func(id utils.ServicePortID) bool {
 		set.Insert(utils.ServiceKeyFunc(id.Service.Namespace, id.Service.Name))
 		return false
 	})
 	return set
 }
 
-func getIngressServicesFromStore(store cache.Store, svc *apiv1.Service) (ings []v1.Ingress) {
-	for _, m := range store.List() {
-		ing := *m.(*v1.Ingress)
+func (c *Controller) getIngressServicesFromStore(svc *apiv1.Service) (ings []v1.Ingress) {
+	var all []*v1.Ingress
+	for _, m := range c.ingressLister.List() {
+		all = append(all, m.(*v1.Ingress))
+	}
+	return c.getIngressServices(all, svc)
+}
+
+// getIngressServices returns the given Ingresses that reference the Service,
+// directly or as the request mirror of a backend.
+func (c *Controller) getIngressServices(all []*v1.Ingress, svc *apiv1.Service) (ings []v1.Ingress) {
+	for _, m := range all {
+		ing := *m
 		if ing.Namespace != svc.Namespace {
 			continue
 		}
 
 		if utils.IsGLBCIngress(&ing) {
-			utils.TraverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
+			c.traverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
 				if id.Service.Name == svc.Name {
 					ings = append(ings, ing)
 					return true
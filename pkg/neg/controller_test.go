@@ -27,7 +27,9 @@ import (
 	"time"
 
 	networkv1 "github.com/GoogleCloudPlatform/gke-networking-api/apis/network/v1"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
@@ -42,6 +44,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/l4annotations"
 	"k8s.io/ingress-gce/pkg/neg/metrics/metricscollector"
@@ -821,7 +824,7 @@ func TestGatherPortMappingUsedByIngress(t *testing.T) {
 			t.Fatalf("failed to create test controller %s", err)
 		}
 		defer controller.stop()
-		portTupleSet := gatherPortMappingUsedByIngress(tc.ings, newTestService(controller, true, []int32{}), klog.TODO())
+		portTupleSet := controller.gatherPortMappingUsedByIngress(tc.ings, newTestService(controller, true, []int32{}))
 		if len(portTupleSet) != len(tc.expect) {
 			t.Errorf("For test case %q, expect %d ports, but got %d.", tc.desc, len(tc.expect), len(portTupleSet))
 		}
@@ -843,6 +846,108 @@ func TestGatherPortMappingUsedByIngress(t *testing.T) {
 	}
 }
 
+func TestGatherPortMappingUsedByIngressRequestMirror(t *testing.T) {
+	controller, err := newTestController(fake.NewSimpleClientset())
+	if err != nil {
+		t.Fatalf("failed to create test controller %s", err)
+	}
+	defer controller.stop()
+
+	backend := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testServiceNamespace,
+			Name:        "web",
+			Annotations: map[string]string{annotations.BackendConfigKey: `{"default": "web"}`},
+		},
+		Spec: apiv1.ServiceSpec{Ports: []apiv1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}}},
+	}
+	controller.serviceLister.Add(backend)
+	mirror := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testServiceNamespace, Name: "shadow"},
+		Spec: apiv1.ServiceSpec{Ports: []apiv1.ServicePort{
+			{Name: "http", Port: 8080, TargetPort: intstr.FromInt(9090)},
+			{Name: "unused", Port: 9000, TargetPort: intstr.FromInt(9000)},
+		}},
+	}
+	ing := newTestIngress("web")
+	ing.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}},
+	}
+	ing.Spec.Rules = nil
+	beConfig := &backendconfigv1.BackendConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testServiceNamespace, Name: "web"},
+		Spec: backendconfigv1.BackendConfigSpec{
+			RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow", ServicePortName: "http"},
+		},
+	}
+
+	// The mirror only gets a NEG once the BackendConfigs are known.
+	if got := controller.gatherPortMappingUsedByIngress([]networkingv1.Ingress{*ing}, mirror); len(got) != 0 {
+		t.Errorf("gatherPortMappingUsedByIngress() = %v without BackendConfigs, want no ports", got)
+	}
+
+	controller.backendConfigLister = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	controller.backendConfigLister.Add(beConfig)
+	want := negtypes.NewSvcPortTupleSet(negtypes.SvcPortTuple{Name: "http", Port: 8080, TargetPort: "9090"})
+	if got := controller.gatherPortMappingUsedByIngress([]networkingv1.Ingress{*ing}, mirror); !reflect.DeepEqual(got, want) {
+		t.Errorf("gatherPortMappingUsedByIngress() = %v, want %v", got, want)
+	}
+	wantKeys := sets.NewString(utils.ServiceKeyFunc(testServiceNamespace, "web"), utils.ServiceKeyFunc(testServiceNamespace, "shadow"))
+	if got := controller.gatherIngressServiceKeys(ing); !got.Equal(wantKeys) {
+		t.Errorf("gatherIngressServiceKeys() = %v, want %v", got.List(), wantKeys.List())
+	}
+}
+
+func TestEnsureExistingExternalNEGs(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	testContext := negtypes.NewTestContextWithKubeClient(kubeClient)
+	controller, err := newTestControllerWithParamsAndContext(kubeClient, testContext, false, false)
+	if err != nil {
+		t.Fatalf("failed to create test controller %s", err)
+	}
+	defer controller.stop()
+	manager := controller.manager.(*syncerManager)
+	ctx := context.Background()
+
+	// The serverless NEG of the service was ensured before the controller
+	// started.
+	key := meta.RegionalKey(manager.namer.NEG(testServiceNamespace, "run", 80), "us-central1")
+	desc := utils.NegDescription{ClusterUID: string(manager.kubeSystemUID), Namespace: testServiceNamespace, ServiceName: "run", Port: "80"}
+	neg := &compute.NetworkEndpointGroup{
+		Name:                key.Name,
+		NetworkEndpointType: string(negtypes.ServerlessEndpointType),
+		Description:         desc.String(),
+		CloudRun:            &compute.NetworkEndpointGroupCloudRun{Service: "api"},
+	}
+	if err := testContext.Cloud.Compute().RegionNetworkEndpointGroups().Insert(ctx, key, neg); err != nil {
+		t.Fatalf("Failed to insert NEG %s: %v", key, err)
+	}
+	controller.serviceLister.Add(&apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testServiceNamespace,
+			Name:        "run",
+			Annotations: map[string]string{negannotation.ExternalNEGAnnotationKey: `{"serverless": {"region": "us-central1", "cloudRun": {"service": "api"}}}`},
+		},
+		Spec: apiv1.ServiceSpec{Ports: []apiv1.ServicePort{{Port: 80}}},
+	})
+
+	// Garbage collection before the existing services are ensured does not
+	// delete their NEGs.
+	controller.gc()
+	if _, err := testContext.Cloud.Compute().RegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("Failed to get NEG %s after garbage collection: %v", key, err)
+	}
+
+	controller.ensureExistingExternalNEGs()
+	controller.gc()
+	if _, err := testContext.Cloud.Compute().RegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("Failed to get NEG %s after garbage collection: %v", key, err)
+	}
+	if !manager.externalNegsSynced {
+		t.Errorf("External NEGs are not synced after ensureExistingExternalNEGs()")
+	}
+}
+
 func TestSyncNegAnnotation(t *testing.T) {
 	t.Parallel()
 	// TODO: test that c.serviceLister.Update is called whenever the annotation
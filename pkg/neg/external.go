@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package neg
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	apiv1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/negannotation"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// externalNEGTypes are the types of the NEGs managed by EnsureExternalNEGs.
+var externalNEGTypes = sets.New(
+	string(negtypes.ServerlessEndpointType),
+	string(negtypes.InternetFQDNEndpointType),
+	string(negtypes.InternetIPEndpointType),
+)
+
+// externalNEGsForService returns the external NEGs described by the
+// annotation of the service, one for each port of the service.
+func (manager *syncerManager) externalNEGsForService(service *apiv1.Service, annotation *negannotation.ExternalNegAnnotation) []negtypes.ExternalNEG {
+	var negs []negtypes.ExternalNEG
+	for _, port := range service.Spec.Ports {
+		desc := utils.NegDescription{
+			ClusterUID:  string(manager.kubeSystemUID),
+			Namespace:   service.Namespace,
+			ServiceName: service.Name,
+			Port:        strconv.Itoa(int(port.Port)),
+		}
+		negName := manager.namer.NEG(service.Namespace, service.Name, port.Port)
+		negs = append(negs, negtypes.NewExternalNEG(annotation, negName, port.Port, desc))
+	}
+	return negs
+}
+
+// EnsureExternalNEGs ensures the serverless and internet NEGs described by
+// the annotation of the service exist with their endpoints. NEGs that the
+// service no longer needs are deleted by the next garbage collection.
+func (manager *syncerManager) EnsureExternalNEGs(service *apiv1.Service, annotation *negannotation.ExternalNegAnnotation) error {
+	key := getServiceKey(service.Namespace, service.Name)
+	var negs []negtypes.ExternalNEG
+	if annotation != nil {
+		negs = manager.externalNEGsForService(service, annotation)
+	}
+	manager.mu.Lock()
+	if len(negs) == 0 {
+		delete(manager.externalNegMap, key)
+	} else {
+		manager.externalNegMap[key] = negs
+	}
+	for _, neg := range negs {
+		if neg.Key.Type() == meta.Regional {
+			manager.externalNegRegions.Insert(neg.Key.Region)
+		}
+	}
+	manager.mu.Unlock()
+
+	var errList []error
+	for _, neg := range negs {
+		if err := manager.ensureExternalNEG(neg); err != nil {
+			errList = append(errList, fmt.Errorf("failed to ensure NEG %s: %w", neg.Key, err))
+		}
+	}
+	return utilerrors.NewAggregate(errList)
+}
+
+// ensureExternalNEG creates the given NEG if it does not exist, and syncs
+// the endpoints of an internet NEG. The backend of an existing serverless NEG
+// cannot be changed.
+func (manager *syncerManager) ensureExternalNEG(neg negtypes.ExternalNEG) error {
+	logger := manager.logger.WithValues("negName", neg.Key.Name, "region", neg.Key.Region)
+	existing, err := manager.cloud.GetExternalNetworkEndpointGroup(neg.Key, logger)
+	switch {
+	case utils.IsNotFoundError(err):
+		if err := manager.cloud.CreateExternalNetworkEndpointGroup(neg.NEG, neg.Key, logger); err != nil {
+			return err
+		}
+		if len(neg.Endpoints) == 0 {
+			return nil
+		}
+		return manager.cloud.AttachExternalNetworkEndpoints(neg.Key, neg.Endpoints, logger)
+	case err != nil:
+		return err
+	}
+
+	expectedDesc, err := utils.NegDescriptionFromString(neg.NEG.Description)
+	if err != nil {
+		return err
+	}
+	if matches, err := utils.VerifyDescription(*expectedDesc, existing.Description, neg.Key.Name, neg.Key.Region); !matches {
+		return err
+	}
+	if !neg.SameConfig(existing) {
+		return fmt.Errorf("NEG already exists with type %s and another backend, it must be deleted to be recreated with type %s", existing.NetworkEndpointType, neg.NEG.NetworkEndpointType)
+	}
+	if existing.NetworkEndpointType == string(negtypes.ServerlessEndpointType) {
+		return nil
+	}
+
+	current, err := manager.cloud.ListExternalNetworkEndpoints(neg.Key, logger)
+	if err != nil {
+		return err
+	}
+	toAdd, toRemove := diffExternalNetworkEndpoints(current, neg.Endpoints)
+	if len(toRemove) > 0 {
+		if err := manager.cloud.DetachExternalNetworkEndpoints(neg.Key, toRemove, logger); err != nil {
+			return err
+		}
+	}
+	if len(toAdd) > 0 {
+		return manager.cloud.AttachExternalNetworkEndpoints(neg.Key, toAdd, logger)
+	}
+	return nil
+}
+
+// diffExternalNetworkEndpoints returns the endpoints to attach to and to
+// detach from an internet NEG with the current endpoints.
+func diffExternalNetworkEndpoints(current, desired []*compute.NetworkEndpoint) (toAdd, toRemove []*compute.NetworkEndpoint) {
+	currentKeys := sets.New[string]()
+	for _, ep := range current {
+		currentKeys.Insert(negtypes.ExternalNetworkEndpointKey(ep))
+	}
+	desiredKeys := sets.New[string]()
+	for _, ep := range desired {
+		desiredKeys.Insert(negtypes.ExternalNetworkEndpointKey(ep))
+		if !currentKeys.Has(negtypes.ExternalNetworkEndpointKey(ep)) {
+			toAdd = append(toAdd, ep)
+		}
+	}
+	for _, ep := range current {
+		if !desiredKeys.Has(negtypes.ExternalNetworkEndpointKey(ep)) {
+			toRemove = append(toRemove, ep)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// SetExternalNEGsSynced signals that EnsureExternalNEGs has run for the
+// services that existed when the controller started.
+func (manager *syncerManager) SetExternalNEGsSynced() {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	manager.externalNegsSynced = true
+}
+
+// garbageCollectExternalNEGs deletes the serverless and internet NEGs of the
+// cluster that no service needs. It looks for them globally, in the region of
+// the cluster and in the regions where serverless NEGs have been ensured or
+// found. The first garbage collection after the manager starts looks in all
+// the regions of the project, since the regions of the NEGs ensured before
+// are not known. Nothing is deleted until SetExternalNEGsSynced is called.
+func (manager *syncerManager) garbageCollectExternalNEGs() error {
+	desired := sets.New[meta.Key]()
+	regions := sets.New("", manager.cloud.Region())
+	var listed, synced bool
+	func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+		synced = manager.externalNegsSynced
+		for _, negs := range manager.externalNegMap {
+			for _, neg := range negs {
+				desired.Insert(*neg.Key)
+			}
+		}
+		regions = regions.Union(manager.externalNegRegions)
+		listed = manager.externalNegRegionsListed
+	}()
+	if !synced {
+		manager.logger.V(2).Info("Skipping external NEG garbage collection until the external NEGs of the existing services are ensured")
+		return nil
+	}
+
+	var errList []error
+	// complete is whether the NEGs of all the regions of the project were
+	// listed.
+	complete := listed
+	if !listed {
+		allRegions, err := manager.cloud.ListRegions(manager.logger)
+		if err != nil {
+			errList = append(errList, fmt.Errorf("failed to list regions: %w", err))
+		} else {
+			regions.Insert(allRegions...)
+			complete = true
+		}
+	}
+
+	found := sets.New[string]()
+	for _, region := range sets.List(regions) {
+		negs, err := manager.cloud.ListExternalNetworkEndpointGroup(region, manager.logger)
+		if err != nil {
+			errList = append(errList, err)
+			complete = false
+			continue
+		}
+		for _, neg := range negs {
+			key := meta.GlobalKey(neg.Name)
+			if region != "" {
+				key = meta.RegionalKey(neg.Name, region)
+			}
+			if !externalNEGTypes.Has(neg.NetworkEndpointType) || !manager.namer.IsNEG(neg.Name) {
+				continue
+			}
+			desc, err := utils.NegDescriptionFromString(neg.Description)
+			if err != nil || desc.ClusterUID != string(manager.kubeSystemUID) {
+				continue
+			}
+			if region != "" {
+				found.Insert(region)
+			}
+			if desired.Has(*key) {
+				continue
+			}
+			if err := manager.cloud.DeleteExternalNetworkEndpointGroup(key, manager.logger); err != nil && !utils.IsNotFoundError(err) {
+				errList = append(errList, fmt.Errorf("failed to delete NEG %s: %w", key, err))
+			}
+		}
+	}
+
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	manager.externalNegRegions = manager.externalNegRegions.Union(found)
+	manager.externalNegRegionsListed = manager.externalNegRegionsListed || complete
+	return utilerrors.NewAggregate(errList)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package neg
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/negannotation"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// mockInternetNetworkEndpoints keeps the endpoints of the global NEGs of the
+// mock cloud, and returns them by NEG name.
+func mockInternetNetworkEndpoints(mockGCE *cloud.MockGCE) map[string][]*compute.NetworkEndpoint {
+	endpoints := map[string][]*compute.NetworkEndpoint{}
+	m := mockGCE.MockGlobalNetworkEndpointGroups
+	m.AttachNetworkEndpointsHook = func(_ context.Context, key *meta.Key, req *compute.GlobalNetworkEndpointGroupsAttachEndpointsRequest, _ *cloud.MockGlobalNetworkEndpointGroups, _ ...cloud.Option) error {
+		endpoints[key.Name] = append(endpoints[key.Name], req.NetworkEndpoints...)
+		return nil
+	}
+	m.DetachNetworkEndpointsHook = func(_ context.Context, key *meta.Key, req *compute.GlobalNetworkEndpointGroupsDetachEndpointsRequest, _ *cloud.MockGlobalNetworkEndpointGroups, _ ...cloud.Option) error {
+		var remaining []*compute.NetworkEndpoint
+		for _, ep := range endpoints[key.Name] {
+			detached := false
+			for _, d := range req.NetworkEndpoints {
+				detached = detached || negtypes.ExternalNetworkEndpointKey(ep) == negtypes.ExternalNetworkEndpointKey(d)
+			}
+			if !detached {
+				remaining = append(remaining, ep)
+			}
+		}
+		endpoints[key.Name] = remaining
+		return nil
+	}
+	m.ListNetworkEndpointsHook = func(_ context.Context, key *meta.Key, _ *filter.F, _ *cloud.MockGlobalNetworkEndpointGroups, _ ...cloud.Option) ([]*compute.NetworkEndpointWithHealthStatus, error) {
+		var ret []*compute.NetworkEndpointWithHealthStatus
+		for _, ep := range endpoints[key.Name] {
+			ret = append(ret, &compute.NetworkEndpointWithHealthStatus{NetworkEndpoint: ep})
+		}
+		return ret, nil
+	}
+	return endpoints
+}
+
+func TestEnsureExternalNEGs(t *testing.T) {
+	t.Parallel()
+
+	manager, fakeGCE, _, err := NewTestSyncerManager(fake.NewSimpleClientset())
+	if err != nil {
+		t.Fatalf("failed to create test syncer manager: %v", err)
+	}
+	endpoints := mockInternetNetworkEndpoints(fakeGCE.Compute().(*cloud.MockGCE))
+	ctx := context.Background()
+
+	origin := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace1, Name: name1},
+		Spec:       apiv1.ServiceSpec{Ports: []apiv1.ServicePort{{Port: 443}}},
+	}
+	internet := &negannotation.ExternalNegAnnotation{Internet: &negannotation.InternetNeg{FQDN: "origin.example.com"}}
+	if err := manager.EnsureExternalNEGs(origin, internet); err != nil {
+		t.Fatalf("EnsureExternalNEGs() = %v", err)
+	}
+	internetKey := meta.GlobalKey(manager.namer.NEG(namespace1, name1, 443))
+	neg, err := fakeGCE.Compute().GlobalNetworkEndpointGroups().Get(ctx, internetKey)
+	if err != nil {
+		t.Fatalf("Failed to get internet NEG %s: %v", internetKey, err)
+	}
+	if neg.NetworkEndpointType != string(negtypes.InternetFQDNEndpointType) {
+		t.Errorf("Got NEG type %q, want %q", neg.NetworkEndpointType, negtypes.InternetFQDNEndpointType)
+	}
+	if eps := endpoints[internetKey.Name]; len(eps) != 1 || eps[0].Fqdn != "origin.example.com" || eps[0].Port != 443 {
+		t.Errorf("Got endpoints %+v, want origin.example.com:443", eps)
+	}
+
+	// Changing the origin replaces the endpoint of the NEG.
+	internet.Internet = &negannotation.InternetNeg{IPAddress: "1.2.3.4", Port: 8443}
+	if err := manager.EnsureExternalNEGs(origin, internet); err == nil {
+		t.Errorf("EnsureExternalNEGs() = nil for an internet NEG with another type, want error")
+	}
+	internet.Internet = &negannotation.InternetNeg{FQDN: "other.example.com", Port: 8443}
+	if err := manager.EnsureExternalNEGs(origin, internet); err != nil {
+		t.Fatalf("EnsureExternalNEGs() = %v", err)
+	}
+	if eps := endpoints[internetKey.Name]; len(eps) != 1 || eps[0].Fqdn != "other.example.com" || eps[0].Port != 8443 {
+		t.Errorf("Got endpoints %+v, want other.example.com:8443", eps)
+	}
+
+	run := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace1, Name: name2},
+		Spec:       apiv1.ServiceSpec{Ports: []apiv1.ServicePort{{Port: 80}}},
+	}
+	serverless := &negannotation.ExternalNegAnnotation{Serverless: &negannotation.ServerlessNeg{Region: "us-west1", CloudRun: &negannotation.CloudRunBackend{Service: "api"}}}
+	if err := manager.EnsureExternalNEGs(run, serverless); err != nil {
+		t.Fatalf("EnsureExternalNEGs() = %v", err)
+	}
+	serverlessKey := meta.RegionalKey(manager.namer.NEG(namespace1, name2, 80), "us-west1")
+	neg, err = fakeGCE.Compute().RegionNetworkEndpointGroups().Get(ctx, serverlessKey)
+	if err != nil {
+		t.Fatalf("Failed to get serverless NEG %s: %v", serverlessKey, err)
+	}
+	if neg.NetworkEndpointType != string(negtypes.ServerlessEndpointType) || neg.CloudRun == nil || neg.CloudRun.Service != "api" {
+		t.Errorf("Got NEG %+v, want a serverless NEG of the Cloud Run service api", neg)
+	}
+	// The backend of a serverless NEG cannot be changed.
+	serverless.Serverless.CloudRun.Service = "other"
+	if err := manager.EnsureExternalNEGs(run, serverless); err == nil {
+		t.Errorf("EnsureExternalNEGs() = nil for a serverless NEG with another backend, want error")
+	}
+
+	// NEGs of other clusters are not garbage collected.
+	otherKey := meta.GlobalKey(manager.namer.NEG(namespace2, name1, 443))
+	other := &compute.NetworkEndpointGroup{Name: otherKey.Name, NetworkEndpointType: string(negtypes.InternetFQDNEndpointType), Description: `{"cluster-uid":"other"}`}
+	if err := fakeGCE.Compute().GlobalNetworkEndpointGroups().Insert(ctx, otherKey, other); err != nil {
+		t.Fatalf("Failed to insert NEG %s: %v", otherKey, err)
+	}
+
+	// The NEGs of services that no longer need them are garbage collected.
+	manager.SetExternalNEGsSynced()
+	manager.StopSyncer(namespace1, name1)
+	if err := manager.EnsureExternalNEGs(run, nil); err != nil {
+		t.Fatalf("EnsureExternalNEGs() = %v", err)
+	}
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v", err)
+	}
+	for _, key := range []*meta.Key{internetKey, serverlessKey} {
+		var err error
+		if key.Type() == meta.Regional {
+			_, err = fakeGCE.Compute().RegionNetworkEndpointGroups().Get(ctx, key)
+		} else {
+			_, err = fakeGCE.Compute().GlobalNetworkEndpointGroups().Get(ctx, key)
+		}
+		if err == nil {
+			t.Errorf("NEG %s still exists after garbage collection", key)
+		}
+	}
+	if _, err := fakeGCE.Compute().GlobalNetworkEndpointGroups().Get(ctx, otherKey); err != nil {
+		t.Errorf("Failed to get NEG %s of another cluster after garbage collection: %v", otherKey, err)
+	}
+}
+
+func TestGarbageCollectExternalNEGsAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	manager, fakeGCE, _, err := NewTestSyncerManager(fake.NewSimpleClientset())
+	if err != nil {
+		t.Fatalf("failed to create test syncer manager: %v", err)
+	}
+	mockGCE := fakeGCE.Compute().(*cloud.MockGCE)
+	regionLists := 0
+	mockGCE.MockRegions.ListHook = func(_ context.Context, _ *filter.F, _ *cloud.MockRegions, _ ...cloud.Option) (bool, []*compute.Region, error) {
+		regionLists++
+		return true, []*compute.Region{{Name: "us-east1"}, {Name: "us-west1"}}, nil
+	}
+	ctx := context.Background()
+
+	// The serverless NEG was ensured before the manager started, in a region
+	// the manager does not know about.
+	key := meta.RegionalKey(manager.namer.NEG(namespace1, name1, 80), "us-east1")
+	desc := utils.NegDescription{ClusterUID: string(manager.kubeSystemUID), Namespace: namespace1, ServiceName: name1, Port: "80"}
+	neg := &compute.NetworkEndpointGroup{Name: key.Name, NetworkEndpointType: string(negtypes.ServerlessEndpointType), Description: desc.String()}
+	if err := fakeGCE.Compute().RegionNetworkEndpointGroups().Insert(ctx, key, neg); err != nil {
+		t.Fatalf("Failed to insert NEG %s: %v", key, err)
+	}
+
+	// Nothing is garbage collected before the external NEGs of the existing
+	// services are ensured.
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v", err)
+	}
+	if _, err := fakeGCE.Compute().RegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("Failed to get NEG %s after garbage collection before sync: %v", key, err)
+	}
+	if regionLists != 0 {
+		t.Errorf("Listed the regions %d times before sync, want 0", regionLists)
+	}
+
+	manager.SetExternalNEGsSynced()
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v", err)
+	}
+	if _, err := fakeGCE.Compute().RegionNetworkEndpointGroups().Get(ctx, key); err == nil {
+		t.Errorf("NEG %s still exists after garbage collection", key)
+	}
+
+	// The regions are only listed by the first garbage collection.
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v", err)
+	}
+	if regionLists != 1 {
+		t.Errorf("Listed the regions %d times, want 1", regionLists)
+	}
+	if got, want := sets.List(manager.externalNegRegions), []string{"us-east1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got external NEG regions %v, want %v", got, want)
+	}
+}
@@ -84,6 +84,23 @@ type syncerManager struct {
 	// syncerMap stores the NEG syncer
 	// key consists of service namespace, name and targetPort. Value is the corresponding syncer.
 	syncerMap map[negtypes.NegSyncerKey]negtypes.NegSyncer
+	// externalNegMap stores the serverless and internet NEGs of the services
+	// with the external NEG annotation. Like svcPortMap, it is the canonical
+	// indicator for whether a service needs these NEGs.
+	externalNegMap map[serviceKey][]negtypes.ExternalNEG
+	// externalNegRegions are the regions where serverless NEGs have been
+	// ensured or found, in which garbage collection looks for unneeded NEGs.
+	externalNegRegions sets.Set[string]
+	// externalNegRegionsListed is whether garbage collection has looked for
+	// serverless NEGs in all the regions of the project since the manager
+	// started. Until then, externalNegRegions misses the regions of the NEGs
+	// ensured before a restart.
+	externalNegRegionsListed bool
+	// externalNegsSynced is whether the external NEGs of the services that
+	// existed when the manager started have been ensured. Until then,
+	// externalNegMap misses NEGs that are still needed, so garbage collection
+	// leaves the external NEGs alone.
+	externalNegsSynced bool
 	// syncCollector collect sync related metrics
 	syncerMetrics *metricscollector.SyncerMetrics
 
@@ -156,6 +173,8 @@ func newSyncerManager(namer negtypes.NetworkEndpointGroupNamer,
 		svcNegLister:        svcNegLister,
 		svcPortMap:          make(map[serviceKey]negtypes.PortInfoMap),
 		syncerMap:           make(map[negtypes.NegSyncerKey]negtypes.NegSyncer),
+		externalNegMap:      make(map[serviceKey][]negtypes.ExternalNEG),
+		externalNegRegions:  sets.New[string](),
 		syncerMetrics:       syncerMetrics,
 		svcNegClient:        svcNegClient,
 		kubeSystemUID:       kubeSystemUID,
@@ -282,7 +301,8 @@ func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts neg
 	return successfulSyncers, errorSyncers, err
 }
 
-// StopSyncer stops all syncers for the input service.
+// StopSyncer stops all syncers for the input service. Its NEGs, including its
+// external NEGs, are deleted by the next garbage collection.
 func (manager *syncerManager) StopSyncer(namespace, name string) {
 	manager.mu.Lock()
 	defer manager.mu.Unlock()
@@ -295,6 +315,7 @@ func (manager *syncerManager) StopSyncer(namespace, name string) {
 		}
 		delete(manager.svcPortMap, key)
 	}
+	delete(manager.externalNegMap, key)
 }
 
 // Sync signals all syncers related to the service to sync.
@@ -410,6 +431,9 @@ func (manager *syncerManager) GC() error {
 	if err != nil {
 		err = fmt.Errorf("failed to garbage collect negs: %w", err)
 	}
+	if externalErr := manager.garbageCollectExternalNEGs(); externalErr != nil {
+		err = utilerrors.NewAggregate([]error{err, fmt.Errorf("failed to garbage collect external negs: %w", externalErr)})
+	}
 	manager.negMetrics.PublishNegManagerProcessMetrics(metrics.GCProcess, err, start)
 	return err
 }
@@ -462,6 +486,78 @@ func (manager *syncerManager) ReadinessGateEnabled(syncerKey negtypes.NegSyncerK
 	return false
 }
 
+// endpointHealthRefreshInterval is how often the LastPollTime of unchanged
+// endpoint health is refreshed in the NEG CR.
+const endpointHealthRefreshInterval = 5 * time.Minute
+
+// ReportNegHealth records the health of the endpoints of a NEG in a zone in
+// the status of the NEG CR of the syncer. The NEG CR is only patched when the
+// health changes, or to refresh the LastPollTime of unchanged health every
+// endpointHealthRefreshInterval.
+func (manager *syncerManager) ReportNegHealth(syncerKey negtypes.NegSyncerKey, health negv1beta1.NegEndpointHealth) {
+	if manager.svcNegClient == nil {
+		return
+	}
+	obj, exists, err := manager.svcNegLister.GetByKey(fmt.Sprintf("%s/%s", syncerKey.Namespace, syncerKey.NegName))
+	if err != nil || !exists {
+		manager.logger.V(4).Info("Skipping reporting the health of NEG without NEG CR", "svcneg", klog.KRef(syncerKey.Namespace, syncerKey.NegName), "err", err)
+		return
+	}
+	origNeg := obj.(*negv1beta1.ServiceNetworkEndpointGroup)
+	neg := origNeg.DeepCopy()
+	if !ensureEndpointHealth(&neg.Status, health, metav1.Now()) {
+		return
+	}
+	if _, err := patchNegStatus(manager.svcNegClient, *origNeg, *neg, manager.negMetrics); err != nil {
+		manager.logger.Error(err, "Failed to report the health of NEG", "svcneg", klog.KObj(origNeg), "negZone", health.Zone)
+	}
+}
+
+// ensureEndpointHealth sets the given health of the endpoints of a NEG in
+// the status, and drops the health of the NEGs that the status no longer
+// references. The LastPollTime of unchanged health is only refreshed once it
+// is older than endpointHealthRefreshInterval. It returns true if the status
+// was changed.
+func ensureEndpointHealth(status *negv1beta1.ServiceNetworkEndpointGroupStatus, health negv1beta1.NegEndpointHealth, now metav1.Time) bool {
+	referenced := sets.New[negtypes.NegInfo]()
+	for _, ref := range status.NetworkEndpointGroups {
+		if info, err := negtypes.NegInfoFromNegRef(ref); err == nil {
+			referenced.Insert(info)
+		}
+	}
+
+	changed := false
+	found := false
+	var ret []negv1beta1.NegEndpointHealth
+	for _, h := range status.EndpointHealth {
+		switch {
+		case h.Name == health.Name && h.Zone == health.Zone:
+			found = true
+			if h.HealthyEndpoints != health.HealthyEndpoints || h.UnhealthyEndpoints != health.UnhealthyEndpoints || !reflect.DeepEqual(h.UnhealthyPods, health.UnhealthyPods) {
+				h = health
+				h.LastTransitionTime = now
+				h.LastPollTime = now
+				changed = true
+			} else if now.Sub(h.LastPollTime.Time) >= endpointHealthRefreshInterval {
+				h.LastPollTime = now
+				changed = true
+			}
+		case len(referenced) > 0 && !referenced.Has(negtypes.NegInfo{Name: h.Name, Zone: h.Zone}):
+			changed = true
+			continue
+		}
+		ret = append(ret, h)
+	}
+	if !found {
+		health.LastTransitionTime = now
+		health.LastPollTime = now
+		ret = append(ret, health)
+		changed = true
+	}
+	status.EndpointHealth = ret
+	return changed
+}
+
 // ensureDeleteSvcNegCR will set the deletion timestamp for the specified NEG CR based
 // on the given neg name. If the Deletion timestamp has already been set on the CR, no
 // change will occur.
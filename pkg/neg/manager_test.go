@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/api/googleapi"
 	"k8s.io/cloud-provider-gcp/providers/gce"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -2286,3 +2287,73 @@ func rebuildSvcNegCache(t *testing.T, manager *syncerManager, svcNegClient svcne
 	manager.svcNegLister.Replace([]any{}, "")
 	populateSvcNegCache(t, manager, svcNegClient, namespace)
 }
+
+func TestReportNegHealth(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, err := NewTestSyncerManager(fake.NewSimpleClientset())
+	if err != nil {
+		t.Fatalf("failed to create test syncer manager: %v", err)
+	}
+	negName := manager.namer.NEG(namespace1, name1, 80)
+	syncerKey := negtypes.NegSyncerKey{Namespace: namespace1, Name: name1, NegName: negName}
+	negRef := func(zone string) negv1beta1.NegObjectReference {
+		return negv1beta1.NegObjectReference{
+			Id:       zone,
+			SelfLink: cloud.SelfLink(meta.VersionGA, "mock-project", "networkEndpointGroups", meta.ZonalKey(negName, zone)),
+		}
+	}
+	svcNeg := &negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace1, Name: negName},
+		Status: negv1beta1.ServiceNetworkEndpointGroupStatus{
+			NetworkEndpointGroups: []negv1beta1.NegObjectReference{negRef(negtypes.TestZone1), negRef(negtypes.TestZone2)},
+			EndpointHealth: []negv1beta1.NegEndpointHealth{
+				{Name: negName, Zone: negtypes.TestZone2, HealthyEndpoints: 2},
+				// The NEG in this zone is no longer referenced.
+				{Name: negName, Zone: negtypes.TestZone3, HealthyEndpoints: 1},
+			},
+		},
+	}
+	if _, err := manager.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(namespace1).Create(context2.Background(), svcNeg, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create NEG CR: %v", err)
+	}
+	manager.svcNegLister.Add(svcNeg)
+
+	health := negv1beta1.NegEndpointHealth{Name: negName, Zone: negtypes.TestZone1, HealthyEndpoints: 1, UnhealthyEndpoints: 1, UnhealthyPods: []string{namespace1 + "/pod1"}}
+	manager.ReportNegHealth(syncerKey, health)
+
+	got, err := manager.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(namespace1).Get(context2.Background(), negName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get NEG CR: %v", err)
+	}
+	want := []negv1beta1.NegEndpointHealth{
+		{Name: negName, Zone: negtypes.TestZone2, HealthyEndpoints: 2},
+		health,
+	}
+	if diff := cmp.Diff(want, got.Status.EndpointHealth, cmpopts.IgnoreFields(negv1beta1.NegEndpointHealth{}, "LastTransitionTime", "LastPollTime")); diff != "" {
+		t.Errorf("Got unexpected endpoint health (-want +got):\n%s", diff)
+	}
+	reported := got.Status.EndpointHealth[1].LastTransitionTime
+	if reported.IsZero() {
+		t.Errorf("Got zero LastTransitionTime for the reported endpoint health, want the time of the report")
+	}
+	if polled := got.Status.EndpointHealth[1].LastPollTime; !polled.Equal(&reported) {
+		t.Errorf("Got LastPollTime %v for the reported endpoint health, want %v", polled, reported)
+	}
+
+	// Reporting the same health does not change the NEG CR.
+	if ensureEndpointHealth(got.Status.DeepCopy(), health, metav1.NewTime(reported.Add(time.Minute))) {
+		t.Errorf("ensureEndpointHealth() = true for unchanged health, want false")
+	}
+
+	// Reporting the same health after the refresh interval only refreshes
+	// the LastPollTime.
+	status := got.Status.DeepCopy()
+	refreshed := metav1.NewTime(reported.Add(endpointHealthRefreshInterval))
+	if !ensureEndpointHealth(status, health, refreshed) {
+		t.Errorf("ensureEndpointHealth() = false for unchanged health after the refresh interval, want true")
+	}
+	if h := status.EndpointHealth[1]; !h.LastPollTime.Equal(&refreshed) || !h.LastTransitionTime.Equal(&reported) {
+		t.Errorf("Got LastPollTime %v and LastTransitionTime %v, want %v and %v", h.LastPollTime, h.LastTransitionTime, refreshed, reported)
+	}
+}
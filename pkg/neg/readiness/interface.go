@@ -18,6 +18,7 @@ package readiness
 
 import (
 	"k8s.io/api/core/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 )
 
@@ -44,6 +45,13 @@ type NegLookup interface {
 	ReadinessGateEnabled(syncerKey negtypes.NegSyncerKey) bool
 }
 
+// HealthReporter defines an interface for publishing the health of the endpoints of NEGs.
+type HealthReporter interface {
+	// ReportNegHealth reports the health of the endpoints of a NEG in a zone, as polled by the reflector.
+	// syncerKey is the key to uniquely identify the NEG syncer
+	ReportNegHealth(syncerKey negtypes.NegSyncerKey, health negv1beta1.NegEndpointHealth)
+}
+
 type NoopReflector struct{}
 
 func (*NoopReflector) Run(<-chan struct{}) {}
@@ -18,6 +18,8 @@ package readiness
 
 import (
 	"fmt"
+	"maps"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -27,9 +29,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/cache"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 )
@@ -43,6 +47,11 @@ const (
 	// More detail: https://cloud.google.com/compute/docs/api-rate-limits
 	retryDelay   = 100 * time.Second
 	hcRetryDelay = time.Second
+
+	// healthPollInterval is how often the health of the endpoints of the
+	// NEGs which are not polled for readiness gates is polled, to keep the
+	// reported health fresh.
+	healthPollInterval = 5 * time.Minute
 )
 
 // negMeta references a GCE NEG resource
@@ -86,6 +95,12 @@ type poller struct {
 	lookup    NegLookup
 	patcher   podStatusPatcher
 	negCloud  negtypes.NetworkEndpointGroupCloud
+	// reporter publishes the health of the endpoints of the polled NEGs.
+	// It is optional.
+	reporter HealthReporter
+	// healthMap contains all the endpoints of each NEG committed to the
+	// poller, whose health is reported. It is only kept if reporter is set.
+	healthMap map[negMeta]negtypes.EndpointPodMap
 
 	// Enables support for Dual-Stack NEGs within the NEG Controller.
 	enableDualStackNEG bool
@@ -97,11 +112,13 @@ type poller struct {
 	negMetrics *metrics.NegMetrics
 }
 
-func NewPoller(podLister cache.Indexer, lookup NegLookup, patcher podStatusPatcher, negCloud negtypes.NetworkEndpointGroupCloud, enableDualStackNEG bool, logger klog.Logger, negMetrics *metrics.NegMetrics) *poller {
+func NewPoller(podLister cache.Indexer, lookup NegLookup, reporter HealthReporter, patcher podStatusPatcher, negCloud negtypes.NetworkEndpointGroupCloud, enableDualStackNEG bool, logger klog.Logger, negMetrics *metrics.NegMetrics) *poller {
 	return &poller{
 		pollMap:            make(map[negMeta]*pollTarget),
+		healthMap:          make(map[negMeta]negtypes.EndpointPodMap),
 		podLister:          podLister,
 		lookup:             lookup,
+		reporter:           reporter,
 		patcher:            patcher,
 		negCloud:           negCloud,
 		enableDualStackNEG: enableDualStackNEG,
@@ -115,6 +132,15 @@ func NewPoller(podLister cache.Indexer, lookup NegLookup, patcher podStatusPatch
 func (p *poller) RegisterNegEndpoints(key negMeta, endpointMap negtypes.EndpointPodMap) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	if p.reporter != nil {
+		if len(endpointMap) == 0 {
+			delete(p.healthMap, key)
+		} else {
+			// registerNegEndpoints drops the endpoints which do not need
+			// to be polled for readiness gates from endpointMap.
+			p.healthMap[key] = maps.Clone(endpointMap)
+		}
+	}
 	p.registerNegEndpoints(key, endpointMap)
 }
 
@@ -152,6 +178,38 @@ func (p *poller) ScanForWork() []negMeta {
 	return ret
 }
 
+// ScanForHealthWork returns the list of NEGs whose health should be polled
+// to be reported, as they are not polled for readiness gates.
+func (p *poller) ScanForHealthWork() []negMeta {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var ret []negMeta
+	for key := range p.healthMap {
+		if _, ok := p.pollMap[key]; ok {
+			continue
+		}
+		ret = append(ret, key)
+	}
+	return ret
+}
+
+// PollHealth polls the health of the endpoints of a NEG and reports it. A
+// NEG which no longer exists is forgotten.
+func (p *poller) PollHealth(key negMeta) error {
+	p.logger.V(3).Info("polling the health of NEG", "neg", key.Name, "negZone", key.Zone)
+	res, err := p.negCloud.ListNetworkEndpoints(key.Name, key.Zone /*showHealthStatus*/, true, key.SyncerKey.GetAPIVersion(), p.logger)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			p.lock.Lock()
+			delete(p.healthMap, key)
+			p.lock.Unlock()
+		}
+		return err
+	}
+	p.reportHealth(key, res)
+	return nil
+}
+
 // Poll polls a NEG and returns error plus whether retry is needed
 // This function is threadsafe.
 func (p *poller) Poll(key negMeta) (retry bool, err error) {
@@ -182,6 +240,7 @@ func (p *poller) Poll(key negMeta) (retry bool, err error) {
 
 	retry, err = p.processHealthStatus(key, res)
 	p.negMetrics.PublishNegControllerErrorCountMetrics(err, true)
+	p.reportHealth(key, res)
 	if retry {
 		<-p.clock.After(hcRetryDelay)
 	}
@@ -284,6 +343,73 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 	return retry, utilerrors.NewAggregate(errList)
 }
 
+// reportHealth reports the health of the endpoints of the NEG to the
+// reporter. Nothing is reported if none of the endpoints is health checked,
+// since the health of the endpoints is unknown.
+func (p *poller) reportHealth(key negMeta, healthStatuses []*composite.NetworkEndpointWithHealthStatus) {
+	if p.reporter == nil {
+		return
+	}
+	health, healthChecked := p.endpointHealth(key, healthStatuses)
+	if !healthChecked {
+		return
+	}
+	p.reporter.ReportNegHealth(key.SyncerKey, health)
+}
+
+// endpointHealth counts the healthy and unhealthy endpoints of the NEG, and
+// lists the pods of the unhealthy endpoints. It also returns whether at least
+// one of the endpoints is health checked.
+func (p *poller) endpointHealth(key negMeta, healthStatuses []*composite.NetworkEndpointWithHealthStatus) (negv1beta1.NegEndpointHealth, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	health := negv1beta1.NegEndpointHealth{Name: key.Name, Zone: key.Zone}
+	healthChecked := false
+	for _, healthStatus := range healthStatuses {
+		if healthStatus == nil || healthStatus.NetworkEndpoint == nil || !hasSupportedHealthStatus(healthStatus) {
+			continue
+		}
+		healthChecked = true
+		if isHealthy(healthStatus, p.enableDualStackNEG) {
+			health.HealthyEndpoints++
+			continue
+		}
+		health.UnhealthyEndpoints++
+
+		ne := negtypes.NetworkEndpoint{
+			IP:   healthStatus.NetworkEndpoint.IpAddress,
+			Port: strconv.FormatInt(healthStatus.NetworkEndpoint.Port, 10),
+			Node: healthStatus.NetworkEndpoint.Instance,
+		}
+		if p.enableDualStackNEG {
+			ne.IPv6 = healthStatus.NetworkEndpoint.Ipv6Address
+		}
+		// The health map lists all the endpoints of the NEG, while the
+		// poll map only lists those which wait for their readiness gate.
+		podName, ok := p.healthMap[key][ne]
+		if !ok {
+			podName, ok = p.getPod(key, ne)
+		}
+		if ok {
+			health.UnhealthyPods = append(health.UnhealthyPods, podName.String())
+		}
+	}
+	sort.Strings(health.UnhealthyPods)
+	return health, healthChecked
+}
+
+// isHealthy returns true if any backend service considers the endpoint
+// healthy.
+func isHealthy(healthStatus *composite.NetworkEndpointWithHealthStatus, enableDualStackNEG bool) bool {
+	for _, hs := range healthStatus.Healths {
+		if hs != nil && (hs.HealthState == healthyState || (enableDualStackNEG && hs.Ipv6HealthState == healthyState)) {
+			return true
+		}
+	}
+	return false
+}
+
 // getHealthyBackendService returns one of the first backend service key where
 // the endpoint is considered healthy. An endpoint is considered healthy if
 // either the IPv4 OR IPv6 endpoint's healthstatus reports HEALTHY.
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cloud-provider-gcp/providers/gce"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
@@ -730,3 +731,140 @@ func TestProcessHealthStatus_dualStackNEGs(t *testing.T) {
 		})
 	}
 }
+
+func TestReportHealth(t *testing.T) {
+	t.Parallel()
+
+	bsURL := "https://www.googleapis.com/compute/v1/projects/foo/global/backendServices/bs1"
+	endpoint := func(ip string, state string) *composite.NetworkEndpointWithHealthStatus {
+		return &composite.NetworkEndpointWithHealthStatus{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: ip, Port: 80, Instance: "instance1"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: bsURL},
+				HealthState:    state,
+			}},
+		}
+	}
+	neg := negMeta{SyncerKey: negtypes.NegSyncerKey{}, Name: "negName", Zone: "zone1"}
+
+	testCases := []struct {
+		desc           string
+		healthStatuses []*composite.NetworkEndpointWithHealthStatus
+		want           []negv1beta1.NegEndpointHealth
+	}{
+		{
+			desc: "endpoints are counted, and the pods of unhealthy endpoints are listed",
+			healthStatuses: []*composite.NetworkEndpointWithHealthStatus{
+				endpoint("10.0.0.1", healthyState),
+				endpoint("10.0.0.2", "UNHEALTHY"),
+				endpoint("10.0.0.3", "UNHEALTHY"),
+				// The pod of this endpoint is not polled.
+				endpoint("10.0.0.4", "UNHEALTHY"),
+			},
+			want: []negv1beta1.NegEndpointHealth{{
+				Name:               "negName",
+				Zone:               "zone1",
+				HealthyEndpoints:   1,
+				UnhealthyEndpoints: 3,
+				UnhealthyPods:      []string{"ns/pod2", "ns/pod3"},
+			}},
+		},
+		{
+			desc: "nothing is reported when no endpoint is health checked",
+			healthStatuses: []*composite.NetworkEndpointWithHealthStatus{
+				{NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: "10.0.0.1", Port: 80, Instance: "instance1"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			poller, err := newFakePoller()
+			if err != nil {
+				t.Fatalf("failed to create fake poller")
+			}
+			reporter := poller.reporter.(*fakeHealthReporter)
+			poller.pollMap[neg] = &pollTarget{
+				endpointMap: negtypes.EndpointPodMap{
+					negtypes.NetworkEndpoint{IP: "10.0.0.1", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod1"},
+					negtypes.NetworkEndpoint{IP: "10.0.0.2", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod2"},
+					negtypes.NetworkEndpoint{IP: "10.0.0.3", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod3"},
+				},
+				polling: true,
+			}
+
+			poller.reportHealth(neg, tc.healthStatuses)
+
+			if diff := cmp.Diff(tc.want, reporter.reports); diff != "" {
+				t.Errorf("Got unexpected health reports (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPollHealth(t *testing.T) {
+	t.Parallel()
+
+	poller, err := newFakePoller()
+	if err != nil {
+		t.Fatalf("failed to create fake poller")
+	}
+	reporter := poller.reporter.(*fakeHealthReporter)
+	negCloud := poller.negCloud
+
+	negName := namer_util.NewNamer("clusteruid", "", klog.TODO()).NEG("ns", "svc", int32(80))
+	zone := "us-central1-b"
+	key := negMeta{SyncerKey: negtypes.NegSyncerKey{}, Name: negName, Zone: zone}
+	bsURL := "https://www.googleapis.com/compute/v1/projects/foo/global/backendServices/bs1"
+	endpoint := func(ip string, state string) negtypes.NetworkEndpointEntry {
+		return negtypes.NetworkEndpointEntry{
+			NetworkEndpoint: &composite.NetworkEndpoint{IpAddress: ip, Port: 80, Instance: "instance1"},
+			Healths: []*composite.HealthStatusForNetworkEndpoint{{
+				BackendService: &composite.BackendServiceReference{BackendService: bsURL},
+				HealthState:    state,
+			}},
+		}
+	}
+	entries := []negtypes.NetworkEndpointEntry{endpoint("10.0.0.1", healthyState), endpoint("10.0.0.2", "UNHEALTHY")}
+	negCloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{Name: negName, Zone: zone, Version: meta.VersionGA}, zone, klog.TODO())
+	negCloud.AttachNetworkEndpoints(negName, zone, []*composite.NetworkEndpoint{entries[0].NetworkEndpoint, entries[1].NetworkEndpoint}, meta.VersionGA, klog.TODO())
+	negtypes.GetNetworkEndpointStore(negCloud).AddNetworkEndpointHealthStatus(*meta.ZonalKey(negName, zone), entries)
+
+	// The NEG does not have readiness gates, so it is only polled for its
+	// health.
+	poller.RegisterNegEndpoints(key, negtypes.EndpointPodMap{
+		negtypes.NetworkEndpoint{IP: "10.0.0.1", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod1"},
+		negtypes.NetworkEndpoint{IP: "10.0.0.2", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod2"},
+	})
+	if got := poller.ScanForWork(); len(got) != 0 {
+		t.Errorf("ScanForWork() = %v, want none", got)
+	}
+	if diff := cmp.Diff([]negMeta{key}, poller.ScanForHealthWork()); diff != "" {
+		t.Fatalf("ScanForHealthWork() returned unexpected NEGs (-want +got):\n%s", diff)
+	}
+	if err := poller.PollHealth(key); err != nil {
+		t.Fatalf("PollHealth(%v) = %v", key, err)
+	}
+	want := []negv1beta1.NegEndpointHealth{{
+		Name:               negName,
+		Zone:               zone,
+		HealthyEndpoints:   1,
+		UnhealthyEndpoints: 1,
+		UnhealthyPods:      []string{"ns/pod2"},
+	}}
+	if diff := cmp.Diff(want, reporter.reports); diff != "" {
+		t.Errorf("Got unexpected health reports (-want +got):\n%s", diff)
+	}
+
+	// A NEG which no longer exists is forgotten.
+	missing := negMeta{SyncerKey: negtypes.NegSyncerKey{}, Name: "missing", Zone: zone}
+	poller.RegisterNegEndpoints(missing, negtypes.EndpointPodMap{
+		negtypes.NetworkEndpoint{IP: "10.0.0.3", Port: "80", Node: "instance1"}: {Namespace: "ns", Name: "pod3"},
+	})
+	if err := poller.PollHealth(missing); err == nil {
+		t.Errorf("PollHealth(%v) = nil, want error", missing)
+	}
+	if diff := cmp.Diff([]negMeta{key}, poller.ScanForHealthWork()); diff != "" {
+		t.Errorf("ScanForHealthWork() returned unexpected NEGs after polling a missing NEG (-want +got):\n%s", diff)
+	}
+}
@@ -85,7 +85,7 @@ type readinessReflector struct {
 	negMetrics *metrics.NegMetrics
 }
 
-func NewReadinessReflector(kubeClient, eventRecorderClient kubernetes.Interface, podLister cache.Indexer, negCloud negtypes.NetworkEndpointGroupCloud, lookup NegLookup, zoneGetter *zonegetter.ZoneGetter, enableDualStackNEG, markNonDefaultSubnetPodsReady bool, logger klog.Logger, negMetrics *metrics.NegMetrics) Reflector {
+func NewReadinessReflector(kubeClient, eventRecorderClient kubernetes.Interface, podLister cache.Indexer, negCloud negtypes.NetworkEndpointGroupCloud, lookup NegLookup, reporter HealthReporter, zoneGetter *zonegetter.ZoneGetter, enableDualStackNEG, markNonDefaultSubnetPodsReady bool, logger klog.Logger, negMetrics *metrics.NegMetrics) Reflector {
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartLogging(klog.Infof)
 	broadcaster.StartRecordingToSink(&unversionedcore.EventSinkImpl{
@@ -105,7 +105,7 @@ func NewReadinessReflector(kubeClient, eventRecorderClient kubernetes.Interface,
 		logger:                        logger,
 		negMetrics:                    negMetrics,
 	}
-	poller := NewPoller(podLister, lookup, reflector, negCloud, enableDualStackNEG, logger, negMetrics)
+	poller := NewPoller(podLister, lookup, reporter, reflector, negCloud, enableDualStackNEG, logger, negMetrics)
 	reflector.poller = poller
 	return reflector
 }
@@ -116,6 +116,9 @@ func (r *readinessReflector) Run(stopCh <-chan struct{}) {
 	defer r.logger.V(2).Info("Shutting down NEG readiness reflector")
 
 	go wait.Until(r.worker, time.Second, stopCh)
+	if r.poller.reporter != nil {
+		go wait.Until(r.pollHealth, healthPollInterval, stopCh)
+	}
 	<-stopCh
 }
 
@@ -289,6 +292,19 @@ func (r *readinessReflector) poll() {
 	}
 }
 
+// pollHealth polls the health of the endpoints of the NEGs which are not
+// polled for readiness gates, so that the reported health does not go stale
+// once all the pods of a NEG are ready. The NEGs are polled one at a time to
+// spread the calls to the GCE NEG API.
+func (r *readinessReflector) pollHealth() {
+	for _, key := range r.poller.ScanForHealthWork() {
+		if err := r.poller.PollHealth(key); err != nil {
+			r.logger.Error(err, "Failed to poll the health of neg", "neg", key)
+			r.negMetrics.PublishNegControllerErrorCountMetrics(err, true)
+		}
+	}
+}
+
 // pollNeg polls a NEG
 func (r *readinessReflector) pollNeg(key negMeta) {
 	r.logger.V(3).Info("Polling NEG", "neg", key.String())
@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/neg/types/shared"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -59,6 +60,15 @@ func (f *fakeLookUp) ReadinessGateEnabled(syncerKey negtypes.NegSyncerKey) bool
 	return f.readinessGateEnabled
 }
 
+// fakeHealthReporter implements HealthReporter interface
+type fakeHealthReporter struct {
+	reports []negv1beta1.NegEndpointHealth
+}
+
+func (f *fakeHealthReporter) ReportNegHealth(syncerKey negtypes.NegSyncerKey, health negv1beta1.NegEndpointHealth) {
+	f.reports = append(f.reports, health)
+}
+
 func newTestReadinessReflector(testContext *negtypes.TestContext, markNonDefaultSubnetPodsReady bool) (*readinessReflector, error) {
 	fakeZoneGetter, err := zonegetter.NewFakeZoneGetter(testContext.NodeInformer, testContext.NodeTopologyInformer, defaultTestSubnetURL, markNonDefaultSubnetPodsReady)
 	if err != nil {
@@ -70,6 +80,7 @@ func newTestReadinessReflector(testContext *negtypes.TestContext, markNonDefault
 		testContext.PodInformer.GetIndexer(),
 		negtypes.NewAdapter(testContext.Cloud, testContext.NegMetrics),
 		&fakeLookUp{},
+		&fakeHealthReporter{},
 		fakeZoneGetter,
 		false,
 		markNonDefaultSubnetPodsReady,
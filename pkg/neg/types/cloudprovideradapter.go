@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	compute "google.golang.org/api/compute/v1"
@@ -189,3 +190,120 @@ func (a *cloudProviderAdapter) Region() string {
 func (a *cloudProviderAdapter) GetNetwork(networkName string) (*compute.Network, error) {
 	return a.c.GetNetwork(networkName)
 }
+
+// GetExternalNetworkEndpointGroup implements NetworkEndpointGroupCloud.
+func (a *cloudProviderAdapter) GetExternalNetworkEndpointGroup(key *meta.Key, logger klog.Logger) (*compute.NetworkEndpointGroup, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	var neg *compute.NetworkEndpointGroup
+	var err error
+	if key.Type() == meta.Regional {
+		neg, err = a.c.Compute().RegionNetworkEndpointGroups().Get(ctx, key)
+	} else {
+		neg, err = a.c.Compute().GlobalNetworkEndpointGroups().Get(ctx, key)
+	}
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.GetRequest, err)
+	return neg, err
+}
+
+// ListExternalNetworkEndpointGroup implements NetworkEndpointGroupCloud.
+func (a *cloudProviderAdapter) ListExternalNetworkEndpointGroup(region string, logger klog.Logger) ([]*compute.NetworkEndpointGroup, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	var negs []*compute.NetworkEndpointGroup
+	var err error
+	if region != "" {
+		negs, err = a.c.Compute().RegionNetworkEndpointGroups().List(ctx, region, filter.None)
+	} else {
+		negs, err = a.c.Compute().GlobalNetworkEndpointGroups().List(ctx, filter.None)
+	}
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.ListRequest, err)
+	return negs, err
+}
+
+// CreateExternalNetworkEndpointGroup implements NetworkEndpointGroupCloud.
+func (a *cloudProviderAdapter) CreateExternalNetworkEndpointGroup(neg *compute.NetworkEndpointGroup, key *meta.Key, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	logger.Info("Creating NetworkEndpointGroup", "name", key.Name, "region", key.Region, "networkEndpointType", neg.NetworkEndpointType)
+	start := time.Now()
+	var err error
+	if key.Type() == meta.Regional {
+		err = a.c.Compute().RegionNetworkEndpointGroups().Insert(ctx, key, neg)
+	} else {
+		err = a.c.Compute().GlobalNetworkEndpointGroups().Insert(ctx, key, neg)
+	}
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.CreateRequest, err)
+	return err
+}
+
+// DeleteExternalNetworkEndpointGroup implements NetworkEndpointGroupCloud.
+func (a *cloudProviderAdapter) DeleteExternalNetworkEndpointGroup(key *meta.Key, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	logger.Info("Deleting NetworkEndpointGroup", "name", key.Name, "region", key.Region)
+	start := time.Now()
+	var err error
+	if key.Type() == meta.Regional {
+		err = a.c.Compute().RegionNetworkEndpointGroups().Delete(ctx, key)
+	} else {
+		err = a.c.Compute().GlobalNetworkEndpointGroups().Delete(ctx, key)
+	}
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.DeleteRequest, err)
+	return err
+}
+
+// AttachExternalNetworkEndpoints implements NetworkEndpointGroupCloud. Only
+// the global internet NEGs have endpoints.
+func (a *cloudProviderAdapter) AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	req := &compute.GlobalNetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}
+	err := a.c.Compute().GlobalNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, req)
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.AttachNERequest, err)
+	return err
+}
+
+// DetachExternalNetworkEndpoints implements NetworkEndpointGroupCloud. Only
+// the global internet NEGs have endpoints.
+func (a *cloudProviderAdapter) DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, logger klog.Logger) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	req := &compute.GlobalNetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}
+	err := a.c.Compute().GlobalNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, req)
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.DetachNERequest, err)
+	return err
+}
+
+// ListExternalNetworkEndpoints implements NetworkEndpointGroupCloud. Only
+// the global internet NEGs have endpoints.
+func (a *cloudProviderAdapter) ListExternalNetworkEndpoints(key *meta.Key, logger klog.Logger) ([]*compute.NetworkEndpoint, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	eps, err := a.c.Compute().GlobalNetworkEndpointGroups().ListNetworkEndpoints(ctx, key, filter.None)
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.ListNERequest, err)
+	var ret []*compute.NetworkEndpoint
+	for _, ep := range eps {
+		ret = append(ret, ep.NetworkEndpoint)
+	}
+	return ret, err
+}
+
+// ListRegions implements NetworkEndpointGroupCloud.
+func (a *cloudProviderAdapter) ListRegions(logger klog.Logger) ([]string, error) {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	start := time.Now()
+	regions, err := a.c.Compute().Regions().List(ctx, filter.None)
+	a.negMetrics.PublishGCERequestCountMetrics(start, metrics.ListRequest, err)
+	var ret []string
+	for _, region := range regions {
+		ret = append(ret, region.Name)
+	}
+	return ret, err
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/ingress-gce/pkg/negannotation"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// ExternalNEG is a serverless or internet NEG of a service port. Unlike the
+// zonal NEGs, its endpoints are outside of the cluster and are not synced
+// from the endpoints of the service.
+type ExternalNEG struct {
+	// Key is the regional key of a serverless NEG, or the global key of an
+	// internet NEG.
+	Key *meta.Key
+	// NEG is the desired NEG.
+	NEG *compute.NetworkEndpointGroup
+	// Endpoints are the network endpoints of an internet NEG.
+	Endpoints []*compute.NetworkEndpoint
+}
+
+// ExternalNEGKey returns the key of the NEG with the given name described by
+// the external NEG annotation.
+func ExternalNEGKey(annotation *negannotation.ExternalNegAnnotation, name string) *meta.Key {
+	if annotation.Serverless != nil {
+		return meta.RegionalKey(name, annotation.Serverless.Region)
+	}
+	return meta.GlobalKey(name)
+}
+
+// NewExternalNEG returns the NEG with the given name described by the
+// external NEG annotation of a service, for the given port of the service.
+func NewExternalNEG(annotation *negannotation.ExternalNegAnnotation, name string, port int32, desc utils.NegDescription) ExternalNEG {
+	ret := ExternalNEG{
+		Key: ExternalNEGKey(annotation, name),
+		NEG: &compute.NetworkEndpointGroup{
+			Name:        name,
+			Description: desc.String(),
+		},
+	}
+	if s := annotation.Serverless; s != nil {
+		ret.NEG.NetworkEndpointType = string(ServerlessEndpointType)
+		if s.CloudRun != nil {
+			ret.NEG.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: s.CloudRun.Service, Tag: s.CloudRun.Tag}
+		} else {
+			ret.NEG.CloudFunction = &compute.NetworkEndpointGroupCloudFunction{Function: s.CloudFunction.Function}
+		}
+		return ret
+	}
+
+	internet := annotation.Internet
+	if internet.Port != 0 {
+		port = internet.Port
+	}
+	endpoint := &compute.NetworkEndpoint{Port: int64(port)}
+	if internet.FQDN != "" {
+		ret.NEG.NetworkEndpointType = string(InternetFQDNEndpointType)
+		endpoint.Fqdn = internet.FQDN
+	} else {
+		ret.NEG.NetworkEndpointType = string(InternetIPEndpointType)
+		endpoint.IpAddress = internet.IPAddress
+	}
+	ret.Endpoints = []*compute.NetworkEndpoint{endpoint}
+	return ret
+}
+
+// SameConfig returns true if the given existing NEG has the type and the
+// backend of the desired NEG. The endpoints of internet NEGs are not
+// compared.
+func (n ExternalNEG) SameConfig(existing *compute.NetworkEndpointGroup) bool {
+	return existing.NetworkEndpointType == n.NEG.NetworkEndpointType &&
+		reflect.DeepEqual(existing.CloudRun, n.NEG.CloudRun) &&
+		reflect.DeepEqual(existing.CloudFunction, n.NEG.CloudFunction)
+}
+
+// ExternalNetworkEndpointKey returns a key identifying the given endpoint of
+// an internet NEG.
+func ExternalNetworkEndpointKey(ep *compute.NetworkEndpoint) string {
+	return ep.Fqdn + "/" + ep.IpAddress + ":" + strconv.FormatInt(ep.Port, 10)
+}
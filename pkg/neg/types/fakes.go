@@ -211,3 +211,35 @@ func (f *FakeNetworkEndpointGroupCloud) Region() string {
 func (f *FakeNetworkEndpointGroupCloud) GetNetwork(networkName string) (*compute.Network, error) {
 	return nil, fmt.Errorf("Not Implemented")
 }
+
+func (f *FakeNetworkEndpointGroupCloud) GetExternalNetworkEndpointGroup(key *meta.Key, _ klog.Logger) (*compute.NetworkEndpointGroup, error) {
+	return nil, fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) ListExternalNetworkEndpointGroup(region string, _ klog.Logger) ([]*compute.NetworkEndpointGroup, error) {
+	return nil, nil
+}
+
+func (f *FakeNetworkEndpointGroupCloud) CreateExternalNetworkEndpointGroup(neg *compute.NetworkEndpointGroup, key *meta.Key, _ klog.Logger) error {
+	return fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) DeleteExternalNetworkEndpointGroup(key *meta.Key, _ klog.Logger) error {
+	return fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, _ klog.Logger) error {
+	return fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, _ klog.Logger) error {
+	return fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) ListExternalNetworkEndpoints(key *meta.Key, _ klog.Logger) ([]*compute.NetworkEndpoint, error) {
+	return nil, fmt.Errorf("Not Implemented")
+}
+
+func (f *FakeNetworkEndpointGroupCloud) ListRegions(_ klog.Logger) ([]string, error) {
+	return []string{f.Region()}, nil
+}
@@ -19,7 +19,9 @@ package types
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	compute "google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/negannotation"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog/v2"
 )
@@ -39,6 +41,20 @@ type NetworkEndpointGroupCloud interface {
 	NetworkProjectID() string
 	Region() string
 	GetNetwork(networkName string) (*compute.Network, error)
+
+	// The following methods manage the regional serverless NEGs and the
+	// global internet NEGs, depending on the scope of the key. The NEGs of
+	// the region "" are the global ones.
+	GetExternalNetworkEndpointGroup(key *meta.Key, logger klog.Logger) (*compute.NetworkEndpointGroup, error)
+	ListExternalNetworkEndpointGroup(region string, logger klog.Logger) ([]*compute.NetworkEndpointGroup, error)
+	CreateExternalNetworkEndpointGroup(neg *compute.NetworkEndpointGroup, key *meta.Key, logger klog.Logger) error
+	DeleteExternalNetworkEndpointGroup(key *meta.Key, logger klog.Logger) error
+	AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, logger klog.Logger) error
+	DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*compute.NetworkEndpoint, logger klog.Logger) error
+	ListExternalNetworkEndpoints(key *meta.Key, logger klog.Logger) ([]*compute.NetworkEndpoint, error)
+	// ListRegions returns the names of the regions of the project, in which
+	// serverless NEGs can be.
+	ListRegions(logger klog.Logger) ([]string, error)
 }
 
 // NetworkEndpointGroupNamer is an interface for generating network endpoint group name.
@@ -68,6 +84,13 @@ type NegSyncerManager interface {
 	// portMap is a map of ServicePort Port to TargetPort. Returns counts of successful Neg syncers
 	// and failed Neg syncer creations
 	EnsureSyncers(namespace, name string, portMap PortInfoMap) (int, int, error)
+	// EnsureExternalNEGs ensures the serverless and internet NEGs described by the external NEG annotation
+	// of the service exist, one for each service port. The NEGs that the service no longer needs, all of them
+	// if annotation is nil, are deleted by the next garbage collection.
+	EnsureExternalNEGs(service *v1.Service, annotation *negannotation.ExternalNegAnnotation) error
+	// SetExternalNEGsSynced signals that EnsureExternalNEGs has run for the services that existed when the
+	// controller started. External NEGs are not garbage collected before.
+	SetExternalNEGsSynced()
 	// StopSyncer stops all syncers related to the service. This call is asynchronous. It will not wait for all syncers to stop.
 	StopSyncer(namespace, name string)
 	// Sync signals all syncers related to the service to sync. This call is asynchronous.
@@ -45,6 +45,9 @@ const (
 	VmIpPortEndpointType      = NetworkEndpointType("GCE_VM_IP_PORT")
 	VmIpEndpointType          = NetworkEndpointType("GCE_VM_IP")
 	NonGCPPrivateEndpointType = NetworkEndpointType("NON_GCP_PRIVATE_IP_PORT")
+	ServerlessEndpointType    = NetworkEndpointType("SERVERLESS")
+	InternetFQDNEndpointType  = NetworkEndpointType("INTERNET_FQDN_PORT")
+	InternetIPEndpointType    = NetworkEndpointType("INTERNET_IP_PORT")
 	L7Mode                    = EndpointsCalculatorMode("L7")
 	L4LocalMode               = EndpointsCalculatorMode("L4, ExternalTrafficPolicy:Local")
 	L4ClusterMode             = EndpointsCalculatorMode("L4, ExternalTrafficPolicy:Cluster")
@@ -38,6 +38,15 @@ const NEGAnnotationKey = "cloud.google.com/neg"
 // on the Service, and is applied by the NEG Controller.
 const NEGStatusKey = "cloud.google.com/neg-status"
 
+// ExternalNEGAnnotationKey is the annotation key to back the ports of a
+// Service with a NEG whose endpoints are outside of the cluster, instead of
+// with the pods of the Service. The value of the annotation must be a valid
+// JSON string in the format specified by type ExternalNegAnnotation.
+// examples:
+// - `{"serverless":{"region":"us-central1","cloudRun":{"service":"api"}}}`
+// - `{"internet":{"fqdn":"origin.example.com","port":443}}`
+const ExternalNEGAnnotationKey = "cloud.google.com/external-neg"
+
 var (
 	ErrNEGAnnotationInvalid = errors.New("NEG annotation is invalid.")
 )
@@ -102,6 +111,82 @@ func (n *NegAnnotation) String() string {
 	return string(bytes)
 }
 
+// ExternalNegAnnotation is the format of the annotation associated with the
+// ExternalNEGAnnotationKey key. Exactly one of Serverless and Internet must
+// be set.
+type ExternalNegAnnotation struct {
+	// Serverless describes a regional serverless NEG.
+	Serverless *ServerlessNeg `json:"serverless,omitempty"`
+	// Internet describes a global internet NEG.
+	Internet *InternetNeg `json:"internet,omitempty"`
+}
+
+// ServerlessNeg describes a serverless NEG, whose endpoint is either a Cloud
+// Run service or a Cloud Function.
+type ServerlessNeg struct {
+	// Region is the region of the serverless backend and of the NEG.
+	Region string `json:"region"`
+	// CloudRun is the Cloud Run service of the NEG.
+	CloudRun *CloudRunBackend `json:"cloudRun,omitempty"`
+	// CloudFunction is the Cloud Function of the NEG.
+	CloudFunction *CloudFunctionBackend `json:"cloudFunction,omitempty"`
+}
+
+// CloudRunBackend references a Cloud Run service.
+type CloudRunBackend struct {
+	Service string `json:"service"`
+	// Tag optionally selects a tagged revision of the service.
+	Tag string `json:"tag,omitempty"`
+}
+
+// CloudFunctionBackend references a Cloud Function.
+type CloudFunctionBackend struct {
+	Function string `json:"function"`
+}
+
+// InternetNeg describes an internet NEG, whose endpoint is either a fully
+// qualified domain name or a public IP address.
+type InternetNeg struct {
+	FQDN      string `json:"fqdn,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+	// Port is the port of the endpoint. It defaults to the port of the
+	// Service.
+	Port int32 `json:"port,omitempty"`
+}
+
+// Validate returns an error if the annotation does not describe exactly one
+// serverless or internet NEG.
+func (n *ExternalNegAnnotation) Validate() error {
+	switch {
+	case n.Serverless != nil && n.Internet != nil:
+		return fmt.Errorf("only one of serverless and internet can be set")
+	case n.Serverless != nil:
+		s := n.Serverless
+		if s.Region == "" {
+			return fmt.Errorf("serverless.region must be set")
+		}
+		if (s.CloudRun == nil) == (s.CloudFunction == nil) {
+			return fmt.Errorf("exactly one of serverless.cloudRun and serverless.cloudFunction must be set")
+		}
+		if s.CloudRun != nil && s.CloudRun.Service == "" {
+			return fmt.Errorf("serverless.cloudRun.service must be set")
+		}
+		if s.CloudFunction != nil && s.CloudFunction.Function == "" {
+			return fmt.Errorf("serverless.cloudFunction.function must be set")
+		}
+	case n.Internet != nil:
+		if (n.Internet.FQDN == "") == (n.Internet.IPAddress == "") {
+			return fmt.Errorf("exactly one of internet.fqdn and internet.ipAddress must be set")
+		}
+		if n.Internet.Port < 0 || n.Internet.Port > 65535 {
+			return fmt.Errorf("internet.port %d is not valid", n.Internet.Port)
+		}
+	default:
+		return fmt.Errorf("one of serverless and internet must be set")
+	}
+	return nil
+}
+
 // NegStatus contains name and zone of the Network Endpoint Group
 // resources associated with this service
 type NegStatus struct {
@@ -169,3 +254,21 @@ func (svc *Service) NEGStatus() (*NegStatus, bool, error) {
 
 	return &res, true, nil
 }
+
+// ExternalNEGAnnotation returns true if the external NEG annotation is found.
+// If found, it also returns the validated external NEG annotation struct.
+func (svc *Service) ExternalNEGAnnotation() (*ExternalNegAnnotation, bool, error) {
+	annotation, ok := svc.v[ExternalNEGAnnotationKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var res ExternalNegAnnotation
+	if err := json.Unmarshal([]byte(annotation), &res); err != nil {
+		return nil, true, fmt.Errorf("failed to parse annotation %s: %w", ExternalNEGAnnotationKey, err)
+	}
+	if err := res.Validate(); err != nil {
+		return nil, true, fmt.Errorf("annotation %s is invalid: %w", ExternalNEGAnnotationKey, err)
+	}
+	return &res, true, nil
+}
@@ -281,3 +281,77 @@ func TestParseNegStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalNEGAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		annotation  *string
+		expect      *ExternalNegAnnotation
+		expectFound bool
+		expectError bool
+	}{
+		{
+			desc: "annotation not specified",
+		},
+		{
+			desc:        "Cloud Run service",
+			annotation:  ptr(`{"serverless":{"region":"us-central1","cloudRun":{"service":"api","tag":"beta"}}}`),
+			expect:      &ExternalNegAnnotation{Serverless: &ServerlessNeg{Region: "us-central1", CloudRun: &CloudRunBackend{Service: "api", Tag: "beta"}}},
+			expectFound: true,
+		},
+		{
+			desc:        "internet FQDN",
+			annotation:  ptr(`{"internet":{"fqdn":"origin.example.com","port":443}}`),
+			expect:      &ExternalNegAnnotation{Internet: &InternetNeg{FQDN: "origin.example.com", Port: 443}},
+			expectFound: true,
+		},
+		{
+			desc:        "malformed",
+			annotation:  ptr(`foo`),
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "serverless and internet",
+			annotation:  ptr(`{"serverless":{"region":"us-central1","cloudRun":{"service":"api"}},"internet":{"fqdn":"origin.example.com"}}`),
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "serverless without region",
+			annotation:  ptr(`{"serverless":{"cloudFunction":{"function":"f"}}}`),
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "internet with FQDN and IP address",
+			annotation:  ptr(`{"internet":{"fqdn":"origin.example.com","ipAddress":"1.2.3.4"}}`),
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "empty",
+			annotation:  ptr(`{}`),
+			expectFound: true,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := &v1.Service{}
+			if tc.annotation != nil {
+				svc.Annotations = map[string]string{ExternalNEGAnnotationKey: *tc.annotation}
+			}
+			got, found, err := FromService(svc).ExternalNEGAnnotation()
+			if found != tc.expectFound || (err != nil) != tc.expectError {
+				t.Fatalf("ExternalNEGAnnotation() = %v, %t, %v, want found %t and error %t", got, found, err, tc.expectFound, tc.expectError)
+			}
+			if !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("ExternalNEGAnnotation() = %+v, want %+v", got, tc.expect)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan records the GCE mutations that the Ingress controller would
+// make when it runs in plan mode, instead of calling the mutating APIs.
+package plan
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/googleapi"
+)
+
+// diffOptions ignore the HTTP response metadata of the resources read from
+// the cloud.
+var diffOptions = []cmp.Option{cmpopts.IgnoreTypes(googleapi.ServerResponse{})}
+
+// Action is the kind of mutation of an operation.
+type Action string
+
+const (
+	// Create is the creation of a resource.
+	Create Action = "Create"
+	// Update is the update or patch of a field of a resource.
+	Update Action = "Update"
+	// Delete is the deletion of a resource.
+	Delete Action = "Delete"
+)
+
+// Operation is a mutating GCE API call that was not made in plan mode.
+type Operation struct {
+	// Action is the kind of mutation.
+	Action Action `json:"action"`
+	// Resource is the path of the resource relative to the project, e.g.
+	// global/urlMaps/k8s2-um-xyz.
+	Resource string `json:"resource"`
+	// Diff is the difference between the resource in the cloud and the
+	// desired resource, for updates.
+	Diff string `json:"diff,omitempty"`
+}
+
+// String returns the action and the resource of the operation.
+func (op Operation) String() string {
+	return fmt.Sprintf("%s %s", op.Action, op.Resource)
+}
+
+// Recorder records the operations of the syncs that run in plan mode. A nil
+// Recorder means that plan mode is disabled, and all its methods are no-ops.
+type Recorder struct {
+	mu  sync.Mutex
+	ops []Operation
+	// planned are the desired resources of the recorded operations, by
+	// resource path, so that the rest of the sync sees the resources it
+	// would have created or updated. Deleted resources map to nil.
+	planned map[string]interface{}
+}
+
+// NewRecorder returns a Recorder that enables plan mode.
+func NewRecorder() *Recorder {
+	return &Recorder{planned: map[string]interface{}{}}
+}
+
+// Enabled returns true if the controller runs in plan mode.
+func (r *Recorder) Enabled() bool {
+	return r != nil
+}
+
+// Record records an operation on the resource of the given collection (e.g.
+// "backendServices") and key. For updates, the diff is computed between the
+// current and the desired resource.
+func (r *Recorder) Record(action Action, resource string, key *meta.Key, current, desired interface{}) {
+	if r == nil {
+		return
+	}
+	op := Operation{Action: action, Resource: cloud.ResourcePath(resource, key)}
+	if action == Update && current != nil && desired != nil {
+		op.Diff = cmp.Diff(current, desired, diffOptions...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ops = append(r.ops, op)
+	if action == Delete {
+		r.planned[op.Resource] = nil
+	} else if desired != nil {
+		r.planned[op.Resource] = desired
+	}
+}
+
+// Planned returns the desired resource of the last recorded operation on the
+// resource of the given collection and key. The returned resource is nil if
+// the resource is planned for deletion. The second value is false if no
+// operation was recorded on the resource.
+func (r *Recorder) Planned(resource string, key *meta.Key) (interface{}, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	obj, ok := r.planned[cloud.ResourcePath(resource, key)]
+	return obj, ok
+}
+
+// Resources returns the desired resources of the recorded operations, in the
+// order in which they were first recorded. The resources planned for deletion
+// are left out.
+func (r *Recorder) Resources() []interface{} {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []interface{}
+	seen := map[string]bool{}
+	for _, op := range r.ops {
+		if seen[op.Resource] {
+			continue
+		}
+		seen[op.Resource] = true
+		if obj := r.planned[op.Resource]; obj != nil {
+			ret = append(ret, obj)
+		}
+	}
+	return ret
+}
+
+// Flush returns the recorded operations and resets the Recorder for the next
+// sync.
+func (r *Recorder) Flush() []Operation {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ops := r.ops
+	r.ops = nil
+	r.planned = map[string]interface{}{}
+	return ops
+}
+
+// SelfLink returns the GA self link of the resource of the given collection
+// and key, to reference resources that are planned for creation.
+func SelfLink(project, resource string, key *meta.Key) string {
+	return cloud.SelfLink(meta.VersionGA, project, resource, key)
+}
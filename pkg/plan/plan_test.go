@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	if r.Enabled() {
+		t.Errorf("Enabled() = true for a nil Recorder, want false")
+	}
+	r.Record(Create, "urlMaps", meta.GlobalKey("um"), nil, &compute.UrlMap{})
+	if _, ok := r.Planned("urlMaps", meta.GlobalKey("um")); ok {
+		t.Errorf("Planned() = _, true for a nil Recorder, want false")
+	}
+	if resources := r.Resources(); resources != nil {
+		t.Errorf("Resources() = %v for a nil Recorder, want nil", resources)
+	}
+	if ops := r.Flush(); ops != nil {
+		t.Errorf("Flush() = %v for a nil Recorder, want nil", ops)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	r := NewRecorder()
+	if !r.Enabled() {
+		t.Fatalf("Enabled() = false, want true")
+	}
+
+	created := &compute.UrlMap{Name: "um", DefaultService: "be-1"}
+	r.Record(Create, "urlMaps", meta.GlobalKey("um"), nil, created)
+	current := &compute.BackendService{Name: "be", TimeoutSec: 30}
+	desired := &compute.BackendService{Name: "be", TimeoutSec: 60}
+	r.Record(Update, "backendServices", meta.RegionalKey("be", "us-central1"), current, desired)
+	r.Record(Delete, "sslCertificates", meta.GlobalKey("cert"), nil, nil)
+
+	if obj, ok := r.Planned("urlMaps", meta.GlobalKey("um")); !ok || obj != created {
+		t.Errorf("Planned(urlMaps/um) = %v, %t, want %v, true", obj, ok, created)
+	}
+	if obj, ok := r.Planned("sslCertificates", meta.GlobalKey("cert")); !ok || obj != nil {
+		t.Errorf("Planned(sslCertificates/cert) = %v, %t, want nil, true", obj, ok)
+	}
+	if _, ok := r.Planned("urlMaps", meta.GlobalKey("other")); ok {
+		t.Errorf("Planned(urlMaps/other) = _, true, want false")
+	}
+
+	updated := &compute.UrlMap{Name: "um", DefaultService: "be-2"}
+	r.Record(Update, "urlMaps", meta.GlobalKey("um"), created, updated)
+	resources := r.Resources()
+	if len(resources) != 2 || resources[0] != updated || resources[1] != desired {
+		t.Errorf("Resources() = %v, want [%v %v]", resources, updated, desired)
+	}
+
+	ops := r.Flush()
+	wantOps := []string{
+		"Create global/urlMaps/um",
+		"Update regions/us-central1/backendServices/be",
+		"Delete global/sslCertificates/cert",
+		"Update global/urlMaps/um",
+	}
+	if len(ops) != len(wantOps) {
+		t.Fatalf("Flush() returned %d operations, want %d: %v", len(ops), len(wantOps), ops)
+	}
+	for i, op := range ops {
+		if op.String() != wantOps[i] {
+			t.Errorf("ops[%d] = %q, want %q", i, op.String(), wantOps[i])
+		}
+	}
+	if ops[0].Diff != "" {
+		t.Errorf("ops[0].Diff = %q, want no diff for a creation", ops[0].Diff)
+	}
+	if !strings.Contains(ops[1].Diff, "TimeoutSec") {
+		t.Errorf("ops[1].Diff = %q, want a diff of TimeoutSec", ops[1].Diff)
+	}
+
+	if ops := r.Flush(); len(ops) != 0 {
+		t.Errorf("Flush() after Flush() = %v, want no operations", ops)
+	}
+	if _, ok := r.Planned("urlMaps", meta.GlobalKey("um")); ok {
+		t.Errorf("Planned(urlMaps/um) after Flush() = _, true, want false")
+	}
+}
+
+func TestSelfLink(t *testing.T) {
+	got := SelfLink("my-project", "healthChecks", meta.GlobalKey("hc"))
+	want := "https://www.googleapis.com/compute/v1/projects/my-project/global/healthChecks/hc"
+	if got != want {
+		t.Errorf("SelfLink() = %q, want %q", got, want)
+	}
+}
@@ -51,15 +51,15 @@ func (s *IngressSyncer) Sync(state interface{}, ingLogger klog.Logger) error {
 		if err == ErrSkipBackendsSync {
 			return nil
 		}
-		return fmt.Errorf("error running backend syncing routine: %v", err)
+		return fmt.Errorf("error running backend syncing routine: %w", err)
 	}
 
 	if err := s.controller.SyncLoadBalancer(state, ingLogger); err != nil {
-		return fmt.Errorf("error running load balancer syncing routine: %v", err)
+		return fmt.Errorf("error running load balancer syncing routine: %w", err)
 	}
 
 	if err := s.controller.PostProcess(state, ingLogger); err != nil {
-		return fmt.Errorf("error running post-process routine: %v", err)
+		return fmt.Errorf("error running post-process routine: %w", err)
 	}
 
 	return nil
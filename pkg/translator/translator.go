@@ -21,6 +21,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -29,6 +31,7 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -52,6 +55,9 @@ type Env struct {
 	Subnetwork string
 	Region     string
 	Project    string
+	// CertificateMap is the name or URL of the Certificate Manager
+	// certificate map used instead of SSL certificates, if any.
+	CertificateMap string
 }
 
 // NewEnv returns an Env for the given Ingress.
@@ -184,7 +190,15 @@ const hostRulePrefix = "host"
 // and remove the mapping. When a new path is added to a host (happens
 // more frequently than service deletion) we just need to lookup the 1
 // path matcher of the host.
-func ToCompositeURLMap(g *utils.GCEURLMap, namer namer.IngressFrontendNamer, key *meta.Key) *composite.UrlMap {
+//
+// The URL rewrites and header actions of the given FrontendConfig, if any,
+// are applied to the route rules of the paths they select. The retry policy,
+// fault injection and request mirroring settings of a backend's
+// BackendConfig are applied to the route rules that send traffic to it. The
+// custom error responses of the FrontendConfig become the default custom
+// error response policy of the URL map. The paths served by backend buckets
+// link to the global backend buckets.
+func ToCompositeURLMap(g *utils.GCEURLMap, namer namer.IngressFrontendNamer, key *meta.Key, feConfig *frontendconfigv1beta1.FrontendConfig) *composite.UrlMap {
 	defaultBackendName := g.DefaultBackend.BackendName()
 	key.Name = defaultBackendName
 	resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: key}
@@ -209,22 +223,268 @@ func ToCompositeURLMap(g *utils.GCEURLMap, namer namer.IngressFrontendNamer, key
 			PathRules:      []*composite.PathRule{},
 		}
 
+		// A path matcher cannot mix path rules and route rules, so all paths
+		// of the host become route rules as soon as one of them needs it.
+		routeActions := routeActionsForHost(feConfig, hostRule.Hostname)
+		if needsRouteRules(hostRule.Paths, routeActions) {
+			pathMatcher.PathRules = nil
+			pathMatcher.RouteRules = toRouteRules(hostRule.Paths, routeActions, key)
+			m.PathMatchers = append(m.PathMatchers, pathMatcher)
+			continue
+		}
+
 		// GCE ensures that matched rule with longest prefix wins.
 		for _, rule := range hostRule.Paths {
-			beName := rule.Backend.BackendName()
-			key.Name = beName
-			resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: key}
-			beLink := resourceID.ResourcePath()
 			pathMatcher.PathRules = append(pathMatcher.PathRules, &composite.PathRule{
 				Paths:   []string{rule.Path},
-				Service: beLink,
+				Service: pathRuleLink(rule, key),
 			})
 		}
 		m.PathMatchers = append(m.PathMatchers, pathMatcher)
 	}
+	m.DefaultCustomErrorResponsePolicy = toCustomErrorResponsePolicy(feConfig)
 	return m
 }
 
+// toCustomErrorResponsePolicy returns the custom error response policy
+// configured in the given FrontendConfig, or nil if there is none.
+func toCustomErrorResponsePolicy(feConfig *frontendconfigv1beta1.FrontendConfig) *composite.CustomErrorResponsePolicy {
+	if feConfig == nil || feConfig.Spec.CustomErrorResponses == nil {
+		return nil
+	}
+	config := feConfig.Spec.CustomErrorResponses
+	policy := &composite.CustomErrorResponsePolicy{
+		ErrorService: backendBucketLink(config.ErrorBackendBucket),
+	}
+	for _, rule := range config.Rules {
+		policy.ErrorResponseRules = append(policy.ErrorResponseRules, &composite.CustomErrorResponsePolicyCustomErrorResponseRule{
+			MatchResponseCodes:   rule.ResponseCodes,
+			Path:                 rule.Path,
+			OverrideResponseCode: rule.OverrideResponseCode,
+		})
+	}
+	return policy
+}
+
+// backendServiceLink returns the resource path of the backend service of the
+// given ServicePort, in the scope of the given key.
+func backendServiceLink(sp utils.ServicePort, key *meta.Key) string {
+	key.Name = sp.BackendName()
+	resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendServices", Key: key}
+	return resourceID.ResourcePath()
+}
+
+// backendBucketLink returns the resource path of the backend bucket of the
+// given name. Backend buckets are global resources.
+func backendBucketLink(name string) string {
+	resourceID := cloud.ResourceID{ProjectID: "", Resource: "backendBuckets", Key: meta.GlobalKey(name)}
+	return resourceID.ResourcePath()
+}
+
+// pathRuleLink returns the resource path of the backend bucket or of the
+// backend service that serves the given path rule.
+func pathRuleLink(rule utils.PathRule, key *meta.Key) string {
+	if rule.BackendBucket != nil {
+		return backendBucketLink(rule.BackendBucket.Name)
+	}
+	return backendServiceLink(rule.Backend, key)
+}
+
+// needsRouteRules returns true if any of the given path rules can only be
+// expressed as a route rule.
+func needsRouteRules(paths []utils.PathRule, routeActions []frontendconfigv1beta1.RouteActionConfig) bool {
+	for _, rule := range paths {
+		if len(rule.WeightedBackends) > 0 || len(rule.RouteMatches) > 0 {
+			return true
+		}
+		if features.HasRouteActionSettings(rule.Backend) {
+			return true
+		}
+		if routeActionForPath(routeActions, rule.Path) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// routeActionsForHost returns the route actions of the FrontendConfig that
+// select the given host, host specific ones first.
+func routeActionsForHost(feConfig *frontendconfigv1beta1.FrontendConfig, host string) []frontendconfigv1beta1.RouteActionConfig {
+	if feConfig == nil {
+		return nil
+	}
+	var hostActions, allHostsActions []frontendconfigv1beta1.RouteActionConfig
+	for _, action := range feConfig.Spec.RouteActions {
+		switch action.Host {
+		case host:
+			hostActions = append(hostActions, action)
+		case "":
+			allHostsActions = append(allHostsActions, action)
+		}
+	}
+	return append(hostActions, allHostsActions...)
+}
+
+// routeActionForPath returns the most specific of the given route actions
+// that selects the given path rule path, or nil if none does. An action with
+// the Ingress path "/foo" selects both the "/foo" and "/foo/*" path rules
+// that a Prefix path type translates into.
+func routeActionForPath(routeActions []frontendconfigv1beta1.RouteActionConfig, path string) *frontendconfigv1beta1.RouteActionConfig {
+	var allPathsAction *frontendconfigv1beta1.RouteActionConfig
+	for i, action := range routeActions {
+		if action.Path == "" {
+			if allPathsAction == nil {
+				allPathsAction = &routeActions[i]
+			}
+			continue
+		}
+		if action.Path == path || strings.TrimSuffix(action.Path, "/")+"/*" == path {
+			return &routeActions[i]
+		}
+	}
+	return allPathsAction
+}
+
+// applyRouteAction sets the URL rewrite and header action of the given route
+// action on the route rule.
+func applyRouteAction(routeRule *composite.HttpRouteRule, action *frontendconfigv1beta1.RouteActionConfig) {
+	if action == nil {
+		return
+	}
+	if action.UrlRewrite != nil {
+		if routeRule.RouteAction == nil {
+			routeRule.RouteAction = &composite.HttpRouteAction{}
+		}
+		routeRule.RouteAction.UrlRewrite = &composite.UrlRewrite{
+			PathPrefixRewrite: action.UrlRewrite.PathPrefixRewrite,
+			HostRewrite:       action.UrlRewrite.HostRewrite,
+		}
+	}
+	if action.HeaderAction != nil {
+		routeRule.HeaderAction = &composite.HttpHeaderAction{
+			RequestHeadersToAdd:     toHeaderOptions(action.HeaderAction.RequestHeadersToAdd),
+			RequestHeadersToRemove:  nonEmpty(action.HeaderAction.RequestHeadersToRemove),
+			ResponseHeadersToAdd:    toHeaderOptions(action.HeaderAction.ResponseHeadersToAdd),
+			ResponseHeadersToRemove: nonEmpty(action.HeaderAction.ResponseHeadersToRemove),
+		}
+	}
+}
+
+// applyBackendRouteAction sets the route action settings of the BackendConfig
+// of the given ServicePort on the route rule.
+func applyBackendRouteAction(routeRule *composite.HttpRouteRule, sp utils.ServicePort, key *meta.Key) {
+	if !features.HasRouteActionSettings(sp) {
+		return
+	}
+	if routeRule.RouteAction == nil {
+		routeRule.RouteAction = &composite.HttpRouteAction{}
+	}
+	var mirrorLink string
+	if sp.RequestMirror != nil {
+		mirrorLink = backendServiceLink(*sp.RequestMirror, key)
+	}
+	features.ApplyRouteActionSettings(sp, routeRule.RouteAction, mirrorLink)
+}
+
+// nonEmpty returns nil for an empty slice, as the GCE API omits empty lists.
+func nonEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func toHeaderOptions(headers []frontendconfigv1beta1.HeaderConfig) []*composite.HttpHeaderOption {
+	var ret []*composite.HttpHeaderOption
+	for _, h := range headers {
+		ret = append(ret, &composite.HttpHeaderOption{HeaderName: h.Name, HeaderValue: h.Value, Replace: h.Replace})
+	}
+	return ret
+}
+
+// toRouteRules translates path rules into equivalent route rules.
+//
+// Path rules are matched by the longest path while route rules are evaluated
+// in order of priority, so the rules are ordered such that exact paths come
+// first, followed by prefixes from longest to shortest. The route matches of
+// a path are evaluated right before the path itself. The route action that
+// selects a path applies to all of its route rules, while the BackendConfig
+// route action settings of a path apply to the rule of the path and those of
+// a route match to the rule of the match.
+func toRouteRules(paths []utils.PathRule, routeActions []frontendconfigv1beta1.RouteActionConfig, key *meta.Key) []*composite.HttpRouteRule {
+	sorted := make([]utils.PathRule, len(paths))
+	copy(sorted, paths)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iPrefix, jPrefix := strings.HasSuffix(sorted[i].Path, "*"), strings.HasSuffix(sorted[j].Path, "*")
+		if iPrefix != jPrefix {
+			return !iPrefix
+		}
+		return len(sorted[i].Path) > len(sorted[j].Path)
+	})
+
+	var routeRules []*composite.HttpRouteRule
+	for _, rule := range sorted {
+		action := routeActionForPath(routeActions, rule.Path)
+		for _, m := range rule.RouteMatches {
+			match := toRouteRuleMatch(rule.Path)
+			for _, h := range m.Headers {
+				headerMatch := &composite.HttpHeaderMatch{HeaderName: h.Name, ExactMatch: h.Value}
+				if h.Value == "" {
+					headerMatch.PresentMatch = true
+				}
+				match.HeaderMatches = append(match.HeaderMatches, headerMatch)
+			}
+			for _, q := range m.QueryParams {
+				queryMatch := &composite.HttpQueryParameterMatch{Name: q.Name, ExactMatch: q.Value}
+				if q.Value == "" {
+					queryMatch.PresentMatch = true
+				}
+				match.QueryParameterMatches = append(match.QueryParameterMatches, queryMatch)
+			}
+			matchRule := &composite.HttpRouteRule{
+				Priority:   int64(len(routeRules) + 1),
+				MatchRules: []*composite.HttpRouteRuleMatch{match},
+				Service:    backendServiceLink(m.Backend, key),
+			}
+			applyRouteAction(matchRule, action)
+			applyBackendRouteAction(matchRule, m.Backend, key)
+			routeRules = append(routeRules, matchRule)
+		}
+
+		routeRule := &composite.HttpRouteRule{
+			Priority:   int64(len(routeRules) + 1),
+			MatchRules: []*composite.HttpRouteRuleMatch{toRouteRuleMatch(rule.Path)},
+		}
+		if len(rule.WeightedBackends) > 0 {
+			routeRule.RouteAction = &composite.HttpRouteAction{}
+			for _, wb := range rule.WeightedBackends {
+				routeRule.RouteAction.WeightedBackendServices = append(routeRule.RouteAction.WeightedBackendServices, &composite.WeightedBackendService{
+					BackendService: backendServiceLink(wb.Backend, key),
+					Weight:         wb.Weight,
+					// A weight of zero is meaningful and must be sent explicitly.
+					ForceSendFields: []string{"Weight"},
+				})
+			}
+		} else {
+			routeRule.Service = pathRuleLink(rule, key)
+		}
+		applyRouteAction(routeRule, action)
+		applyBackendRouteAction(routeRule, rule.Backend, key)
+		routeRules = append(routeRules, routeRule)
+	}
+	return routeRules
+}
+
+// toRouteRuleMatch returns the route rule match equivalent to the given path
+// rule path. A trailing "*" matches any path with the preceding prefix, any
+// other path must match exactly.
+func toRouteRuleMatch(path string) *composite.HttpRouteRuleMatch {
+	if strings.HasSuffix(path, "*") {
+		return &composite.HttpRouteRuleMatch{PrefixMatch: strings.TrimSuffix(path, "*")}
+	}
+	return &composite.HttpRouteRuleMatch{FullPathMatch: path}
+}
+
 // ToRedirectUrlMap returns the UrlMap used for HTTPS Redirects on a L7 ELB
 // This function returns nil if no url map needs to be created
 func (t *Translator) ToRedirectUrlMap(env *Env, version meta.Version) *composite.UrlMap {
@@ -329,6 +589,13 @@ func (t *Translator) ToCompositeTargetHttpsProxy(env *Env, description string, v
 		Version:         version,
 	}
 	var sslPolicySet bool
+	if env.CertificateMap != "" {
+		// Certificate maps are only supported by global external load balancers
+		if t.IsL7ILB || t.IsL7XLBRegional {
+			return nil, sslPolicySet, fmt.Errorf("certificate maps are not supported with L7 ILB or regional L7 XLB")
+		}
+		proxy.CertificateMap = certificateMapLink(env)
+	}
 	sslPolicy, err := sslPolicyLink(env, t.IsL7XLBRegional)
 	if err != nil {
 		return nil, sslPolicySet, err
@@ -337,6 +604,9 @@ func (t *Translator) ToCompositeTargetHttpsProxy(env *Env, description string, v
 		proxy.SslPolicy = *sslPolicy
 		sslPolicySet = true
 	}
+	if serverTlsPolicy := serverTlsPolicyLink(env, t.IsL7ILB || t.IsL7XLBRegional); serverTlsPolicy != nil {
+		proxy.ServerTlsPolicy = *serverTlsPolicy
+	}
 
 	return proxy, sslPolicySet, nil
 }
@@ -378,6 +648,22 @@ func (t *Translator) ToCompositeSSLCertificates(env *Env, tlsName string, tls []
 	return certs
 }
 
+// certificateManagerPrefix is the prefix of Certificate Manager resource URLs.
+const certificateManagerPrefix = "//certificatemanager.googleapis.com/"
+
+// networkSecurityPrefix is the prefix of network security resource URLs.
+const networkSecurityPrefix = "//networksecurity.googleapis.com/"
+
+// certificateMapLink returns the URL of the certificate map of the given
+// Env. Certificate map names are resolved to global certificate maps in the
+// project of the Env.
+func certificateMapLink(env *Env) string {
+	if strings.HasPrefix(env.CertificateMap, certificateManagerPrefix) {
+		return env.CertificateMap
+	}
+	return fmt.Sprintf("%sprojects/%s/locations/global/certificateMaps/%s", certificateManagerPrefix, env.Project, env.CertificateMap)
+}
+
 // sslPolicyLink returns the ref to the ssl policy that is described by the
 // frontend config.  Since Ssl Policy is a *string, there are three possible I/O situations
 // 1) policy is nil -> this returns nil
@@ -410,6 +696,56 @@ func sslPolicyLink(env *Env, isRegional bool) (*string, error) {
 	return &resID, nil
 }
 
+// serverTlsPolicyLink returns the URL of the server TLS policy that is
+// described by the frontend config. Like sslPolicyLink, it returns nil if the
+// policy is not set, and an empty string if it is set to an empty string.
+func serverTlsPolicyLink(env *Env, isRegional bool) *string {
+	var link string
+
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.ServerTlsPolicy == nil {
+		return nil
+	}
+	policyName := *env.FrontendConfig.Spec.ServerTlsPolicy
+	if policyName == "" {
+		return &link
+	}
+
+	location := "global"
+	if isRegional {
+		location = env.Region
+	}
+	link = fmt.Sprintf("%sprojects/%s/locations/%s/serverTlsPolicies/%s", networkSecurityPrefix, env.Project, location, policyName)
+	return &link
+}
+
+// EqualServerTlsPolicies returns true if the given server TLS policy URLs
+// refer to the same policy. The project may be referred to by its ID or its
+// number, so only the location and name of the policies are compared.
+func EqualServerTlsPolicies(a, b string) bool {
+	return equalLocationResources(a, b)
+}
+
+// EqualCertificateMaps returns true if the given certificate map URLs refer
+// to the same certificate map, in the same way as EqualServerTlsPolicies.
+func EqualCertificateMaps(a, b string) bool {
+	return equalLocationResources(a, b)
+}
+
+// equalLocationResources returns true if the given URLs of resources with a
+// location refer to the same resource, ignoring the project part.
+func equalLocationResources(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	locationSuffix := func(link string) string {
+		if i := strings.Index(link, "/locations/"); i >= 0 {
+			return link[i:]
+		}
+		return link
+	}
+	return locationSuffix(a) == locationSuffix(b)
+}
+
 // TODO(shance): find a way to unexport this
 func GetCertHash(contents string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(contents)))[:16]
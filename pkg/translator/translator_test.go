@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/utils/ptr"
 
@@ -115,7 +116,590 @@ func TestToComputeURLMap(t *testing.T) {
 
 	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
 	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
-	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"))
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), nil)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapWeightedBackends(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/*",
+						Backend: utils.ServicePort{NodePort: 31000, BackendNamer: namer},
+					},
+					{
+						Path:    "/web/*",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+						WeightedBackends: []utils.WeightedBackend{
+							{Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer}, Weight: 90},
+							{Backend: utils.ServicePort{NodePort: 32100, BackendNamer: namer}, Weight: 10},
+						},
+					},
+					{
+						Path:    "/other",
+						Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/other"}},
+						Service:    "global/backendServices/k8s-be-32500--uid1",
+					},
+					{
+						Priority:   2,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/web/"}},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 90, ForceSendFields: []string{"Weight"}},
+								{BackendService: "global/backendServices/k8s-be-32100--uid1", Weight: 10, ForceSendFields: []string{"Weight"}},
+							},
+						},
+					},
+					{
+						Priority:   3,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						Service:    "global/backendServices/k8s-be-31000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), nil)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapRouteMatches(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/web/*",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+						RouteMatches: []utils.RouteMatch{
+							{
+								Headers: []utils.MatchCondition{{Name: "x-canary", Value: "true"}},
+								Backend: utils.ServicePort{NodePort: 32100, BackendNamer: namer},
+							},
+							{
+								Headers:     []utils.MatchCondition{{Name: "x-debug"}},
+								QueryParams: []utils.MatchCondition{{Name: "beta", Value: "1"}, {Name: "trace"}},
+								Backend:     utils.ServicePort{NodePort: 32200, BackendNamer: namer},
+							},
+						},
+					},
+					{
+						Path:    "/other",
+						Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/other"}},
+						Service:    "global/backendServices/k8s-be-32500--uid1",
+					},
+					{
+						Priority: 2,
+						MatchRules: []*composite.HttpRouteRuleMatch{
+							{
+								PrefixMatch:   "/web/",
+								HeaderMatches: []*composite.HttpHeaderMatch{{HeaderName: "x-canary", ExactMatch: "true"}},
+							},
+						},
+						Service: "global/backendServices/k8s-be-32100--uid1",
+					},
+					{
+						Priority: 3,
+						MatchRules: []*composite.HttpRouteRuleMatch{
+							{
+								PrefixMatch:   "/web/",
+								HeaderMatches: []*composite.HttpHeaderMatch{{HeaderName: "x-debug", PresentMatch: true}},
+								QueryParameterMatches: []*composite.HttpQueryParameterMatch{
+									{Name: "beta", ExactMatch: "1"},
+									{Name: "trace", PresentMatch: true},
+								},
+							},
+						},
+						Service: "global/backendServices/k8s-be-32200--uid1",
+					},
+					{
+						Priority:   4,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/web/"}},
+						Service:    "global/backendServices/k8s-be-32000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), nil)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapRouteActions(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/api",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+					{
+						Path:    "/api/*",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+					{
+						Path:    "/other",
+						Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer},
+					},
+				},
+			},
+			{
+				Hostname: "foo.bar.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/",
+						Backend: utils.ServicePort{NodePort: 33000, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			RouteActions: []frontendconfigv1beta1.RouteActionConfig{
+				{
+					HeaderAction: &frontendconfigv1beta1.HeaderActionConfig{
+						ResponseHeadersToRemove: []string{"server"},
+					},
+				},
+				{
+					Host: "abc.com",
+					Path: "/api",
+					UrlRewrite: &frontendconfigv1beta1.UrlRewriteConfig{
+						PathPrefixRewrite: "/v2",
+						HostRewrite:       "api.internal",
+					},
+					HeaderAction: &frontendconfigv1beta1.HeaderActionConfig{
+						RequestHeadersToAdd: []frontendconfigv1beta1.HeaderConfig{{Name: "x-api", Value: "true", Replace: true}},
+					},
+				},
+			},
+		},
+	}
+	responseHeaderAction := &composite.HttpHeaderAction{ResponseHeadersToRemove: []string{"server"}}
+	apiRouteAction := &composite.HttpRouteAction{UrlRewrite: &composite.UrlRewrite{PathPrefixRewrite: "/v2", HostRewrite: "api.internal"}}
+	apiHeaderAction := &composite.HttpHeaderAction{RequestHeadersToAdd: []*composite.HttpHeaderOption{{HeaderName: "x-api", HeaderValue: "true", Replace: true}}}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+			{
+				Hosts:       []string{"foo.bar.com"},
+				PathMatcher: "host2d50cf9711f59181be6a5e5658e42c21",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:     1,
+						MatchRules:   []*composite.HttpRouteRuleMatch{{FullPathMatch: "/other"}},
+						Service:      "global/backendServices/k8s-be-32500--uid1",
+						HeaderAction: responseHeaderAction,
+					},
+					{
+						Priority:     2,
+						MatchRules:   []*composite.HttpRouteRuleMatch{{FullPathMatch: "/api"}},
+						Service:      "global/backendServices/k8s-be-32000--uid1",
+						RouteAction:  apiRouteAction,
+						HeaderAction: apiHeaderAction,
+					},
+					{
+						Priority:     3,
+						MatchRules:   []*composite.HttpRouteRuleMatch{{PrefixMatch: "/api/"}},
+						Service:      "global/backendServices/k8s-be-32000--uid1",
+						RouteAction:  apiRouteAction,
+						HeaderAction: apiHeaderAction,
+					},
+				},
+			},
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host2d50cf9711f59181be6a5e5658e42c21",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:     1,
+						MatchRules:   []*composite.HttpRouteRuleMatch{{FullPathMatch: "/"}},
+						Service:      "global/backendServices/k8s-be-33000--uid1",
+						HeaderAction: responseHeaderAction,
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), feConfig)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapBackendRouteActions(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	numRetries := int64(3)
+	mirror := utils.ServicePort{NodePort: 32600, BackendNamer: namer}
+	apiBackend := utils.ServicePort{
+		NodePort:     32000,
+		BackendNamer: namer,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				RetryPolicy: &backendconfigv1.RetryPolicyConfig{
+					RetryConditions: []string{"5xx"},
+					NumRetries:      &numRetries,
+				},
+				FaultInjection: &backendconfigv1.FaultInjectionConfig{
+					Abort: &backendconfigv1.FaultAbortConfig{HttpStatus: 503, Percentage: 1},
+				},
+				RequestMirror: &backendconfigv1.RequestMirrorConfig{ServiceName: "shadow", ServicePort: 80},
+			},
+		},
+		RequestMirror: &mirror,
+	}
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/api",
+						Backend: apiBackend,
+					},
+					{
+						Path:    "/other",
+						Backend: utils.ServicePort{NodePort: 32500, BackendNamer: namer},
+					},
+				},
+			},
+			{
+				Hostname: "foo.bar.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/",
+						Backend: utils.ServicePort{NodePort: 33000, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+			{
+				Hosts:       []string{"foo.bar.com"},
+				PathMatcher: "host2d50cf9711f59181be6a5e5658e42c21",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/other"}},
+						Service:    "global/backendServices/k8s-be-32500--uid1",
+					},
+					{
+						Priority:   2,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/api"}},
+						Service:    "global/backendServices/k8s-be-32000--uid1",
+						RouteAction: &composite.HttpRouteAction{
+							RetryPolicy: &composite.HttpRetryPolicy{RetryConditions: []string{"5xx"}, NumRetries: 3},
+							FaultInjectionPolicy: &composite.HttpFaultInjection{
+								Abort: &composite.HttpFaultAbort{HttpStatus: 503, Percentage: 1},
+							},
+							RequestMirrorPolicy: &composite.RequestMirrorPolicy{BackendService: "global/backendServices/k8s-be-32600--uid1"},
+						},
+					},
+				},
+			},
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host2d50cf9711f59181be6a5e5658e42c21",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/"},
+						Service: "global/backendServices/k8s-be-33000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), nil)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapCustomErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+				},
+			},
+		},
+	}
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			CustomErrorResponses: &frontendconfigv1beta1.CustomErrorResponsesConfig{
+				ErrorBackendBucket: "error-pages",
+				Rules: []frontendconfigv1beta1.CustomErrorResponseRule{
+					{
+						ResponseCodes: []string{"404"},
+						Path:          "/404.html",
+					},
+					{
+						ResponseCodes:        []string{"5xx"},
+						Path:                 "/5xx.html",
+						OverrideResponseCode: 503,
+					},
+				},
+			},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+					},
+				},
+			},
+		},
+		DefaultCustomErrorResponsePolicy: &composite.CustomErrorResponsePolicy{
+			ErrorService: "global/backendBuckets/error-pages",
+			ErrorResponseRules: []*composite.CustomErrorResponsePolicyCustomErrorResponseRule{
+				{
+					MatchResponseCodes: []string{"404"},
+					Path:               "/404.html",
+				},
+				{
+					MatchResponseCodes:   []string{"5xx"},
+					Path:                 "/5xx.html",
+					OverrideResponseCode: 503,
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), feConfig)
+	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
+		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
+	}
+}
+
+func TestToComputeURLMapBackendBuckets(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1", klog.TODO())
+	assets := &utils.BackendBucket{Name: "assets"}
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000, BackendNamer: namer},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+					},
+					{
+						Path:          "/static/*",
+						BackendBucket: assets,
+					},
+				},
+			},
+			{
+				Hostname: "def.com",
+				Paths: []utils.PathRule{
+					{
+						Path:    "/*",
+						Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer},
+						WeightedBackends: []utils.WeightedBackend{
+							{Backend: utils.ServicePort{NodePort: 32000, BackendNamer: namer}, Weight: 90},
+							{Backend: utils.ServicePort{NodePort: 32100, BackendNamer: namer}, Weight: 10},
+						},
+					},
+					{
+						Path:          "/static/*",
+						BackendBucket: assets,
+					},
+				},
+			},
+		},
+	}
+	wantComputeMap := &composite.UrlMap{
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+			{
+				Hosts:       []string{"def.com"},
+				PathMatcher: "hostfb959b6e3577366c69d260593837095a",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+					},
+					{
+						Paths:   []string{"/static/*"},
+						Service: "global/backendBuckets/assets",
+					},
+				},
+			},
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "hostfb959b6e3577366c69d260593837095a",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority:   1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/static/"}},
+						Service:    "global/backendBuckets/assets",
+					},
+					{
+						Priority:   2,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-32000--uid1", Weight: 90, ForceSendFields: []string{"Weight"}},
+								{BackendService: "global/backendServices/k8s-be-32100--uid1", Weight: 10, ForceSendFields: []string{"Weight"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	namerFactory := namer_util.NewFrontendNamerFactory(namer, "", klog.TODO())
+	feNamer := namerFactory.NamerForLoadBalancer("lb-name")
+	gotComputeURLMap := ToCompositeURLMap(gceURLMap, feNamer, meta.GlobalKey("ns-lb-name"), nil)
 	if diff := cmp.Diff(wantComputeMap, gotComputeURLMap); diff != "" {
 		t.Errorf("Unexpected diff from ToComputeURLMap() (-want +got):\n%s", diff)
 	}
@@ -576,12 +1160,14 @@ func TestToCompositeTargetHttpsProxy(t *testing.T) {
 	description := "foo"
 
 	testCases := []struct {
-		desc      string
-		urlMapKey *meta.Key
-		sslCerts  []*composite.SslCertificate
-		sslPolicy *string
-		version   meta.Version
-		want      *composite.TargetHttpsProxy
+		desc            string
+		urlMapKey       *meta.Key
+		sslCerts        []*composite.SslCertificate
+		sslPolicy       *string
+		serverTlsPolicy *string
+		certificateMap  string
+		version         meta.Version
+		want            *composite.TargetHttpsProxy
 	}{
 		{
 			desc:      "https xlb",
@@ -629,13 +1215,68 @@ func TestToCompositeTargetHttpsProxy(t *testing.T) {
 				SslPolicy:   "global/sslPolicies/test-policy",
 			},
 		},
+		{
+			desc:            "https xlb with server tls policy",
+			urlMapKey:       meta.GlobalKey("my-url-map"),
+			version:         meta.VersionGA,
+			serverTlsPolicy: ptr.To("mtls-policy"),
+			want: &composite.TargetHttpsProxy{
+				Name:            "foo-tp",
+				Description:     description,
+				Version:         meta.VersionGA,
+				UrlMap:          "global/urlMaps/my-url-map",
+				ServerTlsPolicy: "//networksecurity.googleapis.com/projects/test-project/locations/global/serverTlsPolicies/mtls-policy",
+			},
+		},
+		{
+			desc:            "https xlb with empty server tls policy",
+			urlMapKey:       meta.GlobalKey("my-url-map"),
+			version:         meta.VersionGA,
+			serverTlsPolicy: ptr.To(""),
+			want: &composite.TargetHttpsProxy{
+				Name:        "foo-tp",
+				Description: description,
+				Version:     meta.VersionGA,
+				UrlMap:      "global/urlMaps/my-url-map",
+			},
+		},
+		{
+			desc:           "https xlb with certificate map name",
+			urlMapKey:      meta.GlobalKey("my-url-map"),
+			version:        meta.VersionGA,
+			certificateMap: "my-cert-map",
+			want: &composite.TargetHttpsProxy{
+				Name:           "foo-tp",
+				Description:    description,
+				Version:        meta.VersionGA,
+				UrlMap:         "global/urlMaps/my-url-map",
+				CertificateMap: "//certificatemanager.googleapis.com/projects/test-project/locations/global/certificateMaps/my-cert-map",
+			},
+		},
+		{
+			desc:           "https xlb with certificate map url",
+			urlMapKey:      meta.GlobalKey("my-url-map"),
+			version:        meta.VersionGA,
+			certificateMap: "//certificatemanager.googleapis.com/projects/other-project/locations/global/certificateMaps/my-cert-map",
+			want: &composite.TargetHttpsProxy{
+				Name:           "foo-tp",
+				Description:    description,
+				Version:        meta.VersionGA,
+				UrlMap:         "global/urlMaps/my-url-map",
+				CertificateMap: "//certificatemanager.googleapis.com/projects/other-project/locations/global/certificateMaps/my-cert-map",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			// isL7ILB or isL7XLBRegional doesn't affect the outcome here since the key is creating during ensure
 			tr := NewTranslator(false, false, &testNamer{"foo"})
-			env := &Env{FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: tc.sslPolicy}}}
+			env := &Env{
+				FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: tc.sslPolicy, ServerTlsPolicy: tc.serverTlsPolicy}},
+				Project:        "test-project",
+				CertificateMap: tc.certificateMap,
+			}
 			got, sslPolicySet, err := tr.ToCompositeTargetHttpsProxy(env, description, tc.version, tc.urlMapKey, tc.sslCerts)
 			if err != nil {
 				t.Fatal(err)
@@ -651,6 +1292,84 @@ func TestToCompositeTargetHttpsProxy(t *testing.T) {
 	}
 }
 
+func TestToCompositeTargetHttpsProxyRegionalCertificateMap(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc            string
+		isL7ILB         bool
+		isL7XLBRegional bool
+	}{
+		{desc: "https ilb", isL7ILB: true},
+		{desc: "https regional xlb", isL7XLBRegional: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := NewTranslator(tc.isL7ILB, tc.isL7XLBRegional, &testNamer{"foo"})
+			env := &Env{Region: "fakeRegion", Project: "test-project", CertificateMap: "my-cert-map"}
+			if _, _, err := tr.ToCompositeTargetHttpsProxy(env, "foo", meta.VersionGA, meta.RegionalKey("my-url-map", "fakeRegion"), nil); err == nil {
+				t.Errorf("ToCompositeTargetHttpsProxy() = nil error, want error")
+			}
+		})
+	}
+}
+
+func TestToCompositeTargetHttpsProxyRegionalServerTlsPolicy(t *testing.T) {
+	t.Parallel()
+
+	tr := NewTranslator(true, false, &testNamer{"foo"})
+	env := &Env{
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{ServerTlsPolicy: ptr.To("mtls-policy")}},
+		Region:         "fakeRegion",
+		Project:        "test-project",
+	}
+	got, _, err := tr.ToCompositeTargetHttpsProxy(env, "foo", meta.VersionGA, meta.RegionalKey("my-url-map", "fakeRegion"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "//networksecurity.googleapis.com/projects/test-project/locations/fakeRegion/serverTlsPolicies/mtls-policy"
+	if got.ServerTlsPolicy != want {
+		t.Errorf("ServerTlsPolicy = %q, want %q", got.ServerTlsPolicy, want)
+	}
+}
+
+func TestEqualServerTlsPolicies(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "", b: "", want: true},
+		{a: "//networksecurity.googleapis.com/projects/p/locations/global/serverTlsPolicies/x", b: "", want: false},
+		{a: "//networksecurity.googleapis.com/projects/p/locations/global/serverTlsPolicies/x", b: "//networksecurity.googleapis.com/projects/123/locations/global/serverTlsPolicies/x", want: true},
+		{a: "//networksecurity.googleapis.com/projects/p/locations/global/serverTlsPolicies/x", b: "//networksecurity.googleapis.com/projects/p/locations/us-central1/serverTlsPolicies/x", want: false},
+		{a: "//networksecurity.googleapis.com/projects/p/locations/global/serverTlsPolicies/x", b: "//networksecurity.googleapis.com/projects/p/locations/global/serverTlsPolicies/y", want: false},
+	} {
+		if got := EqualServerTlsPolicies(tc.a, tc.b); got != tc.want {
+			t.Errorf("EqualServerTlsPolicies(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestEqualCertificateMaps(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "", b: "", want: true},
+		{a: "//certificatemanager.googleapis.com/projects/p/locations/global/certificateMaps/m", b: "", want: false},
+		{a: "//certificatemanager.googleapis.com/projects/p/locations/global/certificateMaps/m", b: "//certificatemanager.googleapis.com/projects/123/locations/global/certificateMaps/m", want: true},
+		{a: "//certificatemanager.googleapis.com/projects/p/locations/global/certificateMaps/m", b: "https://certificatemanager.googleapis.com/v1/projects/p/locations/global/certificateMaps/m", want: true},
+		{a: "//certificatemanager.googleapis.com/projects/p/locations/global/certificateMaps/m", b: "//certificatemanager.googleapis.com/projects/p/locations/global/certificateMaps/n", want: false},
+	} {
+		if got := EqualCertificateMaps(tc.a, tc.b); got != tc.want {
+			t.Errorf("EqualCertificateMaps(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
 func TestToCompositeSSLCertificates(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -15,8 +15,10 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -46,6 +48,45 @@ type HostRule struct {
 type PathRule struct {
 	Path    string
 	Backend ServicePort
+	// WeightedBackends, if set, splits the traffic of the path between the
+	// listed backends instead of sending all of it to Backend.
+	WeightedBackends []WeightedBackend
+	// RouteMatches are evaluated in order before the requests of the path
+	// are sent to its backends.
+	RouteMatches []RouteMatch
+	// BackendBucket, if set, serves the path instead of Backend.
+	BackendBucket *BackendBucket
+}
+
+// BackendBucket is a GCE backend bucket, which serves the objects of a Cloud
+// Storage bucket.
+type BackendBucket struct {
+	// Name is the name of the backend bucket.
+	Name string
+	// BackendConfig is the BackendConfig attached to the backend bucket, whose
+	// CDN settings are applied to it, or nil if there is none.
+	BackendConfig *backendconfigv1.BackendConfig
+}
+
+// WeightedBackend is a backend that receives a share of the traffic of a path.
+type WeightedBackend struct {
+	Backend ServicePort
+	Weight  int64
+}
+
+// RouteMatch sends the requests of a path that meet all of its conditions
+// to a backend.
+type RouteMatch struct {
+	Headers     []MatchCondition
+	QueryParams []MatchCondition
+	Backend     ServicePort
+}
+
+// MatchCondition is a condition on a request header or query parameter. An
+// empty Value only requires the header or query parameter to be present.
+type MatchCondition struct {
+	Name  string
+	Value string
 }
 
 // NewGCEURLMap returns an empty GCEURLMap
@@ -85,6 +126,33 @@ func EqualMapping(a, b *GCEURLMap) bool {
 			if aPath.Backend.ID != bPath.Backend.ID {
 				return false
 			}
+			if (aPath.BackendBucket != nil) != (bPath.BackendBucket != nil) {
+				return false
+			}
+			if aPath.BackendBucket != nil && aPath.BackendBucket.Name != bPath.BackendBucket.Name {
+				return false
+			}
+			if len(aPath.WeightedBackends) != len(bPath.WeightedBackends) {
+				return false
+			}
+			for i, aBackend := range aPath.WeightedBackends {
+				bBackend := bPath.WeightedBackends[i]
+				if aBackend.Backend.ID != bBackend.Backend.ID || aBackend.Weight != bBackend.Weight {
+					return false
+				}
+			}
+			if len(aPath.RouteMatches) != len(bPath.RouteMatches) {
+				return false
+			}
+			for i, aMatch := range aPath.RouteMatches {
+				bMatch := bPath.RouteMatches[i]
+				if aMatch.Backend.ID != bMatch.Backend.ID {
+					return false
+				}
+				if !reflect.DeepEqual(aMatch.Headers, bMatch.Headers) || !reflect.DeepEqual(aMatch.QueryParams, bMatch.QueryParams) {
+					return false
+				}
+			}
 		}
 	}
 	return true
@@ -131,16 +199,33 @@ func (g *GCEURLMap) PutPathRulesForHost(hostname string, pathRules []PathRule) {
 func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 
 	uniqueServerPorts := make(map[ServicePortID]bool)
+	add := func(sp ServicePort) {
+		if !uniqueServerPorts[sp.ID] {
+			svcPorts = append(svcPorts, sp)
+			uniqueServerPorts[sp.ID] = true
+		}
+		// The backend service receiving mirrored requests is not referenced
+		// by the URL map directly but needs to exist as well.
+		if sp.RequestMirror != nil && !uniqueServerPorts[sp.RequestMirror.ID] {
+			svcPorts = append(svcPorts, *sp.RequestMirror)
+			uniqueServerPorts[sp.RequestMirror.ID] = true
+		}
+	}
 	if g.DefaultBackend != nil {
-		svcPorts = append(svcPorts, *g.DefaultBackend)
-		uniqueServerPorts[g.DefaultBackend.ID] = true
+		add(*g.DefaultBackend)
 	}
 
 	for _, rules := range g.HostRules {
 		for _, rule := range rules.Paths {
-			if !uniqueServerPorts[rule.Backend.ID] {
-				svcPorts = append(svcPorts, rule.Backend)
-				uniqueServerPorts[rule.Backend.ID] = true
+			if rule.BackendBucket != nil {
+				continue
+			}
+			add(rule.Backend)
+			for _, wb := range rule.WeightedBackends {
+				add(wb.Backend)
+			}
+			for _, m := range rule.RouteMatches {
+				add(m.Backend)
 			}
 		}
 	}
@@ -148,6 +233,21 @@ func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 	return
 }
 
+// AllBackendBuckets returns the backend buckets that serve the paths of the
+// GCEURLMap.
+func (g *GCEURLMap) AllBackendBuckets() (buckets []BackendBucket) {
+	seen := make(map[string]bool)
+	for _, rules := range g.HostRules {
+		for _, rule := range rules.Paths {
+			if rule.BackendBucket != nil && !seen[rule.BackendBucket.Name] {
+				buckets = append(buckets, *rule.BackendBucket)
+				seen[rule.BackendBucket.Name] = true
+			}
+		}
+	}
+	return
+}
+
 func (g *GCEURLMap) deleteHost(hostname string) {
 	// Iterate HostRules and remove any (should only be zero or one) with the provided hostname.
 	for i := len(g.HostRules) - 1; i >= 0; i-- {
@@ -192,7 +292,17 @@ func (g *GCEURLMap) String() string {
 		b.WriteString(fmt.Sprintf("%v\n", hostRule.Hostname))
 		for _, rule := range hostRule.Paths {
 			b.WriteString(fmt.Sprintf("\t%v: ", rule.Path))
+			if rule.BackendBucket != nil {
+				b.WriteString(fmt.Sprintf("backendBucket=%s\n", rule.BackendBucket.Name))
+				continue
+			}
 			b.WriteString(fmt.Sprintf("%+v\n", rule.Backend))
+			for _, wb := range rule.WeightedBackends {
+				b.WriteString(fmt.Sprintf("\t\t%d: %+v\n", wb.Weight, wb.Backend))
+			}
+			for _, m := range rule.RouteMatches {
+				b.WriteString(fmt.Sprintf("\t\theaders=%v queryParams=%v: %+v\n", m.Headers, m.QueryParams, m.Backend))
+			}
 		}
 	}
 	b.WriteString(fmt.Sprintf("Default Backend: %+v", g.DefaultBackend))
@@ -158,6 +158,42 @@ func TestGCEURLMapEquals(t *testing.T) {
 	if EqualMapping(someMap, diffPaths) {
 		t.Errorf("EqualMapping(%+v, %+v) = true, want false", someMap, diffPaths)
 	}
+
+	// Split a PathRule's traffic between weighted backends.
+	diffWeights := newTestMap()
+	diffWeights.HostRules[0].Paths[0].WeightedBackends = []WeightedBackend{
+		{Backend: newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 90},
+		{Backend: newServicePortWithID("svc-M", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 10},
+	}
+	if EqualMapping(someMap, diffWeights) {
+		t.Errorf("EqualMapping(%+v, %+v) = true, want false", someMap, diffWeights)
+	}
+	// Change a weight.
+	otherWeights := newTestMap()
+	otherWeights.HostRules[0].Paths[0].WeightedBackends = []WeightedBackend{
+		{Backend: newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 50},
+		{Backend: newServicePortWithID("svc-M", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 50},
+	}
+	if EqualMapping(diffWeights, otherWeights) {
+		t.Errorf("EqualMapping(%+v, %+v) = true, want false", diffWeights, otherWeights)
+	}
+
+	// Route a PathRule's requests based on a header.
+	diffMatches := newTestMap()
+	diffMatches.HostRules[0].Paths[0].RouteMatches = []RouteMatch{
+		{Headers: []MatchCondition{{Name: "x-canary", Value: "true"}}, Backend: newServicePortWithID("svc-M", "ns", v1.ServiceBackendPort{Number: 80})},
+	}
+	if EqualMapping(someMap, diffMatches) {
+		t.Errorf("EqualMapping(%+v, %+v) = true, want false", someMap, diffMatches)
+	}
+	// Change the matched header value.
+	otherMatches := newTestMap()
+	otherMatches.HostRules[0].Paths[0].RouteMatches = []RouteMatch{
+		{Headers: []MatchCondition{{Name: "x-canary", Value: "false"}}, Backend: newServicePortWithID("svc-M", "ns", v1.ServiceBackendPort{Number: 80})},
+	}
+	if EqualMapping(diffMatches, otherMatches) {
+		t.Errorf("EqualMapping(%+v, %+v) = true, want false", diffMatches, otherMatches)
+	}
 }
 
 func TestAllServicePorts(t *testing.T) {
@@ -202,6 +238,98 @@ func TestAllServicePortsDistinct(t *testing.T) {
 	}
 }
 
+func TestAllServicePortsWeightedBackends(t *testing.T) {
+	t.Parallel()
+	m := NewGCEURLMap(klog.TODO())
+	b := newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80})
+	m.DefaultBackend = &b
+	rules := []PathRule{
+		PathRule{
+			Path:    "/ex1",
+			Backend: newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}),
+			WeightedBackends: []WeightedBackend{
+				{Backend: newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 90},
+				{Backend: newServicePortWithID("svc-B", "ns", v1.ServiceBackendPort{Number: 80}), Weight: 10},
+			},
+		},
+	}
+	m.PutPathRulesForHost("example.com", rules)
+
+	wantPorts := []ServicePort{
+		newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80}),
+		newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}),
+		newServicePortWithID("svc-B", "ns", v1.ServiceBackendPort{Number: 80}),
+	}
+
+	gotPorts := m.AllServicePorts()
+	if !reflect.DeepEqual(gotPorts, wantPorts) {
+		t.Errorf("AllServicePorts(%+v) = \n%+v\nwant\n%+v", m, gotPorts, wantPorts)
+	}
+}
+
+func TestAllServicePortsRequestMirror(t *testing.T) {
+	t.Parallel()
+	m := NewGCEURLMap(klog.TODO())
+	b := newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80})
+	m.DefaultBackend = &b
+	mirror := newServicePortWithID("svc-shadow", "ns", v1.ServiceBackendPort{Number: 80})
+	backend := newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80})
+	backend.RequestMirror = &mirror
+	rules := []PathRule{
+		PathRule{Path: "/ex1", Backend: backend},
+		PathRule{Path: "/ex2", Backend: backend},
+	}
+	m.PutPathRulesForHost("example.com", rules)
+
+	wantPorts := []ServicePort{
+		newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80}),
+		backend,
+		newServicePortWithID("svc-shadow", "ns", v1.ServiceBackendPort{Number: 80}),
+	}
+
+	gotPorts := m.AllServicePorts()
+	if !reflect.DeepEqual(gotPorts, wantPorts) {
+		t.Errorf("AllServicePorts(%+v) = \n%+v\nwant\n%+v", m, gotPorts, wantPorts)
+	}
+}
+
+func TestAllServicePortsBackendBuckets(t *testing.T) {
+	t.Parallel()
+	m := NewGCEURLMap(klog.TODO())
+	b := newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80})
+	m.DefaultBackend = &b
+	assets := &BackendBucket{Name: "assets"}
+	rules := []PathRule{
+		PathRule{Path: "/ex1", Backend: newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80})},
+		PathRule{Path: "/static", BackendBucket: assets},
+		PathRule{Path: "/static/*", BackendBucket: assets},
+	}
+	m.PutPathRulesForHost("example.com", rules)
+
+	wantPorts := []ServicePort{
+		newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80}),
+		newServicePortWithID("svc-A", "ns", v1.ServiceBackendPort{Number: 80}),
+	}
+	if gotPorts := m.AllServicePorts(); !reflect.DeepEqual(gotPorts, wantPorts) {
+		t.Errorf("AllServicePorts(%+v) = \n%+v\nwant\n%+v", m, gotPorts, wantPorts)
+	}
+	wantBuckets := []BackendBucket{{Name: "assets"}}
+	if gotBuckets := m.AllBackendBuckets(); !reflect.DeepEqual(gotBuckets, wantBuckets) {
+		t.Errorf("AllBackendBuckets(%+v) = %+v, want %+v", m, gotBuckets, wantBuckets)
+	}
+
+	other := NewGCEURLMap(klog.TODO())
+	other.DefaultBackend = &b
+	other.PutPathRulesForHost("example.com", []PathRule{
+		rules[0],
+		PathRule{Path: "/static", BackendBucket: &BackendBucket{Name: "other"}},
+		rules[2],
+	})
+	if EqualMapping(m, other) {
+		t.Errorf("EqualMapping(%+v, %+v) = true, want false", m, other)
+	}
+}
+
 func newTestMap() *GCEURLMap {
 	m := NewGCEURLMap(klog.TODO())
 	b := newServicePortWithID("svc-X", "ns", v1.ServiceBackendPort{Number: 80})
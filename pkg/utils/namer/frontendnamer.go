@@ -19,6 +19,7 @@ import (
 
 	v1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/klog/v2"
 )
@@ -155,6 +156,37 @@ func newV2IngressFrontendNamer(ing *v1.Ingress, kubeSystemUID string, prefix str
 	return namer
 }
 
+// newV2IngressGroupFrontendNamer returns a v2 frontend namer for the load
+// balancer shared by the Ingresses of the given group.
+// Example:
+// For group - shop, clusterUID - uid01234, prefix - k8s
+// The resource names are -
+// LoadBalancer          : uid01234-shop-a1b2c3d4
+// URL Map               : k8s2-um-uid01234-shop-a1b2c3d4
+// The other resource names follow the same pattern as for an Ingress.
+func newV2IngressGroupFrontendNamer(group string, kubeSystemUID string, prefix string) IngressFrontendNamer {
+	clusterUID := common.ContentHash(kubeSystemUID, clusterUIDLength)
+	namer := &V2IngressFrontendNamer{prefix: prefix, clusterUID: clusterUID}
+	// The group name takes the place of both the namespace and the name of
+	// the Ingress, and its hash differs from the hash of any Ingress.
+	truncGroup := TrimFieldsEvenly(maximumAllowedCombinedLength+1, group)[0]
+	suffix := common.ContentHash(strings.Join([]string{kubeSystemUID, "ingress-group", group}, ";"), 8)
+	namer.lbName = LoadBalancerName(fmt.Sprintf("%s-%s-%s", clusterUID, truncGroup, suffix))
+	return namer
+}
+
+// newV2GatewayFrontendNamer returns a v2 frontend namer for the load balancer
+// of the given Gateway. The resource names follow the same pattern as for an
+// Ingress of the same namespace and name, with a different hash.
+func newV2GatewayFrontendNamer(namespace, name string, kubeSystemUID string, prefix string) IngressFrontendNamer {
+	clusterUID := common.ContentHash(kubeSystemUID, clusterUIDLength)
+	namer := &V2IngressFrontendNamer{prefix: prefix, clusterUID: clusterUID}
+	truncFields := TrimFieldsEvenly(maximumAllowedCombinedLength, namespace, name)
+	suffix := common.ContentHash(strings.Join([]string{kubeSystemUID, "gateway", namespace, name}, ";"), 8)
+	namer.lbName = LoadBalancerName(fmt.Sprintf("%s-%s-%s-%s", clusterUID, truncFields[0], truncFields[1], suffix))
+	return namer
+}
+
 // ForwardingRule returns the name of forwarding rule based on given protocol.
 func (vn *V2IngressFrontendNamer) ForwardingRule(protocol NamerProtocol) string {
 	switch protocol {
@@ -248,12 +280,17 @@ func NewFrontendNamerFactory(namer *Namer, kubeSystemUID types.UID, logger klog.
 }
 
 // Namer implements IngressFrontendNamerFactory.
+// Ingresses of a group that use the v2 naming scheme share the namer of the
+// group. The group is ignored for the v1 naming scheme.
 func (rn *FrontendNamerFactory) Namer(ing *v1.Ingress) IngressFrontendNamer {
 	namingScheme := FrontendNamingScheme(ing, rn.logger)
 	switch namingScheme {
 	case V1NamingScheme:
 		return newV1IngressFrontendNamer(ing, rn.namer, rn.logger)
 	case V2NamingScheme:
+		if group := annotations.FromIngress(ing).IngressGroup(); group != "" {
+			return newV2IngressGroupFrontendNamer(group, rn.kubeSystemUID, rn.namer.prefix)
+		}
 		return newV2IngressFrontendNamer(ing, rn.kubeSystemUID, rn.namer.prefix)
 	default:
 		rn.logger.Error(nil, "Unexpected frontend naming scheme", "namingScheme", namingScheme)
@@ -265,3 +302,27 @@ func (rn *FrontendNamerFactory) Namer(ing *v1.Ingress) IngressFrontendNamer {
 func (rn *FrontendNamerFactory) NamerForLoadBalancer(lbName LoadBalancerName) IngressFrontendNamer {
 	return newV1IngressFrontendNamerForLoadBalancer(lbName, rn.namer)
 }
+
+// GatewayFrontendNamerFactory implements IngressFrontendNamerFactory for the
+// load balancers of Gateways, which are passed as Ingresses of the same
+// namespace and name. Gateways always use the v2 naming scheme.
+type GatewayFrontendNamerFactory struct {
+	namer         *Namer
+	kubeSystemUID string
+}
+
+// NewGatewayFrontendNamerFactory returns the IngressFrontendNamerFactory of
+// the load balancers of Gateways given a v1 namer and kube-system uid.
+func NewGatewayFrontendNamerFactory(namer *Namer, kubeSystemUID types.UID) IngressFrontendNamerFactory {
+	return &GatewayFrontendNamerFactory{namer: namer, kubeSystemUID: string(kubeSystemUID)}
+}
+
+// Namer implements IngressFrontendNamerFactory.
+func (gn *GatewayFrontendNamerFactory) Namer(ing *v1.Ingress) IngressFrontendNamer {
+	return newV2GatewayFrontendNamer(ing.Namespace, ing.Name, gn.kubeSystemUID, gn.namer.prefix)
+}
+
+// NamerForLoadBalancer implements IngressFrontendNamerFactory.
+func (gn *GatewayFrontendNamerFactory) NamerForLoadBalancer(lbName LoadBalancerName) IngressFrontendNamer {
+	return newV1IngressFrontendNamerForLoadBalancer(lbName, gn.namer)
+}
@@ -20,11 +20,14 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"k8s.io/klog/v2"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils/common"
 )
 
 const (
@@ -441,3 +444,63 @@ func TestV2IngressFrontendNamer(t *testing.T) {
 		}
 	}
 }
+
+// TestIngressGroupFrontendNamer tests that the v2 Ingresses of a group share
+// the namer of the group, across namespaces.
+func TestIngressGroupFrontendNamer(t *testing.T) {
+	factory := NewFrontendNamerFactory(NewNamerWithPrefix("k8s", clusterUID, "", klog.TODO()), kubeSystemUID, klog.TODO())
+	newGroupIngress := func(namespace, name, group string, finalizer string) *v1.Ingress {
+		ing := newIngress(namespace, name)
+		ing.Finalizers = []string{finalizer}
+		if group != "" {
+			ing.Annotations = map[string]string{annotations.IngressGroupKey: group}
+		}
+		return ing
+	}
+
+	shop1 := factory.Namer(newGroupIngress("ns1", "ing1", "shop", common.FinalizerKeyV2))
+	shop2 := factory.Namer(newGroupIngress("ns2", "ing2", "shop", common.FinalizerKeyV2))
+	if shop1.LoadBalancer() != shop2.LoadBalancer() {
+		t.Errorf("Ingresses of group shop got load balancers %q and %q, want the same", shop1.LoadBalancer(), shop2.LoadBalancer())
+	}
+	if !strings.HasPrefix(shop1.UrlMap(), "k8s2-um-7kpbhpki-shop-") {
+		t.Errorf("shop1.UrlMap() = %q, want prefix %q", shop1.UrlMap(), "k8s2-um-7kpbhpki-shop-")
+	}
+	if !shop1.IsValidLoadBalancer() {
+		t.Errorf("shop1.IsValidLoadBalancer() = false, want true")
+	}
+
+	for _, tc := range []struct {
+		desc string
+		ing  *v1.Ingress
+	}{
+		{"other group", newGroupIngress("ns1", "ing1", "blog", common.FinalizerKeyV2)},
+		{"no group", newGroupIngress("ns1", "ing1", "", common.FinalizerKeyV2)},
+		{"ingress named as the group", newGroupIngress("shop", "shop", "", common.FinalizerKeyV2)},
+		{"v1 naming scheme", newGroupIngress("ns1", "ing1", "shop", common.FinalizerKey)},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := factory.Namer(tc.ing).LoadBalancer(); got == shop1.LoadBalancer() {
+				t.Errorf("Namer(%s/%s).LoadBalancer() = %q, want a load balancer other than the one of group shop", tc.ing.Namespace, tc.ing.Name, got)
+			}
+		})
+	}
+}
+
+func TestGatewayFrontendNamer(t *testing.T) {
+	namer := NewNamerWithPrefix("k8s", clusterUID, "", klog.TODO())
+	gatewayNamer := NewGatewayFrontendNamerFactory(namer, kubeSystemUID).Namer(newIngress("ns1", "gw"))
+	if !strings.HasPrefix(gatewayNamer.UrlMap(), "k8s2-um-7kpbhpki-ns1-gw-") {
+		t.Errorf("gatewayNamer.UrlMap() = %q, want prefix %q", gatewayNamer.UrlMap(), "k8s2-um-7kpbhpki-ns1-gw-")
+	}
+	if !gatewayNamer.IsValidLoadBalancer() {
+		t.Errorf("gatewayNamer.IsValidLoadBalancer() = false, want true")
+	}
+
+	ing := newIngress("ns1", "gw")
+	ing.Finalizers = []string{common.FinalizerKeyV2}
+	ingressNamer := NewFrontendNamerFactory(namer, kubeSystemUID, klog.TODO()).Namer(ing)
+	if gatewayNamer.LoadBalancer() == ingressNamer.LoadBalancer() {
+		t.Errorf("Gateway and Ingress ns1/gw got the same load balancer %q, want different ones", gatewayNamer.LoadBalancer())
+	}
+}
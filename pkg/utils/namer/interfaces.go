@@ -105,6 +105,8 @@ type L4ResourcesNamer interface {
 	L4Firewall(namespace, name string) string
 	// L4IPv6Firewall returns the name of the ipv6 firewall rule for the given service
 	L4IPv6Firewall(namespace, name string) string
+	// L4FirewallPolicyRule returns the name of the firewall rule of a rule of the firewall policy of the given service
+	L4FirewallPolicyRule(namespace, name, ruleName string) string
 	// L4HealthCheck returns the names of the Healthcheck
 	L4HealthCheck(namespace, name string, shared bool) string
 	// L4HealthCheckFirewall returns the names of the Healthcheck Firewall
@@ -20,6 +20,7 @@ const (
 	sharedHcSuffix              = "l4-shared-hc"
 	firewallHcSuffix            = "-fw"
 	ipv6Suffix                  = "ipv6"
+	firewallPolicyRulePrefix    = "p"
 	sharedFirewallHcSuffix      = sharedHcSuffix + firewallHcSuffix
 	maxResourceNameLength       = 63
 	l3ProtocolWithoutUnderscore = "l3"
@@ -114,6 +115,18 @@ func (namer *L4Namer) L4IPv6Firewall(namespace, name string) string {
 	return GetSuffixedName(namer.L4Backend(namespace, name), "-"+ipv6Suffix)
 }
 
+// L4FirewallPolicyRule returns the gce Firewall name of a rule of the L4
+// firewall policy of the service, based on the service namespace and name and
+// the name of the rule.
+// Naming convention:
+//
+//	k8s2-{uid}-{ns}-{name}-{suffix}-p-{ruleName}
+//
+// Output name is at most 63 characters.
+func (namer *L4Namer) L4FirewallPolicyRule(namespace, name, ruleName string) string {
+	return GetSuffixedName(namer.L4Backend(namespace, name), "-"+firewallPolicyRulePrefix+"-"+ruleName)
+}
+
 // L4ForwardingRule returns the name of the L4 forwarding rule name based on the service namespace, name and protocol.
 // Naming convention:
 //
@@ -22,6 +22,7 @@ func TestL4Namer(t *testing.T) {
 		NonDefaultNEGName string
 		FWName            string
 		IPv6FWName        string
+		FWPolicyRuleName  string
 		HcFwName          string
 		IPv6HcFName       string
 		HcName            string
@@ -52,6 +53,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-namespace-name-185075-956p2p7x",
 				FWName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
 				IPv6FWName:        "k8s2-7kpbhpki-namespace-name-956p2p7x-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-namespace-name-956p2p7x-p-web",
 				HcFwName:          "k8s2-7kpbhpki-namespace-name-956p2p7x-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-namespace-name-956p2p7x-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
@@ -73,6 +75,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-namespace-name-185075-956p2p7x",
 				FWName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
 				IPv6FWName:        "k8s2-7kpbhpki-namespace-name-956p2p7x-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-namespace-name-956p2p7x-p-web",
 				HcFwName:          "k8s2-7kpbhpki-l4-shared-hc-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-l4-shared-hc-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-l4-shared-hc",
@@ -94,6 +97,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-0123456789012345-0123456789012345-185075-hwm400mg",
 				FWName:            "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm400mg",
 				IPv6FWName:        "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hw-p-web",
 				HcFwName:          "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm40-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-01234567890123456789-0123456789012345678--fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm400mg",
@@ -115,6 +119,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-0123456789012345-0123456789012345-185075-hwm400mg",
 				FWName:            "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm400mg",
 				IPv6FWName:        "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hw-p-web",
 				HcFwName:          "k8s2-7kpbhpki-l4-shared-hc-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-l4-shared-hc-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-l4-shared-hc",
@@ -136,6 +141,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-namespace-name-1fd834-956p2p7x",
 				FWName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
 				IPv6FWName:        "k8s2-7kpbhpki-namespace-name-956p2p7x-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-namespace-name-956p2p7x-p-web",
 				HcFwName:          "k8s2-7kpbhpki-namespace-name-956p2p7x-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-namespace-name-956p2p7x-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
@@ -157,6 +163,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-namespace-name-1fd834-956p2p7x",
 				FWName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
 				IPv6FWName:        "k8s2-7kpbhpki-namespace-name-956p2p7x-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-namespace-name-956p2p7x-p-web",
 				HcFwName:          "k8s2-7kpbhpki-namespace-name-956p2p7x-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-namespace-name-956p2p7x-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-namespace-name-956p2p7x",
@@ -178,6 +185,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: "k8s2-7kpbhpki-0123456789012345-0123456789012345-185075-hwm400mg",
 				FWName:            "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm400mg",
 				IPv6FWName:        "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hwm-ipv6",
+				FWPolicyRuleName:  "k8s2-7kpbhpki-01234567890123456789-0123456789012345678-hw-p-web",
 				HcFwName:          "k8s2-7kpbhpki-l4-shared-hc-fw",
 				IPv6HcFName:       "k8s2-7kpbhpki-l4-shared-hc-fw-ipv6",
 				HcName:            "k8s2-7kpbhpki-l4-shared-hc",
@@ -202,6 +210,7 @@ func TestL4Namer(t *testing.T) {
 				NonDefaultNEGName: namer.NonDefaultSubnetNEG(tc.namespace, tc.name, tc.subnetName, 0), // Port is not used for L4 NEG
 				FWName:            namer.L4Firewall(tc.namespace, tc.name),
 				IPv6FWName:        namer.L4IPv6Firewall(tc.namespace, tc.name),
+				FWPolicyRuleName:  namer.L4FirewallPolicyRule(tc.namespace, tc.name, "web"),
 				HcName:            namer.L4HealthCheck(tc.namespace, tc.name, tc.sharedHC),
 				HcFwName:          namer.L4HealthCheckFirewall(tc.namespace, tc.name, tc.sharedHC),
 				IPv6HcFName:       namer.L4IPv6HealthCheckFirewall(tc.namespace, tc.name, tc.sharedHC),
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/negannotation"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 )
 
@@ -73,6 +74,18 @@ type ServicePort struct {
 	// Traffic policy fields that apply if non-nil.
 	MaxRatePerEndpoint *float64
 	CapacityScaler     *float64
+	// RequestMirror is the service port that receives a copy of the requests
+	// sent to this service port, as specified in its BackendConfig.
+	RequestMirror *ServicePort
+	// DriftPolicy is the drift policy of the Ingress that references the
+	// service port, applied to its backend service and health check. The
+	// Ingress controller resets it to DriftPolicyReconcile unless all the
+	// Ingresses that reference the Service use DriftPolicyAlert.
+	DriftPolicy annotations.DriftPolicy
+	// ExternalNEG, if set, describes the serverless or internet NEG that
+	// backs the service port instead of the pods of the Service. Its backend
+	// service has no health check.
+	ExternalNEG *negannotation.ExternalNegAnnotation
 }
 
 // GetDescription returns a Description for this ServicePort.
@@ -121,6 +134,28 @@ func BackendToServicePortID(be v1.IngressBackend, namespace string) (ServicePort
 	}, nil
 }
 
+const (
+	// BackendBucketAPIGroup is the API group of the resource backends of
+	// Ingresses that reference backend buckets.
+	BackendBucketAPIGroup = "networking.gke.io"
+	// BackendBucketKind is the kind of the resource backends of Ingresses
+	// that reference backend buckets.
+	BackendBucketKind = "BackendBucket"
+)
+
+// BackendToBackendBucket returns the name of the backend bucket referenced by
+// a resource backend of an Ingress, and false if the backend does not
+// reference a backend bucket.
+func BackendToBackendBucket(be v1.IngressBackend) (string, bool) {
+	if be.Resource == nil || be.Resource.APIGroup == nil {
+		return "", false
+	}
+	if *be.Resource.APIGroup != BackendBucketAPIGroup || be.Resource.Kind != BackendBucketKind {
+		return "", false
+	}
+	return be.Resource.Name, true
+}
+
 func newServicePortWithID(svcName, svcNamespace string, port v1.ServiceBackendPort) ServicePort {
 	return ServicePort{
 		ID: ServicePortID{
@@ -553,12 +553,26 @@ func NewNamespaceIndexer() cache.Indexers {
 }
 
 // JoinErrs returns an aggregated error based on the passed in list of errors.
+// The aggregated error wraps the errors, so that errors.Is and errors.As
+// match each of them.
 func JoinErrs(errs []error) error {
+	return &joinedErrs{errs: errs}
+}
+
+type joinedErrs struct {
+	errs []error
+}
+
+func (e *joinedErrs) Error() string {
 	var errStrs []string
-	for _, e := range errs {
+	for _, e := range e.errs {
 		errStrs = append(errStrs, e.Error())
 	}
-	return errors.New(strings.Join(errStrs, "; "))
+	return strings.Join(errStrs, "; ")
+}
+
+func (e *joinedErrs) Unwrap() []error {
+	return e.errs
 }
 
 // TraverseIngressBackends traverse thru all backends specified in the input ingress and call process
@@ -587,6 +601,43 @@ func TraverseIngressBackends(ing *networkingv1.Ingress, process func(id ServiceP
 			}
 		}
 	}
+
+	// Check the services that receive a share of the traffic of the path rules.
+	// An invalid annotation is surfaced when the Ingress is translated.
+	weightedBackends, err := annotations.FromIngress(ing).WeightedBackends()
+	if err != nil {
+		return
+	}
+	svcNames := make([]string, 0, len(weightedBackends))
+	for svcName := range weightedBackends {
+		svcNames = append(svcNames, svcName)
+	}
+	sort.Strings(svcNames)
+	for _, svcName := range svcNames {
+		for _, b := range weightedBackends[svcName] {
+			if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: b.Service.Name}, Port: b.Service.Port}) {
+				return
+			}
+		}
+	}
+
+	// Check the services that receive the requests matching route matches.
+	routeMatches, err := annotations.FromIngress(ing).RouteMatches()
+	if err != nil {
+		return
+	}
+	svcNames = make([]string, 0, len(routeMatches))
+	for svcName := range routeMatches {
+		svcNames = append(svcNames, svcName)
+	}
+	sort.Strings(svcNames)
+	for _, svcName := range svcNames {
+		for _, m := range routeMatches[svcName] {
+			if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: m.Service.Name}, Port: m.Service.Port}) {
+				return
+			}
+		}
+	}
 	return
 }
 
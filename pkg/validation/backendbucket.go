@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseManagedBackendBuckets parses a comma-separated list of
+// namespace/name pairs, and returns the namespace allowed to manage each
+// backend bucket. A backend bucket can only be managed by one namespace.
+func ParseManagedBackendBuckets(input string) (map[string]string, error) {
+	if input == "" {
+		return nil, nil
+	}
+	result := map[string]string{}
+	for _, entry := range strings.Split(input, ",") {
+		trimmed := strings.TrimSpace(entry)
+		namespace, name, ok := strings.Cut(trimmed, "/")
+		if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid backend bucket %q, want namespace/name", trimmed)
+		}
+		if owner, ok := result[name]; ok && owner != namespace {
+			return nil, fmt.Errorf("backend bucket %q is managed by both namespaces %q and %q", name, owner, namespace)
+		}
+		result[name] = namespace
+	}
+	return result, nil
+}
+
+// ValidateManagedBackendBuckets validates a comma-separated list of
+// namespace/name pairs of backend buckets.
+func ValidateManagedBackendBuckets(input string) error {
+	_, err := ParseManagedBackendBuckets(input)
+	return err
+}
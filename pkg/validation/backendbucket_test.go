@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseManagedBackendBuckets(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		want      map[string]string
+		wantError bool
+	}{
+		{
+			name:  "empty string",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "multiple backend buckets with spaces",
+			input: "web/assets, web/images,shop/static",
+			want:  map[string]string{"assets": "web", "images": "web", "static": "shop"},
+		},
+		{
+			name:  "same backend bucket listed twice for one namespace",
+			input: "web/assets,web/assets",
+			want:  map[string]string{"assets": "web"},
+		},
+		{
+			name:      "backend bucket managed by two namespaces",
+			input:     "web/assets,shop/assets",
+			wantError: true,
+		},
+		{
+			name:      "missing namespace",
+			input:     "assets",
+			wantError: true,
+		},
+		{
+			name:      "empty name",
+			input:     "web/",
+			wantError: true,
+		},
+		{
+			name:      "too many segments",
+			input:     "web/assets/extra",
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseManagedBackendBuckets(tc.input)
+			if gotError := err != nil; gotError != tc.wantError {
+				t.Fatalf("ParseManagedBackendBuckets(%q) returned error %v, want error: %v", tc.input, err, tc.wantError)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseManagedBackendBuckets(%q) returned diff (-want +got):\n%s", tc.input, diff)
+			}
+		})
+	}
+}